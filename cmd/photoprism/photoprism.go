@@ -65,8 +65,12 @@ func main() {
 		commands.CopyCommand,
 		commands.FacesCommand,
 		commands.PlacesCommand,
+		commands.GeotagCommand,
+		commands.TimeShiftCommand,
 		commands.PurgeCommand,
 		commands.CleanUpCommand,
+		commands.DuplicatesCommand,
+		commands.ExportCommand,
 		commands.OptimizeCommand,
 		commands.MomentsCommand,
 		commands.ConvertCommand,
@@ -10,3 +10,4 @@ export const Marker = "marker";
 export const Image = "image";
 export const Keyword = "keyword";
 export const Location = "location";
+export const Track = "track";
@@ -36,6 +36,16 @@ const Minute = 60 * Second;
 const Hour = 60 * Minute;
 let start = new Date();
 
+// Video codecs that can be requested from the server, ordered by preference.
+const VideoCodecs = [
+  ["av01", 'video/mp4; codecs="av01.0.08M.08"'],
+  ["vp09", 'video/webm; codecs="vp09.00.10.08"'],
+  ["hvc1", 'video/mp4; codecs="hvc1.1.6.L93.B0"'],
+  ["avc1", 'video/mp4; codecs="avc1.640028"'],
+];
+
+let supportedVideoCodecs = null;
+
 export default class Util {
   static duration(d) {
     let u = d;
@@ -146,6 +156,25 @@ export default class Util {
     start = now;
   }
 
+  // Returns the names of the video codecs the browser can play, e.g. ["vp09", "avc1"].
+  static videoCodecs() {
+    if (supportedVideoCodecs !== null) {
+      return supportedVideoCodecs;
+    }
+
+    const video = document.createElement("video");
+
+    if (typeof video.canPlayType !== "function") {
+      supportedVideoCodecs = [];
+    } else {
+      supportedVideoCodecs = VideoCodecs.filter(([, type]) => video.canPlayType(type) !== "").map(
+        ([name]) => name
+      );
+    }
+
+    return supportedVideoCodecs;
+  }
+
   static codecName(value) {
     if (!value || typeof value !== "string") {
       return "";
@@ -155,7 +184,12 @@ export default class Util {
       case "avc1":
         return "Advanced Video Coding (AVC) / H.264";
       case "hvc1":
+      case "hev1":
         return "High Efficiency Video Coding (HEVC) / H.265";
+      case "vp09":
+        return "Google VP9";
+      case "av01":
+        return "AOMedia Video 1 (AV1)";
       case "mjpg":
         return "Motion JPEG (MJPEG)";
       case "heic":
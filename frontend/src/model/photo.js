@@ -44,6 +44,7 @@ import * as src from "common/src";
 export const CodecAvc1 = "avc1";
 export const FormatMp4 = "mp4";
 export const FormatAvc = "avc";
+export const FormatAuto = "auto";
 export const FormatJpeg = "jpg";
 export const TypeImage = "image";
 export const TypeVideo = "video";
@@ -95,6 +96,9 @@ export class Photo extends RestModel {
       Scan: false,
       Panorama: false,
       Portrait: false,
+      Rating: 0,
+      ColorLabel: "",
+      RatingSrc: "",
       TakenAt: "",
       TakenAtLocal: "",
       TakenSrc: "",
@@ -423,12 +427,12 @@ export class Photo extends RestModel {
 
   videoUrl() {
     const file = this.videoFile();
+    const hash = file ? file.Hash : this.Hash;
 
-    if (file) {
-      return `${config.apiUri}/videos/${file.Hash}/${config.previewToken()}/${FormatAvc}`;
-    }
+    // The server streams the original or transcodes it to a codec supported by the browser.
+    const codecs = Util.videoCodecs().join(",");
 
-    return `${config.apiUri}/videos/${this.Hash}/${config.previewToken()}/${FormatAvc}`;
+    return `${config.apiUri}/videos/${hash}/${config.previewToken()}/${FormatAuto}?codecs=${codecs}`;
   }
 
   mainFile() {
@@ -841,6 +845,10 @@ export class Photo extends RestModel {
       values.PlaceSrc = src.Manual;
     }
 
+    if ("Rating" in values || "ColorLabel" in values) {
+      values.RatingSrc = src.Manual;
+    }
+
     if (values.TakenAt || values.TimeZone || values.Day || values.Month || values.Year) {
       values.TakenSrc = src.Manual;
     }
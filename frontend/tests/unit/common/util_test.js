@@ -29,6 +29,12 @@ describe("common/util", () => {
     const duration = Util.duration(600000000000);
     assert.equal(duration, "00:10:00");
   });
+  it("should return supported video codecs", () => {
+    const codecs = Util.videoCodecs();
+    assert.isArray(codecs);
+    codecs.forEach((c) => assert.include(["av01", "vp09", "hvc1", "avc1"], c));
+    assert.strictEqual(Util.videoCodecs(), codecs);
+  });
   it("should convert -1 to roman", () => {
     const roman = Util.arabicToRoman(-1);
     assert.equal(roman, "");
@@ -1,9 +1,12 @@
 import "../fixtures";
 import { Photo, FormatJpeg } from "model/photo";
+import Util from "common/util";
 
 let chai = require("chai/chai");
 let assert = chai.assert;
 
+const videoQuery = "?codecs=" + Util.videoCodecs().join(",");
+
 describe("model/photo", () => {
   it("should get photo entity name", () => {
     const values = { UID: 5, Title: "Crazy Cat" };
@@ -639,7 +642,7 @@ describe("model/photo", () => {
     assert.equal(result.height, "463");
     assert.equal(result.width, "695");
     assert.equal(result.loop, false);
-    assert.equal(result.uri, "/api/v1/videos/1xxbgdt55/public/avc");
+    assert.equal(result.uri, "/api/v1/videos/1xxbgdt55/public/auto" + videoQuery);
     const values = {
       ID: 11,
       UID: "ABC127",
@@ -671,7 +674,7 @@ describe("model/photo", () => {
     assert.equal(result2.height, "440");
     assert.equal(result2.width, "440");
     assert.equal(result2.loop, false);
-    assert.equal(result2.uri, "/api/v1/videos/1xxbgdt55/public/avc");
+    assert.equal(result2.uri, "/api/v1/videos/1xxbgdt55/public/auto" + videoQuery);
   });
 
   it("should return videofile", () => {
@@ -760,12 +763,12 @@ describe("model/photo", () => {
       ],
     };
     const photo = new Photo(values);
-    assert.equal(photo.videoUrl(), "/api/v1/videos/703cf8f274fbb265d49c6262825780e1/public/avc");
+    assert.equal(photo.videoUrl(), "/api/v1/videos/703cf8f274fbb265d49c6262825780e1/public/auto" + videoQuery);
     const values2 = { ID: 9, UID: "ABC163", Hash: "2305e512e3b183ec982d60a8b608a8ca501973ba" };
     const photo2 = new Photo(values2);
     assert.equal(
       photo2.videoUrl(),
-      "/api/v1/videos/2305e512e3b183ec982d60a8b608a8ca501973ba/public/avc"
+      "/api/v1/videos/2305e512e3b183ec982d60a8b608a8ca501973ba/public/auto" + videoQuery
     );
     const values3 = {
       ID: 10,
@@ -785,7 +788,7 @@ describe("model/photo", () => {
       ],
     };
     const photo3 = new Photo(values3);
-    assert.equal(photo3.videoUrl(), "/api/v1/videos/1xxbgdt55/public/avc");
+    assert.equal(photo3.videoUrl(), "/api/v1/videos/1xxbgdt55/public/auto" + videoQuery);
     const values4 = {
       ID: 1,
       UID: "ABC128",
@@ -805,7 +808,7 @@ describe("model/photo", () => {
       ],
     };
     const photo4 = new Photo(values4);
-    assert.equal(photo4.videoUrl(), "/api/v1/videos/1xxbgdt53/public/avc");
+    assert.equal(photo4.videoUrl(), "/api/v1/videos/1xxbgdt53/public/auto" + videoQuery);
   });
 
   it("should return main file", () => {
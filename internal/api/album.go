@@ -84,7 +84,19 @@ func CreateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
-		a := entity.NewAlbum(f.AlbumTitle, entity.AlbumDefault)
+		var a *entity.Album
+
+		// Create smart album based on a search filter?
+		if f.AlbumType == entity.AlbumSmart {
+			if abortInvalidAlbumFilter(c, f.AlbumFilter) {
+				return
+			}
+
+			a = entity.NewSmartAlbum(f.AlbumTitle, f.AlbumFilter)
+		} else {
+			a = entity.NewAlbum(f.AlbumTitle, entity.AlbumDefault)
+		}
+
 		a.AlbumFavorite = f.AlbumFavorite
 
 		if res := entity.Db().Create(a); res.Error != nil {
@@ -104,6 +116,20 @@ func CreateAlbum(router *gin.RouterGroup) {
 	})
 }
 
+// abortInvalidAlbumFilter aborts with an error if the smart album filter is invalid, and returns true in this case.
+func abortInvalidAlbumFilter(c *gin.Context, filter string) bool {
+	if _, err := search.SmartAlbumFilter(filter); err == nil {
+		return false
+	} else if queryErr, ok := err.(*form.QueryError); ok {
+		AbortInvalidQuery(c, queryErr)
+	} else {
+		log.Debugf("album: %s (invalid filter)", err)
+		AbortBadRequest(c)
+	}
+
+	return true
+}
+
 // UpdateAlbum updates album metadata like title and description.
 //
 // PUT /api/v1/albums/:uid
@@ -138,6 +164,13 @@ func UpdateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// The album type cannot be changed.
+		f.AlbumType = a.AlbumType
+
+		if a.IsSmart() && abortInvalidAlbumFilter(c, f.AlbumFilter) {
+			return
+		}
+
 		if err := a.SaveForm(f); err != nil {
 			log.Error(err)
 			AbortSaveFailed(c)
@@ -178,7 +211,7 @@ func DeleteAlbum(router *gin.RouterGroup) {
 		}
 
 		// Regular, manually created album?
-		if a.IsDefault() {
+		if a.IsDefault() || a.IsSmart() {
 			// Soft delete manually created albums.
 			err = a.Delete()
 		} else {
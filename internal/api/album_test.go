@@ -46,6 +46,27 @@ func TestCreateAlbum(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": 333, "Description": "Created via unit test", "Notes": "", "Favorite": true}`)
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("smart album", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		SearchPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Smart Favorites", "Type": "smart", "Filter": "favorite:true"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "smart", gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, "favorite:true", gjson.Get(r.Body.String(), "Filter").String())
+		uid := gjson.Get(r.Body.String(), "UID").String()
+		r = PerformRequest(app, "GET", "/api/v1/photos?count=10&album="+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#(Favorite==false)#.#").Int())
+	})
+	t.Run("smart album with invalid filter", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Smart Error", "Type": "smart", "Filter": "q:\"(label:cat\""}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "position").Int())
+	})
 }
 func TestUpdateAlbum(t *testing.T) {
 	app, router, _ := NewApiTest()
@@ -37,6 +37,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/sanitize"
@@ -105,6 +106,20 @@ func AbortBadRequest(c *gin.Context) {
 	Abort(c, http.StatusBadRequest, i18n.ErrBadRequest)
 }
 
+// AbortInvalidQuery aborts with a bad request error that includes the position of a search query syntax error.
+func AbortInvalidQuery(c *gin.Context, err *form.QueryError) {
+	resp := i18n.NewResponse(http.StatusBadRequest, i18n.ErrInvalidQuery)
+
+	log.Debugf("api: abort %s with code %d (%s)", sanitize.Log(c.FullPath()), http.StatusBadRequest, sanitize.Log(err.Error()))
+
+	c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+		"code":     resp.Code,
+		"error":    resp.Err,
+		"details":  err.Error(),
+		"position": err.Pos,
+	})
+}
+
 func AbortAlreadyExists(c *gin.Context, s string) {
 	Abort(c, http.StatusConflict, i18n.ErrAlreadyExists, s)
 }
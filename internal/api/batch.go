@@ -393,3 +393,91 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgPermanentlyDeleted))
 	})
 }
+
+// BatchPhotosTimeShift shifts the date of multiple photos, e.g. to correct a wrong camera clock.
+//
+// POST /api/v1/batch/photos/timeshift
+func BatchPhotosTimeShift(router *gin.RouterGroup) {
+	router.POST("/batch/photos/timeshift", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.TimeShift
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Empty() {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		log.Infof("photos: shifting time of %s", sanitize.Log(f.String()))
+
+		result, err := service.TimeShift().Start(f)
+
+		if err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
+		if !result.Preview {
+			publishTimeShift(result)
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// BatchPhotosTimeShiftUndo restores the photo dates before a time shift.
+//
+// POST /api/v1/batch/photos/timeshift/:uid/undo
+func BatchPhotosTimeShiftUndo(router *gin.RouterGroup) {
+	router.POST("/batch/photos/timeshift/:uid/undo", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		result, err := service.TimeShift().Undo(sanitize.IdString(c.Param("uid")), false)
+
+		if err != nil {
+			log.Errorf("photos: %s (undo time shift)", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		publishTimeShift(result)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// publishTimeShift notifies clients about photos with a changed date.
+func publishTimeShift(result photoprism.TimeShiftResult) {
+	if len(result.Photos) == 0 {
+		return
+	}
+
+	f := form.Selection{Photos: make([]string, 0, len(result.Photos))}
+
+	for _, p := range result.Photos {
+		f.Photos = append(f.Photos, p.PhotoUID)
+	}
+
+	if photos, err := query.PhotoSelection(f); err != nil {
+		log.Errorf("photos: %s (time shift)", err)
+	} else {
+		event.EntitiesUpdated("photos", photos)
+	}
+
+	UpdateClientConfig()
+}
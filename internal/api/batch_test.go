@@ -274,3 +274,45 @@ func TestBatchPhotosDelete(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, r.Code)
 	})
 }
+
+func TestBatchPhotosTimeShift(t *testing.T) {
+	t.Run("preview", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTimeShift(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/timeshift", `{"offset": "-1y", "serial": "123", "preview": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "true", gjson.Get(r.Body.String(), "Preview").String())
+		assert.Equal(t, "-1y", gjson.Get(r.Body.String(), "Offset").String())
+		assert.Equal(t, "pt9jtdre2lvl0y12", gjson.Get(r.Body.String(), "Photos.0.UID").String())
+	})
+	t.Run("shift and undo", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTimeShift(router)
+		BatchPhotosTimeShiftUndo(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/timeshift", `{"offset": "+3h", "photos": ["pt9jtdre2lvl0y12"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		uid := gjson.Get(r.Body.String(), "ShiftUID").String()
+		assert.NotEmpty(t, uid)
+		r2 := PerformRequest(app, "POST", "/api/v1/batch/photos/timeshift/"+uid+"/undo")
+		assert.Equal(t, http.StatusOK, r2.Code)
+		assert.Equal(t, "pt9jtdre2lvl0y12", gjson.Get(r2.Body.String(), "Photos.0.UID").String())
+	})
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTimeShift(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/timeshift", `{"offset": "+1h"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid offset", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTimeShift(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/timeshift", `{"offset": "foo", "serial": "123"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("undo not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTimeShiftUndo(router)
+		r := PerformRequest(app, "POST", "/api/v1/batch/photos/timeshift/tt9jtdre2lvl0y99/undo")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// ExportPhotos streams the pictures matching a search as CSV or JSON Lines, one row per picture.
+//
+// GET /api/v1/export/photos
+//
+// Query:
+//   q:         string Query string, same filters as for "/api/v1/photos"
+//   count:     int    Max number of exported pictures (required)
+//   offset:    int    Number of pictures to skip
+//   order:     string Sort order
+//   format:    string "csv" (default) or "jsonl"
+//   columns:   string Comma-separated list of columns, e.g. "file_name,file_hash,taken_at,labels"
+func ExportPhotos(router *gin.RouterGroup) {
+	router.GET("/export/photos", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionExport)
+
+		// Guests may only see shared albums.
+		if s.Invalid() || s.Guest() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.SearchPhotos
+
+		err := c.MustBindWith(&f, binding.Form)
+
+		// Counts below one would export the whole library, so they are rejected.
+		if err != nil || f.Count < 1 {
+			AbortBadRequest(c)
+			return
+		}
+
+		format, err := search.ParseExportFormat(c.Query("format"))
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		cols, err := search.ParseExportColumns(c.Query("columns"))
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if format == search.ExportJSONL {
+			c.Header("Content-Type", "application/x-ndjson; charset=utf-8")
+			AddDownloadHeader(c, "photos.jsonl")
+		} else {
+			c.Header("Content-Type", "text/csv; charset=utf-8")
+			AddDownloadHeader(c, "photos.csv")
+		}
+
+		_, err = search.ExportPhotos(c.Writer, f, format, cols)
+
+		if err == nil {
+			return
+		} else if c.Writer.Written() {
+			// Headers have already been sent, so the response can't be changed anymore.
+			log.Errorf("export: %s", err)
+			return
+		}
+
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+
+		if queryErr, ok := err.(*form.QueryError); ok {
+			AbortInvalidQuery(c, queryErr)
+		} else {
+			log.Warnf("export: %s", err)
+			AbortBadRequest(c)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportPhotos(t *testing.T) {
+	t.Run("CSV", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/export/photos?count=10&q=uid:pt9jtdre2lvl0yh7&columns=uid,file_hash,labels")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", r.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=photos.csv", r.Header().Get("Content-Disposition"))
+		assert.Equal(t, "uid,file_hash,labels\npt9jtdre2lvl0yh7,2cad9168fa6acc5c5c2965ddf6ec465ca42fd818,Cake; Flower\n", r.Body.String())
+	})
+	t.Run("JSONL", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/export/photos?count=3&format=jsonl&columns=uid,file_name")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "application/x-ndjson; charset=utf-8", r.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(r.Body.String()), "\n")
+		assert.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[0], `{"uid":"`))
+	})
+	t.Run("InvalidCount", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotos(router)
+
+		for _, count := range []string{"", "count=0", "count=-1"} {
+			r := PerformRequest(app, "GET", "/api/v1/export/photos?format=jsonl&"+count)
+			assert.Equal(t, http.StatusBadRequest, r.Code, count)
+			assert.Empty(t, r.Header().Get("Content-Disposition"), count)
+		}
+	})
+	t.Run("InvalidFormat", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/export/photos?count=10&format=xlsx")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("UnknownColumn", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/export/photos?count=10&columns=uid,foo")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidQuery", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ExportPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/export/photos?count=10&q="+url.QueryEscape("label:cat | xyz:foo"))
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Empty(t, r.Header().Get("Content-Disposition"))
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/internal/service"
 )
 
@@ -74,6 +75,8 @@ func DeleteFile(router *gin.RouterGroup) {
 			return
 		}
 
+		search.RemoveSimilarFile(file.FileUID)
+
 		// Notify clients by publishing events.
 		PublishPhotoEvent(EntityUpdated, photoUID, c)
 
@@ -113,13 +113,22 @@ func FolderCover(router *gin.RouterGroup) {
 
 		var thumbnail string
 
+		focusAreas := thumbFocus(&f, size)
+
 		if conf.ThumbUncached() || size.Uncached() {
-			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, f.FileOrientation, size.Options...)
+			thumbnail, err = thumb.FromQueue(c.Request.Context(), thumb.PriorityHigh, fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, f.FileOrientation, focusAreas, size.Options...)
 		} else {
-			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, size.Options...)
+			thumbnail, err = thumb.FromCacheFocus(fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, focusAreas, size.Options...)
 		}
 
-		if err != nil {
+		// Crop thumbnail again if the faces have changed, e.g. in another process.
+		if err == thumb.ErrThumbNotCached && focusAreas.Hash() != "" {
+			thumbnail, err = thumb.FromQueue(c.Request.Context(), thumb.PriorityHigh, fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, f.FileOrientation, focusAreas, size.Options...)
+		}
+
+		if thumbUnavailable(c, err, folderIconSvg) {
+			return
+		} else if err != nil {
 			log.Errorf("%s: %s", folderCover, err)
 			c.Data(http.StatusOK, "image/svg+xml", folderIconSvg)
 			return
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Geotag sets the location of photos without coordinates based on uploaded GPX, KML, or GeoJSON tracks.
+//
+// POST /api/v1/geotag
+func Geotag(router *gin.RouterGroup) {
+	router.POST("/geotag", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.GeotagOptions
+
+		if err := c.ShouldBind(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		mf, err := c.MultipartForm()
+
+		if err != nil {
+			log.Errorf("geotag: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		var track geo.Track
+
+		for _, file := range mf.File["files"] {
+			fileName := filepath.Base(file.Filename)
+
+			r, err := file.Open()
+
+			if err != nil {
+				log.Errorf("geotag: failed opening %s", sanitize.Log(fileName))
+				AbortBadRequest(c)
+				return
+			}
+
+			t, err := geo.ParseTrack(r, geo.TrackFormat(fileName))
+
+			_ = r.Close()
+
+			if err != nil {
+				log.Errorf("geotag: %s in %s", err, sanitize.Log(fileName))
+				AbortBadRequest(c)
+				return
+			}
+
+			track = track.Merge(t)
+		}
+
+		if len(track) == 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		opt := photoprism.GeotagOptions{
+			MaxGap: time.Duration(f.MaxGap) * time.Second,
+			Offset: time.Duration(f.Offset) * time.Second,
+			DryRun: f.DryRun,
+		}
+
+		matches, err := service.Geotag().Start(track, opt)
+
+		if err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrUnexpected)
+			return
+		}
+
+		if !opt.DryRun && len(matches) > 0 {
+			UpdateClientConfig()
+		}
+
+		c.JSON(http.StatusOK, matches)
+	})
+}
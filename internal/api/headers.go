@@ -11,6 +11,8 @@ import (
 
 const (
 	ContentTypeAvc = `video/mp4; codecs="avc1"`
+	ContentTypeMp4 = "video/mp4"
+	ContentTypeVtt = "text/vtt; charset=utf-8"
 )
 
 // AddCacheHeader adds a cache control header to the response.
@@ -28,6 +30,11 @@ func AddThumbCacheHeader(c *gin.Context) {
 	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%s, no-transform, immutable", ThumbCacheTTL.String()))
 }
 
+// AddRetryAfterHeader adds the number of seconds after which a request may be retried to the response.
+func AddRetryAfterHeader(c *gin.Context, seconds int) {
+	c.Header("Retry-After", strconv.Itoa(seconds))
+}
+
 // AddCountHeader adds the actual result count to the response.
 func AddCountHeader(c *gin.Context, count int) {
 	c.Header("X-Count", strconv.Itoa(count))
@@ -43,6 +50,18 @@ func AddOffsetHeader(c *gin.Context, offset int) {
 	c.Header("X-Offset", strconv.Itoa(offset))
 }
 
+// AddCursorHeader adds the cursor pointing to the next result page to the response, if any.
+func AddCursorHeader(c *gin.Context, cursor string) {
+	if cursor != "" {
+		c.Header("X-Next-Cursor", cursor)
+	}
+}
+
+// AddTotalHeader adds the total number of matching results to the response.
+func AddTotalHeader(c *gin.Context, total int) {
+	c.Header("X-Count-Total", strconv.Itoa(total))
+}
+
 // AddDownloadHeader adds a header indicating the response is expected to be downloaded.
 func AddDownloadHeader(c *gin.Context, fileName string) {
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/phash"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetPhotoSimilar returns files of other photos that look similar, e.g. re-encoded or resized copies.
+//
+// GET /api/v1/photos/:uid/similar
+//
+// Query:
+//   dist: int Max Hamming distance of perceptual hashes (0-64, default 10)
+func GetPhotoSimilar(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/similar", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := sanitize.IdString(c.Param("uid"))
+
+		if _, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		dist := phash.DefaultDistance
+
+		if d := c.Query("dist"); d != "" {
+			dist = txt.Int(d)
+		}
+
+		if dist < 0 || dist > phash.Bits {
+			AbortBadRequest(c)
+			return
+		}
+
+		result, err := search.SimilarPhotos(uid, dist)
+
+		if err != nil {
+			log.Warnf("search: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
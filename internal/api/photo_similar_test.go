@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPhotoSimilar(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoSimilar(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh9/similar")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.GreaterOrEqual(t, gjson.Get(r.Body.String(), "#").Int(), int64(1))
+		assert.NotContains(t, gjson.Get(r.Body.String(), "#.PhotoUID").String(), "pt9jtdre2lvl0yh9")
+		assert.LessOrEqual(t, gjson.Get(r.Body.String(), "0.Distance").Int(), int64(10))
+	})
+	t.Run("Distance", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoSimilar(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh9/similar?dist=0")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("InvalidDistance", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoSimilar(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh9/similar?dist=65")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoSimilar(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/similar")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
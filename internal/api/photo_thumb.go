@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"path/filepath"
 	"time"
@@ -8,11 +10,13 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/internal/thumb"
 
+	"github.com/photoprism/photoprism/pkg/focus"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
@@ -93,33 +97,46 @@ func GetThumb(router *gin.RouterGroup) {
 			}
 		}
 
+		// Choose file format based on the Accept header, e.g. to return WebP or AVIF thumbnails.
+		format := size.Format()
+
+		if len(size.Formats) > 0 {
+			c.Header("Vary", "Accept")
+
+			if !download {
+				format = size.Negotiate(c.GetHeader("Accept"))
+			}
+		}
+
+		opts := size.FormatOptions(format)
+
 		cache := service.ThumbCache()
-		cacheKey := CacheKey("thumbs", fileHash, string(thumbName))
+		cacheKey := CacheKey("thumbs", fileHash, string(thumbName)+"."+string(format))
 
 		if cacheData, ok := cache.Get(cacheKey); ok {
 			log.Tracef("api: cache hit for %s [%s]", cacheKey, time.Since(start))
 
 			cached := cacheData.(ThumbCache)
 
-			if !fs.FileExists(cached.FileName) {
-				log.Errorf("%s: %s not found", logPrefix, fileHash)
-				c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
-				return
-			}
+			if fs.FileExists(cached.FileName) {
+				if c.Query("download") != "" {
+					c.FileAttachment(cached.FileName, cached.ShareName)
+				} else {
+					AddThumbCacheHeader(c)
+					c.File(cached.FileName)
+				}
 
-			if c.Query("download") != "" {
-				c.FileAttachment(cached.FileName, cached.ShareName)
-			} else {
-				AddThumbCacheHeader(c)
-				c.File(cached.FileName)
+				return
 			}
 
-			return
+			// Remove stale entry, e.g. if the thumbnail was cropped again because the faces have changed.
+			log.Debugf("%s: %s not found", logPrefix, filepath.Base(cached.FileName))
+			cache.Delete(cacheKey)
 		}
 
-		// Return existing thumbs straight away.
-		if !download {
-			if fileName, err := thumb.FileName(fileHash, conf.ThumbPath(), size.Width, size.Height, size.Options...); err == nil && fs.FileExists(fileName) {
+		// Return existing thumbs straight away, smart crops depend on the faces in the index.
+		if !download && !size.Smart() {
+			if fileName, err := thumb.FileName(fileHash, conf.ThumbPath(), size.Width, size.Height, opts...); err == nil && fs.FileExists(fileName) {
 				AddThumbCacheHeader(c)
 				c.File(fileName)
 				return
@@ -182,13 +199,23 @@ func GetThumb(router *gin.RouterGroup) {
 
 		var thumbnail string
 
+		focusAreas := thumbFocus(&f, size)
+
 		if conf.ThumbUncached() || size.Uncached() {
-			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, f.FileOrientation, size.Options...)
+			thumbnail, err = thumb.FromQueue(c.Request.Context(), thumb.PriorityHigh, fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, f.FileOrientation, focusAreas, opts...)
 		} else {
-			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, size.Options...)
+			thumbnail, err = thumb.FromCacheFocus(fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, focusAreas, opts...)
 		}
 
-		if err != nil {
+		// Render thumbnail from the original image if it doesn't exist in the negotiated file format yet,
+		// or must be cropped again because the faces have changed, e.g. in another process.
+		if err == thumb.ErrThumbNotCached && (format != size.Format() || focusAreas.Hash() != "") {
+			thumbnail, err = thumb.FromQueue(c.Request.Context(), thumb.PriorityHigh, fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, f.FileOrientation, focusAreas, opts...)
+		}
+
+		if thumbUnavailable(c, err, brokenIconSvg) {
+			return
+		} else if err != nil {
 			log.Errorf("%s: %s", logPrefix, err)
 			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
 			return
@@ -209,3 +236,29 @@ func GetThumb(router *gin.RouterGroup) {
 		}
 	})
 }
+
+// ThumbRetryAfter is the number of seconds after which clients may retry when the render queue is full.
+var ThumbRetryAfter = 5
+
+// thumbUnavailable responds with 503 Service Unavailable and returns true if a thumbnail wasn't rendered
+// because the render queue is full, or because the client disconnected while it was waiting.
+func thumbUnavailable(c *gin.Context, err error, icon []byte) bool {
+	if errors.Is(err, thumb.ErrQueueFull) {
+		AddRetryAfterHeader(c, ThumbRetryAfter)
+	} else if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	c.Data(http.StatusServiceUnavailable, "image/svg+xml", icon)
+
+	return true
+}
+
+// thumbFocus returns the face areas to keep visible in smart cropped thumbnails of the file.
+func thumbFocus(f *entity.File, size thumb.Size) focus.Areas {
+	if !size.Smart() {
+		return nil
+	}
+
+	return f.ThumbFocus()
+}
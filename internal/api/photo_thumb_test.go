@@ -1,10 +1,15 @@
 package api
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/thumb"
 )
 
 func TestGetThumb(t *testing.T) {
@@ -22,6 +27,32 @@ func TestGetThumb(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("AcceptWebP", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetThumb(router)
+
+		req, _ := http.NewRequest("GET", "/api/v1/t/1/"+conf.PreviewToken()+"/tile_500", nil)
+		req.Header.Set("Accept", "image/avif,image/webp,*/*")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Accept", w.Header().Get("Vary"))
+	})
+	t.Run("CustomSize", func(t *testing.T) {
+		if err := thumb.AddCustomSize(thumb.CustomSize{Name: "signage_720", Width: 405, Height: 720, Options: []string{"fill"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		defer thumb.ResetCustomSizes()
+
+		app, router, conf := NewApiTest()
+		GetThumb(router)
+		r := PerformRequest(app, "GET", "/api/v1/t/1/"+conf.PreviewToken()+"/signage_720")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Accept", r.Header().Get("Vary"))
+	})
 	t.Run("could not find original", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		GetThumb(router)
@@ -74,3 +105,29 @@ func TestGetThumb(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, r.Code)
 	})
 }
+
+func TestThumbUnavailable(t *testing.T) {
+	t.Run("QueueFull", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		assert.True(t, thumbUnavailable(c, thumb.ErrQueueFull, brokenIconSvg))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "5", w.Header().Get("Retry-After"))
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		assert.True(t, thumbUnavailable(c, context.Canceled, brokenIconSvg))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "", w.Header().Get("Retry-After"))
+	})
+	t.Run("Error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		assert.False(t, thumbUnavailable(c, thumb.ErrThumbNotCached, brokenIconSvg))
+		assert.False(t, thumbUnavailable(c, nil, brokenIconSvg))
+	})
+}
@@ -38,7 +38,7 @@ func SearchAlbums(router *gin.RouterGroup) {
 			f.UID = s.Shares.Join(txt.Or)
 		}
 
-		result, err := search.Albums(f)
+		result, page, err := search.AlbumsPaged(f)
 
 		if err != nil {
 			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
@@ -48,8 +48,13 @@ func SearchAlbums(router *gin.RouterGroup) {
 		AddCountHeader(c, len(result))
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
+		AddCursorHeader(c, page.Cursor)
 		AddTokenHeaders(c)
 
+		if f.Total {
+			AddTotalHeader(c, page.Total)
+		}
+
 		c.JSON(http.StatusOK, result)
 	})
 }
@@ -18,6 +18,14 @@ func TestSearchAlbums(t *testing.T) {
 		assert.LessOrEqual(t, int64(3), count.Int())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("cursor and total", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchAlbums(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums?count=2&total=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, r.Header().Get("X-Count-Total"))
+		assert.NotEmpty(t, r.Header().Get("X-Next-Cursor"))
+	})
 	t.Run("invalid request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		SearchAlbums(router)
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// SearchFacets returns the number of matching pictures grouped by camera, lens, year, country,
+// label, color, ISO and focal length as JSON.
+//
+// GET /api/v1/facets
+//
+// Query:
+//   q:         string Query string, same filters as for "/api/v1/photos"
+//   count:     int    Max number of values per facet (required)
+func SearchFacets(router *gin.RouterGroup) {
+	router.GET("/facets", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.SearchPhotos
+
+		err := c.MustBindWith(&f, binding.Form)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Guests may only see public content in shared albums.
+		if s.Guest() {
+			if f.Album == "" || !s.HasShare(f.Album) {
+				AbortUnauthorized(c)
+				return
+			}
+
+			f.UID = ""
+			f.Public = true
+			f.Private = false
+			f.Hidden = false
+			f.Archived = false
+			f.Review = false
+		}
+
+		result, err := search.PhotoFacets(f)
+
+		if queryErr, ok := err.(*form.QueryError); ok {
+			AbortInvalidQuery(c, queryErr)
+			return
+		} else if err != nil {
+			log.Warnf("search: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestSearchFacets(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchFacets(router)
+		r := PerformRequest(app, "GET", "/api/v1/facets?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "Cameras.#").Int())
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "Years.#").Int())
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "Years.0.Value").String())
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "Years.0.Count").Int())
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchFacets(router)
+		r := PerformRequest(app, "GET", "/api/v1/facets?xxx=10")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidQuery", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchFacets(router)
+		r := PerformRequest(app, "GET", "/api/v1/facets?count=10&q="+url.QueryEscape("label:cat | xyz:foo"))
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -16,6 +16,7 @@ import (
 )
 
 // SearchGeo finds photos and returns results as JSON, so they can be displayed on a map or in a viewer.
+// Nearby photos are grouped by S2 cell if "cluster" is set, using the "zoom" level of the map.
 //
 // GET /api/v1/geo
 func SearchGeo(router *gin.RouterGroup) {
@@ -49,6 +50,29 @@ func SearchGeo(router *gin.RouterGroup) {
 			f.Review = false
 		}
 
+		// Group nearby pictures?
+		if f.Cluster {
+			clusters, err := search.GeoCluster(f)
+
+			if err != nil {
+				log.Warnf("search: %s", err)
+				AbortBadRequest(c)
+				return
+			}
+
+			resp, err := clusters.GeoJSON()
+
+			if err != nil {
+				c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			AddTokenHeaders(c)
+
+			c.Data(http.StatusOK, "application/json", resp)
+			return
+		}
+
 		// Find matching pictures.
 		photos, err := search.Geo(f)
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
 )
 
 func TestSearchGeo(t *testing.T) {
@@ -26,4 +27,34 @@ func TestSearchGeo(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 		t.Logf("response: %s", r.Body.String())
 	})
+	t.Run("BBox", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		SearchGeo(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo?bbox=-99,19.5,-98.5,20")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "features.#").Int())
+	})
+	t.Run("InvalidBBox", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		SearchGeo(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo?bbox=foo")
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Cluster", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		SearchGeo(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo?cluster=true&zoom=2")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "features.#").Int())
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "features.0.properties.Count").Int())
+	})
 }
@@ -32,18 +32,23 @@ func SearchLabels(router *gin.RouterGroup) {
 			return
 		}
 
-		result, err := search.Labels(f)
+		result, page, err := search.LabelsPaged(f)
 
 		if err != nil {
 			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
 			return
 		}
 
-		// TODO c.Header("X-Count", strconv.Itoa(count))
+		AddCountHeader(c, len(result))
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
+		AddCursorHeader(c, page.Cursor)
 		AddTokenHeaders(c)
 
+		if f.Total {
+			AddTotalHeader(c, page.Total)
+		}
+
 		c.JSON(http.StatusOK, result)
 	})
 }
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// SearchMemories finds pictures taken on the same day, or in the same week, in earlier years
+// and returns them as JSON.
+//
+// GET /api/v1/memories
+//
+// Query:
+//   date:      string Date as "YYYY-MM-DD", defaults to today
+//   week:      bool   Include pictures taken a few days before and after
+//   count:     int    Max result count
+//   offset:    int    Result offset
+func SearchMemories(router *gin.RouterGroup) {
+	router.GET("/memories", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+
+		// Guests may only see shared albums.
+		if s.Invalid() || s.Guest() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.SearchMemories
+
+		err := c.MustBindWith(&f, binding.Form)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		result, err := search.Memories(f)
+
+		if err != nil {
+			log.Warnf("search: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, f.Count)
+		AddOffsetHeader(c, f.Offset)
+		AddTokenHeaders(c)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestSearchMemories(t *testing.T) {
+	t.Run("Day", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchMemories(router)
+		r := PerformRequest(app, "GET", "/api/v1/memories?date=2021-11-11&count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(2), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, int64(2020), gjson.Get(r.Body.String(), "0.Year").Int())
+	})
+	t.Run("Week", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchMemories(router)
+		r := PerformRequest(app, "GET", "/api/v1/memories?date=2021-11-14&week=true&count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(2), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("InvalidDate", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchMemories(router)
+		r := PerformRequest(app, "GET", "/api/v1/memories?date=foo")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
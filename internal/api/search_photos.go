@@ -15,7 +15,7 @@ import (
 // GET /api/v1/photos
 //
 // Query:
-//   q:         string Query string
+//   q:         string Query string, e.g. "(label:cat | label:dog) -country:de iso:>1600 taken:2019..2021"
 //   label:     string Label
 //   cat:       string Category
 //   country:   string Country code
@@ -23,6 +23,8 @@ import (
 //   order:     string Sort order
 //   count:     int    Max result count (required)
 //   offset:    int    Result offset
+//   cursor:    string Continue after the last result of the previous page, see "X-Next-Cursor" header
+//   total:     bool   Return the total number of matches in the "X-Count-Total" header
 //   before:    date   Find photos taken before (format: "2006-01-02")
 //   after:     date   Find photos taken after (format: "2006-01-02")
 //   favorite:  bool   Find favorites only
@@ -59,9 +61,12 @@ func SearchPhotos(router *gin.RouterGroup) {
 			f.Review = false
 		}
 
-		result, count, err := search.Photos(f)
+		result, count, page, err := search.PhotosPaged(f)
 
-		if err != nil {
+		if queryErr, ok := err.(*form.QueryError); ok {
+			AbortInvalidQuery(c, queryErr)
+			return
+		} else if err != nil {
 			log.Warnf("search: %s", err)
 			AbortBadRequest(c)
 			return
@@ -70,8 +75,13 @@ func SearchPhotos(router *gin.RouterGroup) {
 		AddCountHeader(c, count)
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
+		AddCursorHeader(c, page.Cursor)
 		AddTokenHeaders(c)
 
+		if f.Total {
+			AddTotalHeader(c, page.Total)
+		}
+
 		c.JSON(http.StatusOK, result)
 	})
 }
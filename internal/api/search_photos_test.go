@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/tidwall/gjson"
@@ -19,10 +20,48 @@ func TestSearchPhotos(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 
+	t.Run("Cursor", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=2&total=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, r.Header().Get("X-Count-Total"))
+		cursor := r.Header().Get("X-Next-Cursor")
+		assert.NotEmpty(t, cursor)
+		next := PerformRequest(app, "GET", "/api/v1/photos?count=2&cursor="+url.QueryEscape(cursor))
+		assert.Equal(t, http.StatusOK, next.Code)
+		assert.Empty(t, next.Header().Get("X-Count-Total"))
+		assert.NotEqual(t, gjson.Get(r.Body.String(), "0.UID").String(), gjson.Get(next.Body.String(), "0.UID").String())
+	})
+
+	t.Run("InvalidCursor", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=2&cursor=foo")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
 	t.Run("InvalidRequest", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		SearchPhotos(router)
 		result := PerformRequest(app, "GET", "/api/v1/photos?xxx=10")
 		assert.Equal(t, http.StatusBadRequest, result.Code)
 	})
+
+	t.Run("BooleanQuery", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=10&q="+url.QueryEscape("(label:cat | label:flower) -country:de"))
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=10&q="+url.QueryEscape("label:cat | xyz:foo"))
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "Invalid search query", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, "unknown filter: Xyz at position 13", gjson.Get(r.Body.String(), "details").String())
+		assert.Equal(t, int64(13), gjson.Get(r.Body.String(), "position").Int())
+	})
 }
@@ -33,7 +33,7 @@ func SearchSubjects(router *gin.RouterGroup) {
 			return
 		}
 
-		result, err := search.Subjects(f)
+		result, page, err := search.SubjectsPaged(f)
 
 		if err != nil {
 			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
@@ -43,8 +43,13 @@ func SearchSubjects(router *gin.RouterGroup) {
 		AddCountHeader(c, len(result))
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
+		AddCursorHeader(c, page.Cursor)
 		AddTokenHeaders(c)
 
+		if f.Total {
+			AddTotalHeader(c, page.Total)
+		}
+
 		c.JSON(http.StatusOK, result)
 	})
 }
@@ -101,7 +101,7 @@ func SharePreview(router *gin.RouterGroup) {
 				return
 			}
 
-			thumbnail, err := thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, f.FileOrientation, size.Options...)
+			thumbnail, err := thumb.FromQueue(c.Request.Context(), thumb.PriorityHigh, fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, f.FileOrientation, nil, size.Options...)
 
 			if err != nil {
 				log.Error(err)
@@ -131,7 +131,7 @@ func SharePreview(router *gin.RouterGroup) {
 				return
 			}
 
-			thumbnail, err := thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, f.FileOrientation, size.Options...)
+			thumbnail, err := thumb.FromQueue(c.Request.Context(), thumb.PriorityHigh, fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, f.FileOrientation, nil, size.Options...)
 
 			if err != nil {
 				log.Error(err)
@@ -4,11 +4,13 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/thumb"
 )
 
 // GET /api/v1/status
 func GetStatus(router *gin.RouterGroup) {
 	router.GET("/status", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "operational"})
+		c.JSON(http.StatusOK, gin.H{"status": "operational", "thumbs": thumb.RenderQueue.Status()})
 	})
 }
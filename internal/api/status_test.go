@@ -15,6 +15,8 @@ func TestGetStatus(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/status")
 		val := gjson.Get(r.Body.String(), "status")
 		assert.Equal(t, "operational", val.String())
+		assert.True(t, gjson.Get(r.Body.String(), "thumbs.workers").Int() >= 1)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "thumbs.queued").Int())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
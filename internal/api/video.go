@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/photoprism/photoprism/pkg/sanitize"
 
@@ -11,15 +12,20 @@ import (
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
+// VideoTypeAuto selects the video type based on the codecs supported by the client.
+const VideoTypeAuto = "auto"
+
 // GetVideo streams videos.
 //
 // GET /api/v1/videos/:hash/:token/:type
 //
 // Parameters:
 //   hash: string The photo or video file hash as returned by the search API
-//   type: string Video type
+//   type: string Video type, or "auto" to select it based on the codecs supported by the client
+//   codecs: string Comma-separated list of codecs supported by the client, e.g. "av01,vp09,hvc1,avc1"
 func GetVideo(router *gin.RouterGroup) {
 	router.GET("/videos/:hash/:token/:type", func(c *gin.Context) {
 		if InvalidPreviewToken(c) {
@@ -32,6 +38,10 @@ func GetVideo(router *gin.RouterGroup) {
 
 		videoType, ok := video.Types[typeName]
 
+		if typeName == VideoTypeAuto {
+			ok = true
+		}
+
 		if !ok {
 			log.Errorf("video: invalid type %s", sanitize.Log(typeName))
 			c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
@@ -64,7 +74,18 @@ func GetVideo(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-		if mf, err := photoprism.NewMediaFile(fileName); err != nil {
+		// Select the video type based on the codecs supported by the client?
+		transcode := f.FileCodec != string(videoType.Codec)
+
+		if typeName == VideoTypeAuto {
+			clientCodecs := strings.Split(c.Query("codecs"), ",")
+			videoType, ok = video.Select(f.FileCodec, clientCodecs, service.Config().VideoCodecs())
+			transcode = !ok
+		}
+
+		mf, err := photoprism.NewMediaFile(fileName)
+
+		if err != nil {
 			log.Errorf("video: file %s is missing", sanitize.Log(f.FileName))
 			c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
 
@@ -72,19 +93,38 @@ func GetVideo(router *gin.RouterGroup) {
 			logError("video", f.Update("FileMissing", true))
 
 			return
-		} else if f.FileCodec != string(videoType.Codec) {
+		}
+
+		// Content type of the original file, unless it must be transcoded.
+		contentType := f.FileMime
+
+		if contentType == "" {
+			contentType = mf.MimeType()
+		}
+
+		if transcode {
 			conv := service.Convert()
 
-			if avcFile, err := conv.ToAvc(mf, service.Config().FFmpegEncoder()); err != nil {
+			// The configured encoder is used for AVC, e.g. to support hardware transcoding.
+			encoderName := ""
+
+			if videoType.Codec == fs.CodecAvc {
+				encoderName = service.Config().FFmpegEncoder()
+			}
+
+			if videoFile, err := conv.ToVideo(mf, videoType, encoderName); err != nil {
 				log.Errorf("video: transcoding %s failed", sanitize.Log(f.FileName))
 				c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
 				return
 			} else {
-				fileName = avcFile.FileName()
+				fileName = videoFile.FileName()
+				contentType = videoType.Mime
 			}
 		}
 
-		AddContentTypeHeader(c, ContentTypeAvc)
+		if contentType != "" {
+			AddContentTypeHeader(c, contentType)
+		}
 
 		if c.Query("download") != "" {
 			c.FileAttachment(fileName, f.DownloadName(DownloadName(c), 0))
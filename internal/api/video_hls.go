@@ -0,0 +1,183 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+const (
+	ContentTypeHlsPlaylist = "application/vnd.apple.mpegurl"
+	ContentTypeHlsSegment  = "video/mp2t"
+	HlsMasterPlaylist      = "index.m3u8"
+)
+
+// GetVideoHls returns HLS playlists for adaptive video streaming.
+//
+// GET /api/v1/hls/:hash/:token/:name
+//
+// Parameters:
+//   hash: string The photo or video file hash as returned by the search API
+//   name: string Playlist name, "index.m3u8" for the master playlist or e.g. "720p.m3u8" for a rendition
+func GetVideoHls(router *gin.RouterGroup) {
+	router.GET("/hls/:hash/:token/:name", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if service.Config().DisableFFmpeg() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		f, fileName, ok := videoFile(c)
+
+		if !ok {
+			return
+		}
+
+		name := sanitize.Token(c.Param("name"))
+
+		AddContentTypeHeader(c, ContentTypeHlsPlaylist)
+
+		if name == HlsMasterPlaylist {
+			c.String(http.StatusOK, video.HlsMasterPlaylist(f.FileWidth, f.FileHeight))
+			return
+		}
+
+		r, found := video.FindRendition(strings.TrimSuffix(name, ".m3u8"))
+
+		if !found || !strings.HasSuffix(name, ".m3u8") {
+			Abort(c, http.StatusNotFound, i18n.ErrNotFound)
+			return
+		}
+
+		c.String(http.StatusOK, video.HlsMediaPlaylist(r, hlsVideoDuration(f, fileName)))
+	})
+}
+
+// GetVideoHlsSegment returns an HLS video segment, which is transcoded on demand if not cached yet.
+//
+// GET /api/v1/hls/:hash/:token/:name/:segment
+//
+// Parameters:
+//   hash: string The photo or video file hash as returned by the search API
+//   name: string Rendition name, e.g. "720p"
+//   segment: string Segment file name, e.g. "0.ts"
+func GetVideoHlsSegment(router *gin.RouterGroup) {
+	router.GET("/hls/:hash/:token/:name/:segment", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if service.Config().DisableFFmpeg() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		r, found := video.FindRendition(sanitize.Token(c.Param("name")))
+
+		if !found {
+			Abort(c, http.StatusNotFound, i18n.ErrNotFound)
+			return
+		}
+
+		segment := sanitize.Token(c.Param("segment"))
+		index, err := strconv.Atoi(strings.TrimSuffix(segment, ".ts"))
+
+		if err != nil || !strings.HasSuffix(segment, ".ts") {
+			Abort(c, http.StatusNotFound, i18n.ErrNotFound)
+			return
+		}
+
+		f, fileName, ok := videoFile(c)
+
+		if !ok {
+			return
+		}
+
+		// Transcoding is canceled if the client disconnects.
+		segmentName, err := service.Convert().ToHlsSegment(c.Request.Context(), fileName, f.FileHash, r, index, hlsVideoDuration(f, fileName))
+
+		if err != nil {
+			if c.Request.Context().Err() != nil {
+				log.Debugf("video: %s segment %d of %s canceled", r.Name, index, sanitize.Log(f.FileName))
+				return
+			}
+
+			log.Errorf("video: %s (transcode segment)", err)
+			Abort(c, http.StatusNotFound, i18n.ErrFileNotFound)
+			return
+		}
+
+		AddThumbCacheHeader(c)
+		AddContentTypeHeader(c, ContentTypeHlsSegment)
+
+		c.File(segmentName)
+	})
+}
+
+// videoFile finds the video file for a streaming request and aborts the request if it can't be streamed.
+func videoFile(c *gin.Context) (f entity.File, fileName string, ok bool) {
+	fileHash := sanitize.Token(c.Param("hash"))
+
+	f, err := query.FileByHash(fileHash)
+
+	if err != nil {
+		Abort(c, http.StatusNotFound, i18n.ErrFileNotFound)
+		return f, "", false
+	}
+
+	if !f.FileVideo {
+		if f, err = query.VideoByPhotoUID(f.PhotoUID); err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrFileNotFound)
+			return f, "", false
+		}
+	}
+
+	if f.FileError != "" {
+		log.Errorf("video: file error %s", f.FileError)
+		Abort(c, http.StatusNotFound, i18n.ErrFileNotFound)
+		return f, "", false
+	}
+
+	fileName = photoprism.FileName(f.FileRoot, f.FileName)
+
+	if _, err = photoprism.NewMediaFile(fileName); err != nil {
+		log.Errorf("video: file %s is missing", sanitize.Log(f.FileName))
+		Abort(c, http.StatusNotFound, i18n.ErrFileNotFound)
+
+		// Set missing flag so that the file doesn't show up in search results anymore.
+		logError("video", f.Update("FileMissing", true))
+
+		return f, "", false
+	}
+
+	return f, fileName, true
+}
+
+// hlsVideoDuration returns the video duration, reading it from the file if it's not indexed yet.
+func hlsVideoDuration(f entity.File, fileName string) time.Duration {
+	if f.FileDuration > 0 {
+		return f.FileDuration
+	}
+
+	if mf, err := photoprism.NewMediaFile(fileName); err == nil {
+		return mf.MetaData().Duration
+	}
+
+	return 0
+}
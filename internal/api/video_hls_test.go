@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVideoHls(t *testing.T) {
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetVideoHls(router)
+		r := PerformRequest(app, "GET", "/api/v1/hls/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/xxx/index.m3u8")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoHls(router)
+		r := PerformRequest(app, "GET", "/api/v1/hls/xxx/"+conf.PreviewToken()+"/index.m3u8")
+
+		if conf.DisableFFmpeg() {
+			assert.Equal(t, http.StatusForbidden, r.Code)
+		} else {
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		}
+	})
+	t.Run("FileWithError", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoHls(router)
+		r := PerformRequest(app, "GET", "/api/v1/hls/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/"+conf.PreviewToken()+"/720p.m3u8")
+
+		if conf.DisableFFmpeg() {
+			assert.Equal(t, http.StatusForbidden, r.Code)
+		} else {
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		}
+	})
+}
+
+func TestGetVideoHlsSegment(t *testing.T) {
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetVideoHlsSegment(router)
+		r := PerformRequest(app, "GET", "/api/v1/hls/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/xxx/720p/0.ts")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("InvalidRendition", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoHlsSegment(router)
+		r := PerformRequest(app, "GET", "/api/v1/hls/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/123p/0.ts")
+
+		if conf.DisableFFmpeg() {
+			assert.Equal(t, http.StatusForbidden, r.Code)
+		} else {
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		}
+	})
+	t.Run("InvalidSegment", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoHlsSegment(router)
+		r := PerformRequest(app, "GET", "/api/v1/hls/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/720p/abc.ts")
+
+		if conf.DisableFFmpeg() {
+			assert.Equal(t, http.StatusForbidden, r.Code)
+		} else {
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// VideoPreviewTypes maps video preview file names to content types.
+var VideoPreviewTypes = map[string]string{
+	video.PreviewClip:   ContentTypeMp4,
+	video.PreviewSprite: fs.MimeTypeJpeg,
+	video.PreviewVtt:    ContentTypeVtt,
+}
+
+// GetVideoPreview returns animated video previews, scrubbing sprites, and WebVTT thumbnail tracks.
+//
+// GET /api/v1/previews/:hash/:token/:name
+//
+// Parameters:
+//   hash: string The photo or video file hash as returned by the search API
+//   name: string Preview file name, either "clip.mp4", "sprite.jpg", or "sprite.vtt"
+func GetVideoPreview(router *gin.RouterGroup) {
+	router.GET("/previews/:hash/:token/:name", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if conf.DisableVideoPreviews() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		name := c.Param("name")
+		contentType, ok := VideoPreviewTypes[name]
+
+		if !ok {
+			Abort(c, http.StatusNotFound, i18n.ErrNotFound)
+			return
+		}
+
+		f, _, ok := videoFile(c)
+
+		if !ok {
+			return
+		}
+
+		fileName, err := photoprism.VideoPreviewName(conf.ThumbPath(), f.FileHash, name)
+
+		if err != nil || !fs.FileExists(fileName) {
+			// Previews are created by a background worker, so they may not exist yet.
+			log.Debugf("video: %s of %s not found", name, sanitize.Log(f.FileName))
+
+			// Create previews again if they have been removed from the cache, but not if they
+			// were skipped on purpose, e.g. sprites of videos with unknown duration.
+			if f.PreviewsAt != nil && f.PreviewErrors == 0 && photoprism.VideoPreviewExpected(f, name) {
+				logError("video", f.ResetPreviews())
+			}
+
+			Abort(c, http.StatusNotFound, i18n.ErrFileNotFound)
+			return
+		}
+
+		AddThumbCacheHeader(c)
+		AddContentTypeHeader(c, contentType)
+
+		c.File(fileName)
+	})
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVideoPreview(t *testing.T) {
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetVideoPreview(router)
+		r := PerformRequest(app, "GET", "/api/v1/previews/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/xxx/clip.mp4")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("InvalidName", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoPreview(router)
+		r := PerformRequest(app, "GET", "/api/v1/previews/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/foo.mp4")
+
+		if conf.DisableVideoPreviews() {
+			assert.Equal(t, http.StatusForbidden, r.Code)
+		} else {
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoPreview(router)
+		r := PerformRequest(app, "GET", "/api/v1/previews/xxx/"+conf.PreviewToken()+"/sprite.vtt")
+
+		if conf.DisableVideoPreviews() {
+			assert.Equal(t, http.StatusForbidden, r.Code)
+		} else {
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		}
+	})
+}
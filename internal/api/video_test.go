@@ -48,4 +48,10 @@ func TestGetVideo(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/videos/ocad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+conf.PreviewToken()+"/mp4")
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("auto", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideo(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/auto?codecs=vp09,avc1")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
 }
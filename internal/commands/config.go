@@ -9,6 +9,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/thumb"
 )
 
 // ConfigCommand registers the display config cli command.
@@ -62,6 +63,8 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %d\n", "wakeup-interval", conf.WakeupInterval()/time.Second)
 	fmt.Printf("%-25s %d\n", "auto-index", conf.AutoIndex()/time.Second)
 	fmt.Printf("%-25s %d\n", "auto-import", conf.AutoImport()/time.Second)
+	fmt.Printf("%-25s %s\n", "search-index", conf.SearchIndex())
+	fmt.Printf("%-25s %t\n", "memories-album", conf.MemoriesAlbum())
 
 	// Features.
 	fmt.Printf("%-25s %t\n", "disable-backups", conf.DisableBackups())
@@ -76,6 +79,7 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %t\n", "disable-sips", conf.DisableSips())
 	fmt.Printf("%-25s %t\n", "disable-heifconvert", conf.DisableHeifConvert())
 	fmt.Printf("%-25s %t\n", "disable-ffmpeg", conf.DisableFFmpeg())
+	fmt.Printf("%-25s %t\n", "disable-video-previews", conf.DisableVideoPreviews())
 
 	// TensorFlow.
 	fmt.Printf("%-25s %s\n", "tensorflow-version", conf.TensorFlowVersion())
@@ -128,22 +132,29 @@ func configAction(ctx *cli.Context) error {
 
 	// External Tools.
 	fmt.Printf("%-25s %t\n", "raw-presets", conf.RawPresets())
+	fmt.Printf("%-25s %t\n", "prefer-raw-previews", conf.PreferRawPreviews())
 	fmt.Printf("%-25s %s\n", "darktable-bin", conf.DarktableBin())
 	fmt.Printf("%-25s %s\n", "darktable-blacklist", conf.DarktableBlacklist())
 	fmt.Printf("%-25s %s\n", "rawtherapee-bin", conf.RawtherapeeBin())
 	fmt.Printf("%-25s %s\n", "rawtherapee-blacklist", conf.RawtherapeeBlacklist())
 	fmt.Printf("%-25s %s\n", "sips-bin", conf.SipsBin())
 	fmt.Printf("%-25s %s\n", "heifconvert-bin", conf.HeifConvertBin())
+	fmt.Printf("%-25s %s\n", "cwebp-bin", conf.CwebpBin())
+	fmt.Printf("%-25s %s\n", "avifenc-bin", conf.AvifencBin())
 	fmt.Printf("%-25s %s\n", "ffmpeg-bin", conf.FFmpegBin())
 	fmt.Printf("%-25s %s\n", "ffmpeg-encoder", conf.FFmpegEncoder())
 	fmt.Printf("%-25s %d\n", "ffmpeg-bitrate", conf.FFmpegBitrate())
 	fmt.Printf("%-25s %d\n", "ffmpeg-buffers", conf.FFmpegBuffers())
+	fmt.Printf("%-25s %s\n", "video-poster", conf.VideoPoster())
+	fmt.Printf("%-25s %s\n", "video-codecs", strings.Join(conf.VideoCodecs(), ","))
 	fmt.Printf("%-25s %s\n", "exiftool-bin", conf.ExifToolBin())
 
 	// Thumbnails.
 	fmt.Printf("%-25s %s\n", "download-token", conf.DownloadToken())
 	fmt.Printf("%-25s %s\n", "preview-token", conf.PreviewToken())
 	fmt.Printf("%-25s %s\n", "thumb-filter", conf.ThumbFilter())
+	fmt.Printf("%-25s %s\n", "thumb-crop", thumb.ResampleMethods[conf.ThumbCrop()])
+	fmt.Printf("%-25s %s\n", "thumb-sizes", strings.Join(conf.CustomThumbSizes().Names(), ","))
 	fmt.Printf("%-25s %t\n", "thumb-uncached", conf.ThumbUncached())
 	fmt.Printf("%-25s %d\n", "thumb-size", conf.ThumbSizePrecached())
 	fmt.Printf("%-25s %d\n", "thumb-size-uncached", conf.ThumbSizeUncached())
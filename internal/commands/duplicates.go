@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// DuplicatesCommand registers the duplicates command.
+var DuplicatesCommand = cli.Command{
+	Name:      "duplicates",
+	Usage:     "Reports duplicate files and, optionally, similar looking pictures",
+	ArgsUsage: "[path]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "similar, s",
+			Usage: "report visually similar pictures, e.g. re-encoded or resized copies",
+		},
+		cli.IntFlag{
+			Name:  "dist, d",
+			Usage: "max `DISTANCE` of similar pictures (0-64)",
+			Value: phash.DefaultDistance,
+		},
+	},
+	Action: duplicatesAction,
+}
+
+// duplicatesAction reports duplicate and similar files.
+func duplicatesAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		if ctx.Bool("similar") {
+			return reportSimilar(ctx.Int("dist"))
+		}
+
+		return reportDuplicates(ctx.Args().First())
+	})
+}
+
+// reportDuplicates prints files that were skipped because an identical file has already been indexed.
+func reportDuplicates(pathName string) error {
+	const batchSize = 1000
+
+	fmt.Printf("%-40s %-10s %-40s %s\n", "HASH", "SIZE", "DUPLICATE", "ORIGINAL")
+
+	count := 0
+
+	for offset := 0; ; offset += batchSize {
+		duplicates, err := query.Duplicates(batchSize, offset, pathName)
+
+		if err != nil {
+			return err
+		}
+
+		for _, d := range duplicates {
+			original := ""
+
+			if f, err := entity.FirstFileByHash(d.FileHash); err == nil {
+				original = filepath.Join(f.FileRoot, f.FileName)
+			}
+
+			fmt.Printf("%-40s %-10s %-40s %s\n", d.FileHash, humanize.Bytes(uint64(d.FileSize)), filepath.Join(d.FileRoot, d.FileName), original)
+		}
+
+		count += len(duplicates)
+
+		if len(duplicates) < batchSize {
+			break
+		}
+	}
+
+	log.Infof("found %s", english.Plural(count, "duplicate", "duplicates"))
+
+	return nil
+}
+
+// reportSimilar prints groups of pictures with similar perceptual hashes.
+func reportSimilar(dist int) error {
+	if dist < 0 || dist > phash.Bits {
+		return fmt.Errorf("distance must be between 0 and %d", phash.Bits)
+	}
+
+	groups, err := search.SimilarGroups(dist)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-5s %-4s %-16s %-16s %-10s %s\n", "GROUP", "DIST", "PHOTO", "PHASH", "SIZE", "NAME")
+
+	for i, group := range groups {
+		for _, f := range group {
+			fmt.Printf("%-5d %-4d %-16s %-16s %-10s %s\n", i+1, f.Distance, f.PhotoUID, f.FilePHash, humanize.Bytes(uint64(f.FileSize)), filepath.Join(f.FileRoot, f.FileName))
+		}
+	}
+
+	log.Infof("found %s of similar pictures", english.Plural(len(groups), "group", "groups"))
+
+	return nil
+}
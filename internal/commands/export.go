@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// ExportCommand registers the export command.
+var ExportCommand = cli.Command{
+	Name:      "export",
+	Usage:     "Exports search results as CSV or JSON Lines, e.g. for spreadsheets",
+	ArgsUsage: "[query]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "format, f",
+			Usage: "output `FORMAT` (csv, jsonl)",
+			Value: search.ExportCSV,
+		},
+		cli.StringFlag{
+			Name:  "columns, c",
+			Usage: "comma-separated list of `COLUMNS` (" + strings.Join(search.ExportColumns, ", ") + ")",
+		},
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "output `FILENAME`, writes to stdout if empty",
+		},
+		cli.IntFlag{
+			Name:  "count, n",
+			Usage: "max `NUMBER` of exported pictures, 0 for no limit",
+		},
+	},
+	Action: exportAction,
+}
+
+// exportAction writes pictures matching the search query as CSV or JSON Lines.
+func exportAction(ctx *cli.Context) error {
+	format, err := search.ParseExportFormat(ctx.String("format"))
+
+	if err != nil {
+		return err
+	}
+
+	cols, err := search.ParseExportColumns(ctx.String("columns"))
+
+	if err != nil {
+		return err
+	}
+
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		var out io.Writer = os.Stdout
+
+		if fileName := ctx.String("output"); fileName != "" {
+			file, err := os.Create(fileName)
+
+			if err != nil {
+				return err
+			}
+
+			defer file.Close()
+
+			out = file
+		}
+
+		w := bufio.NewWriter(out)
+
+		f := form.SearchPhotos{
+			Query: strings.TrimSpace(strings.Join(ctx.Args(), " ")),
+			Count: ctx.Int("count"),
+		}
+
+		count, err := search.ExportPhotos(w, f, format, cols)
+
+		if err != nil {
+			return err
+		}
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if fileName := ctx.String("output"); fileName != "" {
+			log.Infof("export: saved %s in %s", english.Plural(count, "picture", "pictures"), sanitize.Log(fileName))
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// GeotagCommand registers the geotag cli command.
+var GeotagCommand = cli.Command{
+	Name:   "geotag",
+	Usage:  "Sets the location of photos without coordinates based on GPX, KML, or GeoJSON tracks",
+	Flags:  geotagFlags,
+	Action: geotagAction,
+}
+
+var geotagFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "track, t",
+		Usage: "track `FILE` in GPX, KML, or GeoJSON format, can be used multiple times",
+	},
+	cli.DurationFlag{
+		Name:  "max-gap",
+		Usage: "max time difference between a photo and the closest track point",
+		Value: photoprism.GeotagMaxGap,
+	},
+	cli.DurationFlag{
+		Name:  "offset",
+		Usage: "camera clock offset added to the time a photo was taken, e.g. -1h if the camera clock was one hour ahead",
+	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "show matching photos without updating them",
+	},
+}
+
+// geotagAction sets the location of photos based on GPS tracks.
+func geotagAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	fileNames := ctx.StringSlice("track")
+
+	if len(fileNames) == 0 {
+		return fmt.Errorf("no track file specified")
+	}
+
+	var track geo.Track
+
+	for _, fileName := range fileNames {
+		t, err := geo.ReadTrack(fileName)
+
+		if err != nil {
+			return fmt.Errorf("%s in %s", err, sanitize.Log(fileName))
+		}
+
+		log.Infof("geotag: read %s from %s", english.Plural(len(t), "position", "positions"), sanitize.Log(fileName))
+
+		track = track.Merge(t)
+	}
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	opt := photoprism.GeotagOptions{
+		MaxGap: ctx.Duration("max-gap"),
+		Offset: ctx.Duration("offset"),
+		DryRun: ctx.Bool("dry-run"),
+	}
+
+	matches, err := service.Geotag().Start(track, opt)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-16s %-20s %-11s %-11s %-8s %s\n", "UID", "TAKEN", "LAT", "LNG", "GAP", "FILE")
+
+	for _, m := range matches {
+		fmt.Printf("%-16s %-20s %-11.6f %-11.6f %-8s %s\n", m.PhotoUID, m.TakenAt.Format("2006-01-02 15:04:05"), m.Lat, m.Lng, m.Gap.Round(time.Second), m.FileName)
+	}
+
+	if opt.DryRun {
+		log.Infof("dry run, %s would be updated in %s", english.Plural(len(matches), "photo", "photos"), time.Since(start))
+	} else {
+		log.Infof("updated %s in %s", english.Plural(len(matches), "photo", "photos"), time.Since(start))
+	}
+
+	conf.Shutdown()
+
+	return nil
+}
@@ -1,12 +1,16 @@
 package commands
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
@@ -19,6 +23,10 @@ var ThumbsCommand = cli.Command{
 			Name:  "force, f",
 			Usage: "replace existing thumbnails",
 		},
+		cli.StringSliceFlag{
+			Name:  "format, t",
+			Usage: "also create thumbnails in additional file `FORMAT` (webp, avif)",
+		},
 	},
 	Action: thumbsAction,
 }
@@ -34,11 +42,17 @@ func thumbsAction(ctx *cli.Context) error {
 		return err
 	}
 
+	formats, err := thumbFormats(ctx.StringSlice("format"))
+
+	if err != nil {
+		return err
+	}
+
 	log.Infof("creating thumbnails in %s", sanitize.Log(conf.ThumbPath()))
 
 	rs := service.Resample()
 
-	if err := rs.Start(ctx.Bool("force")); err != nil {
+	if err := rs.Start(ctx.Bool("force"), formats...); err != nil {
 		log.Error(err)
 		return err
 	}
@@ -47,3 +61,27 @@ func thumbsAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// thumbFormats parses the additional thumbnail file formats and checks if encoders are available.
+func thumbFormats(values []string) (formats []fs.FileFormat, err error) {
+	for _, val := range values {
+		for _, s := range strings.Split(val, ",") {
+			format := fs.FileFormat(strings.ToLower(strings.TrimSpace(s)))
+
+			switch format {
+			case "":
+				continue
+			case fs.FormatWebP, fs.FormatAvif:
+				if !thumb.FormatSupported(format) {
+					return formats, fmt.Errorf("%s encoder not found, please check your configuration", format)
+				}
+
+				formats = append(formats, format)
+			default:
+				return formats, fmt.Errorf("unsupported thumbnail format %s", sanitize.Log(s))
+			}
+		}
+	}
+
+	return formats, nil
+}
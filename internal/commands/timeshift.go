@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// TimeShiftCommand registers the timeshift cli command.
+var TimeShiftCommand = cli.Command{
+	Name:      "timeshift",
+	Usage:     "Corrects the date of photos taken with a wrong camera clock",
+	ArgsUsage: "[offset]",
+	Flags:     timeShiftFlags,
+	Action:    timeShiftAction,
+	Subcommands: []cli.Command{
+		{
+			Name:      "undo",
+			Usage:     "Restores the photo dates before a time shift",
+			ArgsUsage: "[uid]",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "sidecar",
+					Usage: "update YAML sidecar files",
+				},
+			},
+			Action: timeShiftUndoAction,
+		},
+	},
+}
+
+var timeShiftFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "camera, c",
+		Usage: "camera `NAME` or ID",
+	},
+	cli.StringFlag{
+		Name:  "serial, s",
+		Usage: "camera serial `NUMBER`",
+	},
+	cli.StringFlag{
+		Name:  "album, a",
+		Usage: "album `UID`",
+	},
+	cli.StringFlag{
+		Name:  "folder, f",
+		Usage: "originals folder `PATH`, incl sub folders",
+	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "show matching photos without updating them",
+	},
+	cli.BoolFlag{
+		Name:  "sidecar",
+		Usage: "update YAML sidecar files",
+	},
+}
+
+// timeShiftAction shifts the date of matching photos by a time offset, e.g. "-1y" or "+2h30m".
+func timeShiftAction(ctx *cli.Context) error {
+	offset := strings.TrimSpace(ctx.Args().First())
+
+	if offset == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	f := form.TimeShift{
+		Offset:  offset,
+		Camera:  ctx.String("camera"),
+		Serial:  ctx.String("serial"),
+		Album:   ctx.String("album"),
+		Folder:  ctx.String("folder"),
+		Preview: ctx.Bool("dry-run"),
+		Sidecar: ctx.Bool("sidecar"),
+	}
+
+	if f.Empty() {
+		return fmt.Errorf("camera, serial, album, or folder must be specified")
+	}
+
+	return withTimeShift(ctx, func(w *photoprism.TimeShift) (photoprism.TimeShiftResult, error) {
+		return w.Start(f)
+	})
+}
+
+// timeShiftUndoAction restores the photo dates before a time shift.
+func timeShiftUndoAction(ctx *cli.Context) error {
+	uid := strings.TrimSpace(ctx.Args().First())
+
+	if uid == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	return withTimeShift(ctx, func(w *photoprism.TimeShift) (photoprism.TimeShiftResult, error) {
+		return w.Undo(uid, ctx.Bool("sidecar"))
+	})
+}
+
+// withTimeShift initializes the config, runs the time shift worker, and prints the result.
+func withTimeShift(ctx *cli.Context, run func(w *photoprism.TimeShift) (photoprism.TimeShiftResult, error)) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	defer conf.Shutdown()
+
+	result, err := run(service.TimeShift())
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-16s %-20s %-20s %s\n", "UID", "ORIGINAL", "SHIFTED", "FILE")
+
+	for _, p := range result.Photos {
+		fmt.Printf("%-16s %-20s %-20s %s\n", p.PhotoUID, p.TakenAtLocal.Format("2006-01-02 15:04:05"), p.ShiftedAtLocal.Format("2006-01-02 15:04:05"), p.FileName)
+	}
+
+	if result.Preview {
+		log.Infof("dry run, %s would be shifted by %s in %s", english.Plural(len(result.Photos), "photo", "photos"), result.Offset, time.Since(start))
+	} else {
+		log.Infof("time shift %s: %s by %s in %s", result.ShiftUID, english.Plural(len(result.Photos), "photo", "photos"), result.Offset, time.Since(start))
+	}
+
+	return nil
+}
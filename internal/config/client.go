@@ -84,6 +84,7 @@ type ClientDisable struct {
 	TensorFlow     bool `json:"tensorflow"`
 	Faces          bool `json:"faces"`
 	Classification bool `json:"classification"`
+	VideoPreviews  bool `json:"videopreviews"`
 }
 
 // ClientCounts represents photo, video and album counts for the client UI.
@@ -191,6 +192,7 @@ func (c *Config) PublicConfig() ClientConfig {
 			TensorFlow:     true,
 			Faces:          true,
 			Classification: true,
+			VideoPreviews:  true,
 		},
 		Flags:           strings.Join(c.Flags(), " "),
 		Mode:            "public",
@@ -261,6 +263,7 @@ func (c *Config) GuestConfig() ClientConfig {
 			TensorFlow:     true,
 			Faces:          true,
 			Classification: true,
+			VideoPreviews:  true,
 		},
 		Flags:           strings.Join(c.Flags(), " "),
 		Mode:            "guest",
@@ -326,6 +329,7 @@ func (c *Config) UserConfig() ClientConfig {
 			TensorFlow:     c.DisableTensorFlow(),
 			Faces:          c.DisableFaces(),
 			Classification: c.DisableClassification(),
+			VideoPreviews:  c.DisableVideoPreviews(),
 		},
 		Flags:           strings.Join(c.Flags(), " "),
 		Mode:            "user",
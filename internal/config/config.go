@@ -86,14 +86,7 @@ func init() {
 	}
 
 	// Init public thumb sizes for use in client apps.
-	for i := len(thumb.DefaultSizes) - 1; i >= 0; i-- {
-		name := thumb.DefaultSizes[i]
-		t := thumb.Sizes[name]
-
-		if t.Public {
-			Thumbs = append(Thumbs, ThumbSize{Size: string(name), Use: t.Use, Width: t.Width, Height: t.Height})
-		}
-	}
+	initThumbs()
 }
 
 func initLogger(debug bool) {
@@ -149,7 +142,12 @@ func (c *Config) Propagate() {
 	thumb.SizePrecached = c.ThumbSizePrecached()
 	thumb.SizeUncached = c.ThumbSizeUncached()
 	thumb.Filter = c.ThumbFilter()
+	thumb.Crop = c.ThumbCrop()
 	thumb.JpegQuality = c.JpegQuality()
+	thumb.WebPBin = c.CwebpBin()
+	thumb.AvifBin = c.AvifencBin()
+	c.initCustomThumbs()
+	thumb.RenderQueue.SetWorkers(c.Workers())
 
 	// Set geocoding parameters.
 	places.UserAgent = c.UserAgent()
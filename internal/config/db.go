@@ -242,6 +242,7 @@ func (c *Config) MigrateDb(runFailed bool) {
 	c.SetDbOptions()
 	entity.SetDbProvider(c)
 	entity.MigrateDb(true, runFailed)
+	c.initSearchIndex()
 
 	entity.Admin.InitPassword(c.AdminPassword())
 
@@ -253,6 +254,7 @@ func (c *Config) InitTestDb() {
 	c.SetDbOptions()
 	entity.SetDbProvider(c)
 	entity.ResetTestFixtures()
+	c.initSearchIndex()
 
 	entity.Admin.InitPassword(c.AdminPassword())
 
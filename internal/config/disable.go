@@ -70,6 +70,11 @@ func (c *Config) DisableFFmpeg() bool {
 	return c.options.DisableFFmpeg || c.FFmpegBin() == ""
 }
 
+// DisableVideoPreviews tests if creating animated video previews and scrubbing sprites is disabled.
+func (c *Config) DisableVideoPreviews() bool {
+	return c.options.DisableVideoPreviews || c.DisableFFmpeg()
+}
+
 // DisableDarktable tests if Darktable is disabled for RAW conversion.
 func (c *Config) DisableDarktable() bool {
 	if LowMem && !c.options.DisableDarktable {
@@ -51,3 +51,15 @@ func TestConfig_DisableClassification(t *testing.T) {
 	c.options.DisableTensorFlow = false
 	assert.False(t, c.DisableClassification())
 }
+
+func TestConfig_DisableVideoPreviews(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.DisableFFmpeg = false
+	c.options.FFmpegBin = "sh"
+	assert.False(t, c.DisableVideoPreviews())
+	c.options.DisableVideoPreviews = true
+	assert.True(t, c.DisableVideoPreviews())
+	c.options.DisableVideoPreviews = false
+	c.options.DisableFFmpeg = true
+	assert.True(t, c.DisableVideoPreviews())
+}
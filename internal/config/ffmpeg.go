@@ -1,5 +1,13 @@
 package config
 
+import (
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
 // FFmpegBin returns the ffmpeg executable file name.
 func (c *Config) FFmpegBin() string {
 	return findExecutable(c.options.FFmpegBin, "ffmpeg")
@@ -43,3 +51,43 @@ func (c *Config) FFmpegBitrate() int {
 		return c.options.FFmpegBitrate
 	}
 }
+
+// VideoPoster returns the video poster frame position, either "auto", "first", or a time offset like "5s".
+func (c *Config) VideoPoster() string {
+	s := strings.ToLower(strings.TrimSpace(c.options.VideoPoster))
+
+	switch s {
+	case "", "auto":
+		return "auto"
+	case "first":
+		return "first"
+	}
+
+	if d, err := time.ParseDuration(s); err != nil || d < 0 {
+		return "auto"
+	}
+
+	return s
+}
+
+// VideoCodecs returns the preferred video transcoding codecs, AVC by default.
+func (c *Config) VideoCodecs() (result []string) {
+	done := make(map[fs.FileCodec]bool)
+
+	for _, s := range strings.Split(c.options.VideoCodecs, ",") {
+		name := strings.ToLower(strings.TrimSpace(s))
+
+		if t, ok := video.FindCodec(name); !ok || done[t.Codec] {
+			continue
+		} else {
+			done[t.Codec] = true
+			result = append(result, name)
+		}
+	}
+
+	if len(result) == 0 {
+		return []string{"avc"}
+	}
+
+	return result
+}
@@ -46,3 +46,31 @@ func TestConfig_FFmpegBitrate(t *testing.T) {
 	c.options.FFmpegBitrate = 800
 	assert.Equal(t, 800, c.FFmpegBitrate())
 }
+
+func TestConfig_VideoPoster(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, "auto", c.VideoPoster())
+
+	c.options.VideoPoster = "First"
+	assert.Equal(t, "first", c.VideoPoster())
+
+	c.options.VideoPoster = "2.5s"
+	assert.Equal(t, "2.5s", c.VideoPoster())
+
+	c.options.VideoPoster = "-5s"
+	assert.Equal(t, "auto", c.VideoPoster())
+
+	c.options.VideoPoster = "foo"
+	assert.Equal(t, "auto", c.VideoPoster())
+}
+
+func TestConfig_VideoCodecs(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, []string{"avc"}, c.VideoCodecs())
+
+	c.options.VideoCodecs = " AV1, vp9,hvc1,hevc,foo "
+	assert.Equal(t, []string{"av1", "vp9", "hvc1"}, c.VideoCodecs())
+
+	c.options.VideoCodecs = "foo"
+	assert.Equal(t, []string{"avc"}, c.VideoCodecs())
+}
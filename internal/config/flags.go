@@ -150,6 +150,17 @@ var GlobalFlags = []cli.Flag{
 		Value:  DefaultAutoImportDelay,
 		EnvVar: "PHOTOPRISM_AUTO_IMPORT",
 	},
+	cli.StringFlag{
+		Name:   "search-index",
+		Usage:  "full-text search index `BACKEND` (none, embedded)",
+		Value:  "none",
+		EnvVar: "PHOTOPRISM_SEARCH_INDEX",
+	},
+	cli.BoolFlag{
+		Name:   "memories-album",
+		Usage:  "update an \"On This Day\" album with photos from earlier years once a day",
+		EnvVar: "PHOTOPRISM_MEMORIES_ALBUM",
+	},
 	cli.BoolFlag{
 		Name:   "disable-webdav",
 		Usage:  "disable built-in WebDAV server",
@@ -177,9 +188,14 @@ var GlobalFlags = []cli.Flag{
 	},
 	cli.BoolFlag{
 		Name:   "disable-ffmpeg",
-		Usage:  "disable video transcoding and thumbnail extraction with FFmpeg",
+		Usage:  "disable video transcoding, thumbnail extraction, and HEVC image decoding with FFmpeg",
 		EnvVar: "PHOTOPRISM_DISABLE_FFMPEG",
 	},
+	cli.BoolFlag{
+		Name:   "disable-video-previews",
+		Usage:  "disable creating animated video previews and scrubbing sprites",
+		EnvVar: "PHOTOPRISM_DISABLE_VIDEO_PREVIEWS",
+	},
 	cli.BoolFlag{
 		Name:   "disable-darktable",
 		Usage:  "disable converting RAW files with Darktable",
@@ -373,6 +389,11 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "enable RAW file converter presets (may reduce performance)",
 		EnvVar: "PHOTOPRISM_RAW_PRESETS",
 	},
+	cli.BoolFlag{
+		Name:   "prefer-raw-previews",
+		Usage:  "use embedded RAW previews of any size instead of external converters",
+		EnvVar: "PHOTOPRISM_PREFER_RAW_PREVIEWS",
+	},
 	cli.StringFlag{
 		Name:   "darktable-bin",
 		Usage:  "Darktable CLI `COMMAND` for RAW image conversion",
@@ -409,9 +430,21 @@ var GlobalFlags = []cli.Flag{
 		Value:  "heif-convert",
 		EnvVar: "PHOTOPRISM_HEIFCONVERT_BIN",
 	},
+	cli.StringFlag{
+		Name:   "cwebp-bin",
+		Usage:  "WebP image encoder `COMMAND` for thumbnails",
+		Value:  "cwebp",
+		EnvVar: "PHOTOPRISM_CWEBP_BIN",
+	},
+	cli.StringFlag{
+		Name:   "avifenc-bin",
+		Usage:  "AVIF image encoder `COMMAND` for thumbnails",
+		Value:  "avifenc",
+		EnvVar: "PHOTOPRISM_AVIFENC_BIN",
+	},
 	cli.StringFlag{
 		Name:   "ffmpeg-bin",
-		Usage:  "FFmpeg `COMMAND` for video transcoding and thumbnail extraction",
+		Usage:  "FFmpeg `COMMAND` for video transcoding, thumbnail extraction, and HEVC image decoding",
 		Value:  "ffmpeg",
 		EnvVar: "PHOTOPRISM_FFMPEG_BIN",
 	},
@@ -433,6 +466,18 @@ var GlobalFlags = []cli.Flag{
 		Value:  32,
 		EnvVar: "PHOTOPRISM_FFMPEG_BUFFERS",
 	},
+	cli.StringFlag{
+		Name:   "video-poster",
+		Usage:  "video poster frame `POSITION`: auto (skips black or blurry frames), first, or a time offset like 5s",
+		Value:  "auto",
+		EnvVar: "PHOTOPRISM_VIDEO_POSTER",
+	},
+	cli.StringFlag{
+		Name:   "video-codecs",
+		Usage:  "preferred video transcoding `CODECS` if supported by the client, e.g. av1,vp9,hevc,avc",
+		Value:  "avc",
+		EnvVar: "PHOTOPRISM_VIDEO_CODECS",
+	},
 	cli.StringFlag{
 		Name:   "exiftool-bin",
 		Usage:  "ExifTool `COMMAND` for extracting metadata",
@@ -455,6 +500,12 @@ var GlobalFlags = []cli.Flag{
 		Value:  "lanczos",
 		EnvVar: "PHOTOPRISM_THUMB_FILTER",
 	},
+	cli.StringFlag{
+		Name:   "thumb-crop",
+		Usage:  "tile thumbnail crop `METHOD` (center, smart: keep faces and details visible)",
+		Value:  "center",
+		EnvVar: "PHOTOPRISM_THUMB_CROP",
+	},
 	cli.IntFlag{
 		Name:   "thumb-size, s",
 		Usage:  "maximum pre-cached thumbnail image size in `PIXELS` (720-7680)",
@@ -12,6 +12,7 @@ import (
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v2"
 
+	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
 
@@ -61,12 +62,15 @@ type Options struct {
 	WakeupInterval        int     `yaml:"WakeupInterval" json:"WakeupInterval" flag:"wakeup-interval"`
 	AutoIndex             int     `yaml:"AutoIndex" json:"AutoIndex" flag:"auto-index"`
 	AutoImport            int     `yaml:"AutoImport" json:"AutoImport" flag:"auto-import"`
+	SearchIndex           string  `yaml:"SearchIndex" json:"SearchIndex" flag:"search-index"`
+	MemoriesAlbum         bool    `yaml:"MemoriesAlbum" json:"MemoriesAlbum" flag:"memories-album"`
 	DisableWebDAV         bool    `yaml:"DisableWebDAV" json:"DisableWebDAV" flag:"disable-webdav"`
 	DisableBackups        bool    `yaml:"DisableBackups" json:"DisableBackups" flag:"disable-backups"`
 	DisableSettings       bool    `yaml:"DisableSettings" json:"-" flag:"disable-settings"`
 	DisablePlaces         bool    `yaml:"DisablePlaces" json:"DisablePlaces" flag:"disable-places"`
 	DisableExifTool       bool    `yaml:"DisableExifTool" json:"DisableExifTool" flag:"disable-exiftool"`
 	DisableFFmpeg         bool    `yaml:"DisableFFmpeg" json:"DisableFFmpeg" flag:"disable-ffmpeg"`
+	DisableVideoPreviews  bool    `yaml:"DisableVideoPreviews" json:"DisableVideoPreviews" flag:"disable-video-previews"`
 	DisableDarktable      bool    `yaml:"DisableDarktable" json:"DisableDarktable" flag:"disable-darktable"`
 	DisableRawtherapee    bool    `yaml:"DisableRawtherapee" json:"DisableRawtherapee" flag:"disable-rawtherapee"`
 	DisableSips           bool    `yaml:"DisableSips" json:"DisableSips" flag:"disable-sips"`
@@ -103,21 +107,27 @@ type Options struct {
 	HttpMode              string  `yaml:"HttpMode" json:"-" flag:"http-mode"`
 	HttpCompression       string  `yaml:"HttpCompression" json:"-" flag:"http-compression"`
 	RawPresets            bool    `yaml:"RawPresets" json:"RawPresets" flag:"raw-presets"`
+	PreferRawPreviews     bool    `yaml:"PreferRawPreviews" json:"PreferRawPreviews" flag:"prefer-raw-previews"`
 	DarktableBin          string  `yaml:"DarktableBin" json:"-" flag:"darktable-bin"`
 	DarktableBlacklist    string  `yaml:"DarktableBlacklist" json:"-" flag:"darktable-blacklist"`
 	RawtherapeeBin        string  `yaml:"RawtherapeeBin" json:"-" flag:"rawtherapee-bin"`
 	RawtherapeeBlacklist  string  `yaml:"RawtherapeeBlacklist" json:"-" flag:"rawtherapee-blacklist"`
 	SipsBin               string  `yaml:"SipsBin" json:"-" flag:"sips-bin"`
 	HeifConvertBin        string  `yaml:"HeifConvertBin" json:"-" flag:"heifconvert-bin"`
+	CwebpBin              string  `yaml:"CwebpBin" json:"-" flag:"cwebp-bin"`
+	AvifencBin            string  `yaml:"AvifencBin" json:"-" flag:"avifenc-bin"`
 	FFmpegBin             string  `yaml:"FFmpegBin" json:"-" flag:"ffmpeg-bin"`
 	FFmpegEncoder         string  `yaml:"FFmpegEncoder" json:"FFmpegEncoder" flag:"ffmpeg-encoder"`
 	FFmpegBitrate         int     `yaml:"FFmpegBitrate" json:"FFmpegBitrate" flag:"ffmpeg-bitrate"`
 	FFmpegBuffers         int     `yaml:"FFmpegBuffers" json:"FFmpegBuffers" flag:"ffmpeg-buffers"`
+	VideoPoster           string  `yaml:"VideoPoster" json:"VideoPoster" flag:"video-poster"`
+	VideoCodecs           string  `yaml:"VideoCodecs" json:"VideoCodecs" flag:"video-codecs"`
 	ExifToolBin           string  `yaml:"ExifToolBin" json:"-" flag:"exiftool-bin"`
 	DetachServer          bool    `yaml:"DetachServer" json:"-" flag:"detach-server"`
 	DownloadToken         string  `yaml:"DownloadToken" json:"-" flag:"download-token"`
 	PreviewToken          string  `yaml:"PreviewToken" json:"-" flag:"preview-token"`
 	ThumbFilter           string  `yaml:"ThumbFilter" json:"ThumbFilter" flag:"thumb-filter"`
+	ThumbCrop             string  `yaml:"ThumbCrop" json:"ThumbCrop" flag:"thumb-crop"`
 	ThumbUncached         bool    `yaml:"ThumbUncached" json:"ThumbUncached" flag:"thumb-uncached"`
 	ThumbSize             int     `yaml:"ThumbSize" json:"ThumbSize" flag:"thumb-size"`
 	ThumbSizeUncached     int     `yaml:"ThumbSizeUncached" json:"ThumbSizeUncached" flag:"thumb-size-uncached"`
@@ -133,6 +143,9 @@ type Options struct {
 	FaceMatchDist         float64 `yaml:"-" json:"-" flag:"face-match-dist"`
 	PIDFilename           string  `yaml:"PIDFilename" json:"-" flag:"pid-filename"`
 	LogFilename           string  `yaml:"LogFilename" json:"-" flag:"log-filename"`
+
+	// Custom thumbnail sizes can only be configured in the options.yml file.
+	ThumbSizes thumb.CustomSizes `yaml:"ThumbSizes" json:"ThumbSizes,omitempty"`
 }
 
 // NewOptions creates a new configuration entity by using two methods:
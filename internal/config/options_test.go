@@ -39,6 +39,14 @@ func TestOptions_SetOptionsFromFile(t *testing.T) {
 	assert.NotEmpty(t, c.DatabaseDriver)
 	assert.NotEmpty(t, c.DatabaseDsn)
 	assert.Equal(t, 81, c.HttpPort)
+
+	if assert.Len(t, c.ThumbSizes, 1) {
+		assert.Equal(t, "signage_1080", c.ThumbSizes[0].Name)
+		assert.Equal(t, 1920, c.ThumbSizes[0].Height)
+		assert.Equal(t, []string{"fill"}, c.ThumbSizes[0].Options)
+		assert.Equal(t, 90, c.ThumbSizes[0].Quality)
+		assert.True(t, c.ThumbSizes[0].Public)
+	}
 }
 
 func TestOptions_ExpandFilenames(t *testing.T) {
@@ -5,6 +5,11 @@ func (c *Config) RawPresets() bool {
 	return c.options.RawPresets
 }
 
+// PreferRawPreviews tests if embedded RAW previews should be used regardless of their size.
+func (c *Config) PreferRawPreviews() bool {
+	return c.options.PreferRawPreviews
+}
+
 // DarktableBin returns the darktable-cli executable file name.
 func (c *Config) DarktableBin() string {
 	return findExecutable(c.options.DarktableBin, "darktable-cli")
@@ -47,6 +47,14 @@ func TestConfig_DarktablePresets(t *testing.T) {
 	assert.False(t, c.RawPresets())
 }
 
+func TestConfig_PreferRawPreviews(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.PreferRawPreviews())
+
+	c.options.PreferRawPreviews = true
+	assert.True(t, c.PreferRawPreviews())
+}
+
 func TestConfig_DarktableEnabled(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.True(t, c.DarktableEnabled())
@@ -46,6 +46,26 @@ func (c *Config) ThumbFilter() thumb.ResampleFilter {
 	}
 }
 
+// ThumbCrop returns the resample method for cropping tile thumbnails (center or smart).
+func (c *Config) ThumbCrop() thumb.ResampleOption {
+	switch strings.ToLower(c.options.ThumbCrop) {
+	case "smart":
+		return thumb.ResampleFillSmart
+	default:
+		return thumb.ResampleFillCenter
+	}
+}
+
+// CwebpBin returns the cwebp executable file name for creating WebP thumbnails.
+func (c *Config) CwebpBin() string {
+	return findExecutable(c.options.CwebpBin, "cwebp")
+}
+
+// AvifencBin returns the avifenc executable file name for creating AVIF thumbnails.
+func (c *Config) AvifencBin() string {
+	return findExecutable(c.options.AvifencBin, "avifenc")
+}
+
 // ThumbPath returns the thumbnails directory.
 func (c *Config) ThumbPath() string {
 	return c.CachePath() + "/thumbnails"
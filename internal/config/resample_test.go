@@ -41,6 +41,17 @@ func TestConfig_ThumbFilter(t *testing.T) {
 	assert.Equal(t, thumb.ResampleFilter("cubic"), c.ThumbFilter())
 }
 
+func TestConfig_ThumbCrop(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, thumb.ResampleFillCenter, c.ThumbCrop())
+	c.options.ThumbCrop = "Smart"
+	assert.Equal(t, thumb.ResampleFillSmart, c.ThumbCrop())
+	c.options.ThumbCrop = "invalid"
+	assert.Equal(t, thumb.ResampleFillCenter, c.ThumbCrop())
+	c.options.ThumbCrop = ""
+}
+
 func TestConfig_ThumbSizeUncached(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -65,3 +76,17 @@ func TestConfig_ThumbSizeUncached2(t *testing.T) {
 	c.options.ThumbSize = 900
 	assert.Equal(t, int(900), c.ThumbSizeUncached())
 }
+
+func TestConfig_CwebpBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.CwebpBin = "cwebp-not-found"
+	assert.Equal(t, "", c.CwebpBin())
+}
+
+func TestConfig_AvifencBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.AvifencBin = "avifenc-not-found"
+	assert.Equal(t, "", c.AvifencBin())
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fts"
+)
+
+// SearchIndex returns the full-text search index backend (none or embedded).
+func (c *Config) SearchIndex() string {
+	switch s := strings.ToLower(strings.TrimSpace(c.options.SearchIndex)); s {
+	case fts.Embedded:
+		return s
+	default:
+		return fts.None
+	}
+}
+
+// MemoriesAlbum checks if the "On This Day" album should be updated by the metadata worker.
+func (c *Config) MemoriesAlbum() bool {
+	return c.options.MemoriesAlbum
+}
+
+// initSearchIndex sets the full-text search index for photos, stemming words in the default language.
+func (c *Config) initSearchIndex() {
+	if idx, err := fts.New(c.SearchIndex(), c.DefaultLocale()); err != nil {
+		log.Errorf("config: %s", err)
+	} else {
+		entity.SetSearchIndex(idx)
+	}
+}
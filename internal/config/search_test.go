@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SearchIndex(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "none", c.SearchIndex())
+	c.options.SearchIndex = "Embedded"
+	assert.Equal(t, "embedded", c.SearchIndex())
+	c.options.SearchIndex = "elastic"
+	assert.Equal(t, "none", c.SearchIndex())
+	c.options.SearchIndex = ""
+	assert.Equal(t, "none", c.SearchIndex())
+}
+
+func TestConfig_MemoriesAlbum(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.MemoriesAlbum())
+	c.options.MemoriesAlbum = true
+	assert.True(t, c.MemoriesAlbum())
+	c.options.MemoriesAlbum = false
+}
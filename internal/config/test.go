@@ -143,7 +143,10 @@ func NewTestConfig() *Config {
 	thumb.SizePrecached = c.ThumbSizePrecached()
 	thumb.SizeUncached = c.ThumbSizeUncached()
 	thumb.Filter = c.ThumbFilter()
+	thumb.Crop = c.ThumbCrop()
 	thumb.JpegQuality = c.JpegQuality()
+	thumb.WebPBin = c.CwebpBin()
+	thumb.AvifBin = c.AvifencBin()
 
 	return c
 }
@@ -1,5 +1,9 @@
 package config
 
+import (
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
 // ThumbSize represents thumbnail info for use in client apps.
 type ThumbSize struct {
 	Size   string `json:"size"`
@@ -13,3 +17,41 @@ type ThumbSizes []ThumbSize
 
 // Thumbs is a list of thumbnails for use in client apps.
 var Thumbs ThumbSizes
+
+// initThumbs updates the list of public thumbnail sizes for use in client apps.
+func initThumbs() {
+	Thumbs = nil
+
+	for i := len(thumb.DefaultSizes) - 1; i >= 0; i-- {
+		name := thumb.DefaultSizes[i]
+		t := thumb.Sizes[name]
+
+		if t.Public {
+			Thumbs = append(Thumbs, ThumbSize{Size: string(name), Use: t.Use, Width: t.Width, Height: t.Height})
+		}
+	}
+
+	for _, name := range thumb.CustomNames {
+		if t := thumb.Sizes[name]; t.Public {
+			Thumbs = append(Thumbs, ThumbSize{Size: string(name), Use: t.Use, Width: t.Width, Height: t.Height})
+		}
+	}
+}
+
+// CustomThumbSizes returns the user-defined thumbnail sizes.
+func (c *Config) CustomThumbSizes() thumb.CustomSizes {
+	return c.options.ThumbSizes
+}
+
+// initCustomThumbs validates and registers the user-defined thumbnail sizes.
+func (c *Config) initCustomThumbs() {
+	thumb.ResetCustomSizes()
+
+	for _, s := range c.CustomThumbSizes() {
+		if err := thumb.AddCustomSize(s); err != nil {
+			log.Warnf("config: %s", err)
+		}
+	}
+
+	initThumbs()
+}
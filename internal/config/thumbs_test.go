@@ -0,0 +1,36 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+func TestConfig_CustomThumbSizes(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.CustomThumbSizes())
+
+	c.options.ThumbSizes = thumb.CustomSizes{
+		{Name: "signage_720", Use: "Digital Signage", Width: 405, Height: 720, Options: []string{"fill"}, Public: true},
+		{Name: "tile_500", Width: 400, Height: 400},
+	}
+
+	defer func() {
+		c.options.ThumbSizes = nil
+		c.initCustomThumbs()
+	}()
+
+	c.initCustomThumbs()
+
+	assert.Equal(t, []thumb.Name{"signage_720"}, thumb.CustomNames)
+	assert.Equal(t, 500, thumb.Sizes[thumb.Tile500].Width)
+
+	last := Thumbs[len(Thumbs)-1]
+
+	assert.Equal(t, "signage_720", last.Size)
+	assert.Equal(t, "Digital Signage", last.Use)
+	assert.Equal(t, 720, last.Height)
+}
@@ -25,6 +25,7 @@ const (
 	AlbumMoment  = "moment"
 	AlbumMonth   = "month"
 	AlbumState   = "state"
+	AlbumSmart   = "smart"
 )
 
 type Albums []Album
@@ -176,6 +177,30 @@ func NewMomentsAlbum(albumTitle, albumSlug, albumFilter string) *Album {
 	return result
 }
 
+// NewMemoriesAlbum creates a new moment that contains photos taken on the same day in earlier years.
+// Photos are added by the metadata worker, which also sets the date, so no filter is needed.
+func NewMemoriesAlbum(albumTitle, albumSlug string) *Album {
+	albumTitle = strings.TrimSpace(albumTitle)
+	albumSlug = strings.TrimSpace(albumSlug)
+
+	if albumTitle == "" || albumSlug == "" {
+		return nil
+	}
+
+	now := TimeStamp()
+
+	result := &Album{
+		AlbumOrder: SortOrderNewest,
+		AlbumType:  AlbumMoment,
+		AlbumTitle: albumTitle,
+		AlbumSlug:  albumSlug,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	return result
+}
+
 // NewStateAlbum creates a new moment.
 func NewStateAlbum(albumTitle, albumSlug, albumFilter string) *Album {
 	albumTitle = strings.TrimSpace(albumTitle)
@@ -200,6 +225,21 @@ func NewStateAlbum(albumTitle, albumSlug, albumFilter string) *Album {
 	return result
 }
 
+// NewSmartAlbum creates a new smart album that contains all photos matching a serialized search form.
+func NewSmartAlbum(albumTitle, albumFilter string) *Album {
+	albumFilter = strings.TrimSpace(albumFilter)
+
+	if albumFilter == "" {
+		return nil
+	}
+
+	result := NewAlbum(albumTitle, AlbumSmart)
+	result.AlbumOrder = SortOrderNewest
+	result.AlbumFilter = albumFilter
+
+	return result
+}
+
 // NewMonthAlbum creates a new month album.
 func NewMonthAlbum(albumTitle, albumSlug string, year, month int) *Album {
 	albumTitle = strings.TrimSpace(albumTitle)
@@ -312,7 +352,7 @@ func (m *Album) Find() error {
 
 	stmt := UnscopedDb().Where("album_type = ?", m.AlbumType)
 
-	if m.AlbumType != AlbumDefault && m.AlbumFilter != "" {
+	if m.AlbumType != AlbumDefault && m.AlbumType != AlbumSmart && m.AlbumFilter != "" {
 		stmt = stmt.Where("album_slug = ? OR album_filter = ?", m.AlbumSlug, m.AlbumFilter)
 	} else {
 		stmt = stmt.Where("album_slug = ?", m.AlbumSlug)
@@ -366,6 +406,11 @@ func (m *Album) IsDefault() bool {
 	return m.AlbumType == AlbumDefault
 }
 
+// IsSmart tests if the album is a smart album based on a search filter.
+func (m *Album) IsSmart() bool {
+	return m.AlbumType == AlbumSmart
+}
+
 // SetTitle changes the album name.
 func (m *Album) SetTitle(title string) {
 	title = strings.TrimSpace(title)
@@ -376,7 +421,7 @@ func (m *Album) SetTitle(title string) {
 
 	m.AlbumTitle = txt.Clip(title, txt.ClipDefault)
 
-	if m.AlbumType == AlbumDefault || m.AlbumSlug == "" {
+	if m.AlbumType == AlbumDefault || m.AlbumType == AlbumSmart || m.AlbumSlug == "" {
 		if len(m.AlbumTitle) < txt.ClipSlug {
 			m.AlbumSlug = txt.Slug(m.AlbumTitle)
 		} else {
@@ -631,6 +676,36 @@ func (m *Album) RemovePhotos(UIDs []string) (removed PhotoAlbums) {
 	return removed
 }
 
+// ReplacePhotos removes all photos from an album and adds the given photos instead.
+// Photos that have been removed by the user remain hidden.
+func (m *Album) ReplacePhotos(UIDs []string) (added PhotoAlbums, err error) {
+	if err = UnscopedDb().Delete(PhotoAlbum{}, "album_uid = ? AND hidden = ?", m.AlbumUID, false).Error; err != nil {
+		return added, err
+	}
+
+	var hidden []string
+
+	if err = UnscopedDb().Model(PhotoAlbum{}).Where("album_uid = ? AND hidden = ?", m.AlbumUID, true).Pluck("photo_uid", &hidden).Error; err != nil {
+		return added, err
+	}
+
+	skip := make(map[string]bool, len(hidden))
+
+	for _, uid := range hidden {
+		skip[uid] = true
+	}
+
+	add := make([]string, 0, len(UIDs))
+
+	for _, uid := range UIDs {
+		if !skip[uid] {
+			add = append(add, uid)
+		}
+	}
+
+	return m.AddPhotos(add), nil
+}
+
 // Links returns all share links for this entity.
 func (m *Album) Links() Links {
 	return FindLinks("", m.AlbumUID)
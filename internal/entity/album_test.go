@@ -250,6 +250,24 @@ func TestNewMomentsAlbum(t *testing.T) {
 	})
 }
 
+func TestNewMemoriesAlbum(t *testing.T) {
+	t.Run("OnThisDay", func(t *testing.T) {
+		album := NewMemoriesAlbum("On This Day", "on-this-day")
+		assert.Equal(t, "On This Day", album.AlbumTitle)
+		assert.Equal(t, "on-this-day", album.AlbumSlug)
+		assert.Equal(t, AlbumMoment, album.AlbumType)
+		assert.Equal(t, SortOrderNewest, album.AlbumOrder)
+		assert.Equal(t, "", album.AlbumFilter)
+		assert.Equal(t, 0, album.AlbumYear)
+		assert.Equal(t, 0, album.AlbumMonth)
+		assert.Equal(t, 0, album.AlbumDay)
+	})
+	t.Run("SlugEmpty", func(t *testing.T) {
+		album := NewMemoriesAlbum("On This Day", "")
+		assert.Nil(t, album)
+	})
+}
+
 func TestNewStateAlbum(t *testing.T) {
 	t.Run("name Christmas 2018", func(t *testing.T) {
 		album := NewStateAlbum("Dogs", "dogs", "label:dog")
@@ -265,6 +283,23 @@ func TestNewStateAlbum(t *testing.T) {
 	})
 }
 
+func TestNewSmartAlbum(t *testing.T) {
+	t.Run("Cats", func(t *testing.T) {
+		album := NewSmartAlbum("Cats in Germany", " label:cat country:de ")
+		assert.Equal(t, "Cats in Germany", album.AlbumTitle)
+		assert.Equal(t, "cats-in-germany", album.AlbumSlug)
+		assert.Equal(t, AlbumSmart, album.AlbumType)
+		assert.Equal(t, SortOrderNewest, album.AlbumOrder)
+		assert.Equal(t, "label:cat country:de", album.AlbumFilter)
+		assert.True(t, album.IsSmart())
+		assert.False(t, album.IsDefault())
+	})
+	t.Run("FilterEmpty", func(t *testing.T) {
+		album := NewSmartAlbum("Cats", " ")
+		assert.Nil(t, album)
+	})
+}
+
 func TestNewMonthAlbum(t *testing.T) {
 	t.Run("name Christmas 2018", func(t *testing.T) {
 		album := NewMonthAlbum("Dogs", "dogs", 2020, 7)
@@ -503,6 +538,63 @@ func TestAlbum_RemovePhotos(t *testing.T) {
 	})
 }
 
+func TestAlbum_ReplacePhotos(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		album := Album{
+			AlbumUID:   "at6axuzitorepl01",
+			AlbumSlug:  "test-replace",
+			AlbumType:  AlbumDefault,
+			AlbumTitle: "Test Replace",
+		}
+
+		album.AddPhotos([]string{"ab", "cd"})
+
+		added, err := album.ReplacePhotos([]string{"ef"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, len(added))
+
+		var count int
+
+		if err := UnscopedDb().Model(&PhotoAlbum{}).Where("album_uid = ?", album.AlbumUID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, count)
+	})
+	t.Run("hidden", func(t *testing.T) {
+		album := Album{
+			AlbumUID:   "at6axuzitorepl02",
+			AlbumSlug:  "test-replace-hidden",
+			AlbumType:  AlbumDefault,
+			AlbumTitle: "Test Replace Hidden",
+		}
+
+		album.AddPhotos([]string{"ab", "cd"})
+		album.RemovePhotos([]string{"cd"})
+
+		added, err := album.ReplacePhotos([]string{"cd", "ef"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, len(added))
+		assert.Equal(t, "ef", added[0].PhotoUID)
+
+		var hidden []string
+
+		if err := UnscopedDb().Model(&PhotoAlbum{}).Where("album_uid = ? AND hidden = ?", album.AlbumUID, true).Pluck("photo_uid", &hidden).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"cd"}, hidden)
+	})
+}
+
 func TestAlbum_Find(t *testing.T) {
 	t.Run("existing album", func(t *testing.T) {
 		a := Album{AlbumUID: "at6axuzitogaaiax"}
@@ -77,4 +77,5 @@ const (
 	SortOrderSlug      = "slug"
 	SortOrderCategory  = "category"
 	SortOrderSimilar   = "similar"
+	SortOrderRating    = "rating"
 )
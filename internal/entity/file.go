@@ -15,6 +15,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/pkg/colors"
+	"github.com/photoprism/photoprism/pkg/focus"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sanitize"
@@ -31,6 +32,12 @@ const (
 
 type Files []File
 
+// PreviewRetries is the maximum number of attempts to create video previews.
+var PreviewRetries = 3
+
+// PreviewBackoff is the time to wait before retrying, multiplied by the number of failed attempts.
+var PreviewBackoff = time.Hour
+
 var primaryFileMutex = sync.Mutex{}
 
 // File represents an image or sidecar file that belongs to a photo.
@@ -67,7 +74,10 @@ type File struct {
 	FileLuminance    string        `gorm:"type:VARBINARY(9);" json:"Luminance" yaml:"Luminance,omitempty"`
 	FileDiff         uint32        `json:"Diff" yaml:"Diff,omitempty"`
 	FileChroma       uint8         `json:"Chroma" yaml:"Chroma,omitempty"`
+	FilePHash        string        `gorm:"column:file_phash;type:VARBINARY(16);index;" json:"PHash" yaml:"PHash,omitempty"`
 	FileError        string        `gorm:"type:VARBINARY(512)" json:"Error" yaml:"Error,omitempty"`
+	PreviewsAt       *time.Time    `json:"-" yaml:"-"`
+	PreviewErrors    int           `json:"-" yaml:"-"`
 	ModTime          int64         `json:"ModTime" yaml:"-"`
 	CreatedAt        time.Time     `json:"CreatedAt" yaml:"-"`
 	CreatedIn        int64         `json:"CreatedIn" yaml:"-"`
@@ -379,6 +389,39 @@ func (m *File) UpdateVideoInfos() error {
 	return Db().Model(File{}).Where("photo_id = ? AND file_video = 1", m.PhotoID).Updates(values).Error
 }
 
+// PreviewsPending tests if video previews should be created, taking previous failures into account.
+func (m *File) PreviewsPending() bool {
+	if m.PreviewsAt == nil {
+		return true
+	} else if m.PreviewErrors <= 0 || m.PreviewErrors >= PreviewRetries {
+		return false
+	}
+
+	return time.Since(*m.PreviewsAt) >= time.Duration(m.PreviewErrors)*PreviewBackoff
+}
+
+// PreviewsCreated records the result of creating video previews, so that failed attempts are
+// retried with backoff and at most PreviewRetries times.
+func (m *File) PreviewsCreated(err error) error {
+	m.PreviewsAt = TimePointer()
+
+	if err != nil {
+		m.PreviewErrors++
+	} else {
+		m.PreviewErrors = 0
+	}
+
+	return m.Updates(Values{"PreviewsAt": m.PreviewsAt, "PreviewErrors": m.PreviewErrors})
+}
+
+// ResetPreviews resets the video preview state, e.g. if the previews were removed from the cache.
+func (m *File) ResetPreviews() error {
+	m.PreviewsAt = nil
+	m.PreviewErrors = 0
+
+	return m.Updates(Values{"PreviewsAt": nil, "PreviewErrors": 0})
+}
+
 // Update updates a column in the database.
 func (m *File) Update(attr string, value interface{}) error {
 	return UnscopedDb().Model(m).UpdateColumn(attr, value).Error
@@ -602,6 +645,11 @@ func (m *File) Markers() *Markers {
 	return m.markers
 }
 
+// ThumbFocus returns the face areas to keep visible in smart cropped thumbnails.
+func (m *File) ThumbFocus() focus.Areas {
+	return m.Markers().FocusAreas()
+}
+
 // UnsavedMarkers tests if any marker hasn't been saved yet.
 func (m *File) UnsavedMarkers() bool {
 	if m.markers == nil {
@@ -54,6 +54,7 @@ var FileFixtures = FileMap{
 		FileLuminance:   "8836BD496",
 		FileDiff:        968,
 		FileChroma:      25,
+		FilePHash:       "f0f0f0f0f0f0f0f0",
 		FileError:       "",
 		ModTime:         time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC).Unix(),
 		Share: []FileShare{
@@ -221,6 +222,7 @@ var FileFixtures = FileMap{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        800,
 		FileChroma:      4,
+		FilePHash:       "0f0f0f0f0f0f0f00",
 		FileError:       "Error",
 		Share:           []FileShare{},
 		Sync:            []FileSync{},
@@ -508,6 +510,7 @@ var FileFixtures = FileMap{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        986,
 		FileChroma:      32,
+		FilePHash:       "0f0f0f0f0f0f0f0f",
 		FileError:       "",
 		Share:           []FileShare{},
 		Sync:            []FileSync{},
@@ -549,6 +552,7 @@ var FileFixtures = FileMap{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        986,
 		FileChroma:      32,
+		FilePHash:       "0f0f0f0f0f0f0f0e",
 		FileError:       "",
 		Share:           []FileShare{},
 		Sync:            []FileSync{},
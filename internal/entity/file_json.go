@@ -36,6 +36,7 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		Luminance    string        `json:",omitempty"`
 		Diff         uint32        `json:",omitempty"`
 		Chroma       uint8         `json:",omitempty"`
+		PHash        string        `json:",omitempty"`
 		HDR          bool          `json:",omitempty"`
 		Error        string        `json:",omitempty"`
 		ModTime      int64         `json:",omitempty"`
@@ -74,6 +75,7 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		Luminance:    m.FileLuminance,
 		Diff:         m.FileDiff,
 		Chroma:       m.FileChroma,
+		PHash:        m.FilePHash,
 		HDR:          m.FileHDR,
 		Error:        m.FileError,
 		ModTime:      m.ModTime,
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -253,6 +254,52 @@ func TestFile_Update(t *testing.T) {
 	})
 }
 
+func TestFile_PreviewsCreated(t *testing.T) {
+	file := &File{FileType: "mp4", FileVideo: true, FileName: "PreviewsCreated.mp4", FileRoot: "", PhotoID: 5679}
+
+	if err := file.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, file.PreviewsPending())
+
+	for i := 1; i <= PreviewRetries; i++ {
+		if err := file.PreviewsCreated(errors.New("failed")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, i, file.PreviewErrors)
+		assert.False(t, file.PreviewsPending())
+	}
+
+	file.PreviewErrors = 1
+	failedAt := time.Now().Add(-1 * PreviewBackoff)
+	file.PreviewsAt = &failedAt
+	assert.True(t, file.PreviewsPending())
+
+	if err := file.PreviewsCreated(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, file.PreviewErrors)
+	assert.False(t, file.PreviewsPending())
+
+	var result File
+
+	if err := UnscopedDb().Where("id = ?", file.ID).First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, result.PreviewsAt)
+	assert.Equal(t, 0, result.PreviewErrors)
+
+	if err := file.ResetPreviews(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, file.PreviewsPending())
+}
+
 func TestFile_Links(t *testing.T) {
 	t.Run("result", func(t *testing.T) {
 		file := FileFixturesExampleBridge
@@ -618,6 +665,17 @@ func TestFile_UnsavedMarkers(t *testing.T) {
 	})
 }
 
+func TestFile_ThumbFocus(t *testing.T) {
+	t.Run("Face", func(t *testing.T) {
+		m := FileFixtures.Get("bridge2.jpg")
+		assert.Len(t, m.ThumbFocus(), 1)
+	})
+	t.Run("NoMarkers", func(t *testing.T) {
+		m := &File{}
+		assert.Empty(t, m.ThumbFocus())
+	})
+}
+
 func TestFile_ReplaceHash(t *testing.T) {
 	t.Run("exampleFileName.jpg", func(t *testing.T) {
 		m := FileFixtures.Get("exampleFileName.jpg")
@@ -5,6 +5,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/pkg/focus"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -76,6 +77,17 @@ func (m Markers) ValidFaceCount() (count int) {
 	return count
 }
 
+// FocusAreas returns the areas of valid face markers, e.g. for cropping thumbnails.
+func (m Markers) FocusAreas() (result focus.Areas) {
+	for i := range m {
+		if m[i].ValidFace() {
+			result = append(result, focus.Area{X: float64(m[i].X), Y: float64(m[i].Y), W: float64(m[i].W), H: float64(m[i].H)})
+		}
+	}
+
+	return result
+}
+
 // SubjectNames returns known subject names.
 func (m Markers) SubjectNames() (names []string) {
 	for i := range m {
@@ -92,6 +92,21 @@ func TestMarkers_ValidFaceCount(t *testing.T) {
 	assert.Equal(t, 2, m.ValidFaceCount())
 }
 
+func TestMarkers_FocusAreas(t *testing.T) {
+	m1 := *NewMarker(FileFixtures.Get("exampleFileName.jpg"), cropArea1, "lt9k3pw1wowuy1c1", SrcImage, MarkerFace, 100, 65)
+	m2 := *NewMarker(FileFixtures.Get("exampleFileName.jpg"), cropArea3, "lt9k3pw1wowuy1c3", SrcManual, MarkerFace, 100, 65)
+	m2.MarkerInvalid = true
+
+	result := Markers{m1, m2}.FocusAreas()
+
+	if assert.Len(t, result, 1) {
+		assert.InDelta(t, 0.308333, result[0].X, 0.0001)
+		assert.InDelta(t, 0.355556, result[0].W, 0.0001)
+	}
+
+	assert.Empty(t, Markers{m2}.FocusAreas())
+}
+
 func TestMarkers_SubjectNames(t *testing.T) {
 	m1 := MarkerFixtures.Get("1000003-3")
 	m2 := MarkerFixtures.Get("1000003-4")
@@ -16,6 +16,7 @@ import (
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -66,6 +67,9 @@ type Photo struct {
 	PhotoPrivate     bool         `json:"Private" yaml:"Private,omitempty"`
 	PhotoScan        bool         `json:"Scan" yaml:"Scan,omitempty"`
 	PhotoPanorama    bool         `json:"Panorama" yaml:"Panorama,omitempty"`
+	PhotoRating      int          `gorm:"type:SMALLINT;index;" json:"Rating" yaml:"Rating,omitempty"`
+	PhotoColorLabel  string       `gorm:"type:VARBINARY(16);" json:"ColorLabel" yaml:"ColorLabel,omitempty"`
+	RatingSrc        string       `gorm:"type:VARBINARY(8);" json:"RatingSrc" yaml:"RatingSrc,omitempty"`
 	TimeZone         string       `gorm:"type:VARBINARY(64);" json:"TimeZone" yaml:"TimeZone,omitempty"`
 	PlaceID          string       `gorm:"type:VARBINARY(42);index;default:'zz'" json:"PlaceID" yaml:"-"`
 	PlaceSrc         string       `gorm:"type:VARBINARY(8);" json:"PlaceSrc" yaml:"PlaceSrc,omitempty"`
@@ -157,6 +161,7 @@ func SavePhotoForm(model Photo, form form.Photo) error {
 	}
 
 	model.UpdateDateFields()
+	model.PhotoRating = meta.SanitizeRating(model.PhotoRating)
 
 	details := model.GetDetails()
 
@@ -455,7 +460,13 @@ func (m *Photo) IndexKeywords() error {
 		FirstOrCreatePhotoKeyword(NewPhotoKeyword(m.ID, kw.ID))
 	}
 
-	return db.Where("photo_id = ? AND keyword_id NOT IN (?)", m.ID, keywordIds).Delete(&PhotoKeyword{}).Error
+	if err := db.Where("photo_id = ? AND keyword_id NOT IN (?)", m.ID, keywordIds).Delete(&PhotoKeyword{}).Error; err != nil {
+		return err
+	}
+
+	m.UpdateSearchIndex()
+
+	return nil
 }
 
 // PreloadFiles prepares gorm scope to retrieve photo file
@@ -546,9 +557,11 @@ func (m *Photo) GetDetails() *Details {
 // SaveDetails writes photo details to the database.
 func (m *Photo) SaveDetails() error {
 	if err := m.GetDetails().Save(); err == nil {
+		m.UpdateSearchIndex()
 		return nil
 	} else if details := FirstOrCreateDetails(m.GetDetails()); details != nil {
 		m.Details = details
+		m.UpdateSearchIndex()
 		return nil
 	} else {
 		log.Errorf("photo: %s (save details for %d)", err, m.ID)
@@ -770,6 +783,8 @@ func (m *Photo) DeletePermanently() (files Files, err error) {
 		log.Errorf("photo: %s (remove albums)", err)
 	}
 
+	m.DeleteSearchIndex()
+
 	return files, UnscopedDb().Delete(m).Error
 }
 
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -74,6 +75,43 @@ func (m *Photo) SetTakenAt(taken, local time.Time, zone, source string) {
 	m.UpdateDateFields()
 }
 
+// ShiftTime moves the photo date by the offset, e.g. to correct a wrong camera clock, and
+// sets the date source to manual.
+func (m *Photo) ShiftTime(offset TimeOffset) {
+	if offset.IsZero() || m.TakenAt.IsZero() {
+		return
+	}
+
+	m.TakenAt = offset.Apply(m.TakenAt).UTC()
+
+	if m.TakenAtLocal.IsZero() {
+		m.TakenAtLocal = m.TakenAt
+	} else {
+		m.TakenAtLocal = offset.Apply(m.TakenAtLocal)
+	}
+
+	m.TakenSrc = SrcManual
+	m.PhotoYear = m.TakenAtLocal.Year()
+	m.PhotoMonth = int(m.TakenAtLocal.Month())
+	m.PhotoDay = m.TakenAtLocal.Day()
+}
+
+// SaveTakenAt updates the photo date columns in the database.
+func (m *Photo) SaveTakenAt() error {
+	if !m.HasID() {
+		return fmt.Errorf("photo: cannot save date, id is empty")
+	}
+
+	return m.Updates(Values{
+		"taken_at":       m.TakenAt,
+		"taken_at_local": m.TakenAtLocal,
+		"taken_src":      m.TakenSrc,
+		"photo_year":     m.PhotoYear,
+		"photo_month":    m.PhotoMonth,
+		"photo_day":      m.PhotoDay,
+	})
+}
+
 // TimeZoneUTC tests if the current time zone is UTC.
 func (m *Photo) TimeZoneUTC() bool {
 	return strings.EqualFold(m.TimeZone, time.UTC.String())
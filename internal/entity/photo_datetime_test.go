@@ -329,3 +329,27 @@ func TestPhoto_UpdateTimeZone(t *testing.T) {
 		assert.Equal(t, "Europe/Berlin", photo.TimeZone)
 	})
 }
+
+func TestPhoto_ShiftTime(t *testing.T) {
+	t.Run("YearBack", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+
+		m.ShiftTime(TimeOffset{Years: -1, Duration: 2 * time.Hour})
+
+		assert.Equal(t, time.Date(2005, 1, 1, 4, 0, 0, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, time.Date(2005, 1, 1, 4, 0, 0, 0, time.UTC), m.TakenAtLocal)
+		assert.Equal(t, SrcManual, m.TakenSrc)
+		assert.Equal(t, 2005, m.PhotoYear)
+		assert.Equal(t, 1, m.PhotoMonth)
+		assert.Equal(t, 1, m.PhotoDay)
+	})
+	t.Run("Zero", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		src := m.TakenSrc
+
+		m.ShiftTime(TimeOffset{})
+
+		assert.Equal(t, time.Date(2006, 1, 1, 2, 0, 0, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, src, m.TakenSrc)
+	})
+}
@@ -48,6 +48,9 @@ var PhotoFixtures = PhotoMap{
 		PhotoPrivate:     false,
 		PhotoScan:        false,
 		PhotoPanorama:    false,
+		PhotoRating:      4,
+		PhotoColorLabel:  "red",
+		RatingSrc:        "xmp",
 		TimeZone:         "Europe/Berlin",
 		Place:            &UnknownPlace,
 		PlaceID:          UnknownPlace.ID,
@@ -892,6 +895,9 @@ var PhotoFixtures = PhotoMap{
 		PhotoPrivate:     false,
 		PhotoScan:        false,
 		PhotoPanorama:    false,
+		PhotoRating:      2,
+		PhotoColorLabel:  "green",
+		RatingSrc:        "manual",
 		TimeZone:         "America/Mexico_City",
 		Place:            PlaceFixtures.Pointer("mediumLongLocName"),
 		PlaceID:          PlaceFixtures.Pointer("mediumLongLocName").ID,
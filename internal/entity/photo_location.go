@@ -12,7 +12,6 @@ import (
 	"github.com/photoprism/photoprism/pkg/geo"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 	"github.com/photoprism/photoprism/pkg/txt"
-	"gopkg.in/photoprism/go-tz.v2/tz"
 )
 
 // SetCoordinates changes the photo lat, lng and altitude if not empty and from an acceptable source.
@@ -50,16 +49,17 @@ func (m *Photo) UnknownLocation() bool {
 	return m.CellID == "" || m.CellID == UnknownLocation.ID || m.NoLatLng()
 }
 
-// SetPosition sets a position estimate.
-func (m *Photo) SetPosition(pos geo.Position, source string, force bool) {
+// SetPosition sets a position estimate and returns true if the position was changed.
+func (m *Photo) SetPosition(pos geo.Position, source string, force bool) bool {
 	if SrcPriority[m.PlaceSrc] > SrcPriority[source] && !force {
-		return
+		return false
 	} else if pos.Lat == 0 && pos.Lng == 0 {
-		return
+		return false
 	}
 
 	if m.CellID != UnknownID && pos.InRange(float64(m.PhotoLat), float64(m.PhotoLng), geo.Meter*50) {
 		log.Debugf("photo: %s keeps position %f, %f", m.String(), m.PhotoLat, m.PhotoLng)
+		return false
 	} else {
 		if pos.Estimate {
 			pos.Randomize(geo.Meter * 5)
@@ -81,6 +81,8 @@ func (m *Photo) SetPosition(pos geo.Position, source string, force bool) {
 			log.Debugf("photo: approximate place of %s is %s (id %s)", m, sanitize.Log(m.Place.Label()), m.PlaceID)
 		}
 	}
+
+	return true
 }
 
 // AdoptPlace sets the place based on another photo.
@@ -295,19 +297,51 @@ func (m *Photo) GetTimeZone() string {
 	result := "UTC"
 
 	if m.HasLatLng() {
-		zones, err := tz.GetZone(tz.Point{
-			Lat: float64(m.PhotoLat),
-			Lon: float64(m.PhotoLng),
-		})
-
-		if err == nil && len(zones) > 0 {
-			result = zones[0]
+		if zone := geo.TimeZone(float64(m.PhotoLat), float64(m.PhotoLng)); zone != "" {
+			result = zone
 		}
 	}
 
 	return result
 }
 
+// InferTimeZone sets the time zone based on the photo coordinates if it is unknown or
+// was assumed to be UTC, and updates the UTC or local time accordingly. It returns true
+// if the time zone has changed.
+func (m *Photo) InferTimeZone() bool {
+	if !m.HasLatLng() {
+		return false
+	} else if m.TimeZone != "" && !m.TimeZoneUTC() {
+		return false
+	}
+
+	zone := geo.TimeZone(float64(m.PhotoLat), float64(m.PhotoLng))
+
+	if zone == "" || zone == time.UTC.String() {
+		return false
+	}
+
+	current := m.TimeZone
+
+	if m.TakenSrc == SrcAuto {
+		// File dates are absolute, so only the local time changes.
+		m.TimeZone = zone
+		m.TakenAtLocal = m.GetTakenAtLocal()
+	} else {
+		m.UpdateTimeZone(zone)
+	}
+
+	if m.TimeZone == current {
+		return false
+	}
+
+	m.UpdateDateFields()
+
+	log.Debugf("photo: inferred time zone %s for %s", zone, m.String())
+
+	return true
+}
+
 // CountryName returns the photo country name.
 func (m *Photo) CountryName() string {
 	if name, ok := maps.CountryNames[m.CountryCode()]; ok {
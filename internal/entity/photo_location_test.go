@@ -26,6 +26,21 @@ func TestPhoto_SetPosition(t *testing.T) {
 		assert.InEpsilon(t, 1, p.PhotoLat, 0.01)
 		assert.InEpsilon(t, -1, p.PhotoLng, 0.01)
 	})
+	t.Run("SrcManual", func(t *testing.T) {
+		p := Photo{ID: 1, Place: nil, PlaceID: "", CellID: "s2:479a03fda123", PhotoLat: 0, PhotoLng: 0, PlaceSrc: SrcManual}
+		pos := geo.Position{Lat: 1, Lng: -1}
+		assert.False(t, p.SetPosition(pos, SrcTrack, false))
+		assert.Nil(t, p.Place)
+		assert.Equal(t, SrcManual, p.PlaceSrc)
+		assert.Equal(t, "s2:479a03fda123", p.CellID)
+		assert.Equal(t, 0, int(p.PhotoLat))
+	})
+	t.Run("KeepPosition", func(t *testing.T) {
+		p := Photo{ID: 1, Place: nil, PlaceID: "", CellID: "s2:479a03fda123", PhotoLat: 1, PhotoLng: -1, PlaceSrc: SrcEstimate}
+		pos := geo.Position{Lat: 1, Lng: -1}
+		assert.False(t, p.SetPosition(pos, SrcTrack, false))
+		assert.Equal(t, SrcEstimate, p.PlaceSrc)
+	})
 }
 
 func TestPhoto_AdoptPlace(t *testing.T) {
@@ -355,6 +370,85 @@ func TestPhoto_GetTimeZone(t *testing.T) {
 	}
 }
 
+func TestPhoto_InferTimeZone(t *testing.T) {
+	t.Run("Winter", func(t *testing.T) {
+		m := Photo{PhotoLat: 48.5716, PhotoLng: 7.8156, TakenSrc: SrcMeta}
+		m.TakenAt = time.Date(2020, 2, 4, 11, 54, 34, 0, time.UTC)
+		m.TakenAtLocal = m.TakenAt
+
+		assert.True(t, m.InferTimeZone())
+		assert.Equal(t, "Europe/Berlin", m.TimeZone)
+		assert.Equal(t, time.Date(2020, 2, 4, 10, 54, 34, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, time.Date(2020, 2, 4, 11, 54, 34, 0, time.UTC), m.TakenAtLocal)
+	})
+	t.Run("SrcAuto", func(t *testing.T) {
+		m := Photo{PhotoLat: 48.5716, PhotoLng: 7.8156, TakenSrc: SrcAuto}
+		m.TakenAt = time.Date(2020, 2, 4, 11, 54, 34, 0, time.UTC)
+		m.TakenAtLocal = m.TakenAt
+
+		assert.True(t, m.InferTimeZone())
+		assert.Equal(t, "Europe/Berlin", m.TimeZone)
+		assert.Equal(t, time.Date(2020, 2, 4, 11, 54, 34, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, time.Date(2020, 2, 4, 12, 54, 34, 0, time.UTC), m.TakenAtLocal)
+	})
+	t.Run("SummerDST", func(t *testing.T) {
+		m := Photo{PhotoLat: 48.5716, PhotoLng: 7.8156, TakenSrc: SrcMeta}
+		m.TakenAt = time.Date(2020, 7, 4, 11, 54, 34, 0, time.UTC)
+		m.TakenAtLocal = m.TakenAt
+
+		assert.True(t, m.InferTimeZone())
+		assert.Equal(t, "Europe/Berlin", m.TimeZone)
+		assert.Equal(t, time.Date(2020, 7, 4, 9, 54, 34, 0, time.UTC), m.TakenAt)
+	})
+	t.Run("BorderDetroitWindsor", func(t *testing.T) {
+		detroit := Photo{PhotoLat: 42.3314, PhotoLng: -83.0458, TakenSrc: SrcMeta}
+		detroit.TakenAt = time.Date(2021, 3, 14, 1, 30, 0, 0, time.UTC)
+		detroit.TakenAtLocal = detroit.TakenAt
+
+		windsor := Photo{PhotoLat: 42.3149, PhotoLng: -83.0364, TakenSrc: SrcMeta}
+		windsor.TakenAt = detroit.TakenAt
+		windsor.TakenAtLocal = detroit.TakenAtLocal
+
+		assert.True(t, detroit.InferTimeZone())
+		assert.True(t, windsor.InferTimeZone())
+		assert.Equal(t, "America/Detroit", detroit.TimeZone)
+		assert.Equal(t, "America/Toronto", windsor.TimeZone)
+		assert.Equal(t, time.Date(2021, 3, 14, 6, 30, 0, 0, time.UTC), detroit.TakenAt)
+		assert.Equal(t, detroit.TakenAt, windsor.TakenAt)
+	})
+	t.Run("BorderTijuanaSanYsidro", func(t *testing.T) {
+		tijuana := Photo{PhotoLat: 32.5149, PhotoLng: -117.0382, TakenSrc: SrcMeta}
+		tijuana.TakenAt = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+		tijuana.TakenAtLocal = tijuana.TakenAt
+
+		assert.True(t, tijuana.InferTimeZone())
+		assert.Equal(t, "America/Tijuana", tijuana.TimeZone)
+		assert.Equal(t, time.Date(2021, 6, 1, 19, 0, 0, 0, time.UTC), tijuana.TakenAt)
+	})
+	t.Run("UTC", func(t *testing.T) {
+		m := Photo{PhotoLat: 48.5734, PhotoLng: 7.7521, TimeZone: "UTC", TakenSrc: SrcMeta}
+		m.TakenAt = time.Date(2020, 12, 31, 23, 30, 0, 0, time.UTC)
+		m.TakenAtLocal = time.Time{}
+
+		assert.True(t, m.InferTimeZone())
+		assert.Equal(t, "Europe/Paris", m.TimeZone)
+		assert.Equal(t, time.Date(2020, 12, 31, 23, 30, 0, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, time.Date(2021, 1, 1, 0, 30, 0, 0, time.UTC), m.TakenAtLocal)
+		assert.Equal(t, 2021, m.PhotoYear)
+		assert.Equal(t, 1, m.PhotoDay)
+	})
+	t.Run("KnownTimeZone", func(t *testing.T) {
+		m := Photo{PhotoLat: 48.5734, PhotoLng: 7.7521, TimeZone: "Europe/Berlin"}
+		assert.False(t, m.InferTimeZone())
+		assert.Equal(t, "Europe/Berlin", m.TimeZone)
+	})
+	t.Run("NoLocation", func(t *testing.T) {
+		m := Photo{}
+		assert.False(t, m.InferTimeZone())
+		assert.Equal(t, "", m.TimeZone)
+	})
+}
+
 func TestPhoto_GetTakenAt(t *testing.T) {
 	m := Photo{}
 	m.PhotoLat = 48.533905555
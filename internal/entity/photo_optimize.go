@@ -20,6 +20,9 @@ func (m *Photo) Optimize(mergeMeta, mergeUuid, estimatePlace, force bool) (updat
 		m.UpdateLocation()
 	}
 
+	// Infer time zone from coordinates if unknown.
+	m.InferTimeZone()
+
 	if original, photos, err := m.Merge(mergeMeta, mergeUuid); err != nil {
 		return updated, merged, err
 	} else if len(photos) > 0 && original.ID == m.ID {
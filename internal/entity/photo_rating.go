@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ClipColorLabel is the max length of color label names.
+const ClipColorLabel = 16
+
+// HasRating tests if the photo has a star rating or color label.
+func (m *Photo) HasRating() bool {
+	return m.PhotoRating != meta.RatingNone || m.PhotoColorLabel != ""
+}
+
+// SetRating updates the star rating and color label if the source has priority.
+// A rating of 0 and an empty color label unset the current values.
+func (m *Photo) SetRating(rating int, colorLabel, source string) {
+	if (SrcPriority[source] < SrcPriority[m.RatingSrc]) && m.HasRating() {
+		return
+	}
+
+	m.PhotoRating = meta.SanitizeRating(rating)
+	m.PhotoColorLabel = txt.Clip(strings.ToLower(colorLabel), ClipColorLabel)
+
+	if m.HasRating() {
+		m.RatingSrc = source
+	} else {
+		m.RatingSrc = ""
+	}
+}
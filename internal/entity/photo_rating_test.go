@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+func TestPhoto_SetRating(t *testing.T) {
+	t.Run("Meta", func(t *testing.T) {
+		m := Photo{}
+		assert.False(t, m.HasRating())
+
+		m.SetRating(4, "Red", SrcMeta)
+		assert.Equal(t, 4, m.PhotoRating)
+		assert.Equal(t, "red", m.PhotoColorLabel)
+		assert.Equal(t, SrcMeta, m.RatingSrc)
+		assert.True(t, m.HasRating())
+	})
+	t.Run("Unset", func(t *testing.T) {
+		m := Photo{PhotoRating: 2, PhotoColorLabel: "red", RatingSrc: SrcMeta}
+
+		m.SetRating(meta.RatingNone, "", SrcXmp)
+		assert.Equal(t, meta.RatingNone, m.PhotoRating)
+		assert.Equal(t, "", m.PhotoColorLabel)
+		assert.Equal(t, "", m.RatingSrc)
+		assert.False(t, m.HasRating())
+	})
+	t.Run("UnsetIgnored", func(t *testing.T) {
+		m := Photo{PhotoRating: 2, RatingSrc: SrcXmp}
+
+		m.SetRating(meta.RatingNone, "", SrcMeta)
+		assert.Equal(t, 2, m.PhotoRating)
+		assert.Equal(t, SrcXmp, m.RatingSrc)
+	})
+	t.Run("XmpOverridesMeta", func(t *testing.T) {
+		m := Photo{PhotoRating: 2, RatingSrc: SrcMeta}
+
+		m.SetRating(5, "", SrcXmp)
+		assert.Equal(t, 5, m.PhotoRating)
+		assert.Equal(t, SrcXmp, m.RatingSrc)
+	})
+	t.Run("ManualKeeps", func(t *testing.T) {
+		m := Photo{PhotoRating: 1, PhotoColorLabel: "blue", RatingSrc: SrcManual}
+
+		m.SetRating(5, "green", SrcXmp)
+		assert.Equal(t, 1, m.PhotoRating)
+		assert.Equal(t, "blue", m.PhotoColorLabel)
+		assert.Equal(t, SrcManual, m.RatingSrc)
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		m := Photo{}
+
+		m.SetRating(9, "", SrcMeta)
+		assert.Equal(t, meta.RatingMax, m.PhotoRating)
+	})
+}
@@ -0,0 +1,299 @@
+package entity
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/fts"
+)
+
+// SearchIndexRefresh is the minimum time between checks for photos changed by other processes.
+var SearchIndexRefresh = 5 * time.Second
+
+// searchIndex is the optional full-text search index for photos, see SetSearchIndex.
+var searchIndex fts.Index
+var searchIndexReady bool
+var searchIndexMutex = sync.Mutex{}
+var searchIndexBuildMutex = sync.Mutex{}
+
+// searchIndexSynced is the database state when the index was last updated from it,
+// searchIndexChecked is the time of the last check, see refreshSearchIndex.
+var searchIndexSynced searchIndexState
+var searchIndexChecked time.Time
+
+// searchIndexBatchSize is the number of photos read at once when building the index.
+const searchIndexBatchSize = 1000
+
+// searchIndexState represents the number of photos, the highest ID, and the latest changes in the database.
+type searchIndexState struct {
+	Count     int
+	MaxID     uint
+	PhotosAt  time.Time
+	DetailsAt time.Time
+}
+
+// Equal tests if the database state hasn't changed.
+func (s searchIndexState) Equal(other searchIndexState) bool {
+	return s.Count == other.Count && s.MaxID == other.MaxID && s.PhotosAt.Equal(other.PhotosAt) && s.DetailsAt.Equal(other.DetailsAt)
+}
+
+// currentSearchIndexState returns the current database state, see refreshSearchIndex.
+func currentSearchIndexState() (result searchIndexState, err error) {
+	var ids []uint
+	var photosAt, detailsAt []time.Time
+
+	if err = UnscopedDb().Table(Photo{}.TableName()).Count(&result.Count).Error; err != nil {
+		return result, err
+	} else if err = UnscopedDb().Table(Photo{}.TableName()).Order("id DESC").Limit(1).Pluck("id", &ids).Error; err != nil {
+		return result, err
+	} else if err = UnscopedDb().Table(Photo{}.TableName()).Order("updated_at DESC").Limit(1).Pluck("updated_at", &photosAt).Error; err != nil {
+		return result, err
+	} else if err = UnscopedDb().Table("details").Order("updated_at DESC").Limit(1).Pluck("updated_at", &detailsAt).Error; err != nil {
+		return result, err
+	}
+
+	if len(ids) > 0 {
+		result.MaxID = ids[0]
+	}
+
+	if len(photosAt) > 0 {
+		result.PhotosAt = photosAt[0]
+	}
+
+	if len(detailsAt) > 0 {
+		result.DetailsAt = detailsAt[0]
+	}
+
+	return result, nil
+}
+
+// SetSearchIndex sets the full-text search index for photos, or disables it if nil.
+func SetSearchIndex(idx fts.Index) {
+	searchIndexMutex.Lock()
+	defer searchIndexMutex.Unlock()
+
+	searchIndex = idx
+	searchIndexReady = false
+}
+
+// currentSearchIndex returns the full-text search index without building it.
+func currentSearchIndex() (fts.Index, bool) {
+	searchIndexMutex.Lock()
+	defer searchIndexMutex.Unlock()
+
+	return searchIndex, searchIndexReady
+}
+
+// SearchIndex returns the full-text search index for photos, or nil if it is disabled.
+// All photos are added when the index is used for the first time, photos changed by
+// other processes, e.g. when indexing from the command line, are updated afterwards.
+func SearchIndex() fts.Index {
+	idx, ready := currentSearchIndex()
+
+	if idx == nil {
+		return nil
+	}
+
+	searchIndexBuildMutex.Lock()
+	defer searchIndexBuildMutex.Unlock()
+
+	if ready {
+		refreshSearchIndex(idx)
+		return idx
+	}
+
+	// Already built while waiting?
+	if idx, ready = currentSearchIndex(); idx == nil || ready {
+		return idx
+	}
+
+	state, err := currentSearchIndexState()
+
+	if err != nil {
+		log.Errorf("search: %s (build index)", err)
+		return nil
+	} else if err = BuildSearchIndex(idx); err != nil {
+		log.Errorf("search: %s (build index)", err)
+		return nil
+	}
+
+	searchIndexSynced = state
+	searchIndexChecked = time.Now()
+
+	searchIndexMutex.Lock()
+	defer searchIndexMutex.Unlock()
+
+	if searchIndex == idx {
+		searchIndexReady = true
+	}
+
+	return idx
+}
+
+// refreshSearchIndex updates photos changed in the database since the index was last updated,
+// and rebuilds it if photos were deleted. The caller must hold the build lock.
+func refreshSearchIndex(idx fts.Index) {
+	if time.Since(searchIndexChecked) < SearchIndexRefresh {
+		return
+	}
+
+	searchIndexChecked = time.Now()
+
+	state, err := currentSearchIndexState()
+
+	if err != nil {
+		log.Errorf("search: %s (refresh index)", err)
+		return
+	} else if state.Equal(searchIndexSynced) {
+		return
+	}
+
+	added := 0
+
+	if err = UnscopedDb().Table(Photo{}.TableName()).Where("id > ?", searchIndexSynced.MaxID).Count(&added).Error; err != nil {
+		log.Errorf("search: %s (refresh index)", err)
+		return
+	}
+
+	// Rebuild index if photos were deleted, otherwise update changed photos only.
+	if state.Count < searchIndexSynced.Count+added {
+		if err = idx.Reset(); err == nil {
+			err = BuildSearchIndex(idx)
+		}
+	} else {
+		err = updateSearchIndex(idx, "photos.updated_at >= ? OR details.updated_at >= ?", searchIndexSynced.PhotosAt, searchIndexSynced.DetailsAt)
+	}
+
+	if err != nil {
+		log.Errorf("search: %s (refresh index)", err)
+		return
+	}
+
+	searchIndexSynced = state
+}
+
+// searchIndexRow represents the indexed text of a photo.
+type searchIndexRow struct {
+	ID               uint
+	PhotoTitle       string
+	PhotoDescription string
+	Keywords         string
+	Subject          string
+	Artist           string
+	Notes            string
+	PhotoKeywords    string
+}
+
+// Document returns the search index document.
+func (r searchIndexRow) Document() fts.Document {
+	return fts.Document{
+		ID: r.ID,
+		Fields: []fts.Field{
+			{Text: r.PhotoTitle, Weight: 3},
+			{Text: r.PhotoDescription, Weight: 2},
+			{Text: r.Keywords, Weight: 2},
+			{Text: r.Subject, Weight: 1},
+			{Text: r.Artist, Weight: 1},
+			{Text: r.Notes, Weight: 1},
+			{Text: r.PhotoKeywords, Weight: 1},
+		},
+	}
+}
+
+// BuildSearchIndex adds all photos to a full-text search index, including archived photos.
+func BuildSearchIndex(idx fts.Index) error {
+	if err := updateSearchIndex(idx, "1 = 1"); err != nil {
+		return err
+	}
+
+	log.Debugf("search: indexed %d photos", idx.Len())
+
+	return nil
+}
+
+// updateSearchIndex adds photos matching the condition to a full-text search index.
+func updateSearchIndex(idx fts.Index, where string, args ...interface{}) error {
+	var lastId uint
+
+	for {
+		var rows []searchIndexRow
+
+		if err := UnscopedDb().Table(Photo{}.TableName()).
+			Select(`photos.id, photos.photo_title, photos.photo_description,
+				COALESCE(details.keywords, '') AS keywords, COALESCE(details.subject, '') AS subject,
+				COALESCE(details.artist, '') AS artist, COALESCE(details.notes, '') AS notes,
+				COALESCE((SELECT GROUP_CONCAT(k.keyword) FROM keywords k JOIN photos_keywords pk ON pk.keyword_id = k.id 
+				WHERE pk.photo_id = photos.id AND k.skip = 0), '') AS photo_keywords`).
+			Joins("LEFT JOIN details ON details.photo_id = photos.id").
+			Where("photos.id > ?", lastId).
+			Where(where, args...).
+			Order("photos.id").
+			Limit(searchIndexBatchSize).
+			Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, r := range rows {
+			if err := idx.Update(r.Document()); err != nil {
+				return err
+			}
+
+			lastId = r.ID
+		}
+
+		if len(rows) < searchIndexBatchSize {
+			break
+		}
+	}
+
+	return nil
+}
+
+// UpdateSearchIndex updates the photo in the full-text search index, if enabled.
+func (m *Photo) UpdateSearchIndex() {
+	idx, _ := currentSearchIndex()
+
+	if idx == nil || !m.HasID() {
+		return
+	}
+
+	var keywords []string
+
+	if err := Db().Table("keywords").
+		Joins("JOIN photos_keywords pk ON pk.keyword_id = keywords.id").
+		Where("pk.photo_id = ? AND keywords.skip = 0", m.ID).
+		Pluck("keywords.keyword", &keywords).Error; err != nil {
+		log.Errorf("photo: %s (update search index)", err)
+	}
+
+	details := m.GetDetails()
+
+	doc := searchIndexRow{
+		ID:               m.ID,
+		PhotoTitle:       m.PhotoTitle,
+		PhotoDescription: m.PhotoDescription,
+		Keywords:         details.Keywords,
+		Subject:          details.Subject,
+		Artist:           details.Artist,
+		Notes:            details.Notes,
+		PhotoKeywords:    strings.Join(keywords, ","),
+	}.Document()
+
+	if err := idx.Update(doc); err != nil {
+		log.Errorf("photo: %s (update search index)", err)
+	}
+}
+
+// DeleteSearchIndex removes the photo from the full-text search index, if enabled.
+func (m *Photo) DeleteSearchIndex() {
+	idx, _ := currentSearchIndex()
+
+	if idx == nil || !m.HasID() {
+		return
+	}
+
+	if err := idx.Delete(m.ID); err != nil {
+		log.Errorf("photo: %s (delete from search index)", err)
+	}
+}
@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fts"
+)
+
+func TestSearchIndex(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		SetSearchIndex(nil)
+
+		assert.Nil(t, SearchIndex())
+	})
+	t.Run("Build", func(t *testing.T) {
+		SetSearchIndex(fts.NewMemory("en"))
+		defer SetSearchIndex(nil)
+
+		idx := SearchIndex()
+
+		if idx == nil {
+			t.Fatal("index must not be nil")
+		}
+
+		assert.Greater(t, idx.Len(), 10)
+
+		var photo Photo
+
+		if err := UnscopedDb().Where("photo_title = ?", "Neckarbrücke").First(&photo).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		hits, err := idx.Search("neckarbrücke", 0)
+
+		assert.NoError(t, err)
+		assert.Contains(t, hits.IDs(), photo.ID)
+	})
+}
+
+func TestSearchIndex_Refresh(t *testing.T) {
+	SearchIndexRefresh = 0
+	SetSearchIndex(fts.NewMemory("en"))
+
+	defer func() {
+		SearchIndexRefresh = 5 * time.Second
+		SetSearchIndex(nil)
+	}()
+
+	if SearchIndex() == nil {
+		t.Fatal("index must not be nil")
+	}
+
+	// Changes by other processes are only saved in the database.
+	m := NewPhoto(false)
+	m.PhotoTitle = "Quokka Selfie"
+
+	if err := UnscopedDb().Create(&m).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Added", func(t *testing.T) {
+		hits, err := SearchIndex().Search("quokka", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{m.ID}, hits.IDs())
+	})
+	t.Run("Updated", func(t *testing.T) {
+		if err := UnscopedDb().Model(&m).UpdateColumns(map[string]interface{}{"photo_title": "Wombat Selfie", "updated_at": TimeStamp().Add(time.Second)}).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		hits, err := SearchIndex().Search("wombat", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{m.ID}, hits.IDs())
+
+		hits, err = SearchIndex().Search("quokka", 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+	t.Run("Deleted", func(t *testing.T) {
+		if err := UnscopedDb().Delete(&m).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		hits, err := SearchIndex().Search("wombat", 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, hits)
+		assert.Greater(t, SearchIndex().Len(), 10)
+	})
+}
+
+func TestPhoto_UpdateSearchIndex(t *testing.T) {
+	idx := fts.NewMemory("en")
+	SetSearchIndex(idx)
+	defer SetSearchIndex(nil)
+
+	m := PhotoFixtures.Get("Photo08")
+	m.PhotoTitle = "Zebra crossing"
+	m.PhotoDescription = "Stripes"
+	m.UpdateSearchIndex()
+
+	hits, err := idx.Search("zebra", 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{m.ID}, hits.IDs())
+
+	m.DeleteSearchIndex()
+
+	hits, err = idx.Search("zebra", 0)
+
+	assert.NoError(t, err)
+	assert.Empty(t, hits)
+}
@@ -13,6 +13,7 @@ const (
 	SrcYaml     = "yaml"               // Prio 8
 	SrcLocation = classify.SrcLocation // Prio 8
 	SrcMarker   = "marker"             // Prio 8
+	SrcTrack    = "track"              // Prio 8
 	SrcImage    = classify.SrcImage    // Prio 8
 	SrcKeyword  = classify.SrcKeyword  // Prio 16
 	SrcMeta     = "meta"               // Prio 16
@@ -38,6 +39,7 @@ var SrcPriority = Priorities{
 	SrcYaml:     8,
 	SrcLocation: 8,
 	SrcMarker:   8,
+	SrcTrack:    8,
 	SrcImage:    8,
 	SrcKeyword:  16,
 	SrcMeta:     16,
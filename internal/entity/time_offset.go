@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeOffsetRegexp = regexp.MustCompile(`(\d+)(y|d|h|m|s)`)
+
+// TimeOffset represents a camera clock offset in years, days, and a duration.
+type TimeOffset struct {
+	Years    int
+	Days     int
+	Duration time.Duration
+}
+
+// ParseTimeOffset parses a time offset string like "+1y", "-2h30m", or "1d 12h". Numbers
+// without unit are interpreted as seconds.
+func ParseTimeOffset(s string) (o TimeOffset, err error) {
+	s = strings.ReplaceAll(strings.TrimSpace(strings.ToLower(s)), " ", "")
+
+	if s == "" {
+		return o, fmt.Errorf("time offset is empty")
+	}
+
+	sign := 1
+
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	// Seconds?
+	if i, err := strconv.Atoi(s); err == nil {
+		o.Duration = time.Duration(sign*i) * time.Second
+		return o, nil
+	}
+
+	matches := timeOffsetRegexp.FindAllStringSubmatch(s, -1)
+
+	if len(matches) == 0 || len(timeOffsetRegexp.ReplaceAllString(s, "")) > 0 {
+		return o, fmt.Errorf("invalid time offset %q", s)
+	}
+
+	for _, m := range matches {
+		n, _ := strconv.Atoi(m[1])
+		n = sign * n
+
+		switch m[2] {
+		case "y":
+			o.Years += n
+		case "d":
+			o.Days += n
+		case "h":
+			o.Duration += time.Duration(n) * time.Hour
+		case "m":
+			o.Duration += time.Duration(n) * time.Minute
+		case "s":
+			o.Duration += time.Duration(n) * time.Second
+		}
+	}
+
+	return o, nil
+}
+
+// IsZero tests if the offset is empty.
+func (o TimeOffset) IsZero() bool {
+	return o.Years == 0 && o.Days == 0 && o.Duration == 0
+}
+
+// Apply returns the time shifted by the offset.
+func (o TimeOffset) Apply(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	return t.AddDate(o.Years, 0, o.Days).Add(o.Duration)
+}
+
+// String returns the offset as string, e.g. "+1y2d3h0m0s".
+func (o TimeOffset) String() string {
+	if o.IsZero() {
+		return "0s"
+	}
+
+	var sb strings.Builder
+
+	if o.Years < 0 || o.Years == 0 && o.Days < 0 || o.Years == 0 && o.Days == 0 && o.Duration < 0 {
+		o = TimeOffset{Years: -o.Years, Days: -o.Days, Duration: -o.Duration}
+		sb.WriteString("-")
+	} else {
+		sb.WriteString("+")
+	}
+
+	if o.Years != 0 {
+		sb.WriteString(fmt.Sprintf("%dy", o.Years))
+	}
+
+	if o.Days != 0 {
+		sb.WriteString(fmt.Sprintf("%dd", o.Days))
+	}
+
+	if o.Duration != 0 {
+		sb.WriteString(o.Duration.String())
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeOffset(t *testing.T) {
+	t.Run("Year", func(t *testing.T) {
+		o, err := ParseTimeOffset("+1y")
+
+		assert.NoError(t, err)
+		assert.Equal(t, TimeOffset{Years: 1}, o)
+		assert.Equal(t, "+1y", o.String())
+	})
+	t.Run("Negative", func(t *testing.T) {
+		o, err := ParseTimeOffset("-2h30m")
+
+		assert.NoError(t, err)
+		assert.Equal(t, TimeOffset{Duration: -150 * time.Minute}, o)
+		assert.Equal(t, "-2h30m0s", o.String())
+	})
+	t.Run("DaysAndHours", func(t *testing.T) {
+		o, err := ParseTimeOffset("1d 12h")
+
+		assert.NoError(t, err)
+		assert.Equal(t, TimeOffset{Days: 1, Duration: 12 * time.Hour}, o)
+		assert.Equal(t, "+1d12h0m0s", o.String())
+	})
+	t.Run("Seconds", func(t *testing.T) {
+		o, err := ParseTimeOffset("-3600")
+
+		assert.NoError(t, err)
+		assert.Equal(t, TimeOffset{Duration: -time.Hour}, o)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := ParseTimeOffset("")
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseTimeOffset("1w")
+		assert.Error(t, err)
+
+		_, err = ParseTimeOffset("2hfoo")
+		assert.Error(t, err)
+	})
+}
+
+func TestTimeOffset_Apply(t *testing.T) {
+	t.Run("YearAndHours", func(t *testing.T) {
+		o := TimeOffset{Years: -1, Duration: 2 * time.Hour}
+		assert.Equal(t, time.Date(2019, 12, 31, 23, 30, 0, 0, time.UTC), o.Apply(time.Date(2020, 12, 31, 21, 30, 0, 0, time.UTC)))
+	})
+	t.Run("Zero", func(t *testing.T) {
+		o := TimeOffset{Days: 1}
+		assert.True(t, o.Apply(time.Time{}).IsZero())
+		assert.True(t, TimeOffset{}.IsZero())
+		assert.Equal(t, "0s", TimeOffset{}.String())
+	})
+}
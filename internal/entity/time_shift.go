@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+type TimeShifts []TimeShift
+
+// TimeShift records the original date of a photo before a bulk time shift so that it can be undone.
+type TimeShift struct {
+	ShiftUID     string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"ShiftUID" yaml:"ShiftUID"`
+	PhotoUID     string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index;" json:"PhotoUID" yaml:"PhotoUID"`
+	ShiftOffset  string    `gorm:"type:VARBINARY(64);" json:"Offset" yaml:"Offset"`
+	ShiftedAt    time.Time `gorm:"type:datetime;" json:"ShiftedAt" yaml:"ShiftedAt"`
+	TakenAt      time.Time `gorm:"type:datetime;" json:"TakenAt" yaml:"TakenAt"`
+	TakenAtLocal time.Time `gorm:"type:datetime;" json:"TakenAtLocal" yaml:"TakenAtLocal"`
+	TakenSrc     string    `gorm:"type:VARBINARY(8);" json:"TakenSrc" yaml:"TakenSrc,omitempty"`
+	PhotoYear    int       `json:"Year" yaml:"Year"`
+	PhotoMonth   int       `json:"Month" yaml:"Month"`
+	PhotoDay     int       `json:"Day" yaml:"Day"`
+	CreatedAt    time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (TimeShift) TableName() string {
+	return "time_shifts"
+}
+
+// NewTimeShiftUID returns a new random time shift UID.
+func NewTimeShiftUID() string {
+	return rnd.PPID('t')
+}
+
+// NewTimeShift returns a new time shift record for the photo, which must not be shifted yet.
+func NewTimeShift(shiftUID string, p Photo, offset TimeOffset) *TimeShift {
+	return &TimeShift{
+		ShiftUID:     shiftUID,
+		PhotoUID:     p.PhotoUID,
+		ShiftOffset:  offset.String(),
+		ShiftedAt:    offset.Apply(p.TakenAt).UTC(),
+		TakenAt:      p.TakenAt,
+		TakenAtLocal: p.TakenAtLocal,
+		TakenSrc:     p.TakenSrc,
+		PhotoYear:    p.PhotoYear,
+		PhotoMonth:   p.PhotoMonth,
+		PhotoDay:     p.PhotoDay,
+	}
+}
+
+// Create inserts a new row to the database.
+func (m *TimeShift) Create() error {
+	if !rnd.IsPPID(m.ShiftUID, 't') {
+		return fmt.Errorf("time shift uid %s is invalid (create)", m.ShiftUID)
+	} else if m.PhotoUID == "" {
+		return fmt.Errorf("time shift photo uid must not be empty (create)")
+	}
+
+	return Db().Create(m).Error
+}
+
+// Delete removes the row from the database.
+func (m *TimeShift) Delete() error {
+	return UnscopedDb().Delete(TimeShift{}, "shift_uid = ? AND photo_uid = ?", m.ShiftUID, m.PhotoUID).Error
+}
+
+// Restore resets the photo date to the values before the time shift. It returns false if the
+// photo date was changed again after the shift.
+func (m *TimeShift) Restore(p *Photo) bool {
+	if p == nil || p.PhotoUID != m.PhotoUID {
+		return false
+	} else if !p.TakenAt.Equal(m.ShiftedAt) {
+		return false
+	}
+
+	p.TakenAt = m.TakenAt
+	p.TakenAtLocal = m.TakenAtLocal
+	p.TakenSrc = m.TakenSrc
+	p.PhotoYear = m.PhotoYear
+	p.PhotoMonth = m.PhotoMonth
+	p.PhotoDay = m.PhotoDay
+
+	return true
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTimeShift(t *testing.T) {
+	p := PhotoFixtures.Get("Photo01")
+	uid := NewTimeShiftUID()
+
+	m := NewTimeShift(uid, p, TimeOffset{Years: 1})
+
+	assert.Equal(t, uid, m.ShiftUID)
+	assert.Equal(t, "pt9jtdre2lvl0yh8", m.PhotoUID)
+	assert.Equal(t, "+1y", m.ShiftOffset)
+	assert.Equal(t, time.Date(2007, 1, 1, 2, 0, 0, 0, time.UTC), m.ShiftedAt)
+	assert.Equal(t, p.TakenAt, m.TakenAt)
+	assert.Equal(t, p.TakenSrc, m.TakenSrc)
+	assert.Equal(t, p.PhotoYear, m.PhotoYear)
+}
+
+func TestTimeShift_Create(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		p := PhotoFixtures.Get("Photo01")
+		m := NewTimeShift(NewTimeShiftUID(), p, TimeOffset{Days: 2})
+
+		assert.NoError(t, m.Create())
+		assert.NoError(t, m.Delete())
+	})
+	t.Run("InvalidUID", func(t *testing.T) {
+		m := TimeShift{ShiftUID: "foo", PhotoUID: "pt9jtdre2lvl0yh8"}
+		assert.Error(t, m.Create())
+	})
+	t.Run("NoPhoto", func(t *testing.T) {
+		m := TimeShift{ShiftUID: NewTimeShiftUID()}
+		assert.Error(t, m.Create())
+	})
+}
+
+func TestTimeShift_Restore(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		p := PhotoFixtures.Get("Photo01")
+		offset := TimeOffset{Years: -1, Duration: time.Hour}
+		m := NewTimeShift(NewTimeShiftUID(), p, offset)
+
+		p.ShiftTime(offset)
+
+		assert.Equal(t, 2005, p.PhotoYear)
+		assert.True(t, m.Restore(&p))
+		assert.Equal(t, time.Date(2006, 1, 1, 2, 0, 0, 0, time.UTC), p.TakenAt)
+		assert.Equal(t, "meta", p.TakenSrc)
+		assert.Equal(t, m.PhotoYear, p.PhotoYear)
+	})
+	t.Run("ChangedAfterShift", func(t *testing.T) {
+		p := PhotoFixtures.Get("Photo01")
+		m := NewTimeShift(NewTimeShiftUID(), p, TimeOffset{Days: 1})
+
+		assert.False(t, m.Restore(&p))
+		assert.False(t, m.Restore(nil))
+	})
+}
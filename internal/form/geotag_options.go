@@ -0,0 +1,7 @@
+package form
+
+type GeotagOptions struct {
+	MaxGap int  `json:"maxGap" form:"maxGap"` // Max time gap in seconds.
+	Offset int  `json:"offset" form:"offset"` // Camera clock offset in seconds.
+	DryRun bool `json:"dryRun" form:"dryRun"`
+}
@@ -44,6 +44,9 @@ type Photo struct {
 	PhotoPrivate     bool      `json:"Private"`
 	PhotoScan        bool      `json:"Scan"`
 	PhotoPanorama    bool      `json:"Panorama"`
+	PhotoRating      int       `json:"Rating"`
+	PhotoColorLabel  string    `json:"ColorLabel"`
+	RatingSrc        string    `json:"RatingSrc"`
 	PhotoAltitude    int       `json:"Altitude"`
 	PhotoLat         float32   `json:"Lat"`
 	PhotoLng         float32   `json:"Lng"`
@@ -0,0 +1,419 @@
+package form
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// QueryError represents a search query syntax error at the given character position, starting with 1.
+type QueryError struct {
+	Pos int    `json:"position"`
+	Msg string `json:"message"`
+}
+
+// Error returns the error message including the position.
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+// queryErrorf returns a new syntax error at the given position.
+func queryErrorf(pos int, format string, a ...interface{}) *QueryError {
+	return &QueryError{Pos: pos, Msg: fmt.Sprintf(format, a...)}
+}
+
+// QueryExpr represents a node in the syntax tree of a search query.
+type QueryExpr interface {
+	Pos() int
+	String() string
+}
+
+// QueryAnd matches if both expressions match, e.g. "label:cat country:de" or "label:cat & country:de".
+type QueryAnd struct {
+	Left, Right QueryExpr
+}
+
+func (e *QueryAnd) Pos() int {
+	return e.Left.Pos()
+}
+
+func (e *QueryAnd) String() string {
+	return e.Left.String() + " " + e.Right.String()
+}
+
+// QueryOr matches if either expression matches, e.g. "label:cat | label:dog" or "label:cat OR label:dog".
+type QueryOr struct {
+	Left, Right QueryExpr
+}
+
+func (e *QueryOr) Pos() int {
+	return e.Left.Pos()
+}
+
+func (e *QueryOr) String() string {
+	return e.Left.String() + " | " + e.Right.String()
+}
+
+// QueryNot matches if the expression does not match, e.g. "-country:de", "!country:de", or "NOT country:de".
+type QueryNot struct {
+	Position int
+	Expr     QueryExpr
+}
+
+func (e *QueryNot) Pos() int {
+	return e.Position
+}
+
+func (e *QueryNot) String() string {
+	return "-" + e.Expr.String()
+}
+
+// QueryGroup represents an expression in parentheses.
+type QueryGroup struct {
+	Position int
+	Expr     QueryExpr
+}
+
+func (e *QueryGroup) Pos() int {
+	return e.Position
+}
+
+func (e *QueryGroup) String() string {
+	return "(" + e.Expr.String() + ")"
+}
+
+// QueryField represents a search filter, e.g. "label:cat", "iso:>1600", or "taken:2019..2021".
+type QueryField struct {
+	Position int
+	Name     string
+	Value    string
+}
+
+func (e *QueryField) Pos() int {
+	return e.Position
+}
+
+func (e *QueryField) String() string {
+	return e.Name + ":" + queryQuote(e.Value)
+}
+
+// QueryTerm represents a search term without field name.
+type QueryTerm struct {
+	Position int
+	Value    string
+}
+
+func (e *QueryTerm) Pos() int {
+	return e.Position
+}
+
+func (e *QueryTerm) String() string {
+	return queryQuote(e.Value)
+}
+
+// queryQuote returns the value in double quotes if it contains spaces or operators.
+func queryQuote(s string) string {
+	if strings.ContainsAny(s, " \t()") {
+		return "\"" + s + "\""
+	}
+
+	return s
+}
+
+// QueryBoolean tests if the expression contains groups, alternatives, or negations, so that it cannot be
+// represented by form fields alone.
+func QueryBoolean(expr QueryExpr) bool {
+	switch e := expr.(type) {
+	case *QueryAnd:
+		return QueryBoolean(e.Left) || QueryBoolean(e.Right)
+	case *QueryOr, *QueryNot, *QueryGroup:
+		return true
+	default:
+		return false
+	}
+}
+
+// QueryFields returns the filters in the expression in the order they appear.
+func QueryFields(expr QueryExpr) (result []*QueryField) {
+	switch e := expr.(type) {
+	case *QueryAnd:
+		return append(QueryFields(e.Left), QueryFields(e.Right)...)
+	case *QueryOr:
+		return append(QueryFields(e.Left), QueryFields(e.Right)...)
+	case *QueryNot:
+		return QueryFields(e.Expr)
+	case *QueryGroup:
+		return QueryFields(e.Expr)
+	case *QueryField:
+		return []*QueryField{e}
+	default:
+		return nil
+	}
+}
+
+// queryFieldName matches valid filter names.
+var queryFieldName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// queryTokenKind represents the type of query token.
+type queryTokenKind int
+
+const (
+	queryEOF queryTokenKind = iota
+	queryWord
+	queryOr
+	queryAnd
+	queryNot
+	queryOpen
+	queryClose
+)
+
+// queryToken represents a lexical token of a search query.
+type queryToken struct {
+	kind queryTokenKind
+	pos  int
+	text string
+}
+
+// queryTokens splits a search query into tokens. Operators must be separated by spaces or parentheses,
+// so that values like "label:cat|dog" keep their meaning.
+func queryTokens(q string) (tokens []queryToken, err error) {
+	runes := []rune(q)
+	n := len(runes)
+
+	for i := 0; i < n; {
+		c := runes[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+			continue
+		case c == '(':
+			tokens = append(tokens, queryToken{kind: queryOpen, pos: i + 1, text: "("})
+			i++
+			continue
+		case c == ')':
+			tokens = append(tokens, queryToken{kind: queryClose, pos: i + 1, text: ")"})
+			i++
+			continue
+		case (c == '-' || c == '!') && i+1 < n && !unicode.IsSpace(runes[i+1]) && runes[i+1] != ')':
+			tokens = append(tokens, queryToken{kind: queryNot, pos: i + 1, text: string(c)})
+			i++
+			continue
+		}
+
+		start := i
+		quoted, quote := false, -1
+
+		for i < n && (quoted || !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')') {
+			if runes[i] == '"' {
+				if !quoted {
+					quote = i
+				}
+
+				quoted = !quoted
+			}
+
+			i++
+		}
+
+		if quoted {
+			return tokens, queryErrorf(quote+1, "missing closing quote")
+		}
+
+		word := string(runes[start:i])
+
+		switch word {
+		case "|", "||", "OR":
+			tokens = append(tokens, queryToken{kind: queryOr, pos: start + 1, text: word})
+		case "&", "&&", "AND":
+			tokens = append(tokens, queryToken{kind: queryAnd, pos: start + 1, text: word})
+		case "NOT":
+			tokens = append(tokens, queryToken{kind: queryNot, pos: start + 1, text: word})
+		default:
+			tokens = append(tokens, queryToken{kind: queryWord, pos: start + 1, text: word})
+		}
+	}
+
+	tokens = append(tokens, queryToken{kind: queryEOF, pos: n + 1})
+
+	return tokens, nil
+}
+
+// queryParser builds a syntax tree from query tokens.
+type queryParser struct {
+	tokens []queryToken
+	i      int
+}
+
+// ParseQuery parses a search query and returns its syntax tree, or nil if the query is empty:
+//
+//   query   = or
+//   or      = and { ("|" | "OR") and }
+//   and     = unary { ["&" | "AND"] unary }
+//   unary   = ("-" | "!" | "NOT") unary | primary
+//   primary = "(" or ")" | name ":" value | term
+//
+// Values may be enclosed in double quotes, for example: (label:cat | label:dog) -country:de title:"my cat".
+func ParseQuery(q string) (QueryExpr, error) {
+	tokens, err := queryTokens(q)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryParser{tokens: tokens}
+
+	if p.peek().kind == queryEOF {
+		return nil, nil
+	}
+
+	expr, err := p.or()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != queryEOF {
+		return nil, queryErrorf(t.pos, "unexpected %q", t.text)
+	}
+
+	return expr, nil
+}
+
+// peek returns the current token.
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.i]
+}
+
+// next returns the current token and advances to the next one.
+func (p *queryParser) next() queryToken {
+	t := p.tokens[p.i]
+
+	if t.kind != queryEOF {
+		p.i++
+	}
+
+	return t
+}
+
+func (p *queryParser) or() (QueryExpr, error) {
+	left, err := p.and()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == queryOr {
+		p.next()
+
+		right, err := p.and()
+
+		if err != nil {
+			return nil, err
+		}
+
+		left = &QueryOr{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) and() (QueryExpr, error) {
+	left, err := p.unary()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch p.peek().kind {
+		case queryAnd:
+			p.next()
+		case queryWord, queryNot, queryOpen:
+			// Terms without operator must all match.
+		default:
+			return left, nil
+		}
+
+		right, err := p.unary()
+
+		if err != nil {
+			return nil, err
+		}
+
+		left = &QueryAnd{Left: left, Right: right}
+	}
+}
+
+func (p *queryParser) unary() (QueryExpr, error) {
+	if t := p.peek(); t.kind == queryNot {
+		p.next()
+
+		expr, err := p.unary()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &QueryNot{Position: t.pos, Expr: expr}, nil
+	}
+
+	return p.primary()
+}
+
+func (p *queryParser) primary() (QueryExpr, error) {
+	t := p.next()
+
+	switch t.kind {
+	case queryOpen:
+		if p.peek().kind == queryClose {
+			return nil, queryErrorf(t.pos, "empty parentheses")
+		}
+
+		expr, err := p.or()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek().kind != queryClose {
+			return nil, queryErrorf(t.pos, "missing closing parenthesis")
+		}
+
+		p.next()
+
+		return &QueryGroup{Position: t.pos, Expr: expr}, nil
+	case queryWord:
+		return queryWordExpr(t)
+	case queryEOF:
+		return nil, queryErrorf(t.pos, "unexpected end of query")
+	default:
+		return nil, queryErrorf(t.pos, "unexpected %q", t.text)
+	}
+}
+
+// queryWordExpr returns a field or term expression for a word token.
+func queryWordExpr(t queryToken) (QueryExpr, error) {
+	runes := []rune(t.text)
+	quoted := false
+
+	for i, c := range runes {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted && i > 0 {
+			fieldName := strings.ToLower(string(runes[:i]))
+			value := strings.ReplaceAll(string(runes[i+1:]), "\"", "")
+
+			if !queryFieldName.MatchString(fieldName) {
+				return nil, queryErrorf(t.pos, "invalid filter name %q", fieldName)
+			} else if value == "" {
+				return nil, queryErrorf(t.pos+i+1, "missing value for %s", fieldName)
+			}
+
+			return &QueryField{Position: t.pos, Name: fieldName, Value: value}, nil
+		}
+	}
+
+	return &QueryTerm{Position: t.pos, Value: strings.ReplaceAll(t.text, "\"", "")}, nil
+}
@@ -0,0 +1,126 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		expr, err := ParseQuery("  ")
+		assert.NoError(t, err)
+		assert.Nil(t, expr)
+	})
+	t.Run("Term", func(t *testing.T) {
+		expr, err := ParseQuery("cat")
+		assert.NoError(t, err)
+		assert.Equal(t, &QueryTerm{Position: 1, Value: "cat"}, expr)
+		assert.False(t, QueryBoolean(expr))
+	})
+	t.Run("Field", func(t *testing.T) {
+		expr, err := ParseQuery(`Title:"Jens & Mander"`)
+		assert.NoError(t, err)
+		assert.Equal(t, &QueryField{Position: 1, Name: "title", Value: "Jens & Mander"}, expr)
+		assert.Equal(t, `title:"Jens & Mander"`, expr.String())
+	})
+	t.Run("And", func(t *testing.T) {
+		expr, err := ParseQuery("label:cat|dog country:de")
+		assert.NoError(t, err)
+		assert.IsType(t, &QueryAnd{}, expr)
+		assert.Equal(t, "label:cat|dog country:de", expr.String())
+		assert.False(t, QueryBoolean(expr))
+	})
+	t.Run("Boolean", func(t *testing.T) {
+		expr, err := ParseQuery("(label:cat | label:dog) -country:de iso:>1600 taken:2019..2021")
+		assert.NoError(t, err)
+		assert.Equal(t, "(label:cat | label:dog) -country:de iso:>1600 taken:2019..2021", expr.String())
+		assert.True(t, QueryBoolean(expr))
+
+		fields := QueryFields(expr)
+
+		if assert.Len(t, fields, 5) {
+			assert.Equal(t, &QueryField{Position: 2, Name: "label", Value: "cat"}, fields[0])
+			assert.Equal(t, &QueryField{Position: 26, Name: "country", Value: "de"}, fields[2])
+			assert.Equal(t, &QueryField{Position: 37, Name: "iso", Value: ">1600"}, fields[3])
+			assert.Equal(t, &QueryField{Position: 47, Name: "taken", Value: "2019..2021"}, fields[4])
+		}
+
+		and, ok := expr.(*QueryAnd)
+
+		if !ok {
+			t.Fatalf("unexpected expression type %T", expr)
+		}
+
+		assert.Equal(t, "taken:2019..2021", and.Right.String())
+	})
+	t.Run("Precedence", func(t *testing.T) {
+		expr, err := ParseQuery("a b OR c AND NOT d")
+		assert.NoError(t, err)
+
+		or, ok := expr.(*QueryOr)
+
+		if !ok {
+			t.Fatalf("unexpected expression type %T", expr)
+		}
+
+		assert.Equal(t, "a b", or.Left.String())
+		assert.Equal(t, "c -d", or.Right.String())
+		assert.Equal(t, 14, or.Right.(*QueryAnd).Right.Pos())
+	})
+	t.Run("Not", func(t *testing.T) {
+		expr, err := ParseQuery("!label:cat -(a | b)")
+		assert.NoError(t, err)
+		assert.Equal(t, "-label:cat -(a | b)", expr.String())
+	})
+	t.Run("Unicode", func(t *testing.T) {
+		_, err := ParseQuery(`title:Tübingen )`)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, 16, err.(*QueryError).Pos)
+		}
+	})
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{"(label:cat | label:dog", 1, "missing closing parenthesis"},
+		{"label:cat)", 10, `unexpected ")"`},
+		{"()", 1, "empty parentheses"},
+		{"label:cat |", 12, "unexpected end of query"},
+		{"| label:cat", 1, `unexpected "|"`},
+		{"label:cat AND OR dog", 15, `unexpected "OR"`},
+		{"NOT", 4, "unexpected end of query"},
+		{`title:"foo bar`, 7, "missing closing quote"},
+		{"country: de", 9, "missing value for country"},
+		{"1x:foo", 1, `invalid filter name "1x"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+
+			if err == nil {
+				t.Fatal("error expected")
+			}
+
+			queryErr, ok := err.(*QueryError)
+
+			if !ok {
+				t.Fatalf("unexpected error type %T", err)
+			}
+
+			assert.Equal(t, tt.pos, queryErr.Pos)
+			assert.Equal(t, tt.msg, queryErr.Msg)
+		})
+	}
+}
+
+func TestQueryError_Error(t *testing.T) {
+	err := &QueryError{Pos: 3, Msg: "unexpected end of query"}
+	assert.Equal(t, "unexpected end of query at position 3", err.Error())
+}
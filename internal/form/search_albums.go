@@ -18,6 +18,8 @@ type SearchAlbums struct {
 	Count    int    `form:"count" binding:"required" serialize:"-"`
 	Offset   int    `form:"offset" serialize:"-"`
 	Order    string `form:"order" serialize:"-"`
+	Cursor   string `form:"cursor" serialize:"-"`
+	Total    bool   `form:"total" serialize:"-"`
 }
 
 func (f *SearchAlbums) GetQuery() string {
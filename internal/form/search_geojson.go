@@ -33,6 +33,9 @@ type SearchGeo struct {
 	S2       string    `form:"s2"`
 	Olc      string    `form:"olc"`
 	Dist     uint      `form:"dist"`
+	Bbox     string    `form:"bbox"`     // Bounding box as "minLng,minLat,maxLng,maxLat"
+	Polygon  string    `form:"polygon"`  // GeoJSON polygon geometry or coordinates
+	Cell     string    `form:"cell"`     // S2 cell token
 	Face     string    `form:"face"`     // UIDs
 	Subject  string    `form:"subject"`  // UIDs
 	Person   string    `form:"person"`   // Alias for Subject
@@ -48,6 +51,8 @@ type SearchGeo struct {
 	Color    string    `form:"color"`
 	Camera   int       `form:"camera"`
 	Lens     int       `form:"lens"`
+	Cluster  bool      `form:"cluster" serialize:"-"` // Group nearby photos
+	Zoom     int       `form:"zoom" serialize:"-"`    // Map zoom level for clustering
 	Count    int       `form:"count" serialize:"-"`
 	Offset   int       `form:"offset" serialize:"-"`
 }
@@ -11,6 +11,8 @@ type SearchLabels struct {
 	Count    int    `form:"count" binding:"required" serialize:"-"`
 	Offset   int    `form:"offset" serialize:"-"`
 	Order    string `form:"order" serialize:"-"`
+	Cursor   string `form:"cursor" serialize:"-"`
+	Total    bool   `form:"total" serialize:"-"`
 }
 
 func (f *SearchLabels) GetQuery() string {
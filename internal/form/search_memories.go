@@ -0,0 +1,21 @@
+package form
+
+import "time"
+
+// SearchMemories represents search form fields for "/api/v1/memories".
+type SearchMemories struct {
+	Date   time.Time `form:"date" time_format:"2006-01-02"` // Defaults to today
+	Week   bool      `form:"week"`                          // Include the days before and after
+	Count  int       `form:"count" serialize:"-"`
+	Offset int       `form:"offset" serialize:"-"`
+}
+
+// Serialize returns a string containing non-empty fields and values of a struct.
+func (f *SearchMemories) Serialize() string {
+	return Serialize(f, false)
+}
+
+// SerializeAll returns a string containing all non-empty fields and values of a struct.
+func (f *SearchMemories) SerializeAll() string {
+	return Serialize(f, true)
+}
@@ -1,76 +1,96 @@
 package form
 
 import (
+	"reflect"
 	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // SearchPhotos represents search form fields for "/api/v1/photos".
 type SearchPhotos struct {
-	Query     string    `form:"q"`
-	Filter    string    `form:"filter"`
-	UID       string    `form:"uid"`
-	Type      string    `form:"type"`
-	Path      string    `form:"path"`
-	Folder    string    `form:"folder"` // Alias for Path
-	Name      string    `form:"name"`
-	Filename  string    `form:"filename"`
-	Original  string    `form:"original"`
-	Title     string    `form:"title"`
-	Hash      string    `form:"hash"`
-	Primary   bool      `form:"primary"`
-	Stack     bool      `form:"stack"`
-	Unstacked bool      `form:"unstacked"`
-	Stackable bool      `form:"stackable"`
-	Video     bool      `form:"video"`
-	Photo     bool      `form:"photo"`
-	Raw       bool      `form:"raw"`
-	Live      bool      `form:"live"`
-	Scan      bool      `form:"scan"`
-	Panorama  bool      `form:"panorama"`
-	Error     bool      `form:"error"`
-	Hidden    bool      `form:"hidden"`
-	Archived  bool      `form:"archived"`
-	Public    bool      `form:"public"`
-	Private   bool      `form:"private"`
-	Favorite  bool      `form:"favorite"`
-	Unsorted  bool      `form:"unsorted"`
-	Lat       float32   `form:"lat"`
-	Lng       float32   `form:"lng"`
-	Dist      uint      `form:"dist"`
-	Fmin      float32   `form:"fmin"`
-	Fmax      float32   `form:"fmax"`
-	Chroma    uint8     `form:"chroma"`
-	Diff      uint32    `form:"diff"`
-	Mono      bool      `form:"mono"`
-	Portrait  bool      `form:"portrait"`
-	Geo       bool      `form:"geo"`
-	Keywords  string    `form:"keywords"`
-	Label     string    `form:"label"`
-	Category  string    `form:"category"` // Moments
-	Country   string    `form:"country"`  // Moments
-	State     string    `form:"state"`    // Moments
-	Year      string    `form:"year"`     // Moments
-	Month     string    `form:"month"`    // Moments
-	Day       string    `form:"day"`      // Moments
-	Face      string    `form:"face"`     // UIDs
-	Subject   string    `form:"subject"`  // UIDs
-	Person    string    `form:"person"`   // Alias for Subject
-	Subjects  string    `form:"subjects"` // Text
-	People    string    `form:"people"`   // Alias for Subjects
-	Album     string    `form:"album"`    // UIDs
-	Albums    string    `form:"albums"`   // Text
-	Color     string    `form:"color"`
-	Faces     string    `form:"faces"` // Find or exclude faces if detected.
-	Quality   int       `form:"quality"`
-	Review    bool      `form:"review"`
-	Camera    int       `form:"camera"`
-	Lens      int       `form:"lens"`
-	Before    time.Time `form:"before" time_format:"2006-01-02"`
-	After     time.Time `form:"after" time_format:"2006-01-02"`
-	Count     int       `form:"count" binding:"required" serialize:"-"`
-	Offset    int       `form:"offset" serialize:"-"`
-	Order     string    `form:"order" serialize:"-"`
-	Merged    bool      `form:"merged" serialize:"-"`
+	Query      string    `form:"q"`
+	Filter     string    `form:"filter"`
+	UID        string    `form:"uid"`
+	Type       string    `form:"type"`
+	Path       string    `form:"path"`
+	Folder     string    `form:"folder"` // Alias for Path
+	Name       string    `form:"name"`
+	Filename   string    `form:"filename"`
+	Original   string    `form:"original"`
+	Title      string    `form:"title"`
+	Hash       string    `form:"hash"`
+	Similar    string    `form:"similar"` // Photo UID
+	Primary    bool      `form:"primary"`
+	Stack      bool      `form:"stack"`
+	Unstacked  bool      `form:"unstacked"`
+	Stackable  bool      `form:"stackable"`
+	Video      bool      `form:"video"`
+	Photo      bool      `form:"photo"`
+	Raw        bool      `form:"raw"`
+	Live       bool      `form:"live"`
+	Scan       bool      `form:"scan"`
+	Panorama   bool      `form:"panorama"`
+	Error      bool      `form:"error"`
+	Hidden     bool      `form:"hidden"`
+	Archived   bool      `form:"archived"`
+	Public     bool      `form:"public"`
+	Private    bool      `form:"private"`
+	Favorite   bool      `form:"favorite"`
+	Unsorted   bool      `form:"unsorted"`
+	Lat        float32   `form:"lat"`
+	Lng        float32   `form:"lng"`
+	Dist       uint      `form:"dist"`
+	Bbox       string    `form:"bbox"`    // Bounding box as "minLng,minLat,maxLng,maxLat"
+	Polygon    string    `form:"polygon"` // GeoJSON polygon geometry or coordinates
+	Cell       string    `form:"cell"`    // S2 cell token
+	Fmin       float32   `form:"fmin"`
+	Fmax       float32   `form:"fmax"`
+	Chroma     uint8     `form:"chroma"`
+	Diff       uint32    `form:"diff"`
+	Mono       bool      `form:"mono"`
+	Portrait   bool      `form:"portrait"`
+	Geo        bool      `form:"geo"`
+	Keywords   string    `form:"keywords"`
+	Label      string    `form:"label"`
+	Category   string    `form:"category"` // Moments
+	Country    string    `form:"country"`  // Moments
+	State      string    `form:"state"`    // Moments
+	Year       string    `form:"year"`     // Moments
+	Month      string    `form:"month"`    // Moments
+	Day        string    `form:"day"`      // Moments
+	Face       string    `form:"face"`     // UIDs
+	Subject    string    `form:"subject"`  // UIDs
+	Person     string    `form:"person"`   // Alias for Subject
+	Subjects   string    `form:"subjects"` // Text
+	People     string    `form:"people"`   // Alias for Subjects
+	Album      string    `form:"album"`    // UIDs
+	Albums     string    `form:"albums"`   // Text
+	Color      string    `form:"color"`
+	Faces      string    `form:"faces"` // Find or exclude faces if detected.
+	Quality    int       `form:"quality"`
+	Rating     string    `form:"rating"`     // Star rating, e.g. ">=4" or "1..3"
+	ColorLabel string    `form:"colorlabel"` // Color label, e.g. "red|green"
+	Iso        string    `form:"iso"`        // ISO, e.g. ">1600" or "100..400"
+	Mm         string    `form:"mm"`         // Focal length in mm, 35mm equivalent if known, e.g. "24..70"
+	F          string    `form:"f"`          // Aperture f-number, e.g. "<=2.8" or "1.4..2"
+	Exposure   string    `form:"exposure"`   // Exposure time in seconds, e.g. "<=1/250" or "1..30"
+	Alt        string    `form:"alt"`        // Altitude in meters, e.g. ">1000" or "-10..100"
+	Mp         string    `form:"mp"`         // Resolution in megapixels, e.g. ">=12"
+	Taken      string    `form:"taken"`      // Date taken, e.g. "2019..2021" or ">=2020-06"
+	Review     bool      `form:"review"`
+	Camera     int       `form:"camera"`
+	Lens       int       `form:"lens"`
+	Before     time.Time `form:"before" time_format:"2006-01-02"`
+	After      time.Time `form:"after" time_format:"2006-01-02"`
+	Count      int       `form:"count" binding:"required" serialize:"-"`
+	Offset     int       `form:"offset" serialize:"-"`
+	Order      string    `form:"order" serialize:"-"`
+	Cursor     string    `form:"cursor" serialize:"-"`
+	Total      bool      `form:"total" serialize:"-"`
+	Merged     bool      `form:"merged" serialize:"-"`
+
+	expr QueryExpr // Boolean search query.
 }
 
 func (f *SearchPhotos) GetQuery() string {
@@ -82,8 +102,34 @@ func (f *SearchPhotos) SetQuery(q string) {
 }
 
 func (f *SearchPhotos) ParseQueryString() error {
-	if err := ParseQueryString(f); err != nil {
+	f.expr = nil
+
+	expr, err := ParseQuery(f.Query)
+
+	if err != nil {
 		return err
+	} else if QueryBoolean(expr) {
+		// Validate filters, so that errors can be reported with their position.
+		for _, field := range QueryFields(expr) {
+			var leaf SearchPhotos
+
+			if err := setFormValue(reflect.ValueOf(&leaf).Elem(), field.Name, field.Value); err != nil {
+				return &QueryError{Pos: field.Pos(), Msg: err.Error()}
+			}
+		}
+
+		// Boolean expressions are compiled by the search package.
+		f.expr = expr
+		f.Query = ""
+	} else if err := ParseQueryString(f); err != nil {
+		return err
+	} else if f.Query != "" && f.After.IsZero() && f.Before.IsZero() {
+		// Search for date phrases like "christmas 2019" or "last summer"?
+		if after, before, rest := txt.DateRange(f.Query, time.Now()); !after.IsZero() {
+			f.After = after
+			f.Before = before
+			f.Query = rest
+		}
 	}
 
 	if f.Path == "" && f.Folder != "" {
@@ -110,6 +156,11 @@ func (f *SearchPhotos) ParseQueryString() error {
 	return nil
 }
 
+// Expression returns the boolean search query expression, if any.
+func (f *SearchPhotos) Expression() QueryExpr {
+	return f.expr
+}
+
 // Serialize returns a string containing non-empty fields and values of a struct.
 func (f *SearchPhotos) Serialize() string {
 	return Serialize(f, false)
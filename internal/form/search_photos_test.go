@@ -25,6 +25,107 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.Equal(t, "Jens & Mander", form.Subjects)
 	})
+	t.Run("boolean", func(t *testing.T) {
+		form := &SearchPhotos{Query: "(label:cat | label:dog) -country:de"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", form.Query)
+		assert.Equal(t, "", form.Label)
+		assert.Equal(t, "", form.Country)
+
+		if expr := form.Expression(); assert.NotNil(t, expr) {
+			assert.Equal(t, "(label:cat | label:dog) -country:de", expr.String())
+		}
+	})
+	t.Run("boolean unknown filter", func(t *testing.T) {
+		form := &SearchPhotos{Query: "label:cat | xyz:foo"}
+
+		err := form.ParseQueryString()
+
+		if queryErr, ok := err.(*QueryError); assert.True(t, ok) {
+			assert.Equal(t, 13, queryErr.Pos)
+			assert.Equal(t, "unknown filter: Xyz at position 13", queryErr.Error())
+		}
+
+		assert.Nil(t, form.Expression())
+	})
+	t.Run("boolean invalid value", func(t *testing.T) {
+		form := &SearchPhotos{Query: "-label:cat camera:abc"}
+
+		err := form.ParseQueryString()
+
+		if queryErr, ok := err.(*QueryError); assert.True(t, ok) {
+			assert.Equal(t, 12, queryErr.Pos)
+		}
+	})
+	t.Run("syntax error", func(t *testing.T) {
+		form := &SearchPhotos{Query: "label:cat)"}
+
+		err := form.ParseQueryString()
+
+		assert.EqualError(t, err, "unexpected \")\" at position 10")
+	})
+	t.Run("range", func(t *testing.T) {
+		form := &SearchPhotos{Query: "iso:>1600 taken:2019..2021"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, form.Expression())
+		assert.Equal(t, ">1600", form.Iso)
+		assert.Equal(t, "2019..2021", form.Taken)
+	})
+	t.Run("exposure", func(t *testing.T) {
+		form := &SearchPhotos{Query: "mm:24..70 f:<=2.8 exposure:1/250..1/60 alt:-10..100 mp:>=12"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, form.Expression())
+		assert.Equal(t, "24..70", form.Mm)
+		assert.Equal(t, "<=2.8", form.F)
+		assert.Equal(t, "1/250..1/60", form.Exposure)
+		assert.Equal(t, "-10..100", form.Alt)
+		assert.Equal(t, ">=12", form.Mp)
+	})
+	t.Run("date phrase", func(t *testing.T) {
+		form := &SearchPhotos{Query: "tree christmas 2019 label:cat"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "tree", form.Query)
+		assert.Equal(t, "cat", form.Label)
+		assert.Equal(t, "2019-12-24", form.After.Format("2006-01-02"))
+		assert.Equal(t, "2019-12-27", form.Before.Format("2006-01-02"))
+	})
+	t.Run("date phrase with dates", func(t *testing.T) {
+		form := &SearchPhotos{Query: "march 2020 after:2020-01-01"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "march 2020", form.Query)
+		assert.Equal(t, "2020-01-01", form.After.Format("2006-01-02"))
+		assert.True(t, form.Before.IsZero())
+	})
 	t.Run("aliases", func(t *testing.T) {
 		form := &SearchPhotos{Query: "people:\"Jens & Mander\" folder:Foo person:Bar"}
 
@@ -91,6 +192,31 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.Equal(t, "123abc/,EFG", form.Path)
 	})
+	t.Run("rating", func(t *testing.T) {
+		form := &SearchPhotos{Query: "rating:>=4 colorlabel:red|green"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ">=4", form.Rating)
+		assert.Equal(t, "red|green", form.ColorLabel)
+		assert.Equal(t, "", form.Query)
+	})
+	t.Run("comparison operators only in range filters", func(t *testing.T) {
+		form := &SearchPhotos{Query: "rating:<3 label:>cat"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "<3", form.Rating)
+		assert.Equal(t, "cat", form.Label)
+	})
 	t.Run("valid query", func(t *testing.T) {
 		form := &SearchPhotos{Query: "label:cat query:\"fooBar baz\" before:2019-01-15 camera:23 favorite:false dist:25000 lat:33.45343166666667"}
 
@@ -16,6 +16,8 @@ type SearchSubjects struct {
 	Count    int    `form:"count" binding:"required" serialize:"-"`
 	Offset   int    `form:"offset" serialize:"-"`
 	Order    string `form:"order" serialize:"-"`
+	Cursor   string `form:"cursor" serialize:"-"`
+	Total    bool   `form:"total" serialize:"-"`
 }
 
 func (f *SearchSubjects) GetQuery() string {
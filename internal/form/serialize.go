@@ -93,47 +93,8 @@ func Unserialize(f SearchForm, q string) (result error) {
 	for _, char := range q {
 		if unicode.IsSpace(char) && !escaped {
 			if isKeyValue {
-				fieldName := strings.Title(string(key))
-				field := formValues.FieldByNameFunc(func(name string) bool {
-					return strings.EqualFold(name, fieldName)
-				})
-				stringValue := string(value)
-
-				if field.CanSet() {
-					switch field.Interface().(type) {
-					case time.Time:
-						if timeValue, err := dateparse.ParseAny(stringValue); err != nil {
-							result = err
-						} else {
-							field.Set(reflect.ValueOf(timeValue))
-						}
-					case float32, float64:
-						if floatValue, err := strconv.ParseFloat(stringValue, 64); err != nil {
-							result = err
-						} else {
-							field.SetFloat(floatValue)
-						}
-					case int, int8, int16, int32, int64:
-						if intValue, err := strconv.Atoi(stringValue); err != nil {
-							result = err
-						} else {
-							field.SetInt(int64(intValue))
-						}
-					case uint, uint8, uint16, uint32, uint64:
-						if intValue, err := strconv.Atoi(stringValue); err != nil {
-							result = err
-						} else {
-							field.SetUint(uint64(intValue))
-						}
-					case string:
-						field.SetString(sanitize.SearchString(stringValue))
-					case bool:
-						field.SetBool(txt.Bool(stringValue))
-					default:
-						result = fmt.Errorf("unsupported type: %s", fieldName)
-					}
-				} else {
-					result = fmt.Errorf("unknown filter: %s", fieldName)
+				if err := setFormValue(formValues, string(key), string(value)); err != nil {
+					result = err
 				}
 			} else if len(strings.TrimSpace(string(key))) > 0 {
 				queryStrings = append(queryStrings, strings.TrimSpace(string(key)))
@@ -164,3 +125,76 @@ func Unserialize(f SearchForm, q string) (result error) {
 
 	return result
 }
+
+// RangeFilters lists the filters that accept comparison operators, e.g. "rating:>=4".
+var RangeFilters = map[string]bool{
+	"rating":   true,
+	"iso":      true,
+	"mm":       true,
+	"f":        true,
+	"exposure": true,
+	"alt":      true,
+	"mp":       true,
+	"taken":    true,
+}
+
+// setFormValue converts a string to the type of the form field with the given name or form tag and assigns it.
+func setFormValue(formValues reflect.Value, key, stringValue string) (err error) {
+	fieldName := strings.Title(key)
+	field := formValues.FieldByNameFunc(func(name string) bool {
+		return strings.EqualFold(name, fieldName)
+	})
+
+	// Find field by form tag, so that serialized forms like "q:cat" can be restored.
+	if !field.IsValid() {
+		for i := 0; i < formValues.NumField(); i++ {
+			if formValues.Type().Field(i).Tag.Get("form") == key {
+				field = formValues.Field(i)
+				break
+			}
+		}
+	}
+
+	if !field.CanSet() {
+		return fmt.Errorf("unknown filter: %s", fieldName)
+	}
+
+	switch field.Interface().(type) {
+	case time.Time:
+		if timeValue, err := dateparse.ParseAny(stringValue); err != nil {
+			return err
+		} else {
+			field.Set(reflect.ValueOf(timeValue))
+		}
+	case float32, float64:
+		if floatValue, err := strconv.ParseFloat(stringValue, 64); err != nil {
+			return err
+		} else {
+			field.SetFloat(floatValue)
+		}
+	case int, int8, int16, int32, int64:
+		if intValue, err := strconv.Atoi(stringValue); err != nil {
+			return err
+		} else {
+			field.SetInt(int64(intValue))
+		}
+	case uint, uint8, uint16, uint32, uint64:
+		if intValue, err := strconv.Atoi(stringValue); err != nil {
+			return err
+		} else {
+			field.SetUint(uint64(intValue))
+		}
+	case string:
+		if RangeFilters[strings.ToLower(key)] {
+			field.SetString(sanitize.SearchRange(stringValue))
+		} else {
+			field.SetString(sanitize.SearchString(stringValue))
+		}
+	case bool:
+		field.SetBool(txt.Bool(stringValue))
+	default:
+		return fmt.Errorf("unsupported type: %s", fieldName)
+	}
+
+	return nil
+}
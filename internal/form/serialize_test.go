@@ -100,3 +100,32 @@ func TestSerialize(t *testing.T) {
 		assert.Equal(t, "", result)
 	})
 }
+
+func TestUnserialize(t *testing.T) {
+	t.Run("FormTag", func(t *testing.T) {
+		var f SearchPhotos
+
+		err := Unserialize(&f, `q:"label:cat | favorite:true" colorlabel:red`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "label:cat | favorite:true", f.Query)
+		assert.Equal(t, "red", f.ColorLabel)
+	})
+	t.Run("RoundTrip", func(t *testing.T) {
+		f := SearchPhotos{Query: "cat dog", Label: "animal", Favorite: true, Iso: ">1600"}
+
+		var result SearchPhotos
+
+		err := Unserialize(&result, f.Serialize())
+
+		assert.NoError(t, err)
+		assert.Equal(t, f, result)
+	})
+	t.Run("UnknownFilter", func(t *testing.T) {
+		var f SearchPhotos
+
+		err := Unserialize(&f, "foo:bar")
+
+		assert.EqualError(t, err, "unknown filter: Foo")
+	})
+}
@@ -0,0 +1,52 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TimeShift represents a bulk camera clock offset correction.
+type TimeShift struct {
+	Offset  string   `json:"offset"`  // Time offset, e.g. "-1y" or "+2h30m".
+	Camera  string   `json:"camera"`  // Camera ID, slug, or model name.
+	Serial  string   `json:"serial"`  // Camera serial number.
+	Album   string   `json:"album"`   // Album UID.
+	Folder  string   `json:"folder"`  // Originals folder path, incl sub folders.
+	Photos  []string `json:"photos"`  // Photo UIDs.
+	Preview bool     `json:"preview"` // Show matching photos without changing them.
+	Sidecar bool     `json:"sidecar"` // Write updated YAML sidecar files.
+}
+
+// Empty tests if no scope has been specified.
+func (f TimeShift) Empty() bool {
+	return f.Camera == "" && f.Serial == "" && f.Album == "" && f.Folder == "" && len(f.Photos) == 0
+}
+
+// String returns the scope as string for logging.
+func (f TimeShift) String() string {
+	var s []string
+
+	if f.Camera != "" {
+		s = append(s, fmt.Sprintf("camera %s", f.Camera))
+	}
+
+	if f.Serial != "" {
+		s = append(s, fmt.Sprintf("serial %s", f.Serial))
+	}
+
+	if f.Album != "" {
+		s = append(s, fmt.Sprintf("album %s", f.Album))
+	}
+
+	if f.Folder != "" {
+		s = append(s, fmt.Sprintf("folder %s", f.Folder))
+	}
+
+	if n := len(f.Photos); n == 1 {
+		s = append(s, "1 photo")
+	} else if n > 1 {
+		s = append(s, fmt.Sprintf("%d photos", n))
+	}
+
+	return strings.Join(s, ", ")
+}
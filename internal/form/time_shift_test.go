@@ -0,0 +1,25 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeShift_Empty(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.True(t, TimeShift{Offset: "+1h", Preview: true}.Empty())
+	})
+	t.Run("Serial", func(t *testing.T) {
+		assert.False(t, TimeShift{Offset: "+1h", Serial: "123"}.Empty())
+	})
+	t.Run("Photos", func(t *testing.T) {
+		assert.False(t, TimeShift{Photos: []string{"pt9jtdre2lvl0yh8"}}.Empty())
+	})
+}
+
+func TestTimeShift_String(t *testing.T) {
+	f := TimeShift{Camera: "canon-eos-6d", Folder: "2021/06", Photos: []string{"a", "b"}}
+	assert.Equal(t, "camera canon-eos-6d, folder 2021/06, 2 photos", f.String())
+	assert.Equal(t, "", TimeShift{}.String())
+}
@@ -35,6 +35,7 @@ const (
 	ErrInvalidLink
 	ErrInvalidName
 	ErrBusy
+	ErrInvalidQuery
 
 	MsgChangesSaved
 	MsgAlbumCreated
@@ -114,6 +115,7 @@ var Messages = MessageMap{
 	ErrInvalidLink:        gettext("Invalid link"),
 	ErrInvalidName:        gettext("Invalid name"),
 	ErrBusy:               gettext("Busy, please try again later"),
+	ErrInvalidQuery:       gettext("Invalid search query"),
 
 	// Info and confirmation messages:
 	MsgChangesSaved:          gettext("Changes successfully saved"),
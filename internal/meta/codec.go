@@ -3,6 +3,8 @@ package meta
 const CodecUnknown = ""
 const CodecJpeg = "jpeg"
 const CodecAvc1 = "avc1"
+const CodecHvc1 = "hvc1"
+const CodecHev1 = "hev1"
 const CodecHeic = "heic"
 const CodecXMP = "xmp"
 
@@ -10,3 +12,8 @@ const CodecXMP = "xmp"
 func (data Data) CodecAvc() bool {
 	return data.Codec == CodecAvc1
 }
+
+// CodecHevc returns true if the video format is HEVC, also known as H.265.
+func (data Data) CodecHevc() bool {
+	return data.Codec == CodecHvc1 || data.Codec == CodecHev1
+}
@@ -23,3 +23,29 @@ func TestData_CodecAvc1(t *testing.T) {
 		assert.Equal(t, false, data.CodecAvc())
 	})
 }
+
+func TestData_CodecHevc(t *testing.T) {
+	t.Run("hvc1", func(t *testing.T) {
+		data := Data{
+			Codec: "hvc1",
+		}
+
+		assert.Equal(t, true, data.CodecHevc())
+	})
+
+	t.Run("hev1", func(t *testing.T) {
+		data := Data{
+			Codec: "hev1",
+		}
+
+		assert.Equal(t, true, data.CodecHevc())
+	})
+
+	t.Run("avc1", func(t *testing.T) {
+		data := Data{
+			Codec: "avc1",
+		}
+
+		assert.Equal(t, false, data.CodecHevc())
+	})
+}
@@ -25,6 +25,8 @@ type Data struct {
 	Title        string        `meta:"Title"`
 	Subject      string        `meta:"Subject,PersonInImage,ObjectName,HierarchicalSubject,CatalogSets"`
 	Keywords     Keywords      `meta:"Keywords"`
+	Rating       int           `meta:"Rating"`
+	ColorLabel   string        `meta:"Label,ColorLabel"`
 	Notes        string        `meta:"-"`
 	Artist       string        `meta:"Artist,Creator,OwnerName"`
 	Description  string        `meta:"Description"`
@@ -106,6 +108,11 @@ func (data Data) HasInstanceID() bool {
 	return rnd.IsUUID(data.InstanceID)
 }
 
+// HasRating returns true if a star rating or color label exists.
+func (data Data) HasRating() bool {
+	return data.Rating != RatingNone || data.ColorLabel != ColorLabelNone
+}
+
 // HasTimeAndPlace if data contains a time and GPS position.
 func (data Data) HasTimeAndPlace() bool {
 	return !data.TakenAt.IsZero() && data.Lat != 0 && data.Lng != 0
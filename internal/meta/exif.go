@@ -13,9 +13,10 @@ import (
 	"github.com/dsoprea/go-exif/v3"
 	exifcommon "github.com/dsoprea/go-exif/v3/common"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/geo"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sanitize"
-	"gopkg.in/photoprism/go-tz.v2/tz"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 var exifIfdMapping *exifcommon.IfdMapping
@@ -191,6 +192,14 @@ func (data *Data) Exif(fileName string, fileType fs.FileFormat) (err error) {
 		}
 	}
 
+	if value, ok := tags["Rating"]; ok {
+		data.Rating = ParseRating(value)
+	}
+
+	if value, ok := tags["RatingPercent"]; ok && data.Rating == RatingNone {
+		data.Rating = RatingFromPercent(txt.Int(value))
+	}
+
 	if value, ok := tags["ImageUniqueID"]; ok {
 		if id := rnd.SanitizeUUID(value); id != "" {
 			data.DocumentID = id
@@ -226,13 +235,8 @@ func (data *Data) Exif(fileName string, fileType fs.FileFormat) (err error) {
 	}
 
 	if data.Lat != 0 && data.Lng != 0 {
-		zones, err := tz.GetZone(tz.Point{
-			Lat: float64(data.Lat),
-			Lon: float64(data.Lng),
-		})
-
-		if err == nil && len(zones) > 0 {
-			data.TimeZone = zones[0]
+		if zone := geo.TimeZone(float64(data.Lat), float64(data.Lng)); zone != "" {
+			data.TimeZone = zone
 		}
 	}
 
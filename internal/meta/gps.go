@@ -1,8 +1,10 @@
 package meta
 
 import (
+	"math"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/dsoprea/go-exif/v3"
 )
@@ -10,6 +12,7 @@ import (
 var GpsCoordsRegexp = regexp.MustCompile("[0-9\\.]+")
 var GpsRefRegexp = regexp.MustCompile("[NSEW]+")
 var GpsFloatRegexp = regexp.MustCompile("[+\\-]?(?:(?:0|[1-9]\\d*)(?:\\.\\d*)?|\\.\\d+)")
+var GpsIso6709Regexp = regexp.MustCompile("[+\\-]\\d+(?:\\.\\d*)?")
 
 // GpsToLatLng returns the GPS latitude and longitude as float point number.
 func GpsToLatLng(s string) (lat, lng float32) {
@@ -87,3 +90,50 @@ func GpsCoord(s string) float64 {
 
 	return result
 }
+
+// Iso6709ToLatLng returns latitude, longitude, and altitude from an ISO 6709 location string like "+52.5200+013.4050+034.000/".
+func Iso6709ToLatLng(s string) (lat, lng float32, alt int) {
+	co := GpsIso6709Regexp.FindAllString(s, -1)
+
+	if len(co) < 2 {
+		return 0, 0, 0
+	}
+
+	lat = float32(iso6709Degrees(co[0], 2))
+	lng = float32(iso6709Degrees(co[1], 3))
+
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return 0, 0, 0
+	}
+
+	if len(co) > 2 {
+		alt = int(math.Round(GpsCoord(co[2])))
+	}
+
+	return lat, lng, alt
+}
+
+// iso6709Degrees converts an ISO 6709 coordinate in degrees, degrees and minutes, or degrees, minutes, and seconds.
+func iso6709Degrees(s string, digits int) float64 {
+	sign := 1.0
+
+	if strings.HasPrefix(s, "-") {
+		sign = -1.0
+	}
+
+	s = strings.TrimLeft(s, "+-")
+	intLen := strings.IndexByte(s, '.')
+
+	if intLen < 0 {
+		intLen = len(s)
+	}
+
+	switch intLen {
+	case digits + 2:
+		return sign * (GpsCoord(s[:digits]) + GpsCoord(s[digits:])/60)
+	case digits + 4:
+		return sign * (GpsCoord(s[:digits]) + GpsCoord(s[digits:digits+2])/60 + GpsCoord(s[digits+2:])/3600)
+	default:
+		return sign * GpsCoord(s)
+	}
+}
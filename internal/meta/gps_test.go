@@ -84,3 +84,39 @@ func TestGpsCoord(t *testing.T) {
 		assert.Equal(t, float64(0), r)
 	})
 }
+
+func TestIso6709ToLatLng(t *testing.T) {
+	t.Run("Degrees", func(t *testing.T) {
+		lat, lng, alt := Iso6709ToLatLng("+52.5200+013.4050+034.000/")
+		assert.Equal(t, float32(52.52), lat)
+		assert.Equal(t, float32(13.405), lng)
+		assert.Equal(t, 34, alt)
+	})
+	t.Run("NoAltitude", func(t *testing.T) {
+		lat, lng, alt := Iso6709ToLatLng("-33.8688+151.2093/")
+		assert.Equal(t, float32(-33.8688), lat)
+		assert.Equal(t, float32(151.2093), lng)
+		assert.Equal(t, 0, alt)
+	})
+	t.Run("Minutes", func(t *testing.T) {
+		lat, lng, _ := Iso6709ToLatLng("+4043.5-07400.5/")
+		assert.InEpsilon(t, 40.725, lat, 0.00001)
+		assert.InEpsilon(t, -74.00833, lng, 0.00001)
+	})
+	t.Run("Seconds", func(t *testing.T) {
+		lat, lng, _ := Iso6709ToLatLng("+523112+0132418/")
+		assert.InEpsilon(t, 52.52, lat, 0.00001)
+		assert.InEpsilon(t, 13.405, lng, 0.00001)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		lat, lng, alt := Iso6709ToLatLng("foo")
+		assert.Equal(t, float32(0), lat)
+		assert.Equal(t, float32(0), lng)
+		assert.Equal(t, 0, alt)
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		lat, lng, _ := Iso6709ToLatLng("+95.0000+013.4050/")
+		assert.Equal(t, float32(0), lat)
+		assert.Equal(t, float32(0), lng)
+	})
+}
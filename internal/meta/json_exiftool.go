@@ -9,11 +9,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/photoprism/photoprism/pkg/geo"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 	"github.com/photoprism/photoprism/pkg/txt"
 	"github.com/tidwall/gjson"
-	"gopkg.in/photoprism/go-tz.v2/tz"
 )
 
 const MimeVideoMP4 = "video/mp4"
@@ -154,6 +154,16 @@ func (data *Data) Exiftool(jsonData []byte, originalName string) (err error) {
 		}
 	}
 
+	// Use Microsoft Photo rating percentage if no star rating was found.
+	if data.Rating == RatingNone {
+		if percent, ok := jsonStrings["RatingPercent"]; ok {
+			data.Rating = RatingFromPercent(txt.Int(percent))
+		}
+	}
+
+	data.Rating = SanitizeRating(data.Rating)
+	data.ColorLabel = ParseColorLabel(data.ColorLabel)
+
 	hasTimeOffset := false
 
 	if _, offset := data.TakenAtLocal.Zone(); offset != 0 && !data.TakenAtLocal.IsZero() {
@@ -168,13 +178,8 @@ func (data *Data) Exiftool(jsonData []byte, originalName string) (err error) {
 
 	// Set time zone and calculate UTC time.
 	if data.Lat != 0 && data.Lng != 0 {
-		zones, err := tz.GetZone(tz.Point{
-			Lat: float64(data.Lat),
-			Lon: float64(data.Lng),
-		})
-
-		if err == nil && len(zones) > 0 {
-			data.TimeZone = zones[0]
+		if zone := geo.TimeZone(float64(data.Lat), float64(data.Lng)); zone != "" {
+			data.TimeZone = zone
 		}
 
 		if loc, err := time.LoadLocation(data.TimeZone); err != nil {
@@ -6,7 +6,7 @@ import (
 	"runtime/debug"
 	"time"
 
-	"gopkg.in/photoprism/go-tz.v2/tz"
+	"github.com/photoprism/photoprism/pkg/geo"
 )
 
 type GPhoto struct {
@@ -137,13 +137,8 @@ func (data *Data) GPhoto(jsonData []byte) (err error) {
 
 	// Set time zone and calculate UTC time.
 	if data.Lat != 0 && data.Lng != 0 {
-		zones, err := tz.GetZone(tz.Point{
-			Lat: float64(data.Lat),
-			Lon: float64(data.Lng),
-		})
-
-		if err == nil && len(zones) > 0 {
-			data.TimeZone = zones[0]
+		if zone := geo.TimeZone(float64(data.Lat), float64(data.Lng)); zone != "" {
+			data.TimeZone = zone
 		}
 
 		if !data.TakenAtLocal.IsZero() {
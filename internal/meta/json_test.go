@@ -159,6 +159,7 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, "ELE-L29", data.CameraModel)
 		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
 		assert.Equal(t, 1, data.Orientation)
+		assert.Equal(t, 4, data.Rating)
 	})
 
 	t.Run("canon_eos_6d.json", func(t *testing.T) {
@@ -552,6 +553,8 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, "", data.LensModel)
 		assert.Equal(t, 0, data.FocalLength)
 		assert.Equal(t, 1, int(data.Orientation))
+		assert.Equal(t, 4, data.Rating)
+		assert.Equal(t, ColorLabelWhite, data.ColorLabel)
 	})
 
 	t.Run("date.mov.json", func(t *testing.T) {
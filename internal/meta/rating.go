@@ -0,0 +1,123 @@
+package meta
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Star rating limits as defined by the XMP basic schema, see https://exiftool.org/TagNames/XMP.html#xmp
+const (
+	RatingRejected = -1
+	RatingNone     = 0
+	RatingMax      = 5
+)
+
+// Color label names.
+const (
+	ColorLabelNone   = ""
+	ColorLabelRed    = "red"
+	ColorLabelOrange = "orange"
+	ColorLabelYellow = "yellow"
+	ColorLabelGreen  = "green"
+	ColorLabelBlue   = "blue"
+	ColorLabelPurple = "purple"
+	ColorLabelGray   = "gray"
+	ColorLabelBlack  = "black"
+	ColorLabelWhite  = "white"
+)
+
+// ColorLabels maps known color label names and aliases to their canonical name.
+var ColorLabels = map[string]string{
+	"red":     ColorLabelRed,
+	"orange":  ColorLabelOrange,
+	"yellow":  ColorLabelYellow,
+	"green":   ColorLabelGreen,
+	"blue":    ColorLabelBlue,
+	"purple":  ColorLabelPurple,
+	"magenta": ColorLabelPurple,
+	"violet":  ColorLabelPurple,
+	"gray":    ColorLabelGray,
+	"grey":    ColorLabelGray,
+	"black":   ColorLabelBlack,
+	"white":   ColorLabelWhite,
+}
+
+// DigikamColorLabels maps numeric digiKam color labels to their canonical name.
+var DigikamColorLabels = []string{
+	ColorLabelNone,
+	ColorLabelRed,
+	ColorLabelOrange,
+	ColorLabelYellow,
+	ColorLabelGreen,
+	ColorLabelBlue,
+	ColorLabelPurple,
+	ColorLabelGray,
+	ColorLabelBlack,
+	ColorLabelWhite,
+}
+
+// SanitizeRating returns a valid star rating between -1 (rejected) and 5.
+func SanitizeRating(rating int) int {
+	if rating < RatingRejected {
+		return RatingNone
+	} else if rating > RatingMax {
+		return RatingMax
+	}
+
+	return rating
+}
+
+// ParseRating parses a star rating string, e.g. "4" or "4.0".
+func ParseRating(s string) int {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return RatingNone
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+
+	if err != nil {
+		return RatingNone
+	}
+
+	return SanitizeRating(int(f))
+}
+
+// RatingFromPercent converts a Microsoft Photo rating percentage to stars.
+func RatingFromPercent(percent int) int {
+	switch {
+	case percent <= 0:
+		return RatingNone
+	case percent < 25:
+		return 1
+	case percent < 50:
+		return 2
+	case percent < 75:
+		return 3
+	case percent < 99:
+		return 4
+	default:
+		return RatingMax
+	}
+}
+
+// ParseColorLabel returns the canonical color label name, or an empty string if unknown.
+func ParseColorLabel(s string) string {
+	s = strings.ToLower(strings.TrimSpace(SanitizeString(s)))
+
+	if s == "" {
+		return ColorLabelNone
+	}
+
+	if label, ok := ColorLabels[s]; ok {
+		return label
+	}
+
+	// Numeric labels as used by digiKam.
+	if i, err := strconv.Atoi(s); err == nil && i > 0 && i < len(DigikamColorLabels) {
+		return DigikamColorLabels[i]
+	}
+
+	return ColorLabelNone
+}
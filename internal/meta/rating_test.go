@@ -0,0 +1,46 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeRating(t *testing.T) {
+	assert.Equal(t, RatingNone, SanitizeRating(-5))
+	assert.Equal(t, RatingRejected, SanitizeRating(-1))
+	assert.Equal(t, RatingNone, SanitizeRating(0))
+	assert.Equal(t, 3, SanitizeRating(3))
+	assert.Equal(t, RatingMax, SanitizeRating(7))
+}
+
+func TestParseRating(t *testing.T) {
+	assert.Equal(t, RatingNone, ParseRating(""))
+	assert.Equal(t, RatingNone, ParseRating("foo"))
+	assert.Equal(t, 4, ParseRating(" 4 "))
+	assert.Equal(t, 2, ParseRating("2.0"))
+	assert.Equal(t, RatingRejected, ParseRating("-1"))
+	assert.Equal(t, RatingMax, ParseRating("99"))
+}
+
+func TestRatingFromPercent(t *testing.T) {
+	assert.Equal(t, RatingNone, RatingFromPercent(0))
+	assert.Equal(t, 1, RatingFromPercent(1))
+	assert.Equal(t, 2, RatingFromPercent(25))
+	assert.Equal(t, 3, RatingFromPercent(50))
+	assert.Equal(t, 4, RatingFromPercent(75))
+	assert.Equal(t, 5, RatingFromPercent(99))
+	assert.Equal(t, 5, RatingFromPercent(100))
+}
+
+func TestParseColorLabel(t *testing.T) {
+	assert.Equal(t, ColorLabelNone, ParseColorLabel(""))
+	assert.Equal(t, ColorLabelNone, ParseColorLabel("0"))
+	assert.Equal(t, ColorLabelNone, ParseColorLabel("To Do"))
+	assert.Equal(t, ColorLabelRed, ParseColorLabel("Red"))
+	assert.Equal(t, ColorLabelPurple, ParseColorLabel("Magenta"))
+	assert.Equal(t, ColorLabelGray, ParseColorLabel("grey"))
+	assert.Equal(t, ColorLabelRed, ParseColorLabel("1"))
+	assert.Equal(t, ColorLabelWhite, ParseColorLabel("9"))
+	assert.Equal(t, ColorLabelNone, ParseColorLabel("10"))
+}
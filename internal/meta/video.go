@@ -0,0 +1,607 @@
+package meta
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// VideoMetaMaxSize limits the size of the movie header (moov) box that is read into memory.
+const VideoMetaMaxSize = 64 * 1024 * 1024
+
+// VideoEpoch is the reference time of QuickTime and ISO base media timestamps.
+var VideoEpoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// VideoDateLayouts lists supported formats of embedded creation date strings.
+var VideoDateLayouts = []string{
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02T15:04:05.000Z07:00",
+	"2006:01:02 15:04:05-07:00",
+}
+
+// videoTopLevel lists box types that may appear at the top level of supported video files.
+var videoTopLevel = map[string]bool{
+	"ftyp": true,
+	"moov": true,
+	"mdat": true,
+	"free": true,
+	"skip": true,
+	"wide": true,
+	"pnot": true,
+	"uuid": true,
+	"beam": true,
+	"loop": true,
+	"meta": true,
+}
+
+// videoBox represents an atom in an ISO base media or QuickTime file.
+type videoBox struct {
+	Type string
+	Data []byte
+}
+
+// videoInfo contains the values found while parsing the movie header.
+type videoInfo struct {
+	Brand      string
+	CreatedAt  time.Time
+	Duration   time.Duration
+	Codec      string
+	Width      int
+	Height     int
+	Rotation   int
+	Location   string
+	Lat        float32
+	Lng        float32
+	Altitude   int
+	Tags       map[string]string
+	trackFound bool
+}
+
+// Video parses an MP4, MOV, 3GP or other ISO base media file for embedded metadata and returns it as Data struct.
+func Video(fileName string) (data Data, err error) {
+	err = data.Video(fileName)
+
+	return data, err
+}
+
+// Video parses an MP4, MOV, 3GP or other ISO base media file for embedded metadata without using ExifTool.
+// Existing values are kept, so that it can also be used to complete data from other sources.
+func (data *Data) Video(fileName string) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("metadata: %s in %s (video panic)\nstack: %s", e, sanitize.Log(filepath.Base(fileName)), debug.Stack())
+		}
+	}()
+
+	logName := sanitize.Log(filepath.Base(fileName))
+
+	info, err := readVideoInfo(fileName)
+
+	if err != nil {
+		return fmt.Errorf("metadata: %s in %s (video)", err, logName)
+	}
+
+	if data.All == nil {
+		data.All = make(map[string]string)
+	}
+
+	for key, val := range info.Tags {
+		if _, ok := data.All[key]; !ok {
+			data.All[key] = val
+		}
+	}
+
+	if data.Codec == "" {
+		data.Codec = info.Codec
+	}
+
+	if data.Duration == 0 {
+		data.Duration = info.Duration
+	}
+
+	if data.Width == 0 && data.Height == 0 {
+		data.Width = info.Width
+		data.Height = info.Height
+	}
+
+	if data.Rotation == 0 {
+		data.Rotation = info.Rotation
+	}
+
+	if data.Orientation == 0 {
+		// Set orientation based on rotation.
+		switch data.Rotation {
+		case 0:
+			data.Orientation = 1
+		case -180, 180:
+			data.Orientation = 3
+		case 90:
+			data.Orientation = 6
+		case -90, 270:
+			data.Orientation = 8
+		}
+	}
+
+	if data.CameraMake == "" {
+		data.CameraMake = videoTag(info.Tags, "com.apple.quicktime.make", "©mak")
+	}
+
+	if data.CameraModel == "" {
+		data.CameraModel = videoTag(info.Tags, "com.apple.quicktime.model", "©mod")
+	}
+
+	if data.Title == "" {
+		data.Title = videoTag(info.Tags, "com.apple.quicktime.title", "©nam")
+	}
+
+	if data.Description == "" {
+		data.Description = videoTag(info.Tags, "com.apple.quicktime.description", "©des")
+	}
+
+	if data.Lat == 0 && data.Lng == 0 && info.Lat != 0 && info.Lng != 0 {
+		data.Lat, data.Lng = info.Lat, info.Lng
+
+		if data.Altitude == 0 {
+			data.Altitude = info.Altitude
+		}
+	}
+
+	// Skip date and time if already known.
+	if !data.TakenAt.IsZero() {
+		return nil
+	}
+
+	// Prefer creation dates with time offset, e.g. as written by iPhones.
+	if s := videoTag(info.Tags, "com.apple.quicktime.creationdate", "©day"); s != "" {
+		for _, layout := range VideoDateLayouts {
+			if t, err := time.Parse(layout, s); err == nil && t.Year() > 1900 {
+				data.TakenAt = t.Round(time.Second).UTC()
+
+				if localUtc, err := time.ParseInLocation("2006:01:02 15:04:05", t.Format("2006:01:02 15:04:05"), time.UTC); err == nil {
+					data.TakenAtLocal = localUtc
+				}
+
+				break
+			}
+		}
+	}
+
+	// Otherwise, use the movie creation time, which should be UTC.
+	// see https://exiftool.org/TagNames/QuickTime.html
+	if data.TakenAt.IsZero() && !info.CreatedAt.IsZero() {
+		data.TakenAt = info.CreatedAt
+		data.TakenAtLocal = time.Time{}
+		data.TimeZone = time.UTC.String()
+	}
+
+	if data.TakenAt.IsZero() {
+		return nil
+	}
+
+	// Set time zone and local time based on GPS coordinates.
+	if data.Lat != 0 && data.Lng != 0 {
+		if zone := geo.TimeZone(float64(data.Lat), float64(data.Lng)); zone != "" {
+			data.TimeZone = zone
+		}
+	}
+
+	if loc, err := time.LoadLocation(data.TimeZone); data.TimeZone == "" || err != nil {
+		if data.TakenAtLocal.IsZero() {
+			data.TakenAtLocal = data.TakenAt
+		}
+	} else if localUtc, err := time.ParseInLocation("2006:01:02 15:04:05", data.TakenAt.In(loc).Format("2006:01:02 15:04:05"), time.UTC); err == nil {
+		data.TakenAtLocal = localUtc
+	}
+
+	return nil
+}
+
+// readVideoInfo reads the file type and movie header boxes of a video file.
+func readVideoInfo(fileName string) (info videoInfo, err error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return info, err
+	}
+
+	defer f.Close()
+
+	stat, err := f.Stat()
+
+	if err != nil {
+		return info, err
+	}
+
+	info.Tags = make(map[string]string)
+
+	fileSize := stat.Size()
+	header := make([]byte, 16)
+
+	var offset int64
+	var moovFound bool
+
+	for offset+8 <= fileSize {
+		if _, err = f.ReadAt(header[:8], offset); err != nil {
+			return info, err
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[0:4]))
+		boxType := string(header[4:8])
+		headerSize := int64(8)
+
+		if offset == 0 && !videoTopLevel[boxType] {
+			return info, fmt.Errorf("unsupported file format")
+		}
+
+		switch size {
+		case 0:
+			size = fileSize - offset
+		case 1:
+			if _, err = f.ReadAt(header[8:16], offset+8); err != nil {
+				return info, err
+			}
+
+			size = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerSize = 16
+		}
+
+		if size < headerSize || offset+size > fileSize {
+			break
+		}
+
+		switch boxType {
+		case "ftyp", "moov":
+			if size-headerSize > VideoMetaMaxSize {
+				return info, fmt.Errorf("%s box exceeds size limit", boxType)
+			}
+
+			b := make([]byte, size-headerSize)
+
+			if _, err = f.ReadAt(b, offset+headerSize); err != nil && err != io.EOF {
+				return info, err
+			}
+
+			if boxType == "ftyp" {
+				if len(b) >= 4 {
+					info.Brand = strings.TrimSpace(string(b[0:4]))
+				}
+			} else {
+				moovFound = true
+				info.parseMovie(b)
+			}
+		}
+
+		offset += size
+	}
+
+	if !moovFound {
+		return info, fmt.Errorf("movie header not found")
+	}
+
+	return info, nil
+}
+
+// videoBoxes returns the child boxes contained in b.
+func videoBoxes(b []byte) (result []videoBox) {
+	for len(b) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(b[0:4]))
+		headerSize := uint64(8)
+
+		switch size {
+		case 0:
+			size = uint64(len(b))
+		case 1:
+			if len(b) < 16 {
+				return result
+			}
+
+			size = binary.BigEndian.Uint64(b[8:16])
+			headerSize = 16
+		}
+
+		if size < headerSize || size > uint64(len(b)) {
+			return result
+		}
+
+		result = append(result, videoBox{Type: string(b[4:8]), Data: b[headerSize:size]})
+
+		b = b[size:]
+	}
+
+	return result
+}
+
+// parseMovie parses the content of a movie (moov) box.
+func (info *videoInfo) parseMovie(b []byte) {
+	for _, box := range videoBoxes(b) {
+		switch box.Type {
+		case "mvhd":
+			info.parseMovieHeader(box.Data)
+		case "trak":
+			info.parseTrack(box.Data)
+		case "udta":
+			info.parseUserData(box.Data)
+		case "meta":
+			info.parseMeta(box.Data)
+		}
+	}
+
+	if info.Location != "" {
+		info.Lat, info.Lng, info.Altitude = Iso6709ToLatLng(info.Location)
+	}
+}
+
+// parseMovieHeader parses the creation time and duration from a movie header (mvhd) box.
+func (info *videoInfo) parseMovieHeader(b []byte) {
+	var created, timescale, duration uint64
+
+	if len(b) >= 32 && b[0] == 1 {
+		created = binary.BigEndian.Uint64(b[4:12])
+		timescale = uint64(binary.BigEndian.Uint32(b[20:24]))
+		duration = binary.BigEndian.Uint64(b[24:32])
+	} else if len(b) >= 20 {
+		created = uint64(binary.BigEndian.Uint32(b[4:8]))
+		timescale = uint64(binary.BigEndian.Uint32(b[12:16]))
+		duration = uint64(binary.BigEndian.Uint32(b[16:20]))
+	} else {
+		return
+	}
+
+	if created > 0 {
+		if t := VideoEpoch.Add(time.Duration(created) * time.Second); t.Year() > 1970 {
+			info.CreatedAt = t
+		}
+	}
+
+	if timescale > 0 {
+		info.Duration = time.Duration(float64(duration) / float64(timescale) * float64(time.Second)).Round(time.Millisecond)
+	}
+}
+
+// parseTrack parses the dimensions, rotation and codec of the first video track.
+func (info *videoInfo) parseTrack(b []byte) {
+	if info.trackFound {
+		return
+	}
+
+	var header []byte
+	var handler string
+	var sampleEntry []byte
+
+	for _, box := range videoBoxes(b) {
+		switch box.Type {
+		case "tkhd":
+			header = box.Data
+		case "mdia":
+			for _, mdia := range videoBoxes(box.Data) {
+				switch mdia.Type {
+				case "hdlr":
+					if len(mdia.Data) >= 12 {
+						handler = string(mdia.Data[8:12])
+					}
+				case "minf":
+					sampleEntry = videoSampleEntry(mdia.Data)
+				}
+			}
+		}
+	}
+
+	if handler != "vide" {
+		return
+	}
+
+	info.trackFound = true
+
+	if len(sampleEntry) >= 8 {
+		info.Codec = strings.TrimSpace(string(sampleEntry[4:8]))
+	}
+
+	// Matrix, width and height follow the version specific part of the track header.
+	var matrixOffset int
+
+	if len(header) > 0 && header[0] == 1 {
+		matrixOffset = 52
+	} else {
+		matrixOffset = 40
+	}
+
+	if len(header) >= matrixOffset+44 {
+		matrix := header[matrixOffset : matrixOffset+36]
+		a := float64(int32(binary.BigEndian.Uint32(matrix[0:4])))
+		c := float64(int32(binary.BigEndian.Uint32(matrix[4:8])))
+
+		info.Rotation = (int(math.Round(math.Atan2(c, a)*180/math.Pi)) + 360) % 360
+		info.Width = int(binary.BigEndian.Uint32(header[matrixOffset+36:matrixOffset+40]) >> 16)
+		info.Height = int(binary.BigEndian.Uint32(header[matrixOffset+40:matrixOffset+44]) >> 16)
+	}
+
+	// Use the sample description if the track header contains no dimensions.
+	if (info.Width == 0 || info.Height == 0) && len(sampleEntry) >= 36 {
+		info.Width = int(binary.BigEndian.Uint16(sampleEntry[32:34]))
+		info.Height = int(binary.BigEndian.Uint16(sampleEntry[34:36]))
+	}
+}
+
+// videoSampleEntry returns the first sample description found in a media information (minf) box.
+func videoSampleEntry(b []byte) []byte {
+	for _, minf := range videoBoxes(b) {
+		if minf.Type != "stbl" {
+			continue
+		}
+
+		for _, stbl := range videoBoxes(minf.Data) {
+			if stbl.Type != "stsd" || len(stbl.Data) < 16 {
+				continue
+			}
+
+			// Skip version, flags, and entry count.
+			entry := stbl.Data[8:]
+
+			if size := int(binary.BigEndian.Uint32(entry[0:4])); size >= 8 && size <= len(entry) {
+				return entry[:size]
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseUserData parses QuickTime and 3GPP user data (udta) boxes.
+func (info *videoInfo) parseUserData(b []byte) {
+	for _, box := range videoBoxes(b) {
+		switch {
+		case box.Type == "meta":
+			info.parseMeta(box.Data)
+		case box.Type == "loci":
+			info.parseLocation(box.Data)
+		case strings.HasPrefix(box.Type, "\xa9"):
+			// QuickTime text entries start with a 16-bit length and language code.
+			if len(box.Data) < 4 {
+				continue
+			}
+
+			n := int(binary.BigEndian.Uint16(box.Data[0:2]))
+
+			if n == 0 || 4+n > len(box.Data) {
+				continue
+			}
+
+			info.setTag(videoTagName(box.Type), string(box.Data[4:4+n]))
+		}
+	}
+}
+
+// parseLocation parses a 3GPP location information (loci) box.
+func (info *videoInfo) parseLocation(b []byte) {
+	// Skip version, flags, and language code.
+	if len(b) < 6 {
+		return
+	}
+
+	b = b[6:]
+
+	// Skip null-terminated place name and role.
+	if i := strings.IndexByte(string(b), 0); i < 0 || len(b) < i+14 {
+		return
+	} else {
+		b = b[i+2:]
+	}
+
+	lng := float64(int32(binary.BigEndian.Uint32(b[0:4]))) / 65536
+	lat := float64(int32(binary.BigEndian.Uint32(b[4:8]))) / 65536
+	alt := float64(int32(binary.BigEndian.Uint32(b[8:12]))) / 65536
+
+	if info.Location == "" && (lat != 0 || lng != 0) {
+		info.Location = fmt.Sprintf("%+.4f%+.4f%+.3f/", lat, lng, alt)
+	}
+}
+
+// parseMeta parses QuickTime metadata (meta) boxes with keys and item lists.
+func (info *videoInfo) parseMeta(b []byte) {
+	// ISO base media files contain a full box with version and flags.
+	if len(b) >= 12 && binary.BigEndian.Uint32(b[0:4]) == 0 && string(b[8:12]) == "hdlr" {
+		b = b[4:]
+	}
+
+	var keys []string
+
+	boxes := videoBoxes(b)
+
+	for _, box := range boxes {
+		if box.Type != "keys" || len(box.Data) < 8 {
+			continue
+		}
+
+		k := box.Data[8:]
+
+		for len(k) >= 8 {
+			size := int(binary.BigEndian.Uint32(k[0:4]))
+
+			if size < 8 || size > len(k) {
+				break
+			}
+
+			keys = append(keys, string(k[8:size]))
+			k = k[size:]
+		}
+	}
+
+	for _, box := range boxes {
+		if box.Type != "ilst" {
+			continue
+		}
+
+		for _, item := range videoBoxes(box.Data) {
+			name := videoTagName(item.Type)
+
+			if i := int(binary.BigEndian.Uint32([]byte(item.Type))); i > 0 && i <= len(keys) {
+				name = keys[i-1]
+			}
+
+			for _, value := range videoBoxes(item.Data) {
+				// Data boxes start with a type indicator and locale.
+				if value.Type != "data" || len(value.Data) < 8 {
+					continue
+				}
+
+				// Only UTF-8 strings are supported.
+				if binary.BigEndian.Uint32(value.Data[0:4]) != 1 {
+					continue
+				}
+
+				info.setTag(name, string(value.Data[8:]))
+
+				break
+			}
+		}
+	}
+}
+
+// setTag adds a text value and checks if it contains the location.
+func (info *videoInfo) setTag(name, value string) {
+	value = strings.TrimSpace(strings.Trim(value, "\x00"))
+
+	if name == "" || value == "" {
+		return
+	}
+
+	if _, ok := info.Tags[name]; !ok {
+		info.Tags[name] = txt.Clip(value, txt.ClipDefault)
+	}
+
+	if info.Location == "" && (name == "com.apple.quicktime.location.ISO6709" || name == "©xyz") {
+		info.Location = value
+	}
+}
+
+// videoTagName returns a printable name for a QuickTime box type.
+func videoTagName(boxType string) string {
+	if strings.HasPrefix(boxType, "\xa9") {
+		return "©" + boxType[1:]
+	}
+
+	return boxType
+}
+
+// videoTag returns the first non-empty value of the given tag names.
+func videoTag(tags map[string]string, names ...string) string {
+	for _, name := range names {
+		if s := tags[name]; s != "" {
+			return s
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,136 @@
+package meta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVideo(t *testing.T) {
+	t.Run("quicktime-gps.mov", func(t *testing.T) {
+		data, err := Video("testdata/quicktime-gps.mov")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2021-06-12T08:10:00Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "2021-06-12T10:10:00Z", data.TakenAtLocal.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "Europe/Berlin", data.TimeZone)
+		assert.Equal(t, float32(52.52), data.Lat)
+		assert.Equal(t, float32(13.405), data.Lng)
+		assert.Equal(t, 34, data.Altitude)
+		assert.Equal(t, "Apple", data.CameraMake)
+		assert.Equal(t, "iPhone 12", data.CameraModel)
+		assert.Equal(t, CodecAvc1, data.Codec)
+		assert.Equal(t, 640, data.Width)
+		assert.Equal(t, 416, data.Height)
+		assert.Equal(t, 90, data.Rotation)
+		assert.Equal(t, 6, data.Orientation)
+		assert.Equal(t, 810*time.Millisecond, data.Duration)
+		assert.Equal(t, "14.6", data.All["com.apple.quicktime.software"])
+	})
+
+	t.Run("android-gps.mp4", func(t *testing.T) {
+		data, err := Video("testdata/android-gps.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2020-08-15T14:30:00Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "2020-08-15T10:30:00Z", data.TakenAtLocal.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "America/New_York", data.TimeZone)
+		assert.Equal(t, float32(40.7128), data.Lat)
+		assert.Equal(t, float32(-74.006), data.Lng)
+		assert.Equal(t, 0, data.Altitude)
+		assert.Equal(t, "Google", data.CameraMake)
+		assert.Equal(t, "Pixel 5", data.CameraModel)
+		assert.Equal(t, CodecAvc1, data.Codec)
+		assert.Equal(t, 640, data.Width)
+		assert.Equal(t, 416, data.Height)
+		assert.Equal(t, 0, data.Rotation)
+		assert.Equal(t, 1, data.Orientation)
+		assert.Equal(t, 810*time.Millisecond, data.Duration)
+	})
+
+	t.Run("location.3gp", func(t *testing.T) {
+		data, err := Video("testdata/location.3gp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2019-03-01T03:00:00Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "2019-03-01T12:00:00Z", data.TakenAtLocal.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "Asia/Tokyo", data.TimeZone)
+		assert.InEpsilon(t, 35.6895, data.Lat, 0.0001)
+		assert.InEpsilon(t, 139.6917, data.Lng, 0.0001)
+		assert.Equal(t, 40, data.Altitude)
+		assert.Equal(t, CodecAvc1, data.Codec)
+	})
+
+	t.Run("christmas.mp4", func(t *testing.T) {
+		data, err := Video("testdata/christmas.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, data.TakenAt.IsZero())
+		assert.True(t, data.TakenAtLocal.IsZero())
+		assert.Equal(t, "", data.TimeZone)
+		assert.Equal(t, float32(0), data.Lat)
+		assert.Equal(t, float32(0), data.Lng)
+		assert.Equal(t, CodecAvc1, data.Codec)
+		assert.Equal(t, 640, data.Width)
+		assert.Equal(t, 416, data.Height)
+		assert.Equal(t, 810*time.Millisecond, data.Duration)
+	})
+
+	t.Run("hevc.mp4", func(t *testing.T) {
+		data, err := Video("testdata/hevc.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2022-03-04T15:20:00Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "UTC", data.TimeZone)
+		assert.Equal(t, CodecHvc1, data.Codec)
+		assert.True(t, data.CodecHevc())
+		assert.False(t, data.CodecAvc())
+		assert.Equal(t, 512, data.Width)
+		assert.Equal(t, 512, data.Height)
+		assert.Equal(t, 1500*time.Millisecond, data.Duration)
+	})
+
+	t.Run("KeepExisting", func(t *testing.T) {
+		data := NewData()
+		data.TakenAt = time.Date(2018, 4, 12, 19, 24, 49, 0, time.UTC)
+		data.TakenAtLocal = data.TakenAt
+		data.CameraModel = "iPhone X"
+
+		if err := data.Video("testdata/quicktime-gps.mov"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2018-04-12T19:24:49Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "iPhone X", data.CameraModel)
+		assert.Equal(t, "Apple", data.CameraMake)
+		assert.Equal(t, float32(52.52), data.Lat)
+	})
+
+	t.Run("NotSupported", func(t *testing.T) {
+		_, err := Video("testdata/photoshop.jpg")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Video("testdata/not-found.mp4")
+
+		assert.Error(t, err)
+	})
+}
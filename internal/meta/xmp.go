@@ -61,6 +61,14 @@ func (data *Data) XMP(fileName string) (err error) {
 		data.TakenAt = takenAt
 	}
 
+	if rating := doc.Rating(); rating != RatingNone {
+		data.Rating = rating
+	}
+
+	if label := doc.ColorLabel(); label != ColorLabelNone {
+		data.ColorLabel = label
+	}
+
 	if len(doc.Keywords()) != 0 {
 		data.AddKeywords(doc.Keywords())
 	}
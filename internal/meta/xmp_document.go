@@ -5,6 +5,8 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // XmpDocument represents an XMP sidecar file.
@@ -30,20 +32,27 @@ type XmpDocument struct {
 			XmpRights       string `xml:"xmpRights,attr" json:"xmprights,omitempty"`
 			Iptc4xmpCore    string `xml:"Iptc4xmpCore,attr" json:"iptc4xmpcore,omitempty"`
 			Iptc4xmpExt     string `xml:"Iptc4xmpExt,attr" json:"iptc4xmpext,omitempty"`
-			CreatorTool     string `xml:"CreatorTool"`     // ELE-L29 10.0.0.168(C431E2...
-			ModifyDate      string `xml:"ModifyDate"`      // 2020-01-01T17:28:23.89961...
-			CreateDate      string `xml:"CreateDate"`      // 2020-01-01T17:28:23
-			MetadataDate    string `xml:"MetadataDate"`    // 2020-01-01T17:28:23.89961...
-			Rating          string `xml:"Rating"`          // 4
-			Lens            string `xml:"Lens"`            // HUAWEI P30 Rear Main Came...
-			LensModel       string `xml:"LensModel"`       // HUAWEI P30 Rear Main Came...
-			DateCreated     string `xml:"DateCreated"`     // 2020-01-01T17:28:25.72962...
-			ColorMode       string `xml:"ColorMode"`       // 3
-			ICCProfile      string `xml:"ICCProfile"`      // sRGB IEC61966-2.1
-			AuthorsPosition string `xml:"AuthorsPosition"` // Maintainer
-			DocumentID      string `xml:"DocumentID"`      // 2C678C1811D7095FD79CC822B...
-			InstanceID      string `xml:"InstanceID"`      // 2C678C1811D7095FD79CC822B...
-			Format          string `xml:"format"`          // image/jpeg
+			CreatorTool     string `xml:"CreatorTool"`                                    // ELE-L29 10.0.0.168(C431E2...
+			ModifyDate      string `xml:"ModifyDate"`                                     // 2020-01-01T17:28:23.89961...
+			CreateDate      string `xml:"CreateDate"`                                     // 2020-01-01T17:28:23
+			MetadataDate    string `xml:"MetadataDate"`                                   // 2020-01-01T17:28:23.89961...
+			Rating          string `xml:"http://ns.adobe.com/xap/1.0/ Rating"`            // 4
+			RatingAttr      string `xml:"http://ns.adobe.com/xap/1.0/ Rating,attr"`       // 4
+			RatingPercent   string `xml:"http://ns.microsoft.com/photo/1.0/ Rating"`      // 75
+			RatingPctAttr   string `xml:"http://ns.microsoft.com/photo/1.0/ Rating,attr"` // 75
+			Label           string `xml:"http://ns.adobe.com/xap/1.0/ Label"`             // Red
+			LabelAttr       string `xml:"http://ns.adobe.com/xap/1.0/ Label,attr"`        // Red
+			ColorLabel      string `xml:"http://www.digikam.org/ns/1.0/ ColorLabel"`      // 1
+			ColorLabelAttr  string `xml:"http://www.digikam.org/ns/1.0/ ColorLabel,attr"` // 1
+			Lens            string `xml:"Lens"`                                           // HUAWEI P30 Rear Main Came...
+			LensModel       string `xml:"LensModel"`                                      // HUAWEI P30 Rear Main Came...
+			DateCreated     string `xml:"DateCreated"`                                    // 2020-01-01T17:28:25.72962...
+			ColorMode       string `xml:"ColorMode"`                                      // 3
+			ICCProfile      string `xml:"ICCProfile"`                                     // sRGB IEC61966-2.1
+			AuthorsPosition string `xml:"AuthorsPosition"`                                // Maintainer
+			DocumentID      string `xml:"DocumentID"`                                     // 2C678C1811D7095FD79CC822B...
+			InstanceID      string `xml:"InstanceID"`                                     // 2C678C1811D7095FD79CC822B...
+			Format          string `xml:"format"`                                         // image/jpeg
 			Title           struct {
 				Text string `xml:",chardata" json:"text,omitempty"`
 				Alt  struct {
@@ -287,3 +296,33 @@ func (doc *XmpDocument) Keywords() string {
 
 	return strings.Join(s, ", ")
 }
+
+// Rating returns the XMP document star rating.
+func (doc *XmpDocument) Rating() int {
+	d := doc.RDF.Description
+
+	if s := SanitizeString(d.Rating); s != "" {
+		return ParseRating(s)
+	} else if s = SanitizeString(d.RatingAttr); s != "" {
+		return ParseRating(s)
+	} else if s = SanitizeString(d.RatingPercent); s != "" {
+		return RatingFromPercent(txt.Int(s))
+	} else if s = SanitizeString(d.RatingPctAttr); s != "" {
+		return RatingFromPercent(txt.Int(s))
+	}
+
+	return RatingNone
+}
+
+// ColorLabel returns the XMP document color label.
+func (doc *XmpDocument) ColorLabel() string {
+	d := doc.RDF.Description
+
+	for _, s := range []string{d.Label, d.LabelAttr, d.ColorLabel, d.ColorLabelAttr} {
+		if label := ParseColorLabel(s); label != ColorLabelNone {
+			return label
+		}
+	}
+
+	return ColorLabelNone
+}
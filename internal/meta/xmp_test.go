@@ -37,6 +37,8 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "HUAWEI", data.CameraMake)
 		assert.Equal(t, "ELE-L29", data.CameraModel)
 		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
+		assert.Equal(t, 4, data.Rating)
+		assert.Equal(t, "", data.ColorLabel)
 	})
 
 	t.Run("canon_eos_6d", func(t *testing.T) {
@@ -71,4 +73,27 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "iPhone 7 back camera 3.99mm f/1.8", data.LensModel)
 	})
 
+	t.Run("rating", func(t *testing.T) {
+		data, err := XMP("testdata/rating.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Rated Sunset", data.Title)
+		assert.Equal(t, 5, data.Rating)
+		assert.Equal(t, ColorLabelPurple, data.ColorLabel)
+		assert.True(t, data.HasRating())
+	})
+
+	t.Run("rating-microsoft", func(t *testing.T) {
+		data, err := XMP("testdata/rating-microsoft.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 3, data.Rating)
+		assert.Equal(t, ColorLabelGreen, data.ColorLabel)
+	})
 }
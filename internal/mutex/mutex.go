@@ -13,6 +13,7 @@ var (
 	ShareWorker = Busy{}
 	MetaWorker  = Busy{}
 	FacesWorker = Busy{}
+	VideoWorker = Busy{}
 )
 
 // WorkersBusy returns true if any worker is busy.
@@ -63,44 +63,25 @@ func (w *CleanUp) Start(opt CleanUpOptions) (thumbs int, orphans int, err error)
 		return thumbs, orphans, err
 	}
 
-	// Thumbnails storage path.
-	thumbPath := w.conf.ThumbPath()
+	// Returns true if files with the hash prefix are still needed.
+	keep := func(hash string) bool {
+		return fileHashes[hash] || thumbHashes[hash]
+	}
 
 	// Find and remove orphan thumbnail files.
-	if err := fastwalk.Walk(thumbPath, func(fileName string, info os.FileMode) error {
-		base := filepath.Base(fileName)
-
-		if info.IsDir() || strings.HasPrefix(base, ".") {
-			return nil
-		}
-
-		// Example: 01244519acf35c62a5fea7a5a7dcefdbec4fb2f5_3x3_resize.png
-		i := strings.Index(base, "_")
-
-		if i < 39 {
-			return nil
-		}
-
-		hash := base[:i]
-		logName := sanitize.Log(fs.RelName(fileName, thumbPath))
-
-		if ok := fileHashes[hash]; ok {
-			// Do nothing.
-		} else if ok := thumbHashes[hash]; ok {
-			// Do nothing.
-		} else if opt.Dry {
-			thumbs++
-			log.Debugf("cleanup: thumbnail %s would be removed", logName)
-		} else if err := os.Remove(fileName); err != nil {
-			log.Warnf("cleanup: %s in %s", err, logName)
-		} else {
-			thumbs++
-			log.Debugf("cleanup: removed thumbnail %s", logName)
-		}
+	// Example: 01244519acf35c62a5fea7a5a7dcefdbec4fb2f5_3x3_resize.png
+	if thumbs, err = w.removeOrphanFiles(w.conf.ThumbPath(), "thumbnail", keep, opt.Dry); err != nil {
+		return thumbs, orphans, err
+	}
 
-		return nil
-	}); err != nil {
+	// Find and remove orphan video segments.
+	// Example: cache/hls/0/1/2/01244519acf35c62a5fea7a5a7dcefdbec4fb2f5_720p_3.ts
+	if segments, err := w.removeOrphanFiles(filepath.Join(w.conf.CachePath(), HlsCacheNamespace), "video segment", keep, opt.Dry); err != nil {
 		return thumbs, orphans, err
+	} else if segments > 0 && opt.Dry {
+		log.Infof("cleanup: %s would be removed", english.Plural(segments, "video segment", "video segments"))
+	} else if segments > 0 {
+		log.Infof("cleanup: removed %s", english.Plural(segments, "video segment", "video segments"))
 	}
 
 	// Find and remove orphan photo index entries.
@@ -166,6 +147,46 @@ func (w *CleanUp) Start(opt CleanUpOptions) (thumbs int, orphans int, err error)
 	return thumbs, orphans, nil
 }
 
+// removeOrphanFiles removes files in the storage path whose hash prefix is no longer needed.
+func (w *CleanUp) removeOrphanFiles(storagePath, fileType string, keep func(hash string) bool, dry bool) (count int, err error) {
+	if !fs.PathExists(storagePath) {
+		return 0, nil
+	}
+
+	err = fastwalk.Walk(storagePath, func(fileName string, info os.FileMode) error {
+		base := filepath.Base(fileName)
+
+		if info.IsDir() || strings.HasPrefix(base, ".") {
+			return nil
+		}
+
+		i := strings.Index(base, "_")
+
+		if i < 39 {
+			return nil
+		}
+
+		hash := base[:i]
+		logName := sanitize.Log(fs.RelName(fileName, storagePath))
+
+		if keep(hash) {
+			// Do nothing.
+		} else if dry {
+			count++
+			log.Debugf("cleanup: %s %s would be removed", fileType, logName)
+		} else if err := os.Remove(fileName); err != nil {
+			log.Warnf("cleanup: %s in %s", err, logName)
+		} else {
+			count++
+			log.Debugf("cleanup: removed %s %s", fileType, logName)
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
 // Cancel stops the current operation.
 func (w *CleanUp) Cancel() {
 	mutex.MainWorker.Cancel()
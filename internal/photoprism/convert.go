@@ -20,7 +20,9 @@ import (
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/video"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/heif"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
@@ -44,6 +46,11 @@ func NewConvert(conf *config.Config) *Convert {
 		rawtherapeeBlacklist: fs.NewBlacklist(conf.RawtherapeeBlacklist()),
 	}
 
+	// Decode HEVC coded HEIF images with FFmpeg if the build doesn't include libde265.
+	if conf.FFmpegEnabled() && !heif.Registered(heif.TypeHevc) {
+		heif.RegisterBatchCodec(heif.TypeHevc, c.DecodeHevc)
+	}
+
 	return c
 }
 
@@ -226,7 +233,13 @@ func (c *Convert) JpegConvertCommand(f *MediaFile, jpegName string, xmpName stri
 			return nil, useMutex, fmt.Errorf("no suitable converter found")
 		}
 	} else if f.IsVideo() && c.conf.FFmpegEnabled() {
-		result = exec.Command(c.conf.FFmpegBin(), "-y", "-i", f.FileName(), "-ss", "00:00:00.001", "-vframes", "1", jpegName)
+		offset, ok := video.PosterOffset(c.conf.VideoPoster(), f.MetaData().Duration)
+
+		if !ok {
+			offset = time.Millisecond
+		}
+
+		result = c.VideoPosterCommand(f, jpegName, offset)
 	} else if f.IsHEIF() && c.conf.HeifConvertEnabled() {
 		result = exec.Command(c.conf.HeifConvertBin(), f.FileName(), jpegName)
 	} else {
@@ -295,8 +308,47 @@ func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 		return NewMediaFile(jpegName)
 	}
 
+	// Select a video poster frame that is neither black nor blurry?
+	if f.IsVideo() && c.conf.FFmpegEnabled() && c.conf.VideoPoster() == video.PosterAuto {
+		log.Infof("%s: selecting poster frame for %s", f.FileType(), fileName)
+
+		if err = c.ToVideoPoster(f, jpegName); err != nil {
+			log.Debugf("%s: %s, using first frame", f.FileType(), err)
+		} else {
+			log.Infof("%s: created %s [%s]", f.FileType(), filepath.Base(jpegName), time.Since(start))
+			return NewMediaFile(jpegName)
+		}
+	}
+
+	// Decode HEIF images without external converters if the codec is supported?
+	if f.IsHEIF() {
+		if err = c.ToHeifJpeg(f, jpegName); err != nil {
+			log.Debugf("%s: %s in %s", f.FileType(), err, fileName)
+		} else {
+			log.Infof("%s: created %s [%s]", f.FileType(), filepath.Base(jpegName), time.Since(start))
+			return NewMediaFile(jpegName)
+		}
+	}
+
 	cmd, useMutex, err := c.JpegConvertCommand(f, jpegName, xmpName)
 
+	// Extract embedded RAW preview, which is much faster than external converters?
+	if f.IsRaw() {
+		minSize := RawPreviewMinSize
+
+		// Use previews of any size if preferred or no converter is available.
+		if c.conf.PreferRawPreviews() || err != nil {
+			minSize = 0
+		}
+
+		if previewErr := c.ToRawPreview(f, jpegName, minSize); previewErr != nil {
+			log.Debugf("%s: %s in %s", f.FileType(), previewErr, fileName)
+		} else {
+			log.Infof("%s: created %s from embedded preview [%s]", f.FileType(), filepath.Base(jpegName), time.Since(start))
+			return NewMediaFile(jpegName)
+		}
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -334,16 +386,35 @@ func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 	return NewMediaFile(jpegName)
 }
 
+// VideoBitrates maps codecs to the encoding quality in bits per pixel and second,
+// as newer codecs need a lower bitrate for the same quality.
+var VideoBitrates = map[fs.FileCodec]int{
+	fs.CodecAvc: 12,
+	fs.CodecHvc: 8,
+	fs.CodecVp9: 8,
+	fs.CodecAv1: 6,
+}
+
 // AvcBitrate returns the ideal AVC encoding bitrate in megabits per second.
 func (c *Convert) AvcBitrate(f *MediaFile) string {
-	const defaultBitrate = "8M"
+	return c.VideoBitrate(f, fs.CodecAvc)
+}
+
+// VideoBitrate returns the ideal encoding bitrate for the codec in megabits per second.
+func (c *Convert) VideoBitrate(f *MediaFile, codec fs.FileCodec) string {
+	quality, ok := VideoBitrates[codec]
+
+	if !ok {
+		quality = VideoBitrates[fs.CodecAvc]
+	}
+
+	defaultBitrate := fmt.Sprintf("%dM", 2*quality/3)
 
 	if f == nil {
 		return defaultBitrate
 	}
 
 	limit := c.conf.FFmpegBitrate()
-	quality := 12
 
 	bitrate := int(math.Ceil(float64(f.Width()*f.Height()*quality) / 1000000))
 
@@ -430,8 +501,53 @@ func (c *Convert) AvcConvertCommand(f *MediaFile, avcName, codecName string) (re
 
 // ToAvc converts a single video file to MPEG-4 AVC.
 func (c *Convert) ToAvc(f *MediaFile, encoderName string) (file *MediaFile, err error) {
+	return c.ToVideo(f, video.TypeAvc, encoderName)
+}
+
+// VideoConvertCommand returns the command for transcoding a video to the target type.
+func (c *Convert) VideoConvertCommand(f *MediaFile, videoName string, videoType video.Type, encoderName string) (result *exec.Cmd, useMutex bool, err error) {
+	if videoType.Codec == fs.CodecAvc {
+		return c.AvcConvertCommand(f, videoName, encoderName)
+	}
+
+	if !f.IsVideo() {
+		return nil, useMutex, fmt.Errorf("convert: file type %s not supported in %s", f.FileType(), sanitize.Log(f.BaseName()))
+	}
+
+	// Don't transcode more than one video at the same time.
+	useMutex = true
+
+	args := []string{"-i", f.FileName(), "-c:v", encoderName}
+
+	// Software encoders with settings that are reasonably fast on a CPU-only machine.
+	switch videoType.Codec {
+	case fs.CodecHvc:
+		args = append(args, "-preset", "fast", "-crf", "28", "-tag:v", "hvc1", "-vf", "format=yuv420p", "-c:a", "aac")
+	case fs.CodecVp9:
+		args = append(args, "-b:v", "0", "-crf", "33", "-deadline", "good", "-cpu-used", "4", "-row-mt", "1", "-vf", "format=yuv420p", "-c:a", "libopus")
+	case fs.CodecAv1:
+		args = append(args, "-b:v", "0", "-crf", "34", "-cpu-used", "6", "-row-mt", "1", "-vf", "format=yuv420p", "-c:a", "aac")
+	default:
+		return nil, useMutex, fmt.Errorf("convert: codec %s not supported", sanitize.Log(string(videoType.Codec)))
+	}
+
+	bitrate := c.VideoBitrate(f, videoType.Codec)
+
+	args = append(args, "-maxrate", bitrate, "-bufsize", bitrate, "-vsync", "vfr", "-r", "30")
+
+	if videoType.Container == "mp4" {
+		args = append(args, "-movflags", "+faststart")
+	}
+
+	args = append(args, "-f", videoType.Container, "-y", videoName)
+
+	return exec.Command(c.conf.FFmpegBin(), args...), useMutex, nil
+}
+
+// ToVideo converts a single video file to the target type, e.g. HEVC, VP9, or AV1.
+func (c *Convert) ToVideo(f *MediaFile, videoType video.Type, encoderName string) (file *MediaFile, err error) {
 	if encoderName == "" {
-		encoderName = DefaultAvcEncoder
+		encoderName = videoType.Encoder
 	}
 
 	if f == nil {
@@ -442,9 +558,12 @@ func (c *Convert) ToAvc(f *MediaFile, encoderName string) (file *MediaFile, err
 		return nil, fmt.Errorf("convert: %s not found", f.RelName(c.conf.OriginalsPath()))
 	}
 
-	avcName := fs.FormatAvc.FindFirst(f.FileName(), []string{c.conf.SidecarPath(), fs.HiddenPath}, c.conf.OriginalsPath(), false)
+	// Transcoded files have a codec specific extension, so that they can't be confused with originals.
+	sidecarFormat := videoType.SidecarFormat()
+
+	videoName := sidecarFormat.FindFirst(f.FileName(), []string{c.conf.SidecarPath(), fs.HiddenPath}, c.conf.OriginalsPath(), false)
 
-	mediaFile, err := NewMediaFile(avcName)
+	mediaFile, err := NewMediaFile(videoName)
 
 	if err == nil && mediaFile.IsVideo() {
 		return mediaFile, nil
@@ -458,10 +577,10 @@ func (c *Convert) ToAvc(f *MediaFile, encoderName string) (file *MediaFile, err
 		return nil, fmt.Errorf("convert: ffmpeg is disabled for transcoding %s", f.RelName(c.conf.OriginalsPath()))
 	}
 
-	avcName = fs.FileName(f.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), fs.AvcExt)
+	videoName = fs.FileName(f.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), "."+string(sidecarFormat))
 	fileName := f.RelName(c.conf.OriginalsPath())
 
-	cmd, useMutex, err := c.AvcConvertCommand(f, avcName, encoderName)
+	cmd, useMutex, err := c.VideoConvertCommand(f, videoName, videoType, encoderName)
 
 	if err != nil {
 		log.Error(err)
@@ -475,8 +594,8 @@ func (c *Convert) ToAvc(f *MediaFile, encoderName string) (file *MediaFile, err
 		defer c.cmdMutex.Unlock()
 	}
 
-	if fs.FileExists(avcName) {
-		return NewMediaFile(avcName)
+	if fs.FileExists(videoName) {
+		return NewMediaFile(videoName)
 	}
 
 	// Fetch command output.
@@ -492,12 +611,12 @@ func (c *Convert) ToAvc(f *MediaFile, encoderName string) (file *MediaFile, err
 		"xmpName":  "",
 	})
 
-	log.Infof("%s: transcoding %s to %s", encoderName, fileName, fs.FormatAvc)
+	log.Infof("%s: transcoding %s to %s", encoderName, fileName, sidecarFormat)
 
 	// Run convert command.
 	start := time.Now()
 	if err = cmd.Run(); err != nil {
-		_ = os.Remove(avcName)
+		_ = os.Remove(videoName)
 
 		if stderr.String() != "" {
 			err = errors.New(stderr.String())
@@ -511,15 +630,15 @@ func (c *Convert) ToAvc(f *MediaFile, encoderName string) (file *MediaFile, err
 		// Log filename and transcoding time.
 		log.Warnf("%s: failed transcoding %s [%s]", encoderName, fileName, time.Since(start))
 
-		if encoderName != DefaultAvcEncoder {
-			return c.ToAvc(f, DefaultAvcEncoder)
+		if encoderName != videoType.Encoder {
+			return c.ToVideo(f, videoType, videoType.Encoder)
 		} else {
 			return nil, err
 		}
 	}
 
 	// Log transcoding time.
-	log.Infof("%s: created %s [%s]", encoderName, filepath.Base(avcName), time.Since(start))
+	log.Infof("%s: created %s [%s]", encoderName, filepath.Base(videoName), time.Since(start))
 
-	return NewMediaFile(avcName)
+	return NewMediaFile(videoName)
 }
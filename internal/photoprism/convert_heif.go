@@ -0,0 +1,101 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"os/exec"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/heif"
+)
+
+// HevcDecodeCommand returns the command for decoding HEVC images from an Annex B stream to a sequence of PNG images.
+func (c *Convert) HevcDecodeCommand() *exec.Cmd {
+	return exec.Command(
+		c.conf.FFmpegBin(),
+		"-hide_banner",
+		"-loglevel", "error",
+		"-f", "hevc",
+		"-i", "-",
+		"-f", "image2pipe",
+		"-c:v", "png",
+		"-",
+	)
+}
+
+// DecodeHevc decodes HEVC coded HEIF image items with FFmpeg if the build doesn't include libde265.
+// All items, e.g. the tiles of a grid, are decoded at once so that only one process is started per image.
+func (c *Convert) DecodeHevc(items []*heif.Item, data [][]byte) ([]image.Image, error) {
+	if len(items) != len(data) {
+		return nil, fmt.Errorf("expected data for %d hevc items, got %d", len(items), len(data))
+	}
+
+	var stream bytes.Buffer
+
+	for i, it := range items {
+		b, err := heif.AnnexB(it.Config, data[i])
+
+		if err != nil {
+			return nil, err
+		}
+
+		stream.Write(b)
+	}
+
+	cmd := c.HevcDecodeCommand()
+
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdin = &stream
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return nil, errors.New(stderr.String())
+		} else {
+			return nil, err
+		}
+	}
+
+	// The PNG decoder stops reading at the end of each image.
+	images := make([]image.Image, len(items))
+
+	for i := range images {
+		img, err := png.Decode(&out)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding hevc item %d of %d (%s)", i+1, len(items), err)
+		}
+
+		images[i] = img
+	}
+
+	return images, nil
+}
+
+// ToHeifJpeg decodes the primary image of a HEIF file with the registered codecs and saves it as JPEG,
+// HEVC coded images are decoded with libde265, or FFmpeg if the build doesn't include it.
+func (c *Convert) ToHeifJpeg(f *MediaFile, jpegName string) error {
+	if f == nil {
+		return fmt.Errorf("file is nil - you might have found a bug")
+	}
+
+	if !f.IsHEIF() {
+		return fmt.Errorf("%s is not a heif file", f.BaseName())
+	}
+
+	// Rotation is applied to the image, so the resulting JPEG has no Exif orientation.
+	img, err := thumb.OpenHeif(f.FileName(), f.Orientation())
+
+	if err != nil {
+		return err
+	}
+
+	return imaging.Save(img, jpegName, imaging.JPEGQuality(c.conf.JpegQuality()))
+}
@@ -0,0 +1,120 @@
+package photoprism
+
+import (
+	"image"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/heif"
+)
+
+func TestConvert_HevcDecodeCommand(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	cmd := convert.HevcDecodeCommand()
+
+	assert.Equal(t, conf.FFmpegBin(), cmd.Path)
+	assert.Equal(t, []string{"-f", "hevc", "-i", "-"}, cmd.Args[4:8])
+	assert.Equal(t, "-", cmd.Args[len(cmd.Args)-1])
+}
+
+func TestConvert_DecodeHevc(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	if conf.DisableFFmpeg() {
+		t.Skip("ffmpeg is disabled")
+	}
+
+	f, err := heif.Open(filepath.Join(conf.ExamplesPath(), "iphone_7.heic"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tiles := []*heif.Item{f.Item(1), f.Item(2)}
+	data := make([][]byte, len(tiles))
+
+	for i, tile := range tiles {
+		if data[i], err = f.Data(tile); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	images, err := convert.DecodeHevc(tiles, data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, images, 2) {
+		assert.Equal(t, image.Rect(0, 0, 512, 512), images[0].Bounds())
+		assert.Equal(t, image.Rect(0, 0, 512, 512), images[1].Bounds())
+	}
+
+	_, err = convert.DecodeHevc(tiles, data[:1])
+
+	assert.Error(t, err)
+}
+
+func TestConvert_ToHeifJpeg(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "iphone_7.heic"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Codec", func(t *testing.T) {
+		heif.RegisterCodec(heif.TypeHevc, func(it *heif.Item, data []byte) (image.Image, error) {
+			return image.NewRGBA(image.Rect(0, 0, it.Width, it.Height)), nil
+		})
+
+		defer heif.RegisterCodec(heif.TypeHevc, nil)
+
+		jpegName := filepath.Join(t.TempDir(), "iphone_7.heic.jpg")
+
+		if err := convert.ToHeifJpeg(mf, jpegName); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := NewMediaFile(jpegName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The Exif orientation is applied, as the file has no rotation property.
+		assert.True(t, result.IsJpeg())
+		assert.Equal(t, 3024, result.Width())
+		assert.Equal(t, 4032, result.Height())
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		if heif.Registered(heif.TypeHevc) {
+			t.Skip("hevc codec is registered")
+		}
+
+		jpegName := filepath.Join(t.TempDir(), "iphone_7.heic.jpg")
+
+		assert.Error(t, convert.ToHeifJpeg(mf, jpegName))
+		assert.NoFileExists(t, jpegName)
+	})
+	t.Run("NotHeif", func(t *testing.T) {
+		jpeg, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, convert.ToHeifJpeg(jpeg, filepath.Join(t.TempDir(), "elephants.jpg")))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		assert.Error(t, convert.ToHeifJpeg(nil, "test.jpg"))
+	})
+}
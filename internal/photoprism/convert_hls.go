@@ -0,0 +1,160 @@
+package photoprism
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// HlsCacheNamespace is the cache namespace for HLS video segments.
+const HlsCacheNamespace = "hls"
+
+// hlsSegmentJobs contains the HLS video segments that are currently being transcoded, so that
+// concurrent requests for the same segment wait for the running job instead of starting a new one.
+var hlsSegmentJobs = make(map[string]chan struct{})
+var hlsSegmentMutex = sync.Mutex{}
+
+// hlsSegmentJob registers a transcoding job for an HLS video segment. If another job for the segment
+// is running, it returns a channel that is closed when that job is done. Otherwise, it returns a
+// function that must be called when the new job is done.
+func hlsSegmentJob(segmentName string) (wait <-chan struct{}, done func()) {
+	hlsSegmentMutex.Lock()
+	defer hlsSegmentMutex.Unlock()
+
+	if ch, ok := hlsSegmentJobs[segmentName]; ok {
+		return ch, nil
+	}
+
+	ch := make(chan struct{})
+	hlsSegmentJobs[segmentName] = ch
+
+	return nil, func() {
+		hlsSegmentMutex.Lock()
+		delete(hlsSegmentJobs, segmentName)
+		hlsSegmentMutex.Unlock()
+		close(ch)
+	}
+}
+
+// HlsSegmentName returns the cache file name of an HLS video segment.
+func HlsSegmentName(fileHash string, r video.Rendition, index int) (string, error) {
+	return CacheName(fileHash, HlsCacheNamespace, fmt.Sprintf("%s_%d.ts", r.Name, index))
+}
+
+// HlsSegmentCommand returns the command for transcoding a video segment to MPEG-TS.
+func (c *Convert) HlsSegmentCommand(ctx context.Context, fileName, segmentName string, r video.Rendition, start, length time.Duration) *exec.Cmd {
+	return exec.CommandContext(
+		ctx,
+		c.conf.FFmpegBin(),
+		"-ss", formatSeconds(start),
+		"-i", fileName,
+		"-t", formatSeconds(length),
+		"-map", "0:v:0",
+		"-map", "0:a:0?",
+		"-vf", video.ScaleFilter(r.Height),
+		"-c:v", DefaultAvcEncoder,
+		"-preset", "veryfast",
+		"-profile:v", r.Profile,
+		"-level", r.Level,
+		"-b:v", strconv.Itoa(r.Bitrate),
+		"-maxrate", strconv.Itoa(r.Bitrate),
+		"-bufsize", strconv.Itoa(r.Bitrate*2),
+		"-c:a", "aac",
+		"-b:a", strconv.Itoa(r.AudioBitrate),
+		"-ac", "2",
+		"-output_ts_offset", formatSeconds(start),
+		"-f", "mpegts",
+		"-y",
+		segmentName,
+	)
+}
+
+// ToHlsSegment transcodes a single HLS video segment on demand and returns the cached file name.
+// Transcoding is aborted when the context is canceled, e.g. because the client has disconnected.
+func (c *Convert) ToHlsSegment(ctx context.Context, fileName, fileHash string, r video.Rendition, index int, duration time.Duration) (string, error) {
+	if c.conf.DisableFFmpeg() {
+		return "", fmt.Errorf("convert: ffmpeg is disabled for transcoding %s", sanitize.Log(filepath.Base(fileName)))
+	}
+
+	start, length, err := video.HlsSegment(duration, index)
+
+	if err != nil {
+		return "", err
+	}
+
+	segmentName, err := HlsSegmentName(fileHash, r, index)
+
+	if err != nil {
+		return "", err
+	}
+
+	// Wait for other requests transcoding the same segment, unless the client disconnects.
+	for {
+		if fs.FileExists(segmentName) {
+			return segmentName, nil
+		}
+
+		wait, done := hlsSegmentJob(segmentName)
+
+		if done != nil {
+			defer done()
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-wait:
+		}
+	}
+
+	// The segment may have been created before the job was registered.
+	if fs.FileExists(segmentName) {
+		return segmentName, nil
+	}
+
+	tmpName := segmentName + ".tmp"
+	cmd := c.HlsSegmentCommand(ctx, fileName, tmpName, r, start, length)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	log.Debugf("ffmpeg: %s", cmd.String())
+
+	if err = cmd.Run(); err != nil {
+		_ = os.Remove(tmpName)
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", ctxErr
+		} else if stderr.String() != "" {
+			return "", errors.New(stderr.String())
+		}
+
+		return "", err
+	}
+
+	if err = os.Rename(tmpName, segmentName); err != nil {
+		_ = os.Remove(tmpName)
+		return "", err
+	}
+
+	log.Debugf("convert: created %s segment %d of %s", r.Name, index, sanitize.Log(filepath.Base(fileName)))
+
+	return segmentName, nil
+}
+
+// formatSeconds formats a duration in seconds as expected by ffmpeg.
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
@@ -0,0 +1,150 @@
+package photoprism
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/video"
+)
+
+func TestHlsSegmentName(t *testing.T) {
+	conf := config.TestConfig()
+	r, _ := video.FindRendition("720p")
+
+	result, err := HlsSegmentName("01244519acf35c62a5fea7a5a7dcefdbec4fb2f5", r, 3)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, filepath.Join(conf.CachePath(), "hls", "0", "1", "2", "01244519acf35c62a5fea7a5a7dcefdbec4fb2f5_720p_3.ts"), result)
+
+	_, err = HlsSegmentName("012", r, 3)
+	assert.Error(t, err)
+}
+
+func TestHlsSegmentJob(t *testing.T) {
+	segmentName := "01244519acf35c62a5fea7a5a7dcefdbec4fb2f5_720p_3.ts"
+
+	wait, done := hlsSegmentJob(segmentName)
+
+	assert.Nil(t, wait)
+	assert.NotNil(t, done)
+
+	// Concurrent requests wait for the running job.
+	wait, other := hlsSegmentJob(segmentName)
+
+	assert.NotNil(t, wait)
+	assert.Nil(t, other)
+
+	// Jobs of other segments don't wait.
+	_, otherDone := hlsSegmentJob("01244519acf35c62a5fea7a5a7dcefdbec4fb2f5_720p_4.ts")
+
+	assert.NotNil(t, otherDone)
+
+	otherDone()
+	done()
+
+	select {
+	case <-wait:
+	case <-time.After(time.Second):
+		t.Fatal("wait channel not closed")
+	}
+
+	// Finished jobs are removed, so that memory usage doesn't grow with the number of segments.
+	hlsSegmentMutex.Lock()
+	assert.Len(t, hlsSegmentJobs, 0)
+	hlsSegmentMutex.Unlock()
+}
+
+func TestConvert_HlsSegmentCommand(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+	r, _ := video.FindRendition("360p")
+	fileName := filepath.Join(conf.ExamplesPath(), "gopher-video.mp4")
+
+	cmd := convert.HlsSegmentCommand(context.Background(), fileName, "segment.ts", r, 6*time.Second, 2500*time.Millisecond)
+
+	assert.Equal(t, []string{"-ss", "6.000"}, cmd.Args[1:3])
+	assert.Contains(t, cmd.Args, fileName)
+	assert.Contains(t, cmd.Args, "2.500")
+	assert.Contains(t, cmd.Args, "800000")
+	assert.Contains(t, cmd.Args, "mpegts")
+	assert.Equal(t, "segment.ts", cmd.Args[len(cmd.Args)-1])
+}
+
+func TestConvert_ToHlsSegment(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+	r, _ := video.FindRendition("360p")
+	fileName := filepath.Join(conf.ExamplesPath(), "gopher-video.mp4")
+	fileHash := "f9bbf2a7a4fe2a6a4d4d2fd2d2e0b3f2ea5d0c48"
+
+	t.Run("Cached", func(t *testing.T) {
+		segmentName, err := HlsSegmentName(fileHash, r, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = os.WriteFile(segmentName, []byte("ts"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(segmentName)
+
+		if conf.FFmpegBin() == "" {
+			t.Skip("ffmpeg not installed")
+		}
+
+		result, err := convert.ToHlsSegment(context.Background(), fileName, fileHash, r, 0, 10*time.Second)
+
+		assert.NoError(t, err)
+		assert.Equal(t, segmentName, result)
+	})
+	t.Run("InvalidIndex", func(t *testing.T) {
+		if conf.FFmpegBin() == "" {
+			t.Skip("ffmpeg not installed")
+		}
+
+		_, err := convert.ToHlsSegment(context.Background(), fileName, fileHash, r, 2, 10*time.Second)
+		assert.Error(t, err)
+	})
+	t.Run("WaitCanceled", func(t *testing.T) {
+		if conf.FFmpegBin() == "" {
+			t.Skip("ffmpeg not installed")
+		}
+
+		segmentName, err := HlsSegmentName(fileHash, r, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, done := hlsSegmentJob(segmentName)
+		defer done()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = convert.ToHlsSegment(ctx, fileName, fileHash, r, 1, 10*time.Second)
+		assert.Equal(t, context.Canceled, err)
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		if conf.FFmpegBin() == "" {
+			t.Skip("ffmpeg not installed")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := convert.ToHlsSegment(ctx, fileName, fileHash, r, 1, 10*time.Second)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,122 @@
+package photoprism
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// VideoPosterCommand returns the command for extracting a single video frame as JPEG.
+func (c *Convert) VideoPosterCommand(f *MediaFile, jpegName string, offset time.Duration) *exec.Cmd {
+	return exec.Command(c.conf.FFmpegBin(), "-y", "-ss", formatSeconds(offset), "-i", f.FileName(), "-frames:v", "1", "-q:v", "2", jpegName)
+}
+
+// VideoClipCommand returns the command for creating a short, silent video preview clip.
+func (c *Convert) VideoClipCommand(f *MediaFile, clipName string, offset time.Duration) *exec.Cmd {
+	return exec.Command(
+		c.conf.FFmpegBin(),
+		"-ss", formatSeconds(offset),
+		"-i", f.FileName(),
+		"-t", formatSeconds(video.ClipDuration),
+		"-map", "0:v:0",
+		"-an",
+		"-vf", video.ScaleFilter(video.ClipHeight),
+		"-c:v", DefaultAvcEncoder,
+		"-preset", "veryfast",
+		"-crf", "28",
+		"-movflags", "+faststart",
+		"-f", "mp4",
+		"-y",
+		clipName,
+	)
+}
+
+// VideoSpriteCommand returns the command for rendering a video scrubbing sprite sheet.
+func (c *Convert) VideoSpriteCommand(f *MediaFile, spriteName string, sprite video.Sprite) *exec.Cmd {
+	return exec.Command(
+		c.conf.FFmpegBin(),
+		"-i", f.FileName(),
+		"-vf", sprite.Filter(),
+		"-frames:v", "1",
+		"-q:v", "5",
+		"-y",
+		spriteName,
+	)
+}
+
+// ToVideoPoster extracts candidate frames and saves the first one that is neither black,
+// flat nor blurry as video poster. If no frame is suitable, the one with the best score is used.
+func (c *Convert) ToVideoPoster(f *MediaFile, jpegName string) error {
+	if f == nil {
+		return fmt.Errorf("convert: file is nil - you might have found a bug")
+	}
+
+	offsets := video.PosterOffsets(f.MetaData().Duration, video.PosterCandidates)
+	candidates := make([]string, 0, len(offsets))
+	stats := make([]video.FrameStats, 0, len(offsets))
+
+	defer func() {
+		for _, fileName := range candidates {
+			_ = os.Remove(fileName)
+		}
+	}()
+
+	for i, offset := range offsets {
+		fileName := fmt.Sprintf("%s.%d.jpg", jpegName, i)
+
+		if err := runCommand(c.VideoPosterCommand(f, fileName, offset)); err != nil {
+			log.Debugf("convert: %s (extract frame at %s)", err, offset)
+			break
+		}
+
+		img, err := imaging.Open(fileName)
+
+		if err != nil {
+			_ = os.Remove(fileName)
+			break
+		}
+
+		candidates = append(candidates, fileName)
+		stats = append(stats, video.AnalyzeFrame(img))
+
+		if stats[i].Usable() {
+			break
+		}
+
+		log.Debugf("convert: skipped frame at %s in %s", offset, sanitize.Log(f.BaseName()))
+	}
+
+	best := video.BestFrame(stats)
+
+	if best < 0 {
+		return fmt.Errorf("convert: found no poster frame in %s", sanitize.Log(f.BaseName()))
+	}
+
+	return os.Rename(candidates[best], jpegName)
+}
+
+// runCommand runs a command and returns its error output on failure.
+func runCommand(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	log.Tracef("convert: %s", cmd.String())
+
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return fmt.Errorf("%s (%s)", stderr.String(), filepath.Base(cmd.Path))
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/raw"
+)
+
+// RawPreviewMinSize is the minimum long side of embedded RAW previews in pixels,
+// below which external converters are used if available and previews are not preferred.
+var RawPreviewMinSize = 1920
+
+// ToRawPreview saves the largest JPEG preview embedded in a RAW file with orientation applied,
+// returns an error if there is none or its long side is below minSize.
+func (c *Convert) ToRawPreview(f *MediaFile, jpegName string, minSize int) error {
+	if f == nil {
+		return fmt.Errorf("file is nil - you might have found a bug")
+	}
+
+	if !f.IsRaw() {
+		return fmt.Errorf("%s is not a raw file", f.BaseName())
+	}
+
+	p, err := raw.Largest(f.FileName())
+
+	if err != nil {
+		return err
+	} else if p.LongSide() < minSize {
+		return fmt.Errorf("embedded preview is too small (%dx%d)", p.Width, p.Height)
+	}
+
+	img, _, err := raw.Decode(f.FileName())
+
+	if err != nil {
+		return err
+	}
+
+	if size := c.conf.JpegSize(); p.LongSide() > size {
+		img = imaging.Fit(img, size, size, imaging.Lanczos)
+	}
+
+	if o := f.Orientation(); o > 1 {
+		img = thumb.Rotate(img, o)
+	}
+
+	return imaging.Save(img, jpegName, imaging.JPEGQuality(c.conf.JpegQuality()))
+}
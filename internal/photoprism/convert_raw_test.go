@@ -0,0 +1,64 @@
+package photoprism
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestConvert_ToRawPreview(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	t.Run("canon_eos_6d.dng", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "canon_eos_6d.dng"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jpegName := filepath.Join(t.TempDir(), "canon_eos_6d.dng.jpg")
+
+		if err = convert.ToRawPreview(mf, jpegName, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := NewMediaFile(jpegName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The embedded 1024x683 preview is resized to the configured JPEG size limit.
+		assert.True(t, result.IsJpeg())
+		assert.Equal(t, conf.JpegSize(), result.Width())
+		assert.Equal(t, 480, result.Height())
+	})
+	t.Run("TooSmall", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "canon_eos_6d.dng"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jpegName := filepath.Join(t.TempDir(), "canon_eos_6d.dng.jpg")
+
+		assert.Error(t, convert.ToRawPreview(mf, jpegName, RawPreviewMinSize))
+		assert.NoFileExists(t, jpegName)
+	})
+	t.Run("NotRaw", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, convert.ToRawPreview(mf, filepath.Join(t.TempDir(), "elephants.jpg"), 0))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		assert.Error(t, convert.ToRawPreview(nil, "test.jpg", 0))
+	})
+}
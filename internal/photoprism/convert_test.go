@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/video"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
@@ -260,7 +261,9 @@ func TestConvert_AvcBitrate(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		assert.Equal(t, "1M", convert.AvcBitrate(mf))
+		assert.Equal(t, 270, mf.Width())
+		assert.Equal(t, 480, mf.Height())
+		assert.Equal(t, "2M", convert.AvcBitrate(mf))
 	})
 
 	t.Run("medium", func(t *testing.T) {
@@ -315,6 +318,30 @@ func TestConvert_AvcBitrate(t *testing.T) {
 	})
 }
 
+func TestConvert_VideoBitrate(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	fileName := filepath.Join(conf.ExamplesPath(), "gopher-video.mp4")
+
+	mf, err := NewMediaFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mf.width = 1920
+	mf.height = 1080
+
+	assert.Equal(t, "25M", convert.VideoBitrate(mf, fs.CodecAvc))
+	assert.Equal(t, "17M", convert.VideoBitrate(mf, fs.CodecHvc))
+	assert.Equal(t, "17M", convert.VideoBitrate(mf, fs.CodecVp9))
+	assert.Equal(t, "13M", convert.VideoBitrate(mf, fs.CodecAv1))
+	assert.Equal(t, "25M", convert.VideoBitrate(mf, fs.CodecOther))
+	assert.Equal(t, "8M", convert.VideoBitrate(nil, fs.CodecAvc))
+	assert.Equal(t, "4M", convert.VideoBitrate(nil, fs.CodecAv1))
+}
+
 func TestConvert_AvcConvertCommand(t *testing.T) {
 	conf := config.TestConfig()
 	convert := NewConvert(conf)
@@ -403,3 +430,70 @@ func TestConvert_ToAvc(t *testing.T) {
 		assert.Nil(t, avcFile)
 	})
 }
+
+func TestConvert_VideoConvertCommand(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	fileName := filepath.Join(conf.ExamplesPath(), "gopher-video.mp4")
+	mf, err := NewMediaFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Hevc", func(t *testing.T) {
+		r, useMutex, err := convert.VideoConvertCommand(mf, "video.hevc", video.TypeHevc, video.TypeHevc.Encoder)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, useMutex)
+		assert.Contains(t, r.Args, "libx265")
+		assert.Contains(t, r.Args, "hvc1")
+		assert.Equal(t, []string{"-f", "mp4", "-y", "video.hevc"}, r.Args[len(r.Args)-4:])
+	})
+	t.Run("Vp9", func(t *testing.T) {
+		r, _, err := convert.VideoConvertCommand(mf, "video.vp9", video.TypeVp9, video.TypeVp9.Encoder)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, r.Args, "libvpx-vp9")
+		assert.Contains(t, r.Args, "libopus")
+		assert.NotContains(t, r.Args, "+faststart")
+		assert.Equal(t, []string{"-f", "webm", "-y", "video.vp9"}, r.Args[len(r.Args)-4:])
+	})
+	t.Run("Av1", func(t *testing.T) {
+		r, _, err := convert.VideoConvertCommand(mf, "video.av1", video.TypeAv1, video.TypeAv1.Encoder)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, r.Args, "libaom-av1")
+		assert.Equal(t, []string{"-f", "mp4", "-y", "video.av1"}, r.Args[len(r.Args)-4:])
+	})
+	t.Run("Avc", func(t *testing.T) {
+		r, _, err := convert.VideoConvertCommand(mf, "video.avc", video.TypeAvc, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, r.Args, "mp4")
+	})
+	t.Run("Jpeg", func(t *testing.T) {
+		jpeg, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "cat_black.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, _, err := convert.VideoConvertCommand(jpeg, "video.hevc", video.TypeHevc, video.TypeHevc.Encoder)
+		assert.Error(t, err)
+		assert.Nil(t, r)
+	})
+}
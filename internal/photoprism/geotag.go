@@ -0,0 +1,153 @@
+package photoprism
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// Geotag represents a worker that sets the location of photos based on GPS tracks.
+type Geotag struct {
+	conf *config.Config
+}
+
+// GeotagMatch represents a photo and its position on a GPS track.
+type GeotagMatch struct {
+	PhotoUID string        `json:"UID"`
+	FileName string        `json:"FileName"`
+	TakenAt  time.Time     `json:"TakenAt"`
+	Lat      float64       `json:"Lat"`
+	Lng      float64       `json:"Lng"`
+	Altitude int           `json:"Altitude"`
+	Gap      time.Duration `json:"Gap"`
+}
+
+// GeotagMatches represents a list of geotag matches.
+type GeotagMatches []GeotagMatch
+
+// NewGeotag returns a new Geotag worker.
+func NewGeotag(conf *config.Config) *Geotag {
+	instance := &Geotag{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start matches photos without location with the track and updates their position
+// unless the dry run option is set.
+func (w *Geotag) Start(track geo.Track, opt GeotagOptions) (matches GeotagMatches, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("geotag: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	matches = GeotagMatches{}
+
+	if len(track) == 0 {
+		return matches, geo.ErrEmptyTrack
+	}
+
+	if err = mutex.MainWorker.Start(); err != nil {
+		log.Warnf("geotag: %s (start)", err.Error())
+		return matches, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	start := time.Now()
+
+	if opt.MaxGap <= 0 {
+		opt.MaxGap = GeotagMaxGap
+	}
+
+	// Find photos taken while the track was recorded.
+	from := track.Start().Add(-1 * (opt.Offset + opt.MaxGap))
+	to := track.End().Add(opt.MaxGap - opt.Offset)
+
+	photos, err := query.PhotosWithoutLocation(from, to)
+
+	if err != nil {
+		return matches, err
+	}
+
+	log.Infof("geotag: found %s without location between %s and %s", english.Plural(len(photos), "photo", "photos"), from.Format(time.RFC3339), to.Format(time.RFC3339))
+
+	for _, p := range photos {
+		if mutex.MainWorker.Canceled() {
+			return matches, fmt.Errorf("geotag canceled")
+		}
+
+		// Keep positions from a more reliable source.
+		if entity.SrcPriority[p.PlaceSrc] > entity.SrcPriority[entity.SrcTrack] {
+			continue
+		}
+
+		pos, gap, ok := track.Position(p.TakenAt.Add(opt.Offset), opt.MaxGap)
+
+		if !ok {
+			continue
+		}
+
+		m := GeotagMatch{
+			PhotoUID: p.PhotoUID,
+			FileName: p.PhotoName,
+			TakenAt:  p.TakenAt,
+			Lat:      pos.Lat,
+			Lng:      pos.Lng,
+			Altitude: pos.AltitudeInt(),
+			Gap:      gap,
+		}
+
+		if p.PhotoPath != "" {
+			m.FileName = p.PhotoPath + "/" + p.PhotoName
+		}
+
+		if opt.DryRun {
+			matches = append(matches, m)
+			continue
+		}
+
+		if !p.SetPosition(pos, entity.SrcTrack, false) {
+			log.Debugf("geotag: %s keeps its position", p.PhotoUID)
+			continue
+		}
+
+		if err := p.Save(); err != nil {
+			log.Errorf("geotag: %s while saving %s", err, p.PhotoUID)
+			continue
+		}
+
+		matches = append(matches, m)
+	}
+
+	if opt.DryRun {
+		log.Infof("geotag: would update %s [%s]", english.Plural(len(matches), "photo", "photos"), time.Since(start))
+		return matches, nil
+	}
+
+	if len(matches) > 0 {
+		if err := entity.UpdatePlacesCounts(); err != nil {
+			log.Errorf("geotag: %s (update counts)", err)
+		}
+	}
+
+	log.Infof("geotag: updated %s [%s]", english.Plural(len(matches), "photo", "photos"), time.Since(start))
+
+	return matches, nil
+}
+
+// Cancel stops the current operation.
+func (w *Geotag) Cancel() {
+	mutex.MainWorker.Cancel()
+}
@@ -0,0 +1,25 @@
+package photoprism
+
+import "time"
+
+// GeotagMaxGap is the default max time difference between a photo and the closest track point.
+const GeotagMaxGap = 10 * time.Minute
+
+// GeotagOptions represents track geotagging options.
+//
+// Offset is added to the time a photo was taken to correct a wrong camera clock, e.g. -1h if
+// the camera clock was one hour ahead.
+type GeotagOptions struct {
+	MaxGap time.Duration
+	Offset time.Duration
+	DryRun bool
+}
+
+// GeotagOptionsDefault returns new geotagging options with default values.
+func GeotagOptionsDefault() GeotagOptions {
+	result := GeotagOptions{
+		MaxGap: GeotagMaxGap,
+	}
+
+	return result
+}
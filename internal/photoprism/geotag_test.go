@@ -0,0 +1,66 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+func TestGeotag_Start(t *testing.T) {
+	w := NewGeotag(config.TestConfig())
+
+	track := geo.NewTrack(
+		geo.Position{Time: time.Date(2008, 7, 1, 9, 55, 0, 0, time.UTC), Lat: 52.5163, Lng: 13.3777},
+		geo.Position{Time: time.Date(2008, 7, 1, 10, 5, 0, 0, time.UTC), Lat: 52.5209, Lng: 13.3747},
+	)
+
+	t.Run("DryRun", func(t *testing.T) {
+		opt := GeotagOptionsDefault()
+		opt.DryRun = true
+
+		matches, err := w.Start(track, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, matches, 1)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", matches[0].PhotoUID)
+		assert.Equal(t, 5*time.Minute, matches[0].Gap)
+		assert.InEpsilon(t, 52.5186, matches[0].Lat, 0.000001)
+		assert.InEpsilon(t, 13.3762, matches[0].Lng, 0.000001)
+	})
+	t.Run("Offset", func(t *testing.T) {
+		opt := GeotagOptions{MaxGap: time.Minute, Offset: -5 * time.Minute, DryRun: true}
+
+		matches, err := w.Start(track, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, matches, 1)
+		assert.Equal(t, time.Duration(0), matches[0].Gap)
+		assert.Equal(t, 52.5163, matches[0].Lat)
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		opt := GeotagOptions{MaxGap: time.Minute, DryRun: true}
+
+		matches, err := w.Start(track, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, matches, 0)
+	})
+	t.Run("EmptyTrack", func(t *testing.T) {
+		_, err := w.Start(geo.Track{}, GeotagOptionsDefault())
+
+		assert.Equal(t, geo.ErrEmptyTrack, err)
+	})
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/thumb"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
@@ -337,6 +339,13 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			if file.UnsavedMarkers() {
 				// Add matching labels.
 				extraLabels = append(extraLabels, file.Markers().Labels()...)
+
+				// Keep faces visible in smart cropped tiles.
+				if ind.conf.ThumbCrop() == thumb.ResampleFillSmart {
+					if err := m.ResampleFocus(ind.thumbPath(), file.Markers().FocusAreas()); err != nil {
+						log.Warnf("index: %s while cropping tiles of %s", err, logName)
+					}
+				}
 			} else if o.FacesOnly {
 				// Skip when indexing faces only.
 				result.Status = IndexSkipped
@@ -407,6 +416,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcXmp)
 			photo.SetDescription(metaData.Description, entity.SrcXmp)
+			photo.SetRating(metaData.Rating, metaData.ColorLabel, entity.SrcXmp)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcXmp)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcXmp)
 
@@ -425,6 +435,12 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
+
+			// Ratings are only unset by the metadata of the primary file.
+			if metaData.HasRating() {
+				photo.SetRating(metaData.Rating, metaData.ColorLabel, entity.SrcMeta)
+			}
+
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
@@ -485,6 +501,12 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		if metaData := m.MetaData(); metaData.Error == nil {
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
+
+			// Ratings are only unset by the metadata of the primary file.
+			if metaData.HasRating() {
+				photo.SetRating(metaData.Rating, metaData.ColorLabel, entity.SrcMeta)
+			}
+
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
@@ -559,6 +581,15 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		}
 	}
 
+	// Perceptual hash of the primary thumbnail to find similar images.
+	if m.IsMedia() {
+		if h, err := m.PerceptualHash(ind.thumbPath()); err != nil {
+			log.Debugf("%s while creating perceptual hash", err.Error())
+		} else {
+			file.FilePHash = h.Hex()
+		}
+	}
+
 	// Set taken date based on file mod time or name if other metadata is missing.
 	if m.IsMedia() && entity.SrcPriority[photo.TakenSrc] <= entity.SrcPriority[entity.SrcName] {
 		// Try to extract time from original file name first.
@@ -598,6 +629,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, metaData.ColorLabel, entity.SrcMeta)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
@@ -636,6 +668,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		photo.PlaceID = entity.UnknownPlace.ID
 	}
 
+	// Infer time zone from coordinates if metadata did not provide one.
+	photo.InferTimeZone()
+
 	photo.UpdateDateFields()
 
 	// Panorama?
@@ -803,6 +838,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		}
 	}
 
+	// Update the cached perceptual hashes used to find similar photos.
+	search.UpdateSimilarFile(file.FileUID, file.FilePHash)
+
 	result.FileID = file.ID
 	result.FileUID = file.FileUID
 
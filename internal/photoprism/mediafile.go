@@ -1,6 +1,7 @@
 package photoprism
 
 import (
+	"context"
 	"fmt"
 	"image"
 	"io"
@@ -23,6 +24,7 @@ import (
 	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/capture"
+	"github.com/photoprism/photoprism/pkg/focus"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -850,6 +852,15 @@ func (m *MediaFile) decodeDimensions() error {
 	} else if data := m.MetaData(); data.Error == nil {
 		m.width = data.ActualWidth()
 		m.height = data.ActualHeight()
+	} else if f, err := m.Heif(); err == nil {
+		primary, err := f.Primary()
+
+		if err != nil {
+			return err
+		}
+
+		// Rotation is stored in the container, so the Exif orientation doesn't apply.
+		m.width, m.height = primary.DisplaySize()
 	} else {
 		return data.Error
 	}
@@ -951,8 +962,20 @@ func (m *MediaFile) Resample(path string, sizeName thumb.Name) (img image.Image,
 	return imaging.Open(filename)
 }
 
-// ResampleDefault pre-caches default thumbnails.
-func (m *MediaFile) ResampleDefault(thumbPath string, force bool) (err error) {
+// ResampleDefault pre-caches default thumbnails, optionally also in additional file formats.
+// Interactive thumbnail requests are rendered first, see thumb.RenderQueue.
+func (m *MediaFile) ResampleDefault(thumbPath string, force bool, formats ...fs.FileFormat) (err error) {
+	key := fmt.Sprintf("%s/%s:default:%t:%v", thumbPath, m.Hash(), force, formats)
+
+	_, err = thumb.RenderQueue.Do(context.Background(), key, thumb.PriorityLow, func() (string, error) {
+		return "", m.resampleDefault(thumbPath, force, formats...)
+	})
+
+	return err
+}
+
+// resampleDefault creates the default thumbnails, see ResampleDefault.
+func (m *MediaFile) resampleDefault(thumbPath string, force bool, formats ...fs.FileFormat) (err error) {
 	count := 0
 	start := time.Now()
 
@@ -970,8 +993,10 @@ func (m *MediaFile) ResampleDefault(thumbPath string, force bool) (err error) {
 	var originalImg image.Image
 	var sourceImg image.Image
 	var sourceName thumb.Name
+	var focusAreas focus.Areas
+	var focusLoaded bool
 
-	for _, name := range thumb.DefaultSizes {
+	for _, name := range thumb.CacheSizes() {
 		size := thumb.Sizes[name]
 
 		if size.Uncached() {
@@ -979,11 +1004,31 @@ func (m *MediaFile) ResampleDefault(thumbPath string, force bool) (err error) {
 			continue
 		}
 
-		if fileName, err := thumb.FileName(hash, thumbPath, size.Width, size.Height, size.Options...); err != nil {
-			log.Errorf("media: failed creating %s (%s)", sanitize.Log(string(name)), err)
+		// Smart crops keep the faces visible no matter which code path creates them, see ResampleFocus.
+		if size.Smart() && !focusLoaded {
+			focusAreas = m.thumbFocus()
+			focusLoaded = true
+		}
+
+		// Thumbnails in additional file formats are rendered from the same image as the default thumbnail.
+		sizeFormats := []fs.FileFormat{size.Format()}
+
+		for _, format := range formats {
+			if format != size.Format() && size.HasFormat(format) && thumb.FormatSupported(format) {
+				sizeFormats = append(sizeFormats, format)
+			}
+		}
+
+		for _, format := range sizeFormats {
+			opts := size.FormatOptions(format)
+
+			fileName, err := thumb.FileNameFocus(hash, thumbPath, size.Width, size.Height, focusAreas, opts...)
+
+			if err != nil {
+				log.Errorf("media: failed creating %s %s (%s)", sanitize.Log(string(name)), format, err)
+				return err
+			}
 
-			return err
-		} else {
 			if !force && fs.FileExists(fileName) {
 				continue
 			}
@@ -1001,17 +1046,17 @@ func (m *MediaFile) ResampleDefault(thumbPath string, force bool) (err error) {
 
 			if size.Source != "" {
 				if size.Source == sourceName && sourceImg != nil {
-					_, err = thumb.Create(sourceImg, fileName, size.Width, size.Height, size.Options...)
+					_, err = thumb.CreateFocus(sourceImg, fileName, size.Width, size.Height, focusAreas, opts...)
 				} else {
-					_, err = thumb.Create(originalImg, fileName, size.Width, size.Height, size.Options...)
+					_, err = thumb.CreateFocus(originalImg, fileName, size.Width, size.Height, focusAreas, opts...)
 				}
 			} else {
-				sourceImg, err = thumb.Create(originalImg, fileName, size.Width, size.Height, size.Options...)
+				sourceImg, err = thumb.CreateFocus(originalImg, fileName, size.Width, size.Height, focusAreas, opts...)
 				sourceName = name
 			}
 
 			if err != nil {
-				log.Errorf("media: failed creating %s (%s)", sanitize.Log(string(name)), err)
+				log.Errorf("media: failed creating %s %s (%s)", sanitize.Log(string(name)), format, err)
 				return err
 			}
 
@@ -1022,6 +1067,97 @@ func (m *MediaFile) ResampleDefault(thumbPath string, force bool) (err error) {
 	return nil
 }
 
+// thumbFocus returns the face areas of the indexed file to keep visible in smart cropped thumbnails.
+func (m *MediaFile) thumbFocus() focus.Areas {
+	f, err := entity.FirstFileByHash(m.Hash())
+
+	if err != nil {
+		return nil
+	}
+
+	return f.ThumbFocus()
+}
+
+// ResampleFocus recreates smart cropped default thumbnails so that the focus areas, e.g. faces, remain visible.
+func (m *MediaFile) ResampleFocus(thumbPath string, areas focus.Areas) (err error) {
+	key := fmt.Sprintf("%s/%s:focus:%s", thumbPath, m.Hash(), areas.Hash())
+
+	_, err = thumb.RenderQueue.Do(context.Background(), key, thumb.PriorityLow, func() (string, error) {
+		return "", m.resampleFocus(thumbPath, areas)
+	})
+
+	return err
+}
+
+// resampleFocus recreates smart cropped default thumbnails, see ResampleFocus.
+func (m *MediaFile) resampleFocus(thumbPath string, areas focus.Areas) (err error) {
+	hash := m.Hash()
+
+	var originalImg image.Image
+	var sourceImg image.Image
+	var sourceName thumb.Name
+
+	for _, name := range thumb.CacheSizes() {
+		size := thumb.Sizes[name]
+
+		if size.Uncached() || !size.Smart() {
+			continue
+		}
+
+		fileName, err := thumb.FileNameFocus(hash, thumbPath, size.Width, size.Height, areas, size.Options...)
+
+		if err != nil {
+			log.Errorf("media: failed creating %s (%s)", sanitize.Log(string(name)), err)
+			return err
+		}
+
+		if originalImg == nil {
+			if originalImg, err = thumb.Open(m.FileName(), m.Orientation()); err != nil {
+				log.Debugf("media: %s in %s", err.Error(), sanitize.Log(m.BaseName()))
+				return err
+			}
+		}
+
+		if size.Source != "" && size.Source == sourceName && sourceImg != nil {
+			_, err = thumb.CreateFocus(sourceImg, fileName, size.Width, size.Height, areas, size.Options...)
+		} else if size.Source != "" {
+			_, err = thumb.CreateFocus(originalImg, fileName, size.Width, size.Height, areas, size.Options...)
+		} else {
+			sourceImg, err = thumb.CreateFocus(originalImg, fileName, size.Width, size.Height, areas, size.Options...)
+			sourceName = name
+		}
+
+		if err != nil {
+			log.Errorf("media: failed creating %s (%s)", sanitize.Log(string(name)), err)
+			return err
+		}
+
+		// Remove thumbnails cropped with other focus areas, as well as those in additional file formats,
+		// so that they are rendered again with the new crop.
+		defaultName, err := thumb.FileName(hash, thumbPath, size.Width, size.Height, size.Options...)
+
+		if err != nil {
+			continue
+		}
+
+		matches, err := filepath.Glob(strings.TrimSuffix(defaultName, filepath.Ext(defaultName)) + "*")
+
+		if err != nil {
+			continue
+		}
+
+		for _, staleName := range matches {
+			if staleName == fileName {
+				continue
+			} else if err = os.Remove(staleName); err != nil {
+				log.Warnf("media: %s while removing %s", err, sanitize.Log(filepath.Base(staleName)))
+			}
+		}
+	}
+
+	return nil
+}
+
 // RenameSidecars moves related sidecar files.
 func (m *MediaFile) RenameSidecars(oldFileName string) (renamed map[string]string, err error) {
 	renamed = make(map[string]string)
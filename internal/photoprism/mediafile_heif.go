@@ -0,0 +1,47 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/heif"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Heif returns the parsed HEIF container of the file.
+func (m *MediaFile) Heif() (*heif.File, error) {
+	if !m.IsHEIF() {
+		return nil, fmt.Errorf("%s is not a heif file", sanitize.Log(m.BaseName()))
+	}
+
+	return heif.Open(m.FileName())
+}
+
+// ContainerImages returns the main images stored in a HEIF file, primary image first,
+// e.g. the frames of a burst.
+func (m *MediaFile) ContainerImages() ([]*heif.Item, error) {
+	f, err := m.Heif()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Images(), nil
+}
+
+// AuxiliaryImages returns the auxiliary images of the primary HEIF image,
+// like depth maps, alpha planes, or HDR gain maps.
+func (m *MediaFile) AuxiliaryImages() ([]*heif.Item, error) {
+	f, err := m.Heif()
+
+	if err != nil {
+		return nil, err
+	}
+
+	primary, err := f.Primary()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Auxiliary(primary), nil
+}
@@ -0,0 +1,95 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/heif"
+)
+
+func TestMediaFile_Heif(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("iphone_7.heic", func(t *testing.T) {
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/iphone_7.heic")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := mf.Heif()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		primary, err := f.Primary()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, heif.TypeGrid, primary.Type)
+	})
+	t.Run("elephants.jpg", func(t *testing.T) {
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mf.Heif()
+		assert.Error(t, err)
+	})
+}
+
+func TestMediaFile_ContainerImages(t *testing.T) {
+	conf := config.TestConfig()
+
+	mf, err := NewMediaFile(conf.ExamplesPath() + "/iphone_7.heic")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	images, err := mf.ContainerImages()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, images, 1) {
+		width, height := images[0].DisplaySize()
+		assert.Equal(t, 4032, width)
+		assert.Equal(t, 3024, height)
+	}
+}
+
+func TestMediaFile_AuxiliaryImages(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("iphone_7.heic", func(t *testing.T) {
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/iphone_7.heic")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		aux, err := mf.AuxiliaryImages()
+
+		assert.NoError(t, err)
+		assert.Empty(t, aux)
+	})
+	t.Run("elephants.jpg", func(t *testing.T) {
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mf.AuxiliaryImages()
+		assert.Error(t, err)
+	})
+}
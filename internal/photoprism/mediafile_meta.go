@@ -100,6 +100,16 @@ func (m *MediaFile) MetaData() (result meta.Data) {
 			}
 		}
 
+		// Parse embedded video metadata natively to add values that are still missing,
+		// e.g. if ExifTool is disabled or not installed.
+		if m.IsVideo() {
+			if videoErr := m.metaData.Video(m.FileName()); videoErr != nil {
+				log.Debug(videoErr)
+			} else {
+				err = nil
+			}
+		}
+
 		if err != nil {
 			m.metaData.Error = err
 			log.Debugf("metadata: %s in %s", err, sanitize.Log(m.BaseName()))
@@ -198,6 +198,26 @@ func TestMediaFile_Exif_JPEG(t *testing.T) {
 		assert.IsType(t, meta.Data{}, info)
 	})
 
+	t.Run("example.mp4", func(t *testing.T) {
+		img, err := NewMediaFile(conf.ExamplesPath() + "/example.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := img.MetaData()
+
+		assert.IsType(t, meta.Data{}, data)
+
+		assert.Equal(t, "2020-05-11T14:18:35Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "2020-05-11T14:18:35Z", data.TakenAtLocal.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "UTC", data.TimeZone)
+		assert.Equal(t, meta.CodecAvc1, data.Codec)
+		assert.Equal(t, 270, data.Width)
+		assert.Equal(t, 480, data.Height)
+		assert.Equal(t, "2.411s", data.Duration.String())
+	})
+
 	t.Run("panorama360.jpg", func(t *testing.T) {
 		img, err := NewMediaFile("testdata/panorama360.jpg")
 
@@ -9,6 +9,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/focus"
 	"github.com/photoprism/photoprism/pkg/fs"
 
 	"github.com/stretchr/testify/assert"
@@ -1886,6 +1887,119 @@ func TestMediaFile_RenderDefaultThumbs(t *testing.T) {
 	assert.Empty(t, err)
 }
 
+func TestMediaFile_RenderDefaultThumbsWebP(t *testing.T) {
+	conf := config.TestConfig()
+
+	thumbsPath := conf.CachePath() + "/_tmp_webp"
+
+	defer os.RemoveAll(thumbsPath)
+
+	// Use a fake encoder that copies the temporary PNG file.
+	encoder := filepath.Join(t.TempDir(), "cwebp")
+
+	if err := os.WriteFile(encoder, []byte("#!/bin/sh\ncp \"$4\" \"$6\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	thumb.WebPBin = encoder
+
+	defer func() {
+		thumb.WebPBin = ""
+	}()
+
+	m, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.ResampleDefault(thumbsPath, false, fs.FormatWebP, fs.FormatAvif); err != nil {
+		t.Fatal(err)
+	}
+
+	tile500 := thumb.Sizes[thumb.Tile500]
+
+	if fileName, err := thumb.FileName(m.Hash(), thumbsPath, tile500.Width, tile500.Height, tile500.FormatOptions(fs.FormatWebP)...); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.FileExists(t, fileName)
+	}
+
+	if fileName, err := thumb.FileName(m.Hash(), thumbsPath, tile500.Width, tile500.Height, tile500.FormatOptions(fs.FormatAvif)...); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.NoFileExists(t, fileName)
+	}
+
+	left224 := thumb.Sizes[thumb.Left224]
+
+	if fileName, err := thumb.FileName(m.Hash(), thumbsPath, left224.Width, left224.Height, left224.FormatOptions(fs.FormatWebP)...); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.NoFileExists(t, fileName)
+	}
+}
+
+func TestMediaFile_ResampleFocus(t *testing.T) {
+	conf := config.TestConfig()
+
+	thumbsPath := conf.CachePath() + "/_tmp_focus"
+
+	defer os.RemoveAll(thumbsPath)
+
+	thumb.Crop = thumb.ResampleFillSmart
+
+	defer func() {
+		thumb.Crop = thumb.ResampleFillCenter
+	}()
+
+	m, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tile500 := thumb.Sizes[thumb.Tile500]
+
+	if err = m.ResampleDefault(thumbsPath, false); err != nil {
+		t.Fatal(err)
+	}
+
+	defaultName, err := thumb.FileName(m.Hash(), thumbsPath, tile500.Width, tile500.Height, tile500.Options...)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, strings.HasSuffix(defaultName, "_500x500_smart.jpg"))
+	assert.FileExists(t, defaultName)
+
+	areas := focus.Areas{{X: 0.05, Y: 0.2, W: 0.1, H: 0.1}}
+	fileName, err := thumb.FileNameFocus(m.Hash(), thumbsPath, tile500.Width, tile500.Height, areas, tile500.Options...)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, strings.HasSuffix(fileName, "_500x500_smart_"+areas.Hash()+".jpg"))
+
+	if err = m.ResampleFocus(thumbsPath, areas); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.FileExists(t, fileName)
+	assert.NoFileExists(t, defaultName)
+
+	fit720 := thumb.Sizes[thumb.Fit720]
+
+	// Thumbnails that aren't smart cropped remain unchanged.
+	if fileName, err = thumb.FileName(m.Hash(), thumbsPath, fit720.Width, fit720.Height, fit720.Options...); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.FileExists(t, fileName)
+	}
+}
+
 func TestMediaFile_FileType(t *testing.T) {
 	m, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "this-is-a-jpeg.png"))
 
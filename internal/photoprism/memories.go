@@ -0,0 +1,82 @@
+package photoprism
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+const (
+	MemoriesAlbumTitle = "On This Day"
+	MemoriesAlbumSlug  = "on-this-day"
+	MemoriesAlbumCount = 100
+)
+
+// Memories represents a worker that updates a rolling album with photos taken on the same day in earlier years.
+type Memories struct {
+	conf *config.Config
+}
+
+// NewMemories returns a new Memories worker.
+func NewMemories(conf *config.Config) *Memories {
+	instance := &Memories{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start updates the memories album unless it has already been updated for the given date,
+// or was deleted by the user.
+func (w *Memories) Start(date time.Time) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s (panic)\nstack: %s", r, debug.Stack())
+			log.Errorf("memories: %s", err)
+		}
+	}()
+
+	a := entity.FindAlbumBySlug(MemoriesAlbumSlug, entity.AlbumMoment)
+
+	if a == nil {
+		if a = entity.NewMemoriesAlbum(MemoriesAlbumTitle, MemoriesAlbumSlug); a == nil {
+			return fmt.Errorf("memories: failed creating album")
+		} else if err := a.Create(); err != nil {
+			return fmt.Errorf("memories: %s (create album)", err)
+		}
+	} else if a.Deleted() {
+		log.Debugf("memories: album %s was deleted", a.AlbumUID)
+		return nil
+	} else if a.AlbumYear == date.Year() && a.AlbumMonth == int(date.Month()) && a.AlbumDay == date.Day() {
+		log.Debugf("memories: album %s is up to date", a.AlbumUID)
+		return nil
+	}
+
+	photos, err := search.Memories(form.SearchMemories{Date: date, Count: MemoriesAlbumCount})
+
+	if err != nil {
+		return fmt.Errorf("memories: %s (search)", err)
+	}
+
+	added, err := a.ReplacePhotos(photos.UIDs())
+
+	if err != nil {
+		return fmt.Errorf("memories: %s (replace photos)", err)
+	}
+
+	// Save the date only after the photos have been replaced, so that the album is updated again if this fails.
+	if err := a.Updates(entity.Values{"AlbumYear": date.Year(), "AlbumMonth": int(date.Month()), "AlbumDay": date.Day()}); err != nil {
+		return fmt.Errorf("memories: %s (update album)", err)
+	}
+
+	log.Infof("memories: added %s to %s", english.Plural(len(added), "photo", "photos"), a.AlbumTitle)
+
+	return nil
+}
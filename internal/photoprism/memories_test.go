@@ -0,0 +1,65 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+func TestMemories_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	m := NewMemories(conf)
+	date := time.Date(2021, 11, 11, 10, 0, 0, 0, time.Local)
+
+	if err := m.Start(date); err != nil {
+		t.Fatal(err)
+	}
+
+	a := entity.FindAlbumBySlug(MemoriesAlbumSlug, entity.AlbumMoment)
+
+	if a == nil {
+		t.Fatal("album should not be nil")
+	}
+
+	assert.Equal(t, 2021, a.AlbumYear)
+	assert.Equal(t, 11, a.AlbumMonth)
+	assert.Equal(t, 11, a.AlbumDay)
+
+	photos, err := search.AlbumPhotos(*a, 100, false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(photos), 2)
+
+	// Already up to date.
+	if err := m.Start(date); err != nil {
+		t.Fatal(err)
+	}
+
+	// Next day.
+	if err := m.Start(date.AddDate(0, 0, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if a = entity.FindAlbumBySlug(MemoriesAlbumSlug, entity.AlbumMoment); a == nil {
+		t.Fatal("album should not be nil")
+	}
+
+	assert.Equal(t, 12, a.AlbumDay)
+
+	photos, err = search.AlbumPhotos(*a, 100, false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, photos)
+}
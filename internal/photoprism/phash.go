@@ -0,0 +1,28 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/phash"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// PerceptualHash returns the perceptual hash of the primary thumbnail to find similar images,
+// so that files in other formats are covered if they have a JPEG version.
+func (m *MediaFile) PerceptualHash(thumbPath string) (h phash.Hash, err error) {
+	jpegFile, err := m.Jpeg()
+
+	if err != nil {
+		return h, fmt.Errorf("%s has no jpeg", sanitize.Log(m.BaseName()))
+	}
+
+	img, err := jpegFile.Resample(thumbPath, thumb.Fit720)
+
+	if err != nil {
+		log.Debugf("phash: %s in %s (resample)", err, sanitize.Log(m.BaseName()))
+		return h, err
+	}
+
+	return phash.Difference(img), nil
+}
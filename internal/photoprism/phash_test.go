@@ -0,0 +1,110 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+func TestMediaFile_PerceptualHash(t *testing.T) {
+	conf := config.TestConfig()
+
+	thumbsPath := os.TempDir() + "/TestMediaFile_PerceptualHash"
+	defer os.RemoveAll(thumbsPath)
+
+	hash := func(fileName string) phash.Hash {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/" + fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h, err := mediaFile.PerceptualHash(thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return h
+	}
+
+	t.Run("Reencoded", func(t *testing.T) {
+		img, err := imaging.Open(conf.ExamplesPath() + "/cat_black.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.MkdirAll(thumbsPath, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		// Simulate a copy sent with a messenger app.
+		fileName := filepath.Join(thumbsPath, "cat_black_small.jpg")
+
+		if err := imaging.Save(imaging.Resize(img, 320, 0, imaging.Lanczos), fileName, imaging.JPEGQuality(50)); err != nil {
+			t.Fatal(err)
+		}
+
+		mediaFile, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h, err := mediaFile.PerceptualHash(thumbsPath)
+
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, hash("cat_black.jpg").Distance(h), phash.DefaultDistance)
+	})
+	t.Run("Different", func(t *testing.T) {
+		assert.Greater(t, hash("IMG_4120.JPG").Distance(hash("cat_black.jpg")), phash.DefaultDistance)
+	})
+	t.Run("Png", func(t *testing.T) {
+		img, err := imaging.Open(conf.ExamplesPath() + "/cat_black.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.MkdirAll(thumbsPath, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		// Other formats are hashed based on their JPEG version.
+		pngName := filepath.Join(thumbsPath, "cat_png.png")
+
+		if err := imaging.Save(img, pngName); err != nil {
+			t.Fatal(err)
+		} else if err := imaging.Save(img, filepath.Join(thumbsPath, "cat_png.jpg")); err != nil {
+			t.Fatal(err)
+		}
+
+		mediaFile, err := NewMediaFile(pngName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h, err := mediaFile.PerceptualHash(thumbsPath)
+
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, hash("cat_black.jpg").Distance(h), phash.DefaultDistance)
+	})
+	t.Run("NotJpeg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/blue-go-video.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mediaFile.PerceptualHash(thumbsPath)
+		assert.Error(t, err)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
@@ -112,6 +113,7 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 				}
 
 				w.files.Remove(file.FileName, file.FileRoot)
+				search.RemoveSimilarFile(file.FileUID)
 				purgedFiles[fileName] = true
 				log.Infof("purge: flagged file %s as missing", sanitize.Log(file.FileName))
 
@@ -228,6 +230,7 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 				// Remove files from lookup table.
 				for _, file := range files {
 					w.files.Remove(file.FileName, file.FileRoot)
+					search.RemoveSimilarFile(file.FileUID)
 				}
 			}
 		}
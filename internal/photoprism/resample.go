@@ -25,8 +25,8 @@ func NewResample(conf *config.Config) *Resample {
 	return &Resample{conf: conf}
 }
 
-// Start creates default thumbnails for all files in originalsPath.
-func (w *Resample) Start(force bool) (err error) {
+// Start creates default thumbnails for all files in originalsPath, optionally also in additional file formats.
+func (w *Resample) Start(force bool, formats ...fs.FileFormat) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("resample: %s (panic)\nstack: %s", r, debug.Stack())
@@ -110,6 +110,7 @@ func (w *Resample) Start(force bool) (err error) {
 				mediaFile: mf,
 				path:      thumbnailsPath,
 				force:     force,
+				formats:   formats,
 			}
 
 			return nil
@@ -1,9 +1,12 @@
 package photoprism
 
+import "github.com/photoprism/photoprism/pkg/fs"
+
 type ResampleJob struct {
 	mediaFile *MediaFile
 	path      string
 	force     bool
+	formats   []fs.FileFormat
 }
 
 func ResampleWorker(jobs <-chan ResampleJob) {
@@ -15,7 +18,7 @@ func ResampleWorker(jobs <-chan ResampleJob) {
 			continue
 		}
 
-		if err := mf.ResampleDefault(job.path, job.force); err != nil {
+		if err := mf.ResampleDefault(job.path, job.force, job.formats...); err != nil {
 			log.Errorf("resample: %s", err)
 		}
 	}
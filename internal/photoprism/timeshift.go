@@ -0,0 +1,255 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// TimeShift represents a worker that corrects the date of photos taken with a wrong camera clock.
+type TimeShift struct {
+	conf *config.Config
+}
+
+// TimeShiftPhoto represents a photo date before and after a time shift.
+type TimeShiftPhoto struct {
+	PhotoUID       string    `json:"UID"`
+	FileName       string    `json:"FileName"`
+	TakenAt        time.Time `json:"TakenAt"`
+	TakenAtLocal   time.Time `json:"TakenAtLocal"`
+	ShiftedAt      time.Time `json:"ShiftedAt"`
+	ShiftedAtLocal time.Time `json:"ShiftedAtLocal"`
+}
+
+// TimeShiftResult represents the result of a time shift, or its preview.
+type TimeShiftResult struct {
+	ShiftUID string           `json:"ShiftUID"`
+	Offset   string           `json:"Offset"`
+	Preview  bool             `json:"Preview"`
+	Photos   []TimeShiftPhoto `json:"Photos"`
+}
+
+// NewTimeShift returns a new TimeShift worker.
+func NewTimeShift(conf *config.Config) *TimeShift {
+	instance := &TimeShift{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start shifts the date of all photos in scope by the offset, or only returns a preview.
+func (w *TimeShift) Start(f form.TimeShift) (result TimeShiftResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("timeshift: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	result = TimeShiftResult{Preview: f.Preview, Photos: []TimeShiftPhoto{}}
+
+	offset, err := entity.ParseTimeOffset(f.Offset)
+
+	if err != nil {
+		return result, err
+	} else if offset.IsZero() {
+		return result, errors.New("time offset must not be zero")
+	}
+
+	result.Offset = offset.String()
+
+	if err = w.shift(f, offset, &result); err != nil {
+		return result, err
+	}
+
+	if !result.Preview && len(result.Photos) > 0 {
+		w.updateAlbums()
+	}
+
+	return result, nil
+}
+
+// shift applies the offset to all photos in scope.
+func (w *TimeShift) shift(f form.TimeShift, offset entity.TimeOffset, result *TimeShiftResult) error {
+	if err := mutex.MainWorker.Start(); err != nil {
+		log.Warnf("timeshift: %s (start)", err.Error())
+		return err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	start := time.Now()
+
+	photos, err := query.TimeShiftPhotos(f)
+
+	if err != nil {
+		return err
+	}
+
+	if !result.Preview {
+		result.ShiftUID = entity.NewTimeShiftUID()
+		log.Infof("timeshift: shifting %s by %s (%s)", english.Plural(len(photos), "photo", "photos"), result.Offset, sanitize.Log(f.String()))
+	}
+
+	for _, p := range photos {
+		if mutex.MainWorker.Canceled() {
+			return errors.New("timeshift canceled")
+		}
+
+		record := entity.NewTimeShift(result.ShiftUID, p, offset)
+
+		r := TimeShiftPhoto{
+			PhotoUID:     p.PhotoUID,
+			FileName:     filepath.Join(p.PhotoPath, p.PhotoName),
+			TakenAt:      p.TakenAt,
+			TakenAtLocal: p.TakenAtLocal,
+		}
+
+		p.ShiftTime(offset)
+
+		r.ShiftedAt = p.TakenAt
+		r.ShiftedAtLocal = p.TakenAtLocal
+
+		if result.Preview {
+			result.Photos = append(result.Photos, r)
+			continue
+		}
+
+		if err := record.Create(); err != nil {
+			log.Errorf("timeshift: %s while saving undo information for %s", err, p.PhotoUID)
+			continue
+		} else if err := p.SaveTakenAt(); err != nil {
+			log.Errorf("timeshift: %s while updating %s", err, p.PhotoUID)
+			logWarn("timeshift", record.Delete())
+			continue
+		}
+
+		if f.Sidecar || w.conf.BackupYaml() {
+			w.saveSidecar(p)
+		}
+
+		result.Photos = append(result.Photos, r)
+	}
+
+	if result.Preview {
+		log.Infof("timeshift: %s would be shifted by %s [%s]", english.Plural(len(result.Photos), "photo", "photos"), result.Offset, time.Since(start))
+	} else {
+		log.Infof("timeshift: shifted %s by %s [%s]", english.Plural(len(result.Photos), "photo", "photos"), result.Offset, time.Since(start))
+	}
+
+	return nil
+}
+
+// Undo restores the photo dates before a time shift. Photos whose date has changed since are skipped.
+func (w *TimeShift) Undo(shiftUID string, sidecar bool) (result TimeShiftResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("timeshift: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	result = TimeShiftResult{ShiftUID: shiftUID, Photos: []TimeShiftPhoto{}}
+
+	if err = w.undo(shiftUID, sidecar, &result); err != nil {
+		return result, err
+	}
+
+	if len(result.Photos) > 0 {
+		w.updateAlbums()
+	}
+
+	return result, nil
+}
+
+// undo restores the photo dates recorded for a time shift.
+func (w *TimeShift) undo(shiftUID string, sidecar bool, result *TimeShiftResult) error {
+	if err := mutex.MainWorker.Start(); err != nil {
+		log.Warnf("timeshift: %s (start)", err.Error())
+		return err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	records, err := query.TimeShiftsByUID(shiftUID)
+
+	if err != nil {
+		return err
+	} else if len(records) == 0 {
+		return fmt.Errorf("time shift %s not found", sanitize.Log(shiftUID))
+	}
+
+	result.Offset = records[0].ShiftOffset
+
+	for _, record := range records {
+		p, err := query.PhotoByUID(record.PhotoUID)
+
+		if err != nil {
+			log.Warnf("timeshift: %s while restoring %s", err, record.PhotoUID)
+			continue
+		}
+
+		r := TimeShiftPhoto{
+			PhotoUID:       p.PhotoUID,
+			FileName:       filepath.Join(p.PhotoPath, p.PhotoName),
+			TakenAt:        record.TakenAt,
+			TakenAtLocal:   record.TakenAtLocal,
+			ShiftedAt:      p.TakenAt,
+			ShiftedAtLocal: p.TakenAtLocal,
+		}
+
+		if !record.Restore(&p) {
+			log.Warnf("timeshift: date of %s has changed since shift %s, skipped", p.PhotoUID, sanitize.Log(shiftUID))
+			continue
+		} else if err := p.SaveTakenAt(); err != nil {
+			log.Errorf("timeshift: %s while restoring %s", err, p.PhotoUID)
+			continue
+		}
+
+		logWarn("timeshift", record.Delete())
+
+		if sidecar || w.conf.BackupYaml() {
+			w.saveSidecar(p)
+		}
+
+		result.Photos = append(result.Photos, r)
+	}
+
+	log.Infof("timeshift: restored %s", english.Plural(len(result.Photos), "photo", "photos"))
+
+	return nil
+}
+
+// saveSidecar updates the YAML sidecar file of a photo.
+func (w *TimeShift) saveSidecar(p entity.Photo) {
+	fileName := p.YamlFileName(w.conf.OriginalsPath(), w.conf.SidecarPath())
+
+	if err := p.SaveAsYaml(fileName); err != nil {
+		log.Errorf("timeshift: %s while updating %s", err, sanitize.Log(filepath.Base(fileName)))
+	} else {
+		log.Debugf("timeshift: updated %s", sanitize.Log(filepath.Base(fileName)))
+	}
+}
+
+// updateAlbums updates moments and album covers after photo dates have changed.
+func (w *TimeShift) updateAlbums() {
+	if err := NewMoments(w.conf).Start(); err != nil {
+		log.Warnf("timeshift: %s (moments)", err)
+	}
+
+	if err := query.UpdateCovers(); err != nil {
+		log.Warnf("timeshift: %s (update covers)", err)
+	}
+}
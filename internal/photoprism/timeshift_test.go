@@ -0,0 +1,92 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestTimeShift_Start(t *testing.T) {
+	w := NewTimeShift(config.TestConfig())
+
+	t.Run("Preview", func(t *testing.T) {
+		result, err := w.Start(form.TimeShift{Offset: "-1y", Serial: "123", Preview: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, result.Preview)
+		assert.Equal(t, "", result.ShiftUID)
+		assert.Equal(t, "-1y", result.Offset)
+		assert.Len(t, result.Photos, 1)
+		assert.Equal(t, time.Date(2015, 11, 1, 0, 0, 0, 0, time.UTC), result.Photos[0].TakenAt)
+		assert.Equal(t, time.Date(2014, 11, 1, 0, 0, 0, 0, time.UTC), result.Photos[0].ShiftedAt)
+
+		p, err := query.PhotoByUID("pt9jtdre2lvl0y12")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2015, p.PhotoYear)
+	})
+	t.Run("ShiftAndUndo", func(t *testing.T) {
+		result, err := w.Start(form.TimeShift{Offset: "+1y2h", Serial: "123"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, result.ShiftUID)
+		assert.Len(t, result.Photos, 1)
+
+		p, err := query.PhotoByUID("pt9jtdre2lvl0y12")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, time.Date(2016, 11, 1, 2, 0, 0, 0, time.UTC), p.TakenAt)
+		assert.Equal(t, entity.SrcManual, p.TakenSrc)
+		assert.Equal(t, 2016, p.PhotoYear)
+		assert.Equal(t, 11, p.PhotoMonth)
+
+		undone, err := w.Undo(result.ShiftUID, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, undone.Photos, 1)
+
+		p, err = query.PhotoByUID("pt9jtdre2lvl0y12")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, time.Date(2015, 11, 1, 0, 0, 0, 0, time.UTC), p.TakenAt)
+		assert.Equal(t, 2015, p.PhotoYear)
+
+		_, err = w.Undo(result.ShiftUID, false)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidOffset", func(t *testing.T) {
+		_, err := w.Start(form.TimeShift{Offset: "foo", Serial: "123"})
+		assert.Error(t, err)
+
+		_, err = w.Start(form.TimeShift{Offset: "0", Serial: "123"})
+		assert.Error(t, err)
+	})
+	t.Run("NoScope", func(t *testing.T) {
+		_, err := w.Start(form.TimeShift{Offset: "+1h"})
+		assert.Error(t, err)
+	})
+}
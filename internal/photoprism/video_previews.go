@@ -0,0 +1,206 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"runtime/debug"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// VideoPreviews represents a worker that creates animated video previews and scrubbing sprites.
+type VideoPreviews struct {
+	conf    *config.Config
+	convert *Convert
+}
+
+// NewVideoPreviews returns a new video preview worker.
+func NewVideoPreviews(conf *config.Config) *VideoPreviews {
+	return &VideoPreviews{conf: conf, convert: NewConvert(conf)}
+}
+
+// VideoPreviewName returns the thumbnail cache file name of a video preview, see video.PreviewNames.
+func VideoPreviewName(thumbPath, fileHash, name string) (string, error) {
+	if len(fileHash) < 4 {
+		return "", fmt.Errorf("video: file hash is empty or too short (%s)", sanitize.Log(fileHash))
+	}
+
+	p := path.Join(thumbPath, fileHash[0:1], fileHash[1:2], fileHash[2:3])
+
+	if err := os.MkdirAll(p, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	return path.Join(p, fmt.Sprintf("%s_%s", fileHash, name)), nil
+}
+
+// VideoPreviewExpected tests if the named preview should exist once previews were created for the
+// video file. Sprites and WebVTT tracks are skipped if the duration is unknown.
+func VideoPreviewExpected(f entity.File, name string) bool {
+	switch name {
+	case video.PreviewSprite, video.PreviewVtt:
+		return !video.NewSprite(f.FileDuration, f.FileWidth, f.FileHeight).Empty()
+	default:
+		return true
+	}
+}
+
+// Start creates missing video previews, or all previews if force is true.
+func (w *VideoPreviews) Start(force bool) (created int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("video: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if w.conf.DisableVideoPreviews() {
+		return 0, fmt.Errorf("video: previews are disabled")
+	}
+
+	if err = mutex.VideoWorker.Start(); err != nil {
+		return 0, err
+	}
+
+	defer mutex.VideoWorker.Stop()
+
+	limit := 100
+	offset := 0
+
+	var afterID uint
+	var files entity.Files
+
+	for {
+		// Recreate all previews if forced, otherwise skip videos with previews and failed attempts.
+		if force {
+			files, err = query.Videos(limit, offset)
+		} else {
+			files, err = query.VideosWithoutPreviews(limit, afterID)
+		}
+
+		if err != nil {
+			return created, err
+		} else if len(files) == 0 {
+			break
+		}
+
+		for _, f := range files {
+			if mutex.VideoWorker.Canceled() {
+				return created, fmt.Errorf("video: worker canceled")
+			}
+
+			afterID = f.ID
+
+			if !force && !f.PreviewsPending() {
+				continue
+			}
+
+			n, err := w.Create(f, force)
+
+			if err != nil {
+				log.Warnf("video: %s in %s (create previews)", err, sanitize.Log(f.FileName))
+			} else {
+				created += n
+			}
+
+			// Remember failures, so that they are only retried a few times with backoff.
+			if err := f.PreviewsCreated(err); err != nil {
+				log.Errorf("video: %s in %s (update previews)", err, sanitize.Log(f.FileName))
+			}
+		}
+
+		offset += limit
+	}
+
+	if created > 0 {
+		log.Infof("video: created %s", english.Plural(created, "preview file", "preview files"))
+	}
+
+	return created, nil
+}
+
+// Create renders the animated preview clip, sprite sheet, and WebVTT track of a video file
+// and returns the number of files created.
+func (w *VideoPreviews) Create(f entity.File, force bool) (created int, err error) {
+	thumbPath := w.conf.ThumbPath()
+
+	clipName, err := VideoPreviewName(thumbPath, f.FileHash, video.PreviewClip)
+
+	if err != nil {
+		return 0, err
+	}
+
+	spriteName, _ := VideoPreviewName(thumbPath, f.FileHash, video.PreviewSprite)
+	vttName, _ := VideoPreviewName(thumbPath, f.FileHash, video.PreviewVtt)
+
+	if !force && fs.FileExists(clipName) && fs.FileExists(spriteName) && fs.FileExists(vttName) {
+		return 0, nil
+	}
+
+	mf, err := NewMediaFile(FileName(f.FileRoot, f.FileName))
+
+	if err != nil {
+		return 0, err
+	}
+
+	duration := f.FileDuration
+
+	if duration <= 0 {
+		duration = mf.MetaData().Duration
+	}
+
+	start := time.Now()
+
+	if force || !fs.FileExists(clipName) {
+		if err = runCommand(w.convert.VideoClipCommand(mf, clipName, video.ClipOffset(duration))); err != nil {
+			_ = os.Remove(clipName)
+			return created, err
+		}
+
+		created++
+	}
+
+	sprite := video.NewSprite(duration, f.FileWidth, f.FileHeight)
+
+	if sprite.Empty() {
+		log.Debugf("video: unknown duration, no sprite created for %s", sanitize.Log(f.FileName))
+		return created, nil
+	}
+
+	if force || !fs.FileExists(spriteName) {
+		if err = runCommand(w.convert.VideoSpriteCommand(mf, spriteName, sprite)); err != nil {
+			_ = os.Remove(spriteName)
+			return created, err
+		}
+
+		created++
+	}
+
+	if force || !fs.FileExists(vttName) {
+		// The sprite image URI is relative to the WebVTT track.
+		if err = os.WriteFile(vttName, []byte(sprite.Vtt(video.PreviewSprite)), os.ModePerm); err != nil {
+			return created, err
+		}
+
+		created++
+	}
+
+	log.Debugf("video: created previews for %s [%s]", sanitize.Log(f.FileName), time.Since(start))
+
+	return created, nil
+}
+
+// Cancel stops the current operation.
+func (w *VideoPreviews) Cancel() {
+	mutex.VideoWorker.Cancel()
+}
@@ -0,0 +1,97 @@
+package photoprism
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/video"
+)
+
+func TestVideoPreviewName(t *testing.T) {
+	conf := config.TestConfig()
+
+	result, err := VideoPreviewName(conf.ThumbPath(), "01244519acf35c62a5fea7a5a7dcefdbec4fb2f5", video.PreviewVtt)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, filepath.Join(conf.ThumbPath(), "0", "1", "2", "01244519acf35c62a5fea7a5a7dcefdbec4fb2f5_sprite.vtt"), result)
+
+	_, err = VideoPreviewName(conf.ThumbPath(), "012", video.PreviewVtt)
+	assert.Error(t, err)
+}
+
+func TestVideoPreviewExpected(t *testing.T) {
+	t.Run("Duration", func(t *testing.T) {
+		f := entity.File{FileDuration: 20 * time.Second, FileWidth: 1920, FileHeight: 1080}
+
+		assert.True(t, VideoPreviewExpected(f, video.PreviewClip))
+		assert.True(t, VideoPreviewExpected(f, video.PreviewSprite))
+		assert.True(t, VideoPreviewExpected(f, video.PreviewVtt))
+	})
+	t.Run("UnknownDuration", func(t *testing.T) {
+		f := entity.File{FileWidth: 1920, FileHeight: 1080}
+
+		assert.True(t, VideoPreviewExpected(f, video.PreviewClip))
+		assert.False(t, VideoPreviewExpected(f, video.PreviewSprite))
+		assert.False(t, VideoPreviewExpected(f, video.PreviewVtt))
+	})
+}
+
+func TestVideoPreviews_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	if !conf.DisableVideoPreviews() {
+		t.Skip("ffmpeg is installed")
+	}
+
+	_, err := NewVideoPreviews(conf).Start(false)
+	assert.Error(t, err)
+}
+
+func TestVideoPreviews_Create(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("InvalidHash", func(t *testing.T) {
+		_, err := NewVideoPreviews(conf).Create(entity.File{FileHash: "123", FileName: "foo.mp4"}, false)
+		assert.Error(t, err)
+	})
+	t.Run("Missing", func(t *testing.T) {
+		_, err := NewVideoPreviews(conf).Create(entity.File{FileHash: "01244519acf35c62a5fea7a5a7dcefdbec4fb2f6", FileName: "missing.mp4"}, false)
+		assert.Error(t, err)
+	})
+}
+
+func TestConvert_VideoPreviewCommands(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+	mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Poster", func(t *testing.T) {
+		cmd := convert.VideoPosterCommand(mf, "poster.jpg", 0)
+		assert.Contains(t, cmd.Args, "0.000")
+		assert.Equal(t, "poster.jpg", cmd.Args[len(cmd.Args)-1])
+	})
+	t.Run("Clip", func(t *testing.T) {
+		cmd := convert.VideoClipCommand(mf, "clip.mp4", video.ClipOffset(mf.MetaData().Duration))
+		assert.Contains(t, cmd.Args, "-an")
+		assert.Contains(t, cmd.Args, "3.000")
+		assert.Equal(t, "clip.mp4", cmd.Args[len(cmd.Args)-1])
+	})
+	t.Run("Sprite", func(t *testing.T) {
+		sprite := video.NewSprite(mf.MetaData().Duration, mf.Width(), mf.Height())
+		cmd := convert.VideoSpriteCommand(mf, "sprite.jpg", sprite)
+		assert.Contains(t, cmd.Args, sprite.Filter())
+		assert.Equal(t, "sprite.jpg", cmd.Args[len(cmd.Args)-1])
+	})
+}
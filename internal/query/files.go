@@ -74,6 +74,29 @@ func VideoByPhotoUID(u string) (file entity.File, err error) {
 	return file, nil
 }
 
+// Videos returns indexed video files that are not missing or broken, sorted by id.
+func Videos(limit, offset int) (files entity.Files, err error) {
+	err = Db().
+		Where("file_video = 1 AND file_missing = 0 AND file_error = ''").
+		Order("id").Limit(limit).Offset(offset).
+		Find(&files).Error
+
+	return files, err
+}
+
+// VideosWithoutPreviews returns video files with an ID greater than afterID whose previews have not
+// been created yet, or failed fewer than entity.PreviewRetries times.
+func VideosWithoutPreviews(limit int, afterID uint) (files entity.Files, err error) {
+	err = Db().
+		Where("file_video = 1 AND file_missing = 0 AND file_error = ''").
+		Where("id > ?", afterID).
+		Where("previews_at IS NULL OR (preview_errors > 0 AND preview_errors < ?)", entity.PreviewRetries).
+		Order("id").Limit(limit).
+		Find(&files).Error
+
+	return files, err
+}
+
 // FileByUID finds a file entity for the given UID.
 func FileByUID(uid string) (file entity.File, err error) {
 	if err := Db().Where("file_uid = ?", uid).Preload("Photo").First(&file).Error; err != nil {
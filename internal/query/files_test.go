@@ -129,6 +129,49 @@ func TestVideoByPhotoUID(t *testing.T) {
 	})
 }
 
+func TestVideos(t *testing.T) {
+	files, err := Videos(1000, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, files)
+
+	for _, f := range files {
+		assert.True(t, f.FileVideo)
+		assert.False(t, f.FileMissing)
+		assert.Empty(t, f.FileError)
+	}
+}
+
+func TestVideosWithoutPreviews(t *testing.T) {
+	files, err := VideosWithoutPreviews(1000, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, files)
+
+	var afterID uint
+
+	for _, f := range files {
+		assert.True(t, f.FileVideo)
+		assert.True(t, f.PreviewsPending())
+		assert.Greater(t, f.ID, afterID)
+		afterID = f.ID
+	}
+
+	files, err = VideosWithoutPreviews(1000, afterID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, files)
+}
+
 func TestFileByUID(t *testing.T) {
 	t.Run("files found", func(t *testing.T) {
 		file, err := FileByUID("ft8es39w45bnlqdw")
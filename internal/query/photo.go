@@ -104,6 +104,23 @@ func PhotosMetadataUpdate(limit, offset int, delay, interval time.Duration) (ent
 	return entities, err
 }
 
+// PhotosWithoutLocation returns photos taken in the given time range that have no or only an estimated location.
+func PhotosWithoutLocation(from, to time.Time) (entities entity.Photos, err error) {
+	err = Db().
+		Preload("Camera").
+		Preload("Lens").
+		Preload("Details").
+		Preload("Place").
+		Preload("Cell").
+		Preload("Cell.Place").
+		Where("(photo_lat = 0 AND photo_lng = 0) OR place_src = ?", entity.SrcEstimate).
+		Where("taken_at BETWEEN ? AND ?", from.UTC(), to.UTC()).
+		Where("photo_quality > -1").
+		Order("taken_at, photos.id").Find(&entities).Error
+
+	return entities, err
+}
+
 // OrphanPhotos finds orphan index entries that may be removed.
 func OrphanPhotos() (photos entity.Photos, err error) {
 	err = UnscopedDb().
@@ -78,6 +78,28 @@ func TestPhotosMetadataUpdate(t *testing.T) {
 	assert.IsType(t, entity.Photos{}, result)
 }
 
+func TestPhotosWithoutLocation(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		result, err := PhotosWithoutLocation(time.Date(2008, 7, 1, 9, 0, 0, 0, time.UTC), time.Date(2008, 7, 1, 11, 0, 0, 0, time.UTC))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", result[0].PhotoUID)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		result, err := PhotosWithoutLocation(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(1900, 1, 2, 0, 0, 0, 0, time.UTC))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+}
+
 func TestOrphanPhotos(t *testing.T) {
 	result, err := OrphanPhotos()
 
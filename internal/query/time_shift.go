@@ -0,0 +1,59 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// TimeShiftPhotos returns the photos matching the time shift scope, sorted by date.
+func TimeShiftPhotos(f form.TimeShift) (results entity.Photos, err error) {
+	if f.Empty() {
+		return results, errors.New("no photos selected")
+	}
+
+	s := UnscopedDb().Table(entity.Photo{}.TableName()).
+		Select("photos.*").
+		Where("photos.deleted_at IS NULL")
+
+	if f.Camera != "" {
+		if txt.IsUInt(f.Camera) {
+			s = s.Where("photos.camera_id = ?", txt.UInt(f.Camera))
+		} else {
+			s = s.Where("photos.camera_id IN (SELECT id FROM cameras WHERE camera_slug = ? OR camera_model = ?)", txt.Slug(f.Camera), f.Camera)
+		}
+	}
+
+	if f.Serial != "" {
+		s = s.Where("photos.camera_serial = ?", f.Serial)
+	}
+
+	if f.Album != "" {
+		s = s.Where("photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid = ?)", f.Album)
+	}
+
+	if f.Folder != "" {
+		// Compare the path prefix literally, as folder names may contain LIKE wildcards.
+		p := strings.Trim(f.Folder, "/")
+		s = s.Where("photos.photo_path = ? OR SUBSTR(photos.photo_path, 1, ?) = ?", p, utf8.RuneCountInString(p)+1, p+"/")
+	}
+
+	if len(f.Photos) > 0 {
+		s = s.Where("photos.photo_uid IN (?)", f.Photos)
+	}
+
+	err = s.Order("photos.taken_at, photos.id").Scan(&results).Error
+
+	return results, err
+}
+
+// TimeShiftsByUID returns the photo dates recorded before a time shift.
+func TimeShiftsByUID(shiftUID string) (results entity.TimeShifts, err error) {
+	err = Db().Where("shift_uid = ?", shiftUID).Order("photo_uid").Find(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,87 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestTimeShiftPhotos(t *testing.T) {
+	t.Run("NoScope", func(t *testing.T) {
+		_, err := TimeShiftPhotos(form.TimeShift{Offset: "+1h"})
+		assert.Error(t, err)
+	})
+	t.Run("Serial", func(t *testing.T) {
+		results, err := TimeShiftPhotos(form.TimeShift{Serial: "123"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, "pt9jtdre2lvl0y12", results[0].PhotoUID)
+	})
+	t.Run("Folder", func(t *testing.T) {
+		results, err := TimeShiftPhotos(form.TimeShift{Folder: "/2016/"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 4)
+
+		for _, p := range results {
+			assert.Contains(t, p.PhotoPath, "2016/")
+		}
+	})
+	t.Run("FolderWildcard", func(t *testing.T) {
+		results, err := TimeShiftPhotos(form.TimeShift{Folder: "201_"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 0)
+	})
+	t.Run("CameraAndAlbum", func(t *testing.T) {
+		results, err := TimeShiftPhotos(form.TimeShift{Camera: "Canon EOS 6D", Album: "at9lxuqxpogaaba8"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", results[0].PhotoUID)
+	})
+	t.Run("CameraID", func(t *testing.T) {
+		results, err := TimeShiftPhotos(form.TimeShift{Camera: "1000003", Photos: []string{"pt9jtdre2lvl0yh8"}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+	})
+}
+
+func TestTimeShiftsByUID(t *testing.T) {
+	uid := entity.NewTimeShiftUID()
+	p := entity.PhotoFixtures.Get("Photo01")
+
+	if err := entity.NewTimeShift(uid, p, entity.TimeOffset{Years: 1}).Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := TimeShiftsByUID(uid)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, "+1y", results[0].ShiftOffset)
+	assert.NoError(t, results[0].Delete())
+}
@@ -3,6 +3,8 @@ package search
 import (
 	"strings"
 
+	"github.com/jinzhu/gorm"
+
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -10,8 +12,16 @@ import (
 
 // Albums searches albums based on their name.
 func Albums(f form.SearchAlbums) (results AlbumResults, err error) {
+	results, _, err = AlbumsPaged(f)
+
+	return results, err
+}
+
+// AlbumsPaged searches albums like Albums and additionally returns a Page with the cursor
+// for the next page and, if the form sets Total, the number of matching albums.
+func AlbumsPaged(f form.SearchAlbums) (results AlbumResults, page Page, err error) {
 	if err := f.ParseQueryString(); err != nil {
-		return results, err
+		return results, page, err
 	}
 
 	// Base query.
@@ -23,11 +33,8 @@ func Albums(f form.SearchAlbums) (results AlbumResults, err error) {
 		Where("albums.deleted_at IS NULL")
 
 	// Limit result count.
-	if f.Count > 0 && f.Count <= MaxResults {
-		s = s.Limit(f.Count).Offset(f.Offset)
-	} else {
-		s = s.Limit(MaxResults).Offset(f.Offset)
-	}
+	limit := resultLimit(f.Count)
+	s = s.Limit(limit)
 
 	// Filter by storage path?
 	if f.Query != "" && f.Type == entity.AlbumFolder {
@@ -59,17 +66,13 @@ func Albums(f form.SearchAlbums) (results AlbumResults, err error) {
 	}
 
 	// Set sort order.
+	keys := albumsKeys(f.Order)
+
 	switch f.Order {
 	case entity.SortOrderCount:
 		s = s.Order("photo_count DESC, albums.album_title, albums.album_uid DESC")
 	case entity.SortOrderRelevance:
 		s = s.Order("albums.album_favorite DESC, albums.updated_at DESC, albums.album_uid DESC")
-	case entity.SortOrderNewest:
-		s = s.Order("albums.album_favorite DESC, albums.album_year DESC, albums.album_month DESC, albums.album_day DESC, albums.album_title, albums.album_uid DESC")
-	case entity.SortOrderOldest:
-		s = s.Order("albums.album_favorite DESC, albums.album_year ASC, albums.album_month ASC, albums.album_day ASC, albums.album_title, albums.album_uid ASC")
-	case entity.SortOrderAdded:
-		s = s.Order("albums.album_uid DESC")
 	case entity.SortOrderMoment:
 		s = s.Order("albums.album_favorite DESC, has_year, albums.album_year DESC, albums.album_month DESC, albums.album_title ASC, albums.album_uid DESC")
 	case entity.SortOrderPlace:
@@ -78,22 +81,74 @@ func Albums(f form.SearchAlbums) (results AlbumResults, err error) {
 		s = s.Order("albums.album_path, albums.album_uid DESC")
 	case entity.SortOrderCategory:
 		s = s.Order("albums.album_category, albums.album_title, albums.album_uid DESC")
-	case entity.SortOrderSlug:
-		s = s.Order("albums.album_favorite DESC, albums.album_slug ASC, albums.album_uid DESC")
-	case entity.SortOrderName:
-		s = s.Order("albums.album_favorite DESC, albums.album_title ASC, albums.album_uid DESC")
 	default:
-		s = s.Order("albums.album_favorite DESC, albums.album_title ASC, albums.album_uid DESC")
+		s = s.Order(keys.Order())
 	}
 
-	if f.UID != "" {
-		s = s.Where("albums.album_uid IN (?)", strings.Split(strings.ToLower(f.UID), txt.Or))
+	// Filter by album type, category, year and the other form fields.
+	s = albumsFilter(s, f)
+
+	page, err = scanPage(s, pageRequest{
+		Form:     &f,
+		Cursor:   f.Cursor,
+		Order:    f.Order,
+		Offset:   f.Offset,
+		Limit:    limit,
+		Total:    f.Total,
+		TotalCol: "albums.id",
+		Keys:     keys,
+	}, &results)
+
+	return results, page, err
+}
 
-		if result := s.Scan(&results); result.Error != nil {
-			return results, result.Error
+// albumsKeys returns the unique sort keys for keyset pagination, or nil if the order isn't supported.
+func albumsKeys(order string) sortKeys {
+	switch order {
+	case entity.SortOrderNewest:
+		return sortKeys{
+			{Col: "albums.album_favorite", Desc: true, Type: keyInt},
+			{Col: "albums.album_year", Desc: true, Type: keyInt},
+			{Col: "albums.album_month", Desc: true, Type: keyInt},
+			{Col: "albums.album_day", Desc: true, Type: keyInt},
+			{Col: "albums.album_title", Type: keyString},
+			{Col: "albums.album_uid", Desc: true, Type: keyString},
 		}
+	case entity.SortOrderOldest:
+		return sortKeys{
+			{Col: "albums.album_favorite", Desc: true, Type: keyInt},
+			{Col: "albums.album_year", Type: keyInt},
+			{Col: "albums.album_month", Type: keyInt},
+			{Col: "albums.album_day", Type: keyInt},
+			{Col: "albums.album_title", Type: keyString},
+			{Col: "albums.album_uid", Type: keyString},
+		}
+	case entity.SortOrderAdded:
+		return sortKeys{
+			{Col: "albums.album_uid", Desc: true, Type: keyString},
+		}
+	case entity.SortOrderSlug:
+		return sortKeys{
+			{Col: "albums.album_favorite", Desc: true, Type: keyInt},
+			{Col: "albums.album_slug", Type: keyString},
+			{Col: "albums.album_uid", Desc: true, Type: keyString},
+		}
+	case entity.SortOrderCount, entity.SortOrderRelevance, entity.SortOrderMoment,
+		entity.SortOrderPlace, entity.SortOrderPath, entity.SortOrderCategory:
+		return nil
+	default:
+		return sortKeys{
+			{Col: "albums.album_favorite", Desc: true, Type: keyInt},
+			{Col: "albums.album_title", Type: keyString},
+			{Col: "albums.album_uid", Desc: true, Type: keyString},
+		}
+	}
+}
 
-		return results, nil
+// albumsFilter applies the search form filters to an album query.
+func albumsFilter(s *gorm.DB, f form.SearchAlbums) *gorm.DB {
+	if f.UID != "" {
+		return s.Where("albums.album_uid IN (?)", strings.Split(strings.ToLower(f.UID), txt.Or))
 	}
 
 	if f.Type != "" {
@@ -128,9 +183,5 @@ func Albums(f form.SearchAlbums) (results AlbumResults, err error) {
 		s = s.Where("albums.album_day = ?", f.Day)
 	}
 
-	if result := s.Scan(&results); result.Error != nil {
-		return results, result.Error
-	}
-
-	return results, nil
+	return s
 }
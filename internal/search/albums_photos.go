@@ -1,10 +1,18 @@
 package search
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// ErrNestedSmartAlbum is returned if a smart album filter refers to another smart album.
+var ErrNestedSmartAlbum = errors.New("smart albums must not include other smart albums")
+
 // AlbumPhotos returns up to count photos from an album.
 func AlbumPhotos(a entity.Album, count int, shared bool) (results PhotoResults, err error) {
 	frm := form.SearchPhotos{
@@ -26,3 +34,50 @@ func AlbumPhotos(a entity.Album, count int, shared bool) (results PhotoResults,
 
 	return results, err
 }
+
+// SmartAlbum returns the smart album with the given UID, or nil if there is no such album.
+func SmartAlbum(albumUID string) *entity.Album {
+	if !rnd.IsPPID(albumUID, 'a') {
+		return nil
+	}
+
+	result := entity.Album{}
+
+	if err := Db().Where("album_uid = ? AND album_type = ?", albumUID, entity.AlbumSmart).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// SmartAlbumFilter returns the search form represented by a serialized smart album filter,
+// or an error if it is invalid.
+func SmartAlbumFilter(filter string) (f form.SearchPhotos, err error) {
+	if filter = strings.TrimSpace(filter); filter == "" {
+		return f, errors.New("smart album filter must not be empty")
+	}
+
+	if err = form.Unserialize(&f, filter); err != nil {
+		return f, err
+	}
+
+	if err = f.ParseQueryString(); err != nil {
+		return f, err
+	}
+
+	albums := strings.Split(f.Album, txt.Or)
+
+	for _, field := range form.QueryFields(f.Expression()) {
+		if field.Name == "album" {
+			albums = append(albums, strings.Split(field.Value, txt.Or)...)
+		}
+	}
+
+	for _, uid := range albums {
+		if SmartAlbum(uid) != nil {
+			return f, ErrNestedSmartAlbum
+		}
+	}
+
+	return f, nil
+}
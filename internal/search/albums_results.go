@@ -38,3 +38,33 @@ type Album struct {
 }
 
 type AlbumResults []Album
+
+// sortValues returns the album fields that match the sort keys, formatted for an album cursor.
+func (m Album) sortValues(keys sortKeys) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(keys))
+
+	for i, k := range keys {
+		switch k.Col {
+		case "albums.album_uid":
+			values[i] = m.AlbumUID
+		case "albums.album_title":
+			values[i] = m.AlbumTitle
+		case "albums.album_slug":
+			values[i] = m.AlbumSlug
+		case "albums.album_favorite":
+			values[i] = keyBoolValue(m.AlbumFavorite)
+		case "albums.album_year":
+			values[i] = keyIntValue(int64(m.AlbumYear))
+		case "albums.album_month":
+			values[i] = keyIntValue(int64(m.AlbumMonth))
+		case "albums.album_day":
+			values[i] = keyIntValue(int64(m.AlbumDay))
+		}
+	}
+
+	return values
+}
@@ -21,6 +21,38 @@ func TestAlbumPhotos(t *testing.T) {
 			t.Errorf("at least 2 results expected: %d", len(results))
 		}
 	})
+	t.Run("Smart", func(t *testing.T) {
+		a := entity.NewSmartAlbum("Favorites", "favorite:true")
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer a.DeletePermanently()
+
+		photos, err := AlbumPhotos(*a, 100, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photos)
+
+		for _, p := range photos {
+			assert.True(t, p.PhotoFavorite)
+		}
+
+		shared, err := AlbumPhotos(*a, 100, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range shared {
+			assert.True(t, p.PhotoFavorite)
+			assert.False(t, p.PhotoPrivate)
+		}
+	})
 }
 
 func TestAlbums(t *testing.T) {
@@ -154,3 +186,151 @@ func TestAlbums(t *testing.T) {
 		assert.Equal(t, 0, len(result))
 	})
 }
+
+func TestSmartAlbum(t *testing.T) {
+	a := entity.NewSmartAlbum("Berlin", "country:de")
+
+	if err := a.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer a.DeletePermanently()
+
+	t.Run("Found", func(t *testing.T) {
+		if result := SmartAlbum(a.AlbumUID); assert.NotNil(t, result) {
+			assert.Equal(t, "country:de", result.AlbumFilter)
+		}
+	})
+	t.Run("Regular", func(t *testing.T) {
+		assert.Nil(t, SmartAlbum(entity.AlbumFixtures.Get("holiday-2030").AlbumUID))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Nil(t, SmartAlbum("xyz"))
+	})
+}
+
+func TestSmartAlbumFilter(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		f, err := SmartAlbumFilter(`label:cat q:"(country:de | country:fr) -favorite:true"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "cat", f.Label)
+		assert.NotNil(t, f.Expression())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := SmartAlbumFilter(" ")
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidQuery", func(t *testing.T) {
+		_, err := SmartAlbumFilter(`q:"(label:cat"`)
+
+		assert.IsType(t, &form.QueryError{}, err)
+	})
+	t.Run("Nested", func(t *testing.T) {
+		a := entity.NewSmartAlbum("Nested", "favorite:true")
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer a.DeletePermanently()
+
+		_, err := SmartAlbumFilter("album:" + a.AlbumUID)
+
+		assert.Equal(t, ErrNestedSmartAlbum, err)
+
+		_, err = SmartAlbumFilter(`q:"album:` + a.AlbumUID + ` | favorite:true"`)
+
+		assert.Equal(t, ErrNestedSmartAlbum, err)
+	})
+}
+
+func TestPhotos_SmartAlbum(t *testing.T) {
+	a := entity.NewSmartAlbum("Cats or Favorites", `q:"label:cat | favorite:true"`)
+
+	if err := a.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer a.DeletePermanently()
+
+	t.Run("IgnoreClientFilter", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Album = a.AlbumUID
+		f.Filter = "public:false"
+		f.Count = 100
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photos)
+	})
+	t.Run("Query", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Album = a.AlbumUID
+		f.Query = "favorite:true"
+		f.Count = 100
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photos)
+
+		for _, p := range photos {
+			assert.True(t, p.PhotoFavorite)
+		}
+	})
+}
+
+func TestAlbumsPaged(t *testing.T) {
+	for _, order := range []string{"", entity.SortOrderNewest, entity.SortOrderOldest, entity.SortOrderAdded,
+		entity.SortOrderSlug, entity.SortOrderCount} {
+		t.Run("Order"+order, func(t *testing.T) {
+			f := form.SearchAlbums{Order: order, Count: 1000, Total: true}
+
+			all, allPage, err := AlbumsPaged(f)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, len(all), allPage.Total)
+
+			var results AlbumResults
+
+			f.Count = 2
+			f.Total = false
+
+			for i := 0; i <= len(all); i++ {
+				res, page, err := AlbumsPaged(f)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				results = append(results, res...)
+
+				if page.Cursor == "" {
+					break
+				}
+
+				f.Cursor = page.Cursor
+			}
+
+			if assert.Equal(t, len(all), len(results)) {
+				for i := range all {
+					assert.Equal(t, all[i].AlbumUID, results[i].AlbumUID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package search
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// ErrInvalidCursor indicates that a pagination cursor is malformed or doesn't match the search.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Page contains pagination details of a search result.
+type Page struct {
+	Cursor string // Opaque cursor pointing to the next page, empty if there are no more results.
+	Total  int    // Total number of matches, only set if requested.
+}
+
+// Cursor represents a decoded pagination cursor.
+//
+// Cursors for sort orders with a unique key contain the key values of the last result
+// so that the next page can be found with a keyset condition instead of an offset.
+// All other sort orders fall back to an offset.
+type Cursor struct {
+	Order  string   `json:"o"`
+	Filter string   `json:"f"`
+	Keys   []string `json:"k,omitempty"`
+	Offset int      `json:"n,omitempty"`
+}
+
+// Keyset tests if the cursor contains key values.
+func (c Cursor) Keyset() bool {
+	return len(c.Keys) > 0
+}
+
+// String returns the cursor as opaque URL-safe string.
+func (c Cursor) String() string {
+	b, err := json.Marshal(c)
+
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// NewCursor returns a cursor for the search form and sort order.
+func NewCursor(f interface{}, order string) Cursor {
+	return Cursor{Order: order, Filter: cursorFilter(f)}
+}
+
+// ParseCursor decodes a cursor string and verifies that it matches the search form and sort order.
+func ParseCursor(s string, f interface{}, order string) (c Cursor, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+
+	if err != nil {
+		return c, ErrInvalidCursor
+	} else if err = json.Unmarshal(b, &c); err != nil {
+		return c, ErrInvalidCursor
+	} else if c.Order != order || c.Filter != cursorFilter(f) || c.Offset < 0 {
+		return c, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// cursorFilter returns a checksum of the serialized search filters.
+func cursorFilter(f interface{}) string {
+	return strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(form.Serialize(f, false)))), 16)
+}
+
+// keyType represents the value type of a sort key.
+type keyType int
+
+const (
+	keyString keyType = iota
+	keyInt
+	keyTime
+)
+
+// sortKey represents a column that is part of a unique sort order.
+type sortKey struct {
+	Col  string
+	Desc bool
+	Type keyType
+}
+
+// sortKeys represents a unique sort order that can be used for keyset pagination.
+type sortKeys []sortKey
+
+// Order returns the SQL order clause.
+func (keys sortKeys) Order() string {
+	cols := make([]string, len(keys))
+
+	for i, k := range keys {
+		if k.Desc {
+			cols[i] = k.Col + " DESC"
+		} else {
+			cols[i] = k.Col
+		}
+	}
+
+	return strings.Join(cols, ", ")
+}
+
+// Where returns the SQL condition and values for finding rows after the given key values.
+func (keys sortKeys) Where(values []string) (string, []interface{}, error) {
+	if len(values) != len(keys) {
+		return "", nil, ErrInvalidCursor
+	}
+
+	args := make([]interface{}, len(values))
+
+	for i, k := range keys {
+		switch k.Type {
+		case keyInt:
+			n, err := strconv.ParseInt(values[i], 10, 64)
+
+			if err != nil {
+				return "", nil, ErrInvalidCursor
+			}
+
+			args[i] = n
+		case keyTime:
+			t, err := time.Parse(time.RFC3339Nano, values[i])
+
+			if err != nil {
+				return "", nil, ErrInvalidCursor
+			}
+
+			args[i] = t.UTC()
+		default:
+			args[i] = values[i]
+		}
+	}
+
+	// Expands to "(a < ?) OR (a = ? AND b > ?) OR (a = ? AND b = ? AND c > ?)".
+	var where []string
+	var whereArgs []interface{}
+
+	for i, k := range keys {
+		var cond []string
+
+		for j := 0; j < i; j++ {
+			cond = append(cond, fmt.Sprintf("%s = ?", keys[j].Col))
+			whereArgs = append(whereArgs, args[j])
+		}
+
+		if k.Desc {
+			cond = append(cond, fmt.Sprintf("%s < ?", k.Col))
+		} else {
+			cond = append(cond, fmt.Sprintf("%s > ?", k.Col))
+		}
+
+		whereArgs = append(whereArgs, args[i])
+		where = append(where, "("+strings.Join(cond, " AND ")+")")
+	}
+
+	return strings.Join(where, " OR "), whereArgs, nil
+}
+
+// keyIntValue returns a sort key value for an integer.
+func keyIntValue(i int64) string {
+	return strconv.FormatInt(i, 10)
+}
+
+// keyBoolValue returns a sort key value for a boolean.
+func keyBoolValue(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
+
+// keyTimeValue returns a sort key value for a timestamp.
+func keyTimeValue(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// pageQuery applies the pagination cursor to a query and returns the current cursor.
+// Keyset pagination is only used if keys are not empty, otherwise an offset is used.
+func pageQuery(s *gorm.DB, f interface{}, cursor, order string, offset int, keys sortKeys) (*gorm.DB, Cursor, error) {
+	if cursor == "" {
+		c := NewCursor(f, order)
+		c.Offset = offset
+		return s.Offset(offset), c, nil
+	}
+
+	c, err := ParseCursor(cursor, f, order)
+
+	if err != nil {
+		return s, c, err
+	}
+
+	if c.Keyset() {
+		where, values, err := keys.Where(c.Keys)
+
+		if err != nil {
+			return s, c, err
+		}
+
+		return s.Offset(0).Where(where, values...), c, nil
+	} else if len(keys) > 0 {
+		return s, c, ErrInvalidCursor
+	}
+
+	return s.Offset(c.Offset), c, nil
+}
+
+// nextCursor returns the cursor for the next page, or an empty string if the current page is the last one.
+func nextCursor(c Cursor, limit, count int, keys []string) string {
+	if count < limit || count == 0 {
+		return ""
+	}
+
+	if len(keys) > 0 {
+		c.Keys = keys
+		c.Offset = 0
+	} else {
+		c.Keys = nil
+		c.Offset += count
+	}
+
+	return c.String()
+}
+
+// sortable is implemented by search results that provide the key values for a pagination cursor.
+type sortable interface {
+	sortValues(keys sortKeys) []string
+}
+
+// pageRequest contains the pagination parameters of a search.
+type pageRequest struct {
+	Form     interface{} // Search form the cursor must match.
+	Cursor   string
+	Order    string
+	Offset   int
+	Limit    int
+	Total    bool
+	TotalCol string // Column counted to get the total number of matches.
+	Keys     sortKeys
+}
+
+// scanPage counts the matches if requested, continues after the cursor of the previous page,
+// and scans the results into dest, which must be a pointer to a slice of sortable values.
+func scanPage(s *gorm.DB, r pageRequest, dest interface{}) (page Page, err error) {
+	if r.Total {
+		if page.Total, err = countTotal(s, r.TotalCol); err != nil {
+			return page, err
+		}
+	}
+
+	s, cursor, err := pageQuery(s, r.Form, r.Cursor, r.Order, r.Offset, r.Keys)
+
+	if err != nil {
+		return page, err
+	}
+
+	if err = s.Scan(dest).Error; err != nil {
+		return page, err
+	}
+
+	results := reflect.Indirect(reflect.ValueOf(dest))
+
+	if n := results.Len(); n > 0 {
+		var values []string
+
+		if last, ok := results.Index(n - 1).Interface().(sortable); ok {
+			values = last.sortValues(r.Keys)
+		}
+
+		page.Cursor = nextCursor(cursor, r.Limit, n, values)
+	}
+
+	return page, nil
+}
+
+// countTotal returns the number of distinct rows matched by the query, ignoring limit, offset and order.
+func countTotal(s *gorm.DB, col string) (total int, err error) {
+	err = s.Limit(-1).Offset(-1).Group("-1").Select(fmt.Sprintf("COUNT(DISTINCT %s)", col)).Count(&total).Error
+	return total, err
+}
+
+// resultLimit returns the effective result limit for a requested count.
+func resultLimit(count int) int {
+	if count > 0 && count <= MaxResults {
+		return count
+	}
+
+	return MaxResults
+}
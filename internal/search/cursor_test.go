@@ -0,0 +1,155 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCursor(t *testing.T) {
+	f := form.SearchPhotos{Query: "label:cat", Count: 10}
+
+	t.Run("Keyset", func(t *testing.T) {
+		c := NewCursor(&f, "newest")
+		c.Keys = []string{"2020-01-01T00:00:00Z", "pt9jtdre2lvl0yh7"}
+
+		result, err := ParseCursor(c.String(), &f, "newest")
+
+		assert.NoError(t, err)
+		assert.True(t, result.Keyset())
+		assert.Equal(t, c.Keys, result.Keys)
+	})
+	t.Run("Offset", func(t *testing.T) {
+		c := NewCursor(&f, "name")
+		c.Offset = 20
+
+		result, err := ParseCursor(c.String(), &f, "name")
+
+		assert.NoError(t, err)
+		assert.False(t, result.Keyset())
+		assert.Equal(t, 20, result.Offset)
+	})
+	t.Run("IgnorePagination", func(t *testing.T) {
+		c := NewCursor(&f, "newest")
+		other := f
+		other.Count = 50
+		other.Offset = 100
+
+		_, err := ParseCursor(c.String(), &other, "newest")
+
+		assert.NoError(t, err)
+	})
+	t.Run("OtherOrder", func(t *testing.T) {
+		c := NewCursor(&f, "newest")
+
+		_, err := ParseCursor(c.String(), &f, "oldest")
+
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+	t.Run("OtherFilter", func(t *testing.T) {
+		c := NewCursor(&f, "newest")
+		other := f
+		other.Query = "label:dog"
+
+		_, err := ParseCursor(c.String(), &other, "newest")
+
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := ParseCursor("#foo", &f, "newest")
+		assert.Equal(t, ErrInvalidCursor, err)
+
+		_, err = ParseCursor("Zm9v", &f, "newest")
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+}
+
+func TestSortKeys(t *testing.T) {
+	keys := sortKeys{
+		{Col: "photos.taken_at", Desc: true, Type: keyTime},
+		{Col: "photos.photo_uid", Type: keyString},
+		{Col: "files.id", Type: keyInt},
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		assert.Equal(t, "photos.taken_at DESC, photos.photo_uid, files.id", keys.Order())
+	})
+	t.Run("Where", func(t *testing.T) {
+		where, values, err := keys.Where([]string{"2020-01-01T12:00:00Z", "pt9jtdre2lvl0yh7", "5"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "(photos.taken_at < ?) OR (photos.taken_at = ? AND photos.photo_uid > ?) OR "+
+			"(photos.taken_at = ? AND photos.photo_uid = ? AND files.id > ?)", where)
+		assert.Len(t, values, 6)
+		assert.Equal(t, int64(5), values[5])
+	})
+	t.Run("InvalidValues", func(t *testing.T) {
+		_, _, err := keys.Where([]string{"2020-01-01T12:00:00Z", "pt9jtdre2lvl0yh7", "x"})
+		assert.Equal(t, ErrInvalidCursor, err)
+
+		_, _, err = keys.Where([]string{"yesterday", "pt9jtdre2lvl0yh7", "5"})
+		assert.Equal(t, ErrInvalidCursor, err)
+
+		_, _, err = keys.Where([]string{"2020-01-01T12:00:00Z"})
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+}
+
+func TestNextCursor(t *testing.T) {
+	f := form.SearchPhotos{Query: "label:cat", Count: 10}
+	c := NewCursor(&f, "name")
+	c.Offset = 10
+
+	t.Run("LastPage", func(t *testing.T) {
+		assert.Empty(t, nextCursor(c, 10, 5, []string{"a"}))
+		assert.Empty(t, nextCursor(c, 10, 0, nil))
+	})
+	t.Run("Offset", func(t *testing.T) {
+		next, err := ParseCursor(nextCursor(c, 10, 10, nil), &f, "name")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 20, next.Offset)
+		assert.Empty(t, next.Keys)
+	})
+	t.Run("Keyset", func(t *testing.T) {
+		next, err := ParseCursor(nextCursor(c, 10, 10, []string{"a"}), &f, "name")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, next.Offset)
+		assert.Equal(t, []string{"a"}, next.Keys)
+	})
+}
+
+func TestScanPage(t *testing.T) {
+	f := form.SearchLabels{Count: 2}
+	keys := labelsKeys()
+	r := pageRequest{Form: &f, Order: f.Order, Limit: 2, Total: true, TotalCol: "labels.id", Keys: keys}
+
+	t.Run("Keyset", func(t *testing.T) {
+		var results []Label
+
+		s := UnscopedDb().Table("labels").Select("labels.*").Where("labels.deleted_at IS NULL").Order(keys.Order()).Limit(2)
+		page, err := scanPage(s, r, &results)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.GreaterOrEqual(t, page.Total, 2)
+
+		c, err := ParseCursor(page.Cursor, &f, f.Order)
+
+		assert.NoError(t, err)
+		assert.Equal(t, results[1].sortValues(keys), c.Keys)
+	})
+	t.Run("InvalidCursor", func(t *testing.T) {
+		var results []Label
+
+		r := r
+		r.Cursor = "invalid"
+
+		_, err := scanPage(UnscopedDb().Table("labels"), r, &results)
+
+		assert.Equal(t, ErrInvalidCursor, err)
+		assert.Empty(t, results)
+	})
+}
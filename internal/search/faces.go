@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/jinzhu/gorm"
+
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -11,8 +13,16 @@ import (
 
 // Faces searches faces and returns them.
 func Faces(f form.SearchFaces) (results FaceResults, err error) {
+	results, _, err = FacesPaged(f)
+
+	return results, err
+}
+
+// FacesPaged works like Faces, but also returns the pagination state so that
+// clients can fetch the next faces with a cursor and display the total count.
+func FacesPaged(f form.SearchFaces) (results FaceResults, page Page, err error) {
 	if err := f.ParseQueryString(); err != nil {
-		return results, err
+		return results, page, err
 	}
 
 	facesTable := entity.Face{}.TableName()
@@ -53,33 +63,64 @@ func Faces(f form.SearchFaces) (results FaceResults, err error) {
 	}
 
 	// Limit result count.
-	if f.Count > 0 && f.Count <= MaxResults {
-		s = s.Limit(f.Count).Offset(f.Offset)
-	} else {
-		s = s.Limit(MaxResults).Offset(f.Offset)
-	}
+	limit := resultLimit(f.Count)
+	s = s.Limit(limit)
 
 	// Set sort order.
+	keys := facesKeys(f.Order)
+
 	switch f.Order {
 	case "subject":
 		s = s.Order(fmt.Sprintf("%s.subj_uid", facesTable))
-	case "added":
-		s = s.Order(fmt.Sprintf("%s.created_at DESC", facesTable))
-	case "samples":
-		s = s.Order(fmt.Sprintf("%s.samples DESC, %s.id", facesTable, facesTable))
 	default:
-		s = s.Order(fmt.Sprintf("%s.samples DESC, %s.id", facesTable, facesTable))
+		s = s.Order(keys.Order())
 	}
 
-	// Find specific IDs?
-	if f.UID != "" {
-		s = s.Where(fmt.Sprintf("%s.id IN (?)", facesTable), strings.Split(strings.ToUpper(f.UID), txt.Or))
+	// Filter by subject, marker state and the other form fields.
+	s = facesFilter(s, f)
+
+	// Perform query.
+	page, err = scanPage(s, pageRequest{
+		Form:     &f,
+		Cursor:   f.Cursor,
+		Order:    f.Order,
+		Offset:   f.Offset,
+		Limit:    limit,
+		Total:    f.Total,
+		TotalCol: fmt.Sprintf("%s.id", facesTable),
+		Keys:     keys,
+	}, &results)
+
+	return results, page, err
+}
+
+// facesKeys returns the unique sort keys for keyset pagination, or nil if the order isn't supported.
+func facesKeys(order string) sortKeys {
+	facesTable := entity.Face{}.TableName()
 
-		if result := s.Scan(&results); result.Error != nil {
-			return results, result.Error
+	switch order {
+	case "subject":
+		return nil
+	case "added":
+		return sortKeys{
+			{Col: fmt.Sprintf("%s.created_at", facesTable), Desc: true, Type: keyTime},
+			{Col: fmt.Sprintf("%s.id", facesTable), Type: keyString},
 		}
+	default:
+		return sortKeys{
+			{Col: fmt.Sprintf("%s.samples", facesTable), Desc: true, Type: keyInt},
+			{Col: fmt.Sprintf("%s.id", facesTable), Type: keyString},
+		}
+	}
+}
 
-		return results, nil
+// facesFilter applies the search form filters to a face query.
+func facesFilter(s *gorm.DB, f form.SearchFaces) *gorm.DB {
+	facesTable := entity.Face{}.TableName()
+
+	// Find specific IDs?
+	if f.UID != "" {
+		return s.Where(fmt.Sprintf("%s.id IN (?)", facesTable), strings.Split(strings.ToUpper(f.UID), txt.Or))
 	}
 
 	// Exclude unknown faces?
@@ -94,10 +135,5 @@ func Faces(f form.SearchFaces) (results FaceResults, err error) {
 		s = s.Where(fmt.Sprintf("%s.face_hidden = 0", facesTable))
 	}
 
-	// Perform query.
-	if res := s.Scan(&results); res.Error != nil {
-		return results, res.Error
-	}
-
-	return results, nil
+	return s
 }
@@ -31,3 +31,25 @@ type Face struct {
 
 // FaceResults represents face search results.
 type FaceResults []Face
+
+// sortValues returns the face ID, sample count or creation time for each of the sort keys.
+func (m Face) sortValues(keys sortKeys) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(keys))
+
+	for i, k := range keys {
+		switch k.Col {
+		case "faces.id":
+			values[i] = m.ID
+		case "faces.samples":
+			values[i] = keyIntValue(int64(m.Samples))
+		case "faces.created_at":
+			values[i] = keyTimeValue(m.CreatedAt)
+		}
+	}
+
+	return values
+}
@@ -38,3 +38,46 @@ func TestFaces(t *testing.T) {
 		assert.LessOrEqual(t, 0, len(results))
 	})
 }
+
+func TestFacesPaged(t *testing.T) {
+	for _, order := range []string{"", "added", "subject"} {
+		t.Run("Order"+order, func(t *testing.T) {
+			f := form.SearchFaces{Order: order, Count: 1000, Total: true}
+
+			all, allPage, err := FacesPaged(f)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, len(all), allPage.Total)
+
+			var results FaceResults
+
+			f.Count = 2
+			f.Total = false
+
+			for i := 0; i <= len(all); i++ {
+				res, page, err := FacesPaged(f)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				results = append(results, res...)
+
+				if page.Cursor == "" {
+					break
+				}
+
+				f.Cursor = page.Cursor
+			}
+
+			if assert.Equal(t, len(all), len(results)) {
+				for i := range all {
+					assert.Equal(t, all[i].ID, results[i].ID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/maps"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// FacetLimit is the max number of values per facet.
+const FacetLimit = 100
+
+// IsoBuckets contains the upper bounds of the ISO facet buckets.
+var IsoBuckets = []int{100, 200, 400, 800, 1600, 3200, 6400}
+
+// FocalLengthBuckets contains the upper bounds of the focal length facet buckets in mm.
+var FocalLengthBuckets = []int{15, 24, 35, 50, 85, 135, 300}
+
+// Facet represents a search filter value and the number of matching photos.
+type Facet struct {
+	Value string `json:"Value"`
+	Title string `json:"Title"`
+	Count int    `json:"Count"`
+}
+
+// Facets contains photo counts grouped by common search filters.
+type Facets struct {
+	Cameras     []Facet `json:"Cameras"`
+	Lenses      []Facet `json:"Lenses"`
+	Years       []Facet `json:"Years"`
+	Countries   []Facet `json:"Countries"`
+	Labels      []Facet `json:"Labels"`
+	Colors      []Facet `json:"Colors"`
+	Iso         []Facet `json:"Iso"`
+	FocalLength []Facet `json:"FocalLength"`
+}
+
+// PhotoFacets returns the number of photos matching the search form grouped by camera, lens, year,
+// country, label, color, ISO and focal length so that clients can offer drill-down filters.
+func PhotoFacets(f form.SearchPhotos) (result Facets, err error) {
+	start := time.Now()
+
+	// Smart albums are always evaluated with their stored filter.
+	if SmartAlbum(f.Album) != nil {
+		f.Filter = ""
+	}
+
+	if err = f.ParseQueryString(); err != nil {
+		return result, err
+	}
+
+	limit := FacetLimit
+
+	if f.Count > 0 && f.Count < FacetLimit {
+		limit = f.Count
+	}
+
+	// Counts don't depend on the sort order.
+	f.Order = ""
+
+	where, values, err := photosSubquery(&f)
+
+	if err != nil {
+		return result, err
+	}
+
+	if result.Cameras, err = facetValues(facetPhotos(where, values).
+		Joins("JOIN cameras ON cameras.id = photos.camera_id").
+		Where("cameras.camera_slug <> ?", entity.UnknownID),
+		"cameras.id", "cameras.camera_name", limit); err != nil {
+		return result, err
+	}
+
+	if result.Lenses, err = facetValues(facetPhotos(where, values).
+		Joins("JOIN lenses ON lenses.id = photos.lens_id").
+		Where("lenses.lens_slug <> ?", entity.UnknownID),
+		"lenses.id", "lenses.lens_name", limit); err != nil {
+		return result, err
+	}
+
+	if result.Years, err = facetValues(facetPhotos(where, values).
+		Where("photos.photo_year > 0"),
+		"photos.photo_year", "photos.photo_year", limit); err != nil {
+		return result, err
+	}
+
+	if result.Countries, err = facetValues(facetPhotos(where, values).
+		Where("photos.photo_country <> ?", entity.UnknownID),
+		"photos.photo_country", "photos.photo_country", limit); err != nil {
+		return result, err
+	}
+
+	for i := range result.Countries {
+		result.Countries[i].Title = maps.CountryName(result.Countries[i].Value)
+	}
+
+	if result.Labels, err = facetValues(facetPhotos(where, values).
+		Joins("JOIN photos_labels fpl ON fpl.photo_id = photos.id AND fpl.uncertainty < 100").
+		Joins("JOIN labels fl ON fl.id = fpl.label_id AND fl.deleted_at IS NULL"),
+		"fl.label_slug", "fl.label_name", limit); err != nil {
+		return result, err
+	}
+
+	if result.Colors, err = facetValues(facetPhotos(where, values).
+		Joins("JOIN files fc ON fc.photo_id = photos.id AND fc.file_primary = 1").
+		Where("fc.file_main_color <> ''"),
+		"fc.file_main_color", "fc.file_main_color", limit); err != nil {
+		return result, err
+	}
+
+	for i := range result.Colors {
+		result.Colors[i].Title = txt.UcFirst(result.Colors[i].Title)
+	}
+
+	if result.Iso, err = facetBuckets(facetPhotos(where, values),
+		"photos.photo_iso", IsoBuckets, "ISO %s"); err != nil {
+		return result, err
+	}
+
+	if result.FocalLength, err = facetBuckets(facetPhotos(where, values),
+		"photos.photo_focal_length", FocalLengthBuckets, "%s mm"); err != nil {
+		return result, err
+	}
+
+	log.Debugf("photos: found facets for %s [%s]", f.SerializeAll(), time.Since(start))
+
+	return result, nil
+}
+
+// facetPhotos returns a photo query restricted to the search results.
+func facetPhotos(where string, values []interface{}) *gorm.DB {
+	return UnscopedDb().Table("photos").Where(where, values...)
+}
+
+// facetValues returns the most frequent values of a column and the number of matching photos.
+func facetValues(s *gorm.DB, value, title string, limit int) (result []Facet, err error) {
+	err = s.Select(fmt.Sprintf("%s AS value, MIN(%s) AS title, COUNT(DISTINCT photos.id) AS count", value, title)).
+		Group(value).Order("count DESC, value", true).Limit(limit).Scan(&result).Error
+
+	return result, err
+}
+
+// facetBuckets returns the number of matching photos for each range of column values.
+func facetBuckets(s *gorm.DB, col string, bounds []int, title string) (result []Facet, err error) {
+	var b strings.Builder
+
+	b.WriteString("CASE")
+
+	for i, max := range bounds {
+		b.WriteString(fmt.Sprintf(" WHEN %s <= %d THEN %d", col, max, i))
+	}
+
+	b.WriteString(fmt.Sprintf(" ELSE %d END", len(bounds)))
+
+	var rows []struct {
+		Bucket int
+		Count  int
+	}
+
+	if err = s.Select(fmt.Sprintf("%s AS bucket, COUNT(DISTINCT photos.id) AS count", b.String())).
+		Where(fmt.Sprintf("%s > 0", col)).
+		Group("bucket").Order("bucket", true).Scan(&rows).Error; err != nil {
+		return result, err
+	}
+
+	result = make([]Facet, 0, len(rows))
+
+	for _, r := range rows {
+		var value, label string
+
+		switch {
+		case r.Bucket <= 0:
+			value = fmt.Sprintf("1..%d", bounds[0])
+			label = fmt.Sprintf("1-%d", bounds[0])
+		case r.Bucket >= len(bounds):
+			value = fmt.Sprintf(">%d", bounds[len(bounds)-1])
+			label = value
+		default:
+			value = fmt.Sprintf("%d..%d", bounds[r.Bucket-1]+1, bounds[r.Bucket])
+			label = fmt.Sprintf("%d-%d", bounds[r.Bucket-1]+1, bounds[r.Bucket])
+		}
+
+		result = append(result, Facet{Value: value, Title: fmt.Sprintf(title, label), Count: r.Count})
+	}
+
+	return result, nil
+}
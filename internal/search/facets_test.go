@@ -0,0 +1,123 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotoFacets(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		result, err := PhotoFacets(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, result.Cameras)
+		assert.NotEmpty(t, result.Years)
+		assert.NotEmpty(t, result.Countries)
+		assert.NotEmpty(t, result.Labels)
+
+		for i := 1; i < len(result.Cameras); i++ {
+			assert.GreaterOrEqual(t, result.Cameras[i-1].Count, result.Cameras[i].Count)
+		}
+
+		for _, c := range result.Countries {
+			assert.NotEqual(t, "zz", c.Value)
+			assert.NotEmpty(t, c.Title)
+		}
+	})
+	t.Run("DrillDown", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		all, err := PhotoFacets(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(all.Years) == 0 {
+			t.Fatal("years must not be empty")
+		}
+
+		year := all.Years[0]
+
+		f.Year = year.Value
+		f.Count = 5
+
+		result, err := PhotoFacets(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, result.Years, 1) {
+			assert.Equal(t, year, result.Years[0])
+		}
+
+		f.Merged = true
+		f.Count = 1000
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, year.Count)
+	})
+	t.Run("Iso", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		all, err := PhotoFacets(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, b := range all.Iso {
+			f.Iso = b.Value
+
+			result, err := PhotoFacets(f)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if assert.Len(t, result.Iso, 1) {
+				assert.Equal(t, b, result.Iso[0])
+			}
+		}
+	})
+	t.Run("NoResults", func(t *testing.T) {
+		f := form.NewPhotoSearch("label:xyz-not-existing")
+
+		result, err := PhotoFacets(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result.Cameras)
+		assert.Empty(t, result.Labels)
+	})
+}
+
+func TestFacetBuckets(t *testing.T) {
+	result, err := facetBuckets(UnscopedDb().Table("photos").Where("photos.deleted_at IS NULL"), "photos.photo_iso", IsoBuckets, "ISO %s")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, b := range result {
+		assert.NotEmpty(t, b.Value)
+		assert.Contains(t, b.Title, "ISO ")
+		assert.Greater(t, b.Count, 0)
+	}
+}
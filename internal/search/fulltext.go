@@ -0,0 +1,70 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fts"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// FullTextLimit is the max number of full-text search hits, ranked by score, that results are limited to.
+const FullTextLimit = 1000
+
+// FullText returns the photos best matching the search query, sorted by relevance. The second return
+// value is false if the index is disabled or the query has no indexable terms, so that the database
+// must be searched instead.
+func FullText(q string) (fts.Hits, bool) {
+	idx := entity.SearchIndex()
+
+	if idx == nil || q == "" {
+		return nil, false
+	}
+
+	hits, err := idx.Search(q, FullTextLimit)
+
+	if err == fts.ErrEmptyQuery {
+		return nil, false
+	} else if err != nil {
+		log.Errorf("search: %s (full-text query %s)", err, txt.LogParamLower(q))
+		return nil, false
+	}
+
+	return hits, true
+}
+
+// FullTextWhere returns a where condition matching the photo IDs of the hits.
+func FullTextWhere(col string, hits fts.Hits) string {
+	if len(hits) == 0 {
+		return "1 = 0"
+	}
+
+	ids := make([]string, len(hits))
+
+	for i, hit := range hits {
+		ids[i] = fmt.Sprintf("%d", hit.ID)
+	}
+
+	return fmt.Sprintf("%s IN (%s)", col, strings.Join(ids, ","))
+}
+
+// FullTextOrder returns a sort order expression ranking the hits by score, other results come last.
+func FullTextOrder(col string, hits fts.Hits) string {
+	if len(hits) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString("CASE ")
+	b.WriteString(col)
+
+	for i, hit := range hits {
+		b.WriteString(fmt.Sprintf(" WHEN %d THEN %d", hit.ID, i))
+	}
+
+	b.WriteString(fmt.Sprintf(" ELSE %d END", len(hits)))
+
+	return b.String()
+}
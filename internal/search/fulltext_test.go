@@ -0,0 +1,102 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/fts"
+)
+
+func TestFullText(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		hits, ok := FullText("bridge")
+
+		assert.False(t, ok)
+		assert.Empty(t, hits)
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		entity.SetSearchIndex(fts.NewMemory("en"))
+		defer entity.SetSearchIndex(nil)
+
+		hits, ok := FullText("bridges")
+
+		assert.True(t, ok)
+		assert.GreaterOrEqual(t, len(hits), 2)
+		assert.LessOrEqual(t, len(hits), FullTextLimit)
+		assert.Contains(t, hits.IDs(), entity.PhotoFixtures.Get("Photo04").ID)
+
+		for i := 1; i < len(hits); i++ {
+			assert.GreaterOrEqual(t, hits[i-1].Score, hits[i].Score)
+		}
+
+		hits, ok = FullText("the")
+
+		assert.False(t, ok)
+		assert.Empty(t, hits)
+	})
+}
+
+func TestFullTextWhere(t *testing.T) {
+	assert.Equal(t, "1 = 0", FullTextWhere("photos.id", nil))
+	assert.Equal(t, "photos.id IN (3,1)", FullTextWhere("photos.id", fts.Hits{{ID: 3, Score: 2}, {ID: 1, Score: 1}}))
+}
+
+func TestFullTextOrder(t *testing.T) {
+	assert.Equal(t, "", FullTextOrder("photos.id", nil))
+	assert.Equal(t, "CASE photos.id WHEN 3 THEN 0 WHEN 1 THEN 1 ELSE 2 END", FullTextOrder("photos.id", fts.Hits{{ID: 3, Score: 2}, {ID: 1, Score: 1}}))
+}
+
+func TestPhotos_FullText(t *testing.T) {
+	entity.SetSearchIndex(fts.NewMemory("en"))
+	defer entity.SetSearchIndex(nil)
+
+	t.Run("Keywords", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "bridges"
+		f.Count = 100
+		f.Order = entity.SortOrderRelevance
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 2)
+
+		for _, p := range photos {
+			assert.NotEqual(t, entity.PhotoFixtures.Get("Photo01").ID, p.ID)
+		}
+	})
+	t.Run("Title", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "neckarbrücke"
+		f.Count = 10
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, entity.PhotoFixtures.Get("Photo04").PhotoUID, photos[0].PhotoUID)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "xyzzyq"
+		f.Count = 10
+
+		photos, _, err := Photos(f)
+
+		assert.NoError(t, err)
+		assert.Empty(t, photos)
+	})
+}
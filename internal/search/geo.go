@@ -0,0 +1,267 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	geojson "github.com/paulmach/go.geojson"
+
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+var (
+	ErrInvalidBBox    = errors.New("invalid bounding box")
+	ErrInvalidPolygon = errors.New("invalid polygon")
+	ErrInvalidCell    = errors.New("invalid s2 cell")
+)
+
+// MaxPolygonCells is the max number of S2 cells used to cover a search polygon.
+var MaxPolygonCells = 64
+
+// MaxPolygonPoints is the max number of positions in a search polygon, so that the size of the
+// condition testing locations on its boundary is limited.
+var MaxPolygonPoints = 250
+
+// BBox represents a geographic bounding box in degrees.
+type BBox struct {
+	LatMin float64
+	LngMin float64
+	LatMax float64
+	LngMax float64
+}
+
+// ParseBBox parses a bounding box in GeoJSON order, e.g. "8.9,48.5,9.1,48.6" for "minLng,minLat,maxLng,maxLat".
+func ParseBBox(s string) (b BBox, err error) {
+	values := strings.Split(s, ",")
+
+	if len(values) != 4 {
+		return b, ErrInvalidBBox
+	}
+
+	var n [4]float64
+
+	for i, v := range values {
+		if n[i], err = strconv.ParseFloat(strings.TrimSpace(v), 64); err != nil || math.IsNaN(n[i]) {
+			return b, ErrInvalidBBox
+		}
+	}
+
+	b = BBox{LngMin: n[0], LatMin: n[1], LngMax: n[2], LatMax: n[3]}
+
+	if b.LatMin > b.LatMax || b.LatMin < -90 || b.LatMax > 90 ||
+		b.LngMin < -180 || b.LngMin > 180 || b.LngMax < -180 || b.LngMax > 180 {
+		return b, ErrInvalidBBox
+	}
+
+	return b, nil
+}
+
+// CrossesAntimeridian tests if the longitude range wraps around at 180 degrees.
+func (b BBox) CrossesAntimeridian() bool {
+	return b.LngMin > b.LngMax
+}
+
+// ParsePolygon parses a GeoJSON polygon geometry or its coordinates and returns the outer ring
+// as a list of [lng, lat] positions.
+func ParsePolygon(s string) (ring [][]float64, err error) {
+	s = strings.TrimSpace(s)
+
+	var rings [][][]float64
+
+	switch {
+	case strings.HasPrefix(s, "{"):
+		g, err := geojson.UnmarshalGeometry([]byte(s))
+
+		if err != nil || !g.IsPolygon() {
+			return ring, ErrInvalidPolygon
+		}
+
+		rings = g.Polygon
+	case strings.HasPrefix(s, "[[["):
+		if err = json.Unmarshal([]byte(s), &rings); err != nil {
+			return ring, ErrInvalidPolygon
+		}
+	default:
+		return ring, ErrInvalidPolygon
+	}
+
+	if len(rings) == 0 || len(rings[0]) < 3 || len(rings[0]) > MaxPolygonPoints {
+		return ring, ErrInvalidPolygon
+	}
+
+	return rings[0], nil
+}
+
+// polygonBBox returns the bounding box of a polygon ring.
+func polygonBBox(ring [][]float64) (b BBox) {
+	b = BBox{LatMin: 90, LngMin: 180, LatMax: -90, LngMax: -180}
+
+	for _, p := range ring {
+		b.LngMin = math.Min(b.LngMin, p[0])
+		b.LngMax = math.Max(b.LngMax, p[0])
+		b.LatMin = math.Min(b.LatMin, p[1])
+		b.LatMax = math.Max(b.LatMax, p[1])
+	}
+
+	return b
+}
+
+// cellsWhere returns a condition matching locations in one of the S2 cells,
+// so that an index on the cell id column can be used.
+func cellsWhere(col string, cells []string) (where string, values []interface{}) {
+	cond := make([]string, 0, len(cells))
+
+	for _, c := range cells {
+		if min, max := s2.PrefixedTokenRange(c); min != "" {
+			cond = append(cond, col+" BETWEEN ? AND ?")
+			values = append(values, min, max)
+		}
+	}
+
+	return strings.Join(cond, " OR "), values
+}
+
+// polygonWhere returns a condition matching locations inside a polygon ring of [lng, lat] positions with the
+// even-odd rule, so that locations can be tested exactly in the database. Like in GeoJSON, edges are straight
+// lines between positions in degrees, and rings with a longitude range of more than 180 degrees are
+// assumed to cross the antimeridian.
+func polygonWhere(latCol, lngCol string, ring [][]float64) string {
+	b := polygonBBox(ring)
+	lng := lngCol
+	shift := b.LngMax-b.LngMin > 180
+
+	// Shift western longitudes east of the antimeridian?
+	if shift {
+		lng = fmt.Sprintf("(CASE WHEN %s < 0 THEN %s + 360 ELSE %s END)", lngCol, lngCol, lngCol)
+	}
+
+	position := func(i int) (x, y float64) {
+		p := ring[i%len(ring)]
+
+		if x, y = p[0], p[1]; shift && x < 0 {
+			x += 360
+		}
+
+		return x, y
+	}
+
+	f := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	crossings := make([]string, 0, len(ring))
+
+	for i := range ring {
+		x1, y1 := position(i)
+		x2, y2 := position(i + 1)
+
+		// Horizontal edges are never crossed.
+		if y1 == y2 {
+			continue
+		}
+
+		if y1 > y2 {
+			x1, y1, x2, y2 = x2, y2, x1, y1
+		}
+
+		// Count the edge if a ray heading east from the location crosses it.
+		crossings = append(crossings, fmt.Sprintf("CASE WHEN %s >= %s AND %s < %s AND %s < %s + %s * (%s - %s) THEN 1 ELSE 0 END",
+			latCol, f(y1), latCol, f(y2), lng, f(x1), f((x2-x1)/(y2-y1)), latCol, f(y1)))
+	}
+
+	if len(crossings) == 0 {
+		return "1 = 0"
+	}
+
+	return fmt.Sprintf("(%s) %% 2 = 1", strings.Join(crossings, " + "))
+}
+
+// geoFilter restricts a photo query to locations in a bounding box, a polygon, or an S2 cell.
+func geoFilter(s *gorm.DB, bbox, polygon, cell string) (*gorm.DB, error) {
+	// Filter by S2 cell, e.g. "s2:4799e"?
+	if cell != "" {
+		min, max := s2.PrefixedTokenRange(cell)
+
+		if min == "" {
+			return s, ErrInvalidCell
+		}
+
+		s = s.Where("photos.cell_id BETWEEN ? AND ?", min, max)
+	}
+
+	// Filter by bounding box?
+	if bbox != "" {
+		b, err := ParseBBox(bbox)
+
+		if err != nil {
+			return s, err
+		}
+
+		where, values := cellsWhere("photos.cell_id", s2.CoverRect(b.LatMin, b.LngMin, b.LatMax, b.LngMax, s2.MaxCoverCells))
+
+		if where == "" {
+			return s, ErrInvalidBBox
+		}
+
+		// Cells may exceed the bounding box, so coordinates are compared as well.
+		s = s.Where(where, values...).Where("photos.photo_lat BETWEEN ? AND ?", b.LatMin, b.LatMax)
+
+		if b.CrossesAntimeridian() {
+			s = s.Where("photos.photo_lng >= ? OR photos.photo_lng <= ?", b.LngMin, b.LngMax)
+		} else {
+			s = s.Where("photos.photo_lng BETWEEN ? AND ?", b.LngMin, b.LngMax)
+		}
+	}
+
+	// Filter by polygon?
+	if polygon != "" {
+		ring, err := ParsePolygon(polygon)
+
+		if err != nil {
+			return s, err
+		}
+
+		p := s2.NewPolygon(ring)
+
+		if p == nil {
+			return s, ErrInvalidPolygon
+		}
+
+		interior, boundary := p.Cover(MaxPolygonCells)
+
+		if len(interior) == 0 && len(boundary) == 0 {
+			return s, ErrInvalidPolygon
+		}
+
+		where, values := cellsWhere("photos.cell_id", interior)
+
+		// Locations in cells that intersect the polygon's boundary are tested exactly.
+		if exact, exactValues := cellsWhere("photos.cell_id", boundary); exact != "" {
+			exact = "(" + exact + ") AND " + polygonWhere("photos.photo_lat", "photos.photo_lng", ring)
+
+			// Exclude locations outside the polygon's bounding box, unless it crosses the antimeridian.
+			if b := polygonBBox(ring); b.LngMax-b.LngMin <= 180 {
+				exact = "photos.photo_lat BETWEEN ? AND ? AND photos.photo_lng BETWEEN ? AND ? AND " + exact
+				exactValues = append([]interface{}{b.LatMin, b.LatMax, b.LngMin, b.LngMax}, exactValues...)
+			}
+
+			if where != "" {
+				where = "(" + where + ") OR "
+			}
+
+			where += "(" + exact + ")"
+			values = append(values, exactValues...)
+		} else if where == "" {
+			where = "1 = 0"
+		}
+
+		s = s.Where(where, values...)
+	}
+
+	return s, nil
+}
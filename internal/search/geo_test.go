@@ -0,0 +1,120 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBBox(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		b, err := ParseBBox("8.9, 48.5, 9.1, 48.6")
+
+		assert.NoError(t, err)
+		assert.Equal(t, BBox{LatMin: 48.5, LngMin: 8.9, LatMax: 48.6, LngMax: 9.1}, b)
+		assert.False(t, b.CrossesAntimeridian())
+	})
+	t.Run("Antimeridian", func(t *testing.T) {
+		b, err := ParseBBox("170,-20,-170,-10")
+
+		assert.NoError(t, err)
+		assert.True(t, b.CrossesAntimeridian())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, s := range []string{"", "1,2,3", "a,b,c,d", "8,50,9,40", "8,48,190,49", "NaN,1,2,3"} {
+			_, err := ParseBBox(s)
+			assert.Equal(t, ErrInvalidBBox, err, s)
+		}
+	})
+}
+
+func TestParsePolygon(t *testing.T) {
+	t.Run("Geometry", func(t *testing.T) {
+		ring, err := ParsePolygon(`{"type":"Polygon","coordinates":[[[8.9,48.5],[9.1,48.5],[9.1,48.6],[8.9,48.5]]]}`)
+
+		assert.NoError(t, err)
+		assert.Len(t, ring, 4)
+		assert.Equal(t, []float64{9.1, 48.5}, ring[1])
+	})
+	t.Run("Coordinates", func(t *testing.T) {
+		ring, err := ParsePolygon("[[[8.9,48.5],[9.1,48.5],[9.1,48.6]]]")
+
+		assert.NoError(t, err)
+		assert.Len(t, ring, 3)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, s := range []string{"", "foo", `{"type":"Point","coordinates":[8.9,48.5]}`, "[[[8.9,48.5],[9.1,48.5]]]", "[[[8.9"} {
+			_, err := ParsePolygon(s)
+			assert.Equal(t, ErrInvalidPolygon, err, s)
+		}
+	})
+	t.Run("TooManyPoints", func(t *testing.T) {
+		positions := make([]string, MaxPolygonPoints+1)
+
+		for i := range positions {
+			positions[i] = fmt.Sprintf("[%f,%f]", 9+math.Cos(float64(i)/100), 48+math.Sin(float64(i)/100))
+		}
+
+		_, err := ParsePolygon("[[" + strings.Join(positions, ",") + "]]")
+
+		assert.Equal(t, ErrInvalidPolygon, err)
+	})
+}
+
+func TestPolygonBBox(t *testing.T) {
+	b := polygonBBox([][]float64{{8.9, 48.5}, {9.1, 48.5}, {9.1, 48.6}})
+
+	assert.Equal(t, BBox{LatMin: 48.5, LngMin: 8.9, LatMax: 48.6, LngMax: 9.1}, b)
+}
+
+func TestPolygonWhere(t *testing.T) {
+	// contains evaluates the condition in the test database.
+	contains := func(ring [][]float64, lat, lng float64) bool {
+		var result []int
+
+		if err := Db().Raw("SELECT CASE WHEN "+polygonWhere("p.lat", "p.lng", ring)+" THEN 1 ELSE 0 END FROM (SELECT ? AS lat, ? AS lng) p", lat, lng).Pluck("1", &result).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		return len(result) == 1 && result[0] == 1
+	}
+
+	t.Run("Triangle", func(t *testing.T) {
+		ring := [][]float64{{8.9, 48.5}, {9.1, 48.5}, {9.1, 48.6}}
+
+		assert.Equal(t, "(CASE WHEN p.lat >= 0 AND p.lat < 2 AND p.lng < 2 + 0 * (p.lat - 0) THEN 1 ELSE 0 END + "+
+			"CASE WHEN p.lat >= 0 AND p.lat < 2 AND p.lng < 0 + 1 * (p.lat - 0) THEN 1 ELSE 0 END) % 2 = 1",
+			polygonWhere("p.lat", "p.lng", [][]float64{{0, 0}, {2, 0}, {2, 2}}))
+		assert.True(t, contains(ring, 48.52, 9.05))
+		assert.False(t, contains(ring, 48.58, 8.95))
+		assert.False(t, contains(ring, 48.55, 9.2))
+	})
+	t.Run("Concave", func(t *testing.T) {
+		ring := [][]float64{{-99, 19.5}, {-98.5, 19.5}, {-98.5, 20}, {-98.8, 20}, {-98.8, 19.6}, {-98.9, 19.6}, {-98.9, 20}, {-99, 20}, {-99, 19.5}}
+
+		assert.True(t, contains(ring, 19.8, -98.95))
+		assert.True(t, contains(ring, 19.8, -98.6))
+		assert.False(t, contains(ring, 19.8, -98.85))
+	})
+	t.Run("Antimeridian", func(t *testing.T) {
+		ring := [][]float64{{170, -20}, {-170, -20}, {-170, -10}, {170, -10}}
+
+		assert.True(t, contains(ring, -15, 179))
+		assert.True(t, contains(ring, -15, -179))
+		assert.False(t, contains(ring, -15, 0))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "1 = 0", polygonWhere("p.lat", "p.lng", [][]float64{{8.9, 48.5}, {9.1, 48.5}, {9.0, 48.5}}))
+	})
+}
+
+func TestCellsWhere(t *testing.T) {
+	where, values := cellsWhere("photos.cell_id", []string{"85d1ea7c", "invalid", "85d1ea74"})
+
+	assert.Equal(t, "photos.cell_id BETWEEN ? AND ? OR photos.cell_id BETWEEN ? AND ?", where)
+	assert.Len(t, values, 4)
+	assert.Equal(t, "s2:85d1ea780004", values[0])
+}
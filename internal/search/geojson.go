@@ -26,6 +26,37 @@ func Geo(f form.SearchGeo) (results GeoResults, err error) {
 		return GeoResults{}, err
 	}
 
+	s, err := geoQuery(&f)
+
+	if err != nil {
+		return GeoResults{}, err
+	}
+
+	if f.Near == "" {
+		// Default sort order.
+		s = s.Order("taken_at, photos.photo_uid")
+	} else {
+		// Sort by distance to UID.
+		s = s.Order(gorm.Expr("(photos.photo_uid = ?) DESC, ABS(? - photos.photo_lat)+ABS(? - photos.photo_lng)", f.Near, f.Lat, f.Lng))
+	}
+
+	// Limit result count?
+	if f.Count > 0 {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	}
+
+	// Fetch results.
+	if result := s.Scan(&results); result.Error != nil {
+		return results, result.Error
+	}
+
+	log.Debugf("geo: found %s for %s [%s]", english.Plural(len(results), "result", "results"), f.SerializeAll(), time.Since(start))
+
+	return results, nil
+}
+
+// geoQuery returns a photo query with the geo search filters applied.
+func geoQuery(f *form.SearchGeo) (s *gorm.DB, err error) {
 	S2Levels := 7
 
 	// Search for nearby photos?
@@ -33,7 +64,7 @@ func Geo(f form.SearchGeo) (results GeoResults, err error) {
 		photo := Photo{}
 
 		if err := Db().First(&photo, "photo_uid = ?", f.Near).Error; err != nil {
-			return GeoResults{}, err
+			return s, err
 		}
 
 		f.S2 = photo.CellID
@@ -43,7 +74,7 @@ func Geo(f form.SearchGeo) (results GeoResults, err error) {
 		S2Levels = 12
 	}
 
-	s := UnscopedDb()
+	s = UnscopedDb()
 
 	// s.LogMode(true)
 
@@ -341,6 +372,11 @@ func Geo(f form.SearchGeo) (results GeoResults, err error) {
 		}
 	}
 
+	// Filter by bounding box, polygon, or S2 cell?
+	if s, err = geoFilter(s, f.Bbox, f.Polygon, f.Cell); err != nil {
+		return s, err
+	}
+
 	// Find photos taken before date?
 	if !f.Before.IsZero() {
 		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
@@ -351,25 +387,5 @@ func Geo(f form.SearchGeo) (results GeoResults, err error) {
 		s = s.Where("photos.taken_at >= ?", f.After.Format("2006-01-02"))
 	}
 
-	if f.Near == "" {
-		// Default sort order.
-		s = s.Order("taken_at, photos.photo_uid")
-	} else {
-		// Sort by distance to UID.
-		s = s.Order(gorm.Expr("(photos.photo_uid = ?) DESC, ABS(? - photos.photo_lat)+ABS(? - photos.photo_lng)", f.Near, f.Lat, f.Lng))
-	}
-
-	// Limit result count?
-	if f.Count > 0 {
-		s = s.Limit(f.Count).Offset(f.Offset)
-	}
-
-	// Fetch results.
-	if result := s.Scan(&results); result.Error != nil {
-		return results, result.Error
-	}
-
-	log.Debugf("geo: found %s for %s [%s]", english.Plural(len(results), "result", "results"), f.SerializeAll(), time.Since(start))
-
-	return results, nil
+	return s, nil
 }
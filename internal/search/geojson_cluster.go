@@ -0,0 +1,60 @@
+package search
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+// GeoCluster groups photos matching the search form by S2 cell, so that large numbers of
+// locations can be displayed on a map. Cells get smaller with increasing zoom level and
+// roughly match a quarter of a map tile.
+func GeoCluster(f form.SearchGeo) (results GeoClusterResults, err error) {
+	start := time.Now()
+
+	if err := f.ParseQueryString(); err != nil {
+		return GeoClusterResults{}, err
+	}
+
+	s, err := geoQuery(&f)
+
+	if err != nil {
+		return GeoClusterResults{}, err
+	}
+
+	cell := fmt.Sprintf("SUBSTR(photos.cell_id, 1, %d)", len(s2.TokenPrefix)+s2.TokenLength(f.Zoom))
+
+	s = s.Select(fmt.Sprintf(`%s AS cell, COUNT(*) AS photo_count, 
+		AVG(photos.photo_lat) AS photo_lat, AVG(photos.photo_lng) AS photo_lng, 
+		MIN(photos.photo_lat) AS lat_min, MAX(photos.photo_lat) AS lat_max, 
+		MIN(photos.photo_lng) AS lng_min, MAX(photos.photo_lng) AS lng_max, 
+		MIN(photos.photo_uid) AS photo_uid, MIN(files.file_hash) AS file_hash`, cell)).
+		Where("photos.cell_id LIKE ?", s2.TokenPrefix+"%").
+		Group("cell").
+		Order("photo_count DESC, cell", true)
+
+	// Limit result count?
+	if f.Count > 0 {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	}
+
+	// Fetch results.
+	if result := s.Scan(&results); result.Error != nil {
+		return results, result.Error
+	}
+
+	// Only single photos can be opened directly.
+	for i := range results {
+		if results[i].PhotoCount > 1 {
+			results[i].PhotoUID = ""
+		}
+	}
+
+	log.Debugf("geo: found %s for %s [%s]", english.Plural(len(results), "cluster", "clusters"), f.SerializeAll(), time.Since(start))
+
+	return results, nil
+}
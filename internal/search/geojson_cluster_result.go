@@ -0,0 +1,73 @@
+package search
+
+import (
+	"math"
+
+	"github.com/gin-gonic/gin"
+	geojson "github.com/paulmach/go.geojson"
+)
+
+// GeoClusterResult represents a group of nearby photos.
+type GeoClusterResult struct {
+	Cell       string  `json:"Cell"`
+	PhotoCount int     `json:"Count"`
+	PhotoLat   float64 `json:"Lat"`
+	PhotoLng   float64 `json:"Lng"`
+	LatMin     float64 `json:"-"`
+	LatMax     float64 `json:"-"`
+	LngMin     float64 `json:"-"`
+	LngMax     float64 `json:"-"`
+	PhotoUID   string  `json:"UID,omitempty"`
+	FileHash   string  `json:"Hash"`
+}
+
+// BBox returns the bounding box of the photos in the cluster.
+func (c GeoClusterResult) BBox() []float64 {
+	return []float64{c.LngMin, c.LatMin, c.LngMax, c.LatMax}
+}
+
+// GeoClusterResults represents a list of photo clusters.
+type GeoClusterResults []GeoClusterResult
+
+// GeoJSON returns clusters as point features with the number of photos and their bounding box,
+// so that clients can zoom into a cluster, see https://geojson.org/.
+func (clusters GeoClusterResults) GeoJSON() ([]byte, error) {
+	fc := geojson.NewFeatureCollection()
+
+	var bbox []float64
+
+	for _, c := range clusters {
+		if bbox == nil {
+			bbox = c.BBox()
+		} else {
+			bbox[0] = math.Min(bbox[0], c.LngMin)
+			bbox[1] = math.Min(bbox[1], c.LatMin)
+			bbox[2] = math.Max(bbox[2], c.LngMax)
+			bbox[3] = math.Max(bbox[3], c.LatMax)
+		}
+
+		props := gin.H{
+			"Cell":  c.Cell,
+			"Count": c.PhotoCount,
+			"Hash":  c.FileHash,
+		}
+
+		if c.PhotoUID != "" {
+			props["UID"] = c.PhotoUID
+		}
+
+		feat := geojson.NewPointFeature([]float64{c.PhotoLng, c.PhotoLat})
+		feat.ID = c.Cell
+		feat.BoundingBox = c.BBox()
+		feat.Properties = props
+		fc.AddFeature(feat)
+	}
+
+	if bbox == nil {
+		bbox = make([]float64, 4)
+	}
+
+	fc.BoundingBox = bbox
+
+	return fc.MarshalJSON()
+}
@@ -0,0 +1,82 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestGeoCluster(t *testing.T) {
+	t.Run("World", func(t *testing.T) {
+		var frm form.SearchGeo
+
+		frm.Zoom = 0
+
+		clusters, err := GeoCluster(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		photos, err := Geo(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(clusters))
+		assert.Less(t, len(clusters), len(photos))
+
+		count := 0
+
+		for _, c := range clusters {
+			assert.Len(t, c.Cell, 4)
+			assert.NotEmpty(t, c.FileHash)
+			assert.GreaterOrEqual(t, c.PhotoLat, c.LatMin)
+			assert.LessOrEqual(t, c.PhotoLat, c.LatMax)
+			count += c.PhotoCount
+		}
+
+		assert.LessOrEqual(t, count, len(photos))
+	})
+	t.Run("Street", func(t *testing.T) {
+		var frm form.SearchGeo
+
+		frm.Zoom = 18
+		frm.Bbox = "-99,19.5,-98.5,20"
+
+		clusters, err := GeoCluster(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(clusters))
+
+		for _, c := range clusters {
+			assert.Len(t, c.Cell, 13)
+
+			if c.PhotoCount == 1 {
+				assert.NotEmpty(t, c.PhotoUID)
+			} else {
+				assert.Empty(t, c.PhotoUID)
+			}
+		}
+	})
+	t.Run("Count", func(t *testing.T) {
+		var frm form.SearchGeo
+
+		frm.Zoom = 21
+		frm.Count = 1
+
+		clusters, err := GeoCluster(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, clusters, 1)
+	})
+}
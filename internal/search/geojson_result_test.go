@@ -101,3 +101,33 @@ func TestGeoResults_GeoJSON(t *testing.T) {
 
 	t.Logf("result: %s", b)
 }
+
+func TestGeoClusterResults_GeoJSON(t *testing.T) {
+	t.Run("Clusters", func(t *testing.T) {
+		items := GeoClusterResults{
+			{Cell: "s2:85d", PhotoCount: 5, PhotoLat: 19.68, PhotoLng: -98.84, LatMin: 19.6, LatMax: 19.7, LngMin: -98.9, LngMax: -98.8, FileHash: "d2b4a5d18276f96f1b5a1bf17fd82d6fab3807f2"},
+			{Cell: "s2:1ef", PhotoCount: 1, PhotoLat: 48.5, PhotoLng: 9.1, LatMin: 48.5, LatMax: 48.5, LngMin: 9.1, LngMax: 9.1, PhotoUID: "pt9jtdre2lvl0yh0", FileHash: "da639e836dfa9179e66c619499b0a5e592f72fc1"},
+		}
+
+		b, err := items.GeoJSON()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := string(b)
+
+		assert.Contains(t, s, `"bbox":[-98.9,19.6,9.1,48.5]`)
+		assert.Contains(t, s, `"Count":5`)
+		assert.Contains(t, s, `"UID":"pt9jtdre2lvl0yh0"`)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		b, err := GeoClusterResults{}.GeoJSON()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(b), `"bbox":[0,0,0,0]`)
+	})
+}
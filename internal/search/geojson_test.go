@@ -801,4 +801,84 @@ func TestGeo(t *testing.T) {
 			assert.NotEmpty(t, r.ID)
 		}
 	})
+	t.Run("BBox", func(t *testing.T) {
+		var frm form.SearchGeo
+
+		frm.Bbox = "-99,19.5,-98.5,20"
+
+		photos, err := Geo(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, r := range photos {
+			assert.InDelta(t, 19.68, r.Lat(), 0.1)
+			assert.InDelta(t, -98.84, r.Lng(), 0.1)
+		}
+	})
+	t.Run("BBoxNoMatch", func(t *testing.T) {
+		var frm form.SearchGeo
+
+		frm.Bbox = "-99,-19.5,-98.5,-19"
+
+		photos, err := Geo(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+	t.Run("Polygon", func(t *testing.T) {
+		var frm form.SearchGeo
+
+		frm.Query = "polygon:[[[-99,19.5],[-98.5,19.5],[-98.5,20],[-99,20],[-99,19.5]]]"
+
+		photos, err := Geo(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, r := range photos {
+			assert.InDelta(t, 19.68, r.Lat(), 0.1)
+			assert.InDelta(t, -98.84, r.Lng(), 0.1)
+		}
+	})
+	t.Run("Cell", func(t *testing.T) {
+		var frm form.SearchGeo
+
+		frm.Cell = "s2:85d1ea7c"
+
+		photos, err := Geo(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+	})
+	t.Run("InvalidBBox", func(t *testing.T) {
+		var frm form.SearchGeo
+
+		frm.Bbox = "foo"
+
+		_, err := Geo(frm)
+
+		assert.Equal(t, ErrInvalidBBox, err)
+	})
+	t.Run("InvalidCell", func(t *testing.T) {
+		var frm form.SearchGeo
+
+		frm.Cell = "foo"
+
+		_, err := Geo(frm)
+
+		assert.Equal(t, ErrInvalidCell, err)
+	})
 }
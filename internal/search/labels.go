@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	"github.com/gosimple/slug"
+	"github.com/jinzhu/gorm"
+
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/sanitize"
@@ -12,8 +14,15 @@ import (
 
 // Labels searches labels based on their name.
 func Labels(f form.SearchLabels) (results []Label, err error) {
+	results, _, err = LabelsPaged(f)
+
+	return results, err
+}
+
+// LabelsPaged is the paginated variant of Labels, see Page for the returned cursor and total.
+func LabelsPaged(f form.SearchLabels) (results []Label, page Page, err error) {
 	if err := f.ParseQueryString(); err != nil {
-		return results, err
+		return results, page, err
 	}
 
 	s := UnscopedDb()
@@ -27,28 +36,49 @@ func Labels(f form.SearchLabels) (results []Label, err error) {
 		Group("labels.id")
 
 	// Limit result count.
-	if f.Count > 0 && f.Count <= MaxResults {
-		s = s.Limit(f.Count).Offset(f.Offset)
-	} else {
-		s = s.Limit(MaxResults).Offset(f.Offset)
-	}
+	limit := resultLimit(f.Count)
+	s = s.Limit(limit)
 
 	// Set sort order.
+	keys := labelsKeys()
+
 	switch f.Order {
 	case "slug":
-		s = s.Order("labels.label_favorite DESC, custom_slug ASC")
+		s = s.Order(keys.Order())
 	default:
-		s = s.Order("labels.label_favorite DESC, custom_slug ASC")
+		s = s.Order(keys.Order())
 	}
 
-	if f.UID != "" {
-		s = s.Where("labels.label_uid IN (?)", strings.Split(strings.ToLower(f.UID), txt.Or))
+	// Filter by name, category and the other form fields.
+	s = labelsFilter(s, f)
+
+	page, err = scanPage(s, pageRequest{
+		Form:     &f,
+		Cursor:   f.Cursor,
+		Order:    f.Order,
+		Offset:   f.Offset,
+		Limit:    limit,
+		Total:    f.Total,
+		TotalCol: "labels.id",
+		Keys:     keys,
+	}, &results)
+
+	return results, page, err
+}
 
-		if result := s.Scan(&results); result.Error != nil {
-			return results, result.Error
-		}
+// labelsKeys returns the unique sort keys for keyset pagination.
+func labelsKeys() sortKeys {
+	return sortKeys{
+		{Col: "labels.label_favorite", Desc: true, Type: keyInt},
+		{Col: "labels.custom_slug", Type: keyString},
+		{Col: "labels.label_uid", Type: keyString},
+	}
+}
 
-		return results, nil
+// labelsFilter applies the search form filters to a label query.
+func labelsFilter(s *gorm.DB, f form.SearchLabels) *gorm.DB {
+	if f.UID != "" {
+		return s.Where("labels.label_uid IN (?)", strings.Split(strings.ToLower(f.UID), txt.Or))
 	}
 
 	if f.Query != "" {
@@ -86,9 +116,5 @@ func Labels(f form.SearchLabels) (results []Label, err error) {
 		s = s.Where("labels.label_priority >= 0 OR labels.label_favorite = 1")
 	}
 
-	if result := s.Scan(&results); result.Error != nil {
-		return results, result.Error
-	}
-
-	return results, nil
+	return s
 }
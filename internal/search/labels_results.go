@@ -22,3 +22,25 @@ type Label struct {
 	UpdatedAt        time.Time `json:"UpdatedAt"`
 	DeletedAt        time.Time `json:"DeletedAt,omitempty"`
 }
+
+// sortValues returns the favorite flag, slug and UID of the label in the order of the sort keys.
+func (m Label) sortValues(keys sortKeys) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(keys))
+
+	for i, k := range keys {
+		switch k.Col {
+		case "labels.label_favorite":
+			values[i] = keyBoolValue(m.LabelFavorite)
+		case "labels.custom_slug":
+			values[i] = m.CustomSlug
+		case "labels.label_uid":
+			values[i] = m.LabelUID
+		}
+	}
+
+	return values
+}
@@ -144,3 +144,42 @@ func TestLabels(t *testing.T) {
 		assert.Equal(t, "flower", result[0].LabelSlug)
 	})
 }
+
+func TestLabelsPaged(t *testing.T) {
+	f := form.SearchLabels{Count: 1000, Total: true}
+
+	all, allPage, err := LabelsPaged(f)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(all), allPage.Total)
+
+	var results []Label
+
+	f.Count = 2
+	f.Total = false
+
+	for i := 0; i <= len(all); i++ {
+		res, page, err := LabelsPaged(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		results = append(results, res...)
+
+		if page.Cursor == "" {
+			break
+		}
+
+		f.Cursor = page.Cursor
+	}
+
+	if assert.Equal(t, len(all), len(results)) {
+		for i := range all {
+			assert.Equal(t, all[i].LabelUID, results[i].LabelUID)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package search
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// MemoriesDays is the number of days before and after the date that are included in weekly memories.
+const MemoriesDays = 3
+
+// MemoriesQuality is the minimum quality score of photos shown as memories.
+const MemoriesQuality = 3
+
+// Memories finds photos taken on the same day, or in the same week, in earlier years. Private, archived
+// and low quality photos are skipped. Favorites come first, followed by photos with a high quality
+// score and many faces.
+func Memories(f form.SearchMemories) (results PhotoResults, err error) {
+	start := time.Now()
+
+	if f.Date.IsZero() {
+		f.Date = time.Now()
+	}
+
+	days := 0
+
+	if f.Week {
+		days = MemoriesDays
+	}
+
+	// Match month and day, so that indexed photo dates can be used across all years.
+	var where []string
+	var values []interface{}
+
+	for i := -days; i <= days; i++ {
+		d := f.Date.AddDate(0, 0, i)
+		where = append(where, "(photos.photo_month = ? AND photos.photo_day = ?)")
+		values = append(values, int(d.Month()), d.Day())
+	}
+
+	s := photosQuery().
+		Where("photos.deleted_at IS NULL AND photos.photo_private = 0").
+		Where("photos.photo_quality >= ?", MemoriesQuality).
+		Where("files.file_primary = 1 AND files.file_error = ''").
+		Where("photos.photo_year > 0 AND photos.photo_year < ?", f.Date.Year()).
+		Where(strings.Join(where, " OR "), values...).
+		Order("photos.photo_favorite DESC, photos.photo_quality DESC, photos.photo_faces DESC, photos.taken_at DESC, photos.photo_uid").
+		Limit(resultLimit(f.Count)).
+		Offset(f.Offset)
+
+	if err = s.Scan(&results).Error; err != nil {
+		return results, err
+	}
+
+	log.Debugf("photos: found %s for %s [%s]", english.Plural(len(results), "memory", "memories"), f.SerializeAll(), time.Since(start))
+
+	return results, nil
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestMemories(t *testing.T) {
+	t.Run("Day", func(t *testing.T) {
+		photos, err := Memories(form.SearchMemories{Date: time.Date(2021, 11, 11, 12, 0, 0, 0, time.UTC)})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 2)
+
+		for _, p := range photos {
+			assert.Equal(t, 11, p.PhotoMonth)
+			assert.Equal(t, 11, p.PhotoDay)
+			assert.Less(t, p.PhotoYear, 2021)
+			assert.False(t, p.PhotoPrivate)
+			assert.GreaterOrEqual(t, p.PhotoQuality, MemoriesQuality)
+		}
+	})
+	t.Run("Week", func(t *testing.T) {
+		date := time.Date(2021, 11, 14, 12, 0, 0, 0, time.UTC)
+
+		day, err := Memories(form.SearchMemories{Date: date})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		week, err := Memories(form.SearchMemories{Date: date, Week: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, day)
+		assert.GreaterOrEqual(t, len(week), 2)
+	})
+	t.Run("SameYear", func(t *testing.T) {
+		photos, err := Memories(form.SearchMemories{Date: time.Date(2020, 11, 11, 12, 0, 0, 0, time.UTC)})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+	t.Run("Ranking", func(t *testing.T) {
+		photos, err := Memories(form.SearchMemories{Date: time.Date(2021, 4, 18, 12, 0, 0, 0, time.UTC), Week: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 1; i < len(photos); i++ {
+			if photos[i-1].PhotoFavorite == photos[i].PhotoFavorite {
+				assert.GreaterOrEqual(t, photos[i-1].PhotoQuality, photos[i].PhotoQuality)
+			} else {
+				assert.True(t, photos[i-1].PhotoFavorite)
+			}
+		}
+	})
+	t.Run("Today", func(t *testing.T) {
+		_, err := Memories(form.SearchMemories{Count: 10})
+
+		assert.NoError(t, err)
+	})
+}
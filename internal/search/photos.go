@@ -1,6 +1,7 @@
 package search
 
 import (
+	"errors"
 	"fmt"
 	"path"
 	"strings"
@@ -12,23 +13,139 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/phash"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// errNoResults indicates that a search filter doesn't match any photos.
+var errNoResults = errors.New("no results")
+
+// photosExposure converts exposure times like "1/250" or "2" to seconds.
+const photosExposure = `(CASE WHEN INSTR(photos.photo_exposure, '/') > 0
+	THEN (photos.photo_exposure + 0.0) / (SUBSTR(photos.photo_exposure, INSTR(photos.photo_exposure, '/') + 1) + 0.0)
+	ELSE photos.photo_exposure + 0.0 END)`
+
 // Photos searches for photos based on a Form and returns PhotoResults ([]Photo).
 func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
+	results, count, _, err = PhotosPaged(f)
+
+	return results, count, err
+}
+
+// PhotosPaged searches for photos like Photos. It also returns the next page cursor,
+// and the total number of photos if f.Total is set, counted before results are merged.
+func PhotosPaged(f form.SearchPhotos) (results PhotoResults, count int, page Page, err error) {
 	start := time.Now()
 
+	// Smart albums are always evaluated with their stored filter.
+	if SmartAlbum(f.Album) != nil {
+		f.Filter = ""
+	}
+
 	if err := f.ParseQueryString(); err != nil {
-		return PhotoResults{}, 0, err
+		return PhotoResults{}, 0, page, err
 	}
 
-	s := UnscopedDb()
-	// s = s.LogMode(true)
+	s := photosQuery()
 
-	// Base query.
-	s = s.Table("photos").
+	// Limit result count.
+	limit := resultLimit(f.Count)
+	s = s.Limit(limit)
+
+	// Set sort order.
+	keys := photosKeys(f.Order)
+
+	switch f.Order {
+	case entity.SortOrderEdited:
+		s = s.Where("edited_at IS NOT NULL").Order(keys.Order())
+	case entity.SortOrderRelevance:
+		s = s.Order(photosRelevance(f))
+	case entity.SortOrderSimilar:
+		s = s.Where("files.file_diff > 0")
+		s = s.Order("photos.photo_color, photos.cell_id, files.file_diff, taken_at DESC, files.file_primary DESC")
+	case entity.SortOrderName:
+		s = s.Order("photos.photo_path, photos.photo_name, files.file_primary DESC")
+	default:
+		s = s.Order(keys.Order())
+	}
+
+	// Filter by albums, labels, location and the other form fields.
+	if s, err = photosFilter(s, &f); err == errNoResults {
+		return PhotoResults{}, 0, page, nil
+	} else if err != nil {
+		return PhotoResults{}, 0, page, err
+	}
+
+	if page, err = scanPage(s, pageRequest{
+		Form:     &f,
+		Cursor:   f.Cursor,
+		Order:    f.Order,
+		Offset:   f.Offset,
+		Limit:    limit,
+		Total:    f.Total,
+		TotalCol: "photos.id",
+		Keys:     keys,
+	}, &results); err != nil {
+		return PhotoResults{}, 0, page, err
+	}
+
+	log.Debugf("photos: found %s for %s [%s]", english.Plural(len(results), "result", "results"), f.SerializeAll(), time.Since(start))
+
+	if f.Merged {
+		results, count, err = results.Merged()
+		return results, count, page, err
+	}
+
+	return results, len(results), page, nil
+}
+
+// photosKeys returns the unique sort keys for keyset pagination, or nil if the order isn't supported.
+func photosKeys(order string) sortKeys {
+	switch order {
+	case entity.SortOrderRelevance, entity.SortOrderSimilar, entity.SortOrderName:
+		return nil
+	case entity.SortOrderEdited:
+		return sortKeys{
+			{Col: "photos.edited_at", Desc: true, Type: keyTime},
+			{Col: "photos.photo_uid", Type: keyString},
+			{Col: "files.file_primary", Desc: true, Type: keyInt},
+			{Col: "files.id", Type: keyInt},
+		}
+	case entity.SortOrderOldest:
+		return sortKeys{
+			{Col: "photos.taken_at", Type: keyTime},
+			{Col: "photos.photo_uid", Type: keyString},
+			{Col: "files.file_primary", Desc: true, Type: keyInt},
+			{Col: "files.id", Type: keyInt},
+		}
+	case entity.SortOrderAdded:
+		return sortKeys{
+			{Col: "photos.id", Desc: true, Type: keyInt},
+			{Col: "files.file_primary", Desc: true, Type: keyInt},
+			{Col: "files.id", Type: keyInt},
+		}
+	case entity.SortOrderRating:
+		return sortKeys{
+			{Col: "photos.photo_rating", Desc: true, Type: keyInt},
+			{Col: "photos.taken_at", Desc: true, Type: keyTime},
+			{Col: "photos.photo_uid", Type: keyString},
+			{Col: "files.file_primary", Desc: true, Type: keyInt},
+			{Col: "files.id", Type: keyInt},
+		}
+	default:
+		return sortKeys{
+			{Col: "photos.taken_at", Desc: true, Type: keyTime},
+			{Col: "photos.photo_uid", Type: keyString},
+			{Col: "files.file_primary", Desc: true, Type: keyInt},
+			{Col: "files.id", Type: keyInt},
+		}
+	}
+}
+
+// photosQuery returns the base query for photo search results.
+func photosQuery() *gorm.DB {
+	return UnscopedDb().Table("photos").
 		Select(`photos.*, photos.id AS composite_id,
 		files.id AS file_id, files.file_uid, files.instance_id, files.file_primary, files.file_sidecar, 
 		files.file_portrait,files.file_video, files.file_missing, files.file_name, files.file_root, files.file_hash, 
@@ -42,39 +159,19 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		Joins("LEFT JOIN cameras ON photos.camera_id = cameras.id").
 		Joins("LEFT JOIN lenses ON photos.lens_id = lenses.id").
 		Joins("LEFT JOIN places ON photos.place_id = places.id")
+}
 
-	// Limit result count.
-	if f.Count > 0 && f.Count <= MaxResults {
-		s = s.Limit(f.Count).Offset(f.Offset)
-	} else {
-		s = s.Limit(MaxResults).Offset(f.Offset)
+// photosRelevance returns the default sort order for search results by relevance.
+func photosRelevance(f form.SearchPhotos) string {
+	if f.Label != "" {
+		return "photo_quality DESC, photos_labels.uncertainty ASC, taken_at DESC, files.file_primary DESC"
 	}
 
-	// Set sort order.
-	switch f.Order {
-	case entity.SortOrderEdited:
-		s = s.Where("edited_at IS NOT NULL").Order("edited_at DESC, photos.photo_uid, files.file_primary DESC")
-	case entity.SortOrderRelevance:
-		if f.Label != "" {
-			s = s.Order("photo_quality DESC, photos_labels.uncertainty ASC, taken_at DESC, files.file_primary DESC")
-		} else {
-			s = s.Order("photo_quality DESC, taken_at DESC, files.file_primary DESC")
-		}
-	case entity.SortOrderNewest:
-		s = s.Order("taken_at DESC, photos.photo_uid, files.file_primary DESC")
-	case entity.SortOrderOldest:
-		s = s.Order("taken_at, photos.photo_uid, files.file_primary DESC")
-	case entity.SortOrderAdded:
-		s = s.Order("photos.id DESC, files.file_primary DESC")
-	case entity.SortOrderSimilar:
-		s = s.Where("files.file_diff > 0")
-		s = s.Order("photos.photo_color, photos.cell_id, files.file_diff, taken_at DESC, files.file_primary DESC")
-	case entity.SortOrderName:
-		s = s.Order("photos.photo_path, photos.photo_name, files.file_primary DESC")
-	default:
-		s = s.Order("taken_at DESC, photos.photo_uid, files.file_primary DESC")
-	}
+	return "photo_quality DESC, taken_at DESC, files.file_primary DESC"
+}
 
+// photosFilter applies the search form filters to a photo query.
+func photosFilter(s *gorm.DB, f *form.SearchPhotos) (*gorm.DB, error) {
 	// Include hidden files?
 	if !f.Hidden {
 		s = s.Where("files.file_type = 'jpg' OR files.file_video = 1")
@@ -95,20 +192,17 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		s = s.Where("photos.photo_uid IN (?)", strings.Split(strings.ToLower(f.UID), txt.Or))
 
 		// Take shortcut?
-		if f.Album == "" && f.Query == "" {
-			s = s.Order("files.file_primary DESC")
-
-			if result := s.Scan(&results); result.Error != nil {
-				return results, 0, result.Error
-			}
-
-			log.Debugf("photos: found %s for %s [%s]", english.Plural(len(results), "result", "results"), f.SerializeAll(), time.Since(start))
-
-			if f.Merged {
-				return results.Merged()
-			}
+		if f.Album == "" && f.Query == "" && f.Expression() == nil {
+			return s.Order("files.file_primary DESC"), nil
+		}
+	}
 
-			return results, len(results), nil
+	// Filter by boolean search query expression?
+	if expr := f.Expression(); expr != nil {
+		if where, values, err := photosExpr(expr, *f); err != nil {
+			return s, err
+		} else {
+			s = s.Where(where, values...)
 		}
 	}
 
@@ -120,7 +214,7 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 	if f.Label != "" {
 		if err := Db().Where(AnySlug("label_slug", f.Label, txt.Or)).Or(AnySlug("custom_slug", f.Label, txt.Or)).Find(&labels).Error; len(labels) == 0 || err != nil {
 			log.Debugf("search: label %s not found", txt.LogParamLower(f.Label))
-			return PhotoResults{}, 0, nil
+			return s, errNoResults
 		} else {
 			for _, l := range labels {
 				labelIds = append(labelIds, l.ID)
@@ -186,19 +280,36 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		}
 	}
 
+	// Use full-text index if enabled.
+	hits, fullText := FullText(f.Query)
+
 	// Filter by location?
 	if f.Geo == true {
 		s = s.Where("photos.cell_id <> 'zz'")
 
-		for _, where := range LikeAnyKeyword("k.keyword", f.Query) {
-			s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
+		if fullText {
+			s = s.Where(FullTextWhere("photos.id", hits))
+		} else {
+			for _, where := range LikeAnyKeyword("k.keyword", f.Query) {
+				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
+			}
 		}
 	} else if f.Query != "" {
 		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
-			log.Debugf("search: label %s not found, using fuzzy search", txt.LogParamLower(f.Query))
+			if fullText {
+				log.Debugf("search: label %s not found, using full-text index", txt.LogParamLower(f.Query))
 
-			for _, where := range LikeAnyKeyword("k.keyword", f.Query) {
-				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
+				if len(hits) == 0 {
+					return s, errNoResults
+				}
+
+				s = s.Where(FullTextWhere("photos.id", hits))
+			} else {
+				log.Debugf("search: label %s not found, using fuzzy search", txt.LogParamLower(f.Query))
+
+				for _, where := range LikeAnyKeyword("k.keyword", f.Query) {
+					s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
+				}
 			}
 		} else {
 			for _, l := range labels {
@@ -213,7 +324,10 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 				}
 			}
 
-			if wheres := LikeAnyKeyword("k.keyword", f.Query); len(wheres) > 0 {
+			if fullText {
+				s = s.Where(FullTextWhere("photos.id", hits)+" OR "+
+					"photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", labelIds)
+			} else if wheres := LikeAnyKeyword("k.keyword", f.Query); len(wheres) > 0 {
 				for _, where := range wheres {
 					s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
 						"photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", gorm.Expr(where), labelIds)
@@ -224,6 +338,11 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		}
 	}
 
+	// Rank full-text search hits by score?
+	if fullText && len(hits) > 0 && f.Order == entity.SortOrderRelevance {
+		s = s.Order(FullTextOrder("photos.id", hits), true).Order(photosRelevance(*f))
+	}
+
 	// Search for one or more keywords?
 	if f.Keywords != "" {
 		for _, where := range LikeAnyWord("k.keyword", f.Keywords) {
@@ -301,6 +420,51 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		}
 	}
 
+	// Filter by star rating?
+	if where := RangeInt("photos.photo_rating", f.Rating); where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by ISO?
+	if where := RangeInt("photos.photo_iso", f.Iso); where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by focal length?
+	if where := RangeInt("photos.photo_focal_length", f.Mm); where != "" {
+		s = s.Where("photos.photo_focal_length > 0").Where(where)
+	}
+
+	// Filter by aperture?
+	if where := RangeFloat("photos.photo_f_number", f.F); where != "" {
+		s = s.Where("photos.photo_f_number > 0").Where(where)
+	}
+
+	// Filter by exposure time?
+	if where := RangeFloat(photosExposure, f.Exposure); where != "" {
+		s = s.Where("photos.photo_exposure <> ''").Where(where)
+	}
+
+	// Filter by altitude?
+	if where := RangeInt("photos.photo_altitude", f.Alt); where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by resolution in megapixels?
+	if where := RangeInt("photos.photo_resolution", f.Mp); where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by date taken?
+	if where := RangeDate("photos.taken_at", f.Taken); where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by color label?
+	if f.ColorLabel != "" {
+		s = s.Where("photos.photo_color_label IN (?)", strings.Split(strings.ToLower(f.ColorLabel), txt.Or))
+	}
+
 	// Filter by camera?
 	if f.Camera > 0 {
 		s = s.Where("photos.camera_id = ?", f.Camera)
@@ -437,6 +601,17 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		s = s.Where("files.file_hash IN (?)", strings.Split(strings.ToLower(f.Hash), txt.Or))
 	}
 
+	// Find photos that look similar, e.g. re-encoded or resized copies?
+	if rnd.IsPPID(f.Similar, 'p') {
+		if similar, err := SimilarPhotos(f.Similar, phash.DefaultDistance); err != nil {
+			return s, err
+		} else if uids := similar.PhotoUIDs(); len(uids) == 0 {
+			return s, errNoResults
+		} else {
+			s = s.Where("photos.photo_uid IN (?)", uids)
+		}
+	}
+
 	if f.Mono {
 		s = s.Where("files.file_chroma = 0 OR file_colors = '111111111'")
 	} else if f.Chroma > 9 {
@@ -475,6 +650,13 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		s = s.Where("photos.photo_lng BETWEEN ? AND ?", lngMin, lngMax)
 	}
 
+	// Filter by bounding box, polygon, or S2 cell?
+	if geo, err := geoFilter(s, f.Bbox, f.Polygon, f.Cell); err != nil {
+		return s, err
+	} else {
+		s = geo
+	}
+
 	if !f.Before.IsZero() {
 		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
 	}
@@ -490,7 +672,15 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 
 	// Filter by album?
 	if rnd.IsPPID(f.Album, 'a') {
-		if f.Filter != "" {
+		if a := SmartAlbum(f.Album); a != nil {
+			if where, values, err := photosSmart(*a, *f); err != nil {
+				return s, err
+			} else {
+				s = s.Where(where, values...)
+			}
+
+			s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 1 AND pa.album_uid = ?)", f.Album)
+		} else if f.Filter != "" {
 			s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 1 AND pa.album_uid = ?)", f.Album)
 		} else {
 			s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").
@@ -508,15 +698,80 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		}
 	}
 
-	if err := s.Scan(&results).Error; err != nil {
-		return results, 0, err
+	return s, nil
+}
+
+// photosExpr compiles a boolean search query expression to a where condition. Each filter and search term
+// is compiled to a subquery, so that it matches the same photos as if it were used alone.
+func photosExpr(expr form.QueryExpr, scope form.SearchPhotos) (where string, values []interface{}, err error) {
+	switch e := expr.(type) {
+	case *form.QueryAnd:
+		return photosExprJoin("AND", e.Left, e.Right, scope)
+	case *form.QueryOr:
+		return photosExprJoin("OR", e.Left, e.Right, scope)
+	case *form.QueryNot:
+		if where, values, err = photosExpr(e.Expr, scope); err != nil {
+			return "", nil, err
+		}
+
+		return fmt.Sprintf("NOT (%s)", where), values, nil
+	case *form.QueryGroup:
+		return photosExpr(e.Expr, scope)
 	}
 
-	log.Debugf("photos: found %s for %s [%s]", english.Plural(len(results), "result", "results"), f.SerializeAll(), time.Since(start))
+	// Filters must match photos in the same scope, so that negations don't include hidden or archived photos.
+	leaf := form.SearchPhotos{Query: expr.String(), Hidden: scope.Hidden, Archived: scope.Archived, Error: scope.Error}
 
-	if f.Merged {
-		return results.Merged()
+	if err = leaf.ParseQueryString(); err != nil {
+		return "", nil, &form.QueryError{Pos: expr.Pos(), Msg: err.Error()}
+	}
+
+	return photosSubquery(&leaf)
+}
+
+// photosSmart compiles the search filter of a smart album, matching photos in the same scope.
+func photosSmart(a entity.Album, scope form.SearchPhotos) (where string, values []interface{}, err error) {
+	f, err := SmartAlbumFilter(a.AlbumFilter)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	f.Hidden = scope.Hidden
+	f.Archived = scope.Archived
+	f.Error = scope.Error
+
+	return photosSubquery(&f)
+}
+
+// photosSubquery returns a condition matching the IDs of photos found with the search form.
+func photosSubquery(f *form.SearchPhotos) (where string, values []interface{}, err error) {
+	sub := UnscopedDb().Table("photos").Select("photos.id").
+		Joins("JOIN files ON photos.id = files.photo_id AND files.file_missing = 0 AND files.deleted_at IS NULL").
+		Joins("LEFT JOIN places ON photos.place_id = places.id")
+
+	if sub, err = photosFilter(sub, f); err == errNoResults {
+		return "1 = 0", nil, nil
+	} else if err != nil {
+		return "", nil, err
+	}
+
+	return "photos.id IN ?", []interface{}{sub.SubQuery()}, nil
+}
+
+// photosExprJoin compiles two expressions and joins them with the given operator.
+func photosExprJoin(op string, left, right form.QueryExpr, scope form.SearchPhotos) (where string, values []interface{}, err error) {
+	l, lv, err := photosExpr(left, scope)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	r, rv, err := photosExpr(right, scope)
+
+	if err != nil {
+		return "", nil, err
 	}
 
-	return results, len(results), nil
+	return fmt.Sprintf("(%s) %s (%s)", l, op, r), append(lv, rv...), nil
 }
@@ -0,0 +1,480 @@
+package search
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// Supported export formats.
+const (
+	ExportCSV   = "csv"
+	ExportJSONL = "jsonl"
+)
+
+// ExportBatchSize is the number of pictures fetched from the database at once when exporting search results.
+var ExportBatchSize = 1000
+
+// ExportListSep separates multiple values like labels or albums in CSV cells.
+const ExportListSep = "; "
+
+// ExportColumns lists the columns available for exporting search results in their default order.
+var ExportColumns = []string{
+	"uid",
+	"type",
+	"title",
+	"taken_at",
+	"taken_at_local",
+	"time_zone",
+	"file_root",
+	"file_name",
+	"file_hash",
+	"file_size",
+	"file_mime",
+	"width",
+	"height",
+	"lat",
+	"lng",
+	"altitude",
+	"place",
+	"country",
+	"camera",
+	"lens",
+	"iso",
+	"focal_length",
+	"f_number",
+	"exposure",
+	"labels",
+	"people",
+	"albums",
+}
+
+// exportDetails contains related names of the pictures in an export batch.
+type exportDetails struct {
+	Labels map[uint][]string
+	People map[uint][]string
+	Albums map[string][]string
+}
+
+// exportPhotoName represents the name of a label, person or album related to a picture.
+type exportPhotoName struct {
+	PhotoID  uint
+	PhotoUID string
+	Name     string
+}
+
+// exportValue returns the value of an export column for a search result.
+type exportValue func(p Photo, d exportDetails) interface{}
+
+// exportValues maps export column names to their values.
+var exportValues = map[string]exportValue{
+	"uid":            func(p Photo, d exportDetails) interface{} { return p.PhotoUID },
+	"type":           func(p Photo, d exportDetails) interface{} { return p.PhotoType },
+	"title":          func(p Photo, d exportDetails) interface{} { return p.PhotoTitle },
+	"taken_at":       func(p Photo, d exportDetails) interface{} { return p.TakenAt.UTC().Format(time.RFC3339) },
+	"taken_at_local": func(p Photo, d exportDetails) interface{} { return p.TakenAtLocal.Format("2006-01-02T15:04:05") },
+	"time_zone":      func(p Photo, d exportDetails) interface{} { return p.TimeZone },
+	"file_root":      func(p Photo, d exportDetails) interface{} { return p.FileRoot },
+	"file_name":      func(p Photo, d exportDetails) interface{} { return p.FileName },
+	"file_hash":      func(p Photo, d exportDetails) interface{} { return p.FileHash },
+	"file_size":      func(p Photo, d exportDetails) interface{} { return p.FileSize },
+	"file_mime":      func(p Photo, d exportDetails) interface{} { return p.FileMime },
+	"width":          func(p Photo, d exportDetails) interface{} { return p.FileWidth },
+	"height":         func(p Photo, d exportDetails) interface{} { return p.FileHeight },
+	"lat": func(p Photo, d exportDetails) interface{} {
+		if p.PhotoLat == 0 && p.PhotoLng == 0 {
+			return nil
+		}
+
+		return p.PhotoLat
+	},
+	"lng": func(p Photo, d exportDetails) interface{} {
+		if p.PhotoLat == 0 && p.PhotoLng == 0 {
+			return nil
+		}
+
+		return p.PhotoLng
+	},
+	"altitude": func(p Photo, d exportDetails) interface{} {
+		if p.PhotoLat == 0 && p.PhotoLng == 0 {
+			return nil
+		}
+
+		return p.PhotoAltitude
+	},
+	"place": func(p Photo, d exportDetails) interface{} {
+		if p.PlaceID == "" || p.PlaceID == entity.UnknownPlace.ID {
+			return ""
+		}
+
+		return p.PlaceLabel
+	},
+	"country": func(p Photo, d exportDetails) interface{} {
+		if p.PhotoCountry == entity.UnknownCountry.ID {
+			return ""
+		}
+
+		return p.PhotoCountry
+	},
+	"camera": func(p Photo, d exportDetails) interface{} {
+		if p.CameraModel == entity.UnknownCamera.CameraModel {
+			return ""
+		}
+
+		return strings.TrimSpace(p.CameraMake + " " + p.CameraModel)
+	},
+	"lens": func(p Photo, d exportDetails) interface{} {
+		if p.LensModel == entity.UnknownLens.LensModel {
+			return ""
+		}
+
+		return strings.TrimSpace(p.LensMake + " " + p.LensModel)
+	},
+	"iso":          func(p Photo, d exportDetails) interface{} { return p.PhotoIso },
+	"focal_length": func(p Photo, d exportDetails) interface{} { return p.PhotoFocalLength },
+	"f_number":     func(p Photo, d exportDetails) interface{} { return p.PhotoFNumber },
+	"exposure":     func(p Photo, d exportDetails) interface{} { return p.PhotoExposure },
+	"labels":       func(p Photo, d exportDetails) interface{} { return exportList(d.Labels[p.ID]) },
+	"people":       func(p Photo, d exportDetails) interface{} { return exportList(d.People[p.ID]) },
+	"albums":       func(p Photo, d exportDetails) interface{} { return exportList(d.Albums[p.PhotoUID]) },
+}
+
+// exportList returns an empty list instead of nil, so that it is encoded as [] in JSON.
+func exportList(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
+
+// ParseExportFormat returns the normalized export format, or an error if it isn't supported.
+func ParseExportFormat(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", ExportCSV:
+		return ExportCSV, nil
+	case ExportJSONL, "ndjson", "json":
+		return ExportJSONL, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %s", strconv.Quote(s))
+	}
+}
+
+// ParseExportColumns parses a comma-separated list of export columns,
+// all columns are returned in their default order if the list is empty.
+func ParseExportColumns(s string) (cols []string, err error) {
+	if strings.TrimSpace(s) == "" {
+		return ExportColumns, nil
+	}
+
+	for _, col := range strings.Split(s, ",") {
+		col = strings.ToLower(strings.TrimSpace(col))
+
+		if col == "" {
+			continue
+		} else if _, ok := exportValues[col]; !ok {
+			return nil, fmt.Errorf("unknown export column %s", strconv.Quote(col))
+		}
+
+		cols = append(cols, col)
+	}
+
+	if len(cols) == 0 {
+		return ExportColumns, nil
+	}
+
+	return cols, nil
+}
+
+// ExportPhotos writes pictures matching the search form as CSV or JSON Lines, one row per picture.
+// Results are fetched and written in batches, so that large libraries don't need to be loaded into
+// memory at once. The form count limits the number of exported pictures, zero means no limit.
+func ExportPhotos(w io.Writer, f form.SearchPhotos, format string, cols []string) (count int, err error) {
+	start := time.Now()
+
+	if format, err = ParseExportFormat(format); err != nil {
+		return 0, err
+	}
+
+	if len(cols) == 0 {
+		cols = ExportColumns
+	}
+
+	values := make([]exportValue, len(cols))
+
+	for i, col := range cols {
+		if values[i] = exportValues[col]; values[i] == nil {
+			return 0, fmt.Errorf("unknown export column %s", strconv.Quote(col))
+		}
+	}
+
+	var out exportWriter
+
+	if format == ExportJSONL {
+		out = &exportJSONL{enc: json.NewEncoder(w)}
+	} else {
+		out = &exportCSV{w: csv.NewWriter(w)}
+
+		if err = out.Write(cols, nil); err != nil {
+			return 0, err
+		}
+	}
+
+	// Export one row per picture.
+	limit := f.Count
+	f.Primary = true
+	f.Merged = false
+	f.Total = false
+
+	row := make([]interface{}, len(cols))
+
+	for {
+		f.Count = ExportBatchSize
+
+		if limit > 0 && limit-count < f.Count {
+			f.Count = limit - count
+		}
+
+		results, _, page, err := PhotosPaged(f)
+
+		if err != nil {
+			return count, err
+		}
+
+		details, err := exportPhotoDetails(results, cols)
+
+		if err != nil {
+			return count, err
+		}
+
+		for _, p := range results {
+			for i := range values {
+				row[i] = values[i](p, details)
+			}
+
+			if err = out.Write(cols, row); err != nil {
+				return count, err
+			}
+		}
+
+		count += len(results)
+
+		if err = out.Flush(); err != nil {
+			return count, err
+		}
+
+		if page.Cursor == "" || limit > 0 && count >= limit {
+			break
+		}
+
+		f.Cursor = page.Cursor
+	}
+
+	log.Debugf("photos: exported %s as %s [%s]", english.Plural(count, "result", "results"), format, time.Since(start))
+
+	return count, nil
+}
+
+// exportPhotoDetails finds the labels, people and albums of the pictures in a batch if needed.
+func exportPhotoDetails(results PhotoResults, cols []string) (d exportDetails, err error) {
+	if len(results) == 0 {
+		return d, nil
+	}
+
+	ids := make([]uint, len(results))
+	uids := make([]string, len(results))
+
+	for i, p := range results {
+		ids[i] = p.ID
+		uids[i] = p.PhotoUID
+	}
+
+	for _, col := range cols {
+		switch col {
+		case "labels":
+			var names []exportPhotoName
+
+			if err = Db().Table("photos_labels").
+				Select("photos_labels.photo_id, labels.label_name AS name").
+				Joins("JOIN labels ON labels.id = photos_labels.label_id AND labels.deleted_at IS NULL").
+				Where("photos_labels.uncertainty < 100 AND photos_labels.photo_id IN (?)", ids).
+				Order("photos_labels.photo_id, photos_labels.uncertainty, labels.label_name").
+				Scan(&names).Error; err != nil {
+				return d, err
+			}
+
+			d.Labels = make(map[uint][]string)
+
+			for _, n := range names {
+				d.Labels[n.PhotoID] = append(d.Labels[n.PhotoID], n.Name)
+			}
+		case "people":
+			var names []exportPhotoName
+
+			if err = Db().Table("files").
+				Select("DISTINCT files.photo_id, subjects.subj_name AS name").
+				Joins("JOIN markers ON markers.file_uid = files.file_uid AND markers.marker_invalid = 0").
+				Joins("JOIN subjects ON subjects.subj_uid = markers.subj_uid AND subjects.deleted_at IS NULL").
+				Where("subjects.subj_type = ? AND files.photo_id IN (?)", entity.SubjPerson, ids).
+				Order("files.photo_id, subjects.subj_name").
+				Scan(&names).Error; err != nil {
+				return d, err
+			}
+
+			d.People = make(map[uint][]string)
+
+			for _, n := range names {
+				d.People[n.PhotoID] = append(d.People[n.PhotoID], n.Name)
+			}
+		case "albums":
+			var names []exportPhotoName
+
+			if err = Db().Table("photos_albums").
+				Select("photos_albums.photo_uid, albums.album_title AS name").
+				Joins("JOIN albums ON albums.album_uid = photos_albums.album_uid AND albums.deleted_at IS NULL").
+				Where("photos_albums.hidden = 0 AND albums.album_type = ? AND photos_albums.photo_uid IN (?)", entity.AlbumDefault, uids).
+				Order("photos_albums.photo_uid, albums.album_title").
+				Scan(&names).Error; err != nil {
+				return d, err
+			}
+
+			d.Albums = make(map[string][]string)
+
+			for _, n := range names {
+				d.Albums[n.PhotoUID] = append(d.Albums[n.PhotoUID], n.Name)
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// exportWriter writes export rows in a specific format.
+type exportWriter interface {
+	Write(cols []string, row []interface{}) error
+	Flush() error
+}
+
+// exportCSV writes export rows as CSV.
+type exportCSV struct {
+	w *csv.Writer
+}
+
+// Write writes a CSV row, or the header if row is nil.
+func (e *exportCSV) Write(cols []string, row []interface{}) error {
+	if row == nil {
+		return e.w.Write(cols)
+	}
+
+	record := make([]string, len(row))
+
+	for i, v := range row {
+		switch v := v.(type) {
+		case nil:
+			record[i] = ""
+		case string:
+			record[i] = csvSafe(v)
+		case []string:
+			record[i] = csvSafe(strings.Join(v, ExportListSep))
+		case float32:
+			record[i] = strconv.FormatFloat(float64(v), 'f', -1, 32)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+
+	return e.w.Write(record)
+}
+
+// Flush writes buffered rows.
+func (e *exportCSV) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvSafe prefixes text that spreadsheet applications would evaluate as formula with a single quote,
+// since titles, labels, and names are user-controlled.
+func csvSafe(s string) string {
+	if s == "" {
+		return s
+	}
+
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + s
+	}
+
+	return s
+}
+
+// exportJSONL writes export rows as JSON Lines, with the keys in column order.
+type exportJSONL struct {
+	enc *json.Encoder
+}
+
+// Write writes a row as JSON object on a single line.
+func (e *exportJSONL) Write(cols []string, row []interface{}) error {
+	obj := make(exportObject, len(cols))
+
+	for i, col := range cols {
+		obj[i] = exportField{Key: col, Value: row[i]}
+	}
+
+	return e.enc.Encode(obj)
+}
+
+// Flush does nothing, as rows are written immediately.
+func (e *exportJSONL) Flush() error {
+	return nil
+}
+
+// exportField represents a key-value pair in a JSON Lines row.
+type exportField struct {
+	Key   string
+	Value interface{}
+}
+
+// exportObject represents a JSON Lines row that preserves the column order.
+type exportObject []exportField
+
+// MarshalJSON returns the row as JSON object.
+func (obj exportObject) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+
+	b.WriteByte('{')
+
+	for i, field := range obj {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		key, err := json.Marshal(field.Key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(field.Value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+
+	b.WriteByte('}')
+
+	return []byte(b.String()), nil
+}
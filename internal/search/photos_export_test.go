@@ -0,0 +1,180 @@
+package search
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestParseExportFormat(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		format, err := ParseExportFormat("")
+		assert.NoError(t, err)
+		assert.Equal(t, ExportCSV, format)
+	})
+	t.Run("JSON", func(t *testing.T) {
+		format, err := ParseExportFormat(" JSON ")
+		assert.NoError(t, err)
+		assert.Equal(t, ExportJSONL, format)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseExportFormat("xlsx")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseExportColumns(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cols, err := ParseExportColumns("")
+		assert.NoError(t, err)
+		assert.Equal(t, ExportColumns, cols)
+	})
+	t.Run("Selected", func(t *testing.T) {
+		cols, err := ParseExportColumns("file_name, File_Hash,,labels")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"file_name", "file_hash", "labels"}, cols)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := ParseExportColumns("file_name,foo")
+		assert.Error(t, err)
+	})
+}
+
+func TestExportPhotos(t *testing.T) {
+	t.Run("CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		count, err := ExportPhotos(&buf, form.SearchPhotos{Query: "uid:pt9jtdre2lvl0yh7"}, ExportCSV, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, count)
+
+		records, err := csv.NewReader(&buf).ReadAll()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, records, 2)
+		assert.Equal(t, ExportColumns, records[0])
+
+		row := make(map[string]string)
+
+		for i, col := range records[0] {
+			row[col] = records[1][i]
+		}
+
+		assert.Equal(t, "pt9jtdre2lvl0yh7", row["uid"])
+		assert.Equal(t, "2790/07/27900704_070228_D6D51B6C.jpg", row["file_name"])
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", row["file_hash"])
+		assert.Equal(t, "2008-07-01T10:00:00Z", row["taken_at"])
+		assert.Equal(t, "Canon EOS 6D", row["camera"])
+		assert.Equal(t, "", row["lat"])
+		assert.Equal(t, "Cake; Flower", row["labels"])
+		assert.Equal(t, "Actor A; Actress A", row["people"])
+		assert.Equal(t, "Holiday 2030", row["albums"])
+	})
+	t.Run("JSONL", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		count, err := ExportPhotos(&buf, form.SearchPhotos{Query: "person:actor-a"}, ExportJSONL, []string{"uid", "lat", "people"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+		assert.GreaterOrEqual(t, count, 2)
+		assert.Len(t, lines, count)
+		assert.True(t, strings.HasPrefix(lines[0], `{"uid":`))
+
+		for _, line := range lines {
+			var row struct {
+				UID    string   `json:"uid"`
+				Lat    *float64 `json:"lat"`
+				People []string `json:"people"`
+			}
+
+			if err := json.Unmarshal([]byte(line), &row); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.NotEmpty(t, row.UID)
+			assert.Contains(t, row.People, "Actor A")
+		}
+	})
+	t.Run("Batches", func(t *testing.T) {
+		batchSize := ExportBatchSize
+		ExportBatchSize = 3
+		defer func() { ExportBatchSize = batchSize }()
+
+		var buf bytes.Buffer
+
+		count, err := ExportPhotos(&buf, form.SearchPhotos{Count: 10}, ExportJSONL, []string{"uid"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 10, count)
+		assert.Equal(t, 10, strings.Count(buf.String(), "\n"))
+
+		buf.Reset()
+
+		all, err := ExportPhotos(&buf, form.SearchPhotos{}, ExportJSONL, []string{"uid"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, all, count)
+	})
+	t.Run("InvalidFormat", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		_, err := ExportPhotos(&buf, form.SearchPhotos{}, "xlsx", nil)
+
+		assert.Error(t, err)
+		assert.Empty(t, buf.String())
+	})
+	t.Run("UnknownColumn", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		_, err := ExportPhotos(&buf, form.SearchPhotos{}, ExportCSV, []string{"uid", "foo"})
+
+		assert.Error(t, err)
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestExportCSV_Write(t *testing.T) {
+	var buf bytes.Buffer
+
+	out := &exportCSV{w: csv.NewWriter(&buf)}
+
+	assert.NoError(t, out.Write([]string{"title", "labels", "lat", "notes"}, []interface{}{
+		"=HYPERLINK(\"http://example.com\")", []string{"+Cat", "Dog"}, float32(-12.5), "\tTab",
+	}))
+	assert.NoError(t, out.Write(nil, []interface{}{"-1", "@SUM(A1)", nil, "Bridge - Day 1"}))
+	assert.NoError(t, out.Flush())
+
+	assert.Equal(t, "\"'=HYPERLINK(\"\"http://example.com\"\")\",'+Cat; Dog,-12.5,'\tTab\n"+
+		"'-1,'@SUM(A1),,Bridge - Day 1\n", buf.String())
+}
+
+func TestCsvSafe(t *testing.T) {
+	assert.Equal(t, "", csvSafe(""))
+	assert.Equal(t, "Bridge", csvSafe("Bridge"))
+	assert.Equal(t, "'=1+1", csvSafe("=1+1"))
+	assert.Equal(t, "'\rfoo", csvSafe("\rfoo"))
+}
@@ -44,6 +44,8 @@ type Photo struct {
 	PhotoColor       uint8         `json:"Color"`
 	PhotoScan        bool          `json:"Scan"`
 	PhotoPanorama    bool          `json:"Panorama"`
+	PhotoRating      int           `json:"Rating"`
+	PhotoColorLabel  string        `json:"ColorLabel,omitempty"`
 	CameraID         uint          `json:"CameraID"` // Camera
 	CameraSerial     string        `json:"CameraSerial,omitempty"`
 	CameraSrc        string        `json:"CameraSrc,omitempty"`
@@ -111,6 +113,36 @@ func (m PhotoResults) UIDs() []string {
 	return result
 }
 
+// sortValues returns the photo and primary file values that the next page of photos must follow.
+func (m Photo) sortValues(keys sortKeys) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(keys))
+
+	for i, k := range keys {
+		switch k.Col {
+		case "photos.id":
+			values[i] = keyIntValue(int64(m.ID))
+		case "photos.photo_uid":
+			values[i] = m.PhotoUID
+		case "photos.taken_at":
+			values[i] = keyTimeValue(m.TakenAt)
+		case "photos.edited_at":
+			values[i] = keyTimeValue(m.EditedAt)
+		case "photos.photo_rating":
+			values[i] = keyIntValue(int64(m.PhotoRating))
+		case "files.id":
+			values[i] = keyIntValue(int64(m.FileID))
+		case "files.file_primary":
+			values[i] = keyBoolValue(m.FilePrimary)
+		}
+	}
+
+	return values
+}
+
 func (m PhotoResults) Merged() (PhotoResults, int, error) {
 	count := len(m)
 	merged := make([]Photo, 0, count)
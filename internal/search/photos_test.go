@@ -2,7 +2,9 @@ package search
 
 import (
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/entity"
 
@@ -274,6 +276,83 @@ func TestPhotos(t *testing.T) {
 		}
 		assert.LessOrEqual(t, 1, len(photos))
 	})
+	t.Run("search for rating", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "rating:>=4"
+		f.Count = 5000
+		f.Offset = 0
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, r := range photos {
+			assert.GreaterOrEqual(t, r.PhotoRating, 4)
+		}
+	})
+	t.Run("search for rating range", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Rating = "1..3"
+		f.Count = 5000
+		f.Offset = 0
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, r := range photos {
+			assert.GreaterOrEqual(t, r.PhotoRating, 1)
+			assert.LessOrEqual(t, r.PhotoRating, 3)
+		}
+	})
+	t.Run("search for color label", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "colorlabel:red|blue"
+		f.Count = 5000
+		f.Offset = 0
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, r := range photos {
+			assert.Contains(t, []string{"red", "blue"}, r.PhotoColorLabel)
+		}
+	})
+	t.Run("order by rating", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Order = entity.SortOrderRating
+		f.Count = 5000
+		f.Offset = 0
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 2, len(photos))
+
+		for i := 1; i < len(photos); i++ {
+			assert.GreaterOrEqual(t, photos[i-1].PhotoRating, photos[i].PhotoRating)
+		}
+	})
 	t.Run("search for file error", func(t *testing.T) {
 		var f form.SearchPhotos
 
@@ -455,6 +534,36 @@ func TestPhotos(t *testing.T) {
 		//t.Logf("results: %+v", photos)
 		assert.Equal(t, 1, len(photos))
 	})
+	t.Run("form.similar", func(t *testing.T) {
+		var f form.SearchPhotos
+		f.Query = "similar:pt9jtdre2lvl0yh9"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photos)
+		assert.Contains(t, photos.UIDs(), "pt9jtdre2lvl0yh0")
+		assert.NotContains(t, photos.UIDs(), "pt9jtdre2lvl0yh9")
+	})
+	t.Run("form.similar not found", func(t *testing.T) {
+		var f form.SearchPhotos
+		f.Query = "similar:pt9jtdre2lvl0000"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
 
 	t.Run("form.portrait", func(t *testing.T) {
 		var f form.SearchPhotos
@@ -1494,3 +1603,334 @@ func TestPhotos(t *testing.T) {
 		assert.Equal(t, photos[0].PhotoTitle, "Neckarbrücke")
 	})
 }
+
+func TestPhotos_Boolean(t *testing.T) {
+	// uids returns the distinct photo UIDs found for a query.
+	uids := func(t *testing.T, q string) map[string]bool {
+		var f form.SearchPhotos
+		f.Query = q
+		f.Count = MaxResults
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result := make(map[string]bool)
+
+		for _, uid := range photos.UIDs() {
+			result[uid] = true
+		}
+
+		return result
+	}
+
+	all := uids(t, "")
+	cats := uids(t, "label:cat")
+	flowers := uids(t, "label:flower")
+	germany := uids(t, "country:de")
+
+	t.Run("Or", func(t *testing.T) {
+		result := uids(t, "label:cat | label:flower")
+
+		assert.NotEmpty(t, result)
+		assert.Len(t, result, len(cats)+len(flowers))
+
+		for uid := range cats {
+			assert.True(t, result[uid])
+		}
+
+		for uid := range flowers {
+			assert.True(t, result[uid])
+		}
+	})
+	t.Run("Not", func(t *testing.T) {
+		result := uids(t, "-country:de")
+
+		assert.NotEmpty(t, germany)
+		assert.Len(t, result, len(all)-len(germany))
+
+		for uid := range germany {
+			assert.False(t, result[uid])
+		}
+	})
+	t.Run("Group", func(t *testing.T) {
+		result := uids(t, "(label:cat OR label:flower) NOT country:de")
+
+		for uid := range result {
+			assert.True(t, cats[uid] || flowers[uid])
+			assert.False(t, germany[uid])
+		}
+	})
+	t.Run("Ranges", func(t *testing.T) {
+		result := uids(t, "(iso:>100 | iso:<=100) taken:1990..2100")
+		assert.NotEmpty(t, result)
+		assert.Empty(t, uids(t, "-(taken:..2100 | taken:2100..)"))
+	})
+	t.Run("NoResults", func(t *testing.T) {
+		assert.Empty(t, uids(t, "label:xxx | label:yyy"))
+		assert.Len(t, uids(t, "-label:xxx"), len(all))
+	})
+	t.Run("Error", func(t *testing.T) {
+		var f form.SearchPhotos
+		f.Query = "(label:cat | label:dog"
+		f.Count = 10
+
+		_, _, err := Photos(f)
+
+		if queryErr, ok := err.(*form.QueryError); assert.True(t, ok) {
+			assert.Equal(t, 1, queryErr.Pos)
+		}
+	})
+}
+
+func TestPhotosPaged(t *testing.T) {
+	for _, order := range []string{"", entity.SortOrderNewest, entity.SortOrderOldest, entity.SortOrderAdded,
+		entity.SortOrderRating, entity.SortOrderEdited, entity.SortOrderName} {
+		t.Run("Order"+order, func(t *testing.T) {
+			var f form.SearchPhotos
+
+			f.Order = order
+			f.Count = 1000
+			f.Total = true
+
+			all, _, allPage, err := PhotosPaged(f)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			uids := make(map[string]bool)
+
+			for _, r := range all {
+				uids[r.PhotoUID] = true
+			}
+
+			assert.Equal(t, len(uids), allPage.Total)
+			assert.Empty(t, allPage.Cursor)
+
+			var results PhotoResults
+
+			f.Count = 3
+			f.Total = false
+
+			for i := 0; i <= len(all); i++ {
+				res, _, page, err := PhotosPaged(f)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				results = append(results, res...)
+
+				if page.Cursor == "" {
+					break
+				}
+
+				f.Cursor = page.Cursor
+			}
+
+			if assert.Equal(t, len(all), len(results)) {
+				for i := range all {
+					assert.Equal(t, all[i].PhotoUID, results[i].PhotoUID)
+					assert.Equal(t, all[i].FileID, results[i].FileID)
+				}
+			}
+		})
+	}
+	t.Run("InvalidCursor", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Count = 3
+		f.Cursor = "foo"
+
+		_, _, _, err := PhotosPaged(f)
+
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+	t.Run("FilterChanged", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Count = 3
+		f.Favorite = true
+
+		_, _, page, err := PhotosPaged(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f.Favorite = false
+		f.Cursor = page.Cursor
+
+		_, _, _, err = PhotosPaged(f)
+
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+}
+
+func TestPhotos_Exposure(t *testing.T) {
+	// search returns the photos found with the given form, checking each result.
+	search := func(t *testing.T, f form.SearchPhotos, check func(p Photo) bool) PhotoResults {
+		f.Count = MaxResults
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.True(t, check(p), p.PhotoUID)
+		}
+
+		return photos
+	}
+
+	t.Run("FocalLength", func(t *testing.T) {
+		result := search(t, form.SearchPhotos{Mm: "35..50"}, func(p Photo) bool {
+			return p.PhotoFocalLength >= 35 && p.PhotoFocalLength <= 50
+		})
+
+		assert.NotEmpty(t, result)
+		assert.Equal(t, len(result), len(search(t, form.SearchPhotos{Query: "mm:35..50"}, func(p Photo) bool { return true })))
+	})
+	t.Run("Aperture", func(t *testing.T) {
+		result := search(t, form.SearchPhotos{F: "5"}, func(p Photo) bool {
+			return p.PhotoFNumber > 4.99 && p.PhotoFNumber < 5.01
+		})
+
+		assert.NotEmpty(t, result)
+
+		search(t, form.SearchPhotos{Query: "f:<=2.8"}, func(p Photo) bool {
+			return p.PhotoFNumber > 0 && p.PhotoFNumber <= 2.8
+		})
+	})
+	t.Run("Exposure", func(t *testing.T) {
+		result := search(t, form.SearchPhotos{Exposure: "1/80"}, func(p Photo) bool {
+			return p.PhotoExposure == "1/80"
+		})
+
+		assert.NotEmpty(t, result)
+
+		result = search(t, form.SearchPhotos{Query: "exposure:1/100..1/40"}, func(p Photo) bool {
+			return p.PhotoExposure == "1/80" || p.PhotoExposure == "1/50"
+		})
+
+		assert.NotEmpty(t, result)
+
+		search(t, form.SearchPhotos{Query: "exposure:>=1"}, func(p Photo) bool {
+			return p.PhotoExposure != "" && !strings.HasPrefix(p.PhotoExposure, "1/")
+		})
+	})
+	t.Run("Altitude", func(t *testing.T) {
+		result := search(t, form.SearchPhotos{Alt: "1..10"}, func(p Photo) bool {
+			return p.PhotoAltitude >= 1 && p.PhotoAltitude <= 10
+		})
+
+		assert.NotEmpty(t, result)
+	})
+	t.Run("Megapixels", func(t *testing.T) {
+		result := search(t, form.SearchPhotos{Query: "mp:>=2"}, func(p Photo) bool {
+			return p.PhotoResolution >= 2
+		})
+
+		assert.NotEmpty(t, result)
+
+		search(t, form.SearchPhotos{Mp: "<2"}, func(p Photo) bool {
+			return p.PhotoResolution < 2
+		})
+	})
+}
+
+func TestPhotos_Geo(t *testing.T) {
+	t.Run("BBox", func(t *testing.T) {
+		photos, _, err := Photos(form.SearchPhotos{Bbox: "-99,19.5,-98.5,20", Count: MaxResults})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photos)
+
+		for _, p := range photos {
+			assert.InDelta(t, 19.68, p.PhotoLat, 0.1)
+			assert.InDelta(t, -98.84, p.PhotoLng, 0.1)
+		}
+	})
+	t.Run("Polygon", func(t *testing.T) {
+		photos, _, err := Photos(form.SearchPhotos{
+			Polygon: `{"type":"Polygon","coordinates":[[[-99,19.5],[-98.5,19.5],[-98.5,20],[-99,20],[-99,19.5]]]}`,
+			Count:   MaxResults,
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photos)
+
+		for _, p := range photos {
+			assert.InDelta(t, 19.68, p.PhotoLat, 0.1)
+		}
+	})
+	t.Run("ConcavePolygon", func(t *testing.T) {
+		// The locations are in the notch of a "U" shaped polygon, but within its bounding box.
+		photos, _, err := Photos(form.SearchPhotos{
+			Polygon: `[[[-99,19.5],[-98.5,19.5],[-98.5,20],[-98.8,20],[-98.8,19.6],[-98.9,19.6],[-98.9,20],[-99,20],[-99,19.5]]]`,
+			Count:   MaxResults,
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+	t.Run("Cell", func(t *testing.T) {
+		photos, _, err := Photos(form.SearchPhotos{Query: "cell:85d1ea7c", Count: MaxResults})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photos)
+
+		for _, p := range photos {
+			assert.Equal(t, "s2:85d1ea7d382c", p.CellID)
+		}
+	})
+	t.Run("InvalidPolygon", func(t *testing.T) {
+		_, _, err := Photos(form.SearchPhotos{Polygon: "foo", Count: MaxResults})
+
+		assert.Equal(t, ErrInvalidPolygon, err)
+	})
+}
+
+func TestPhotos_DatePhrase(t *testing.T) {
+	t.Run("MonthYear", func(t *testing.T) {
+		photos, _, err := Photos(form.SearchPhotos{Query: "november 2020", Count: MaxResults})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photos)
+
+		for _, p := range photos {
+			assert.Equal(t, 2020, p.TakenAt.Year())
+			assert.Equal(t, time.November, p.TakenAt.Month())
+		}
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		photos, _, err := Photos(form.SearchPhotos{Query: "christmas 1850", Count: MaxResults})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+}
@@ -0,0 +1,325 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// RangeSep separates the lower and upper bound of a range, e.g. "1..3".
+const RangeSep = ".."
+
+// RangeInt returns a where condition that matches integers based on comparison and range
+// expressions like ">=4", "<2", "1..3", or "3|5".
+func RangeInt(col, s string) (where string) {
+	s = strings.TrimSpace(s)
+
+	if col == "" || s == "" {
+		return ""
+	}
+
+	var wheres []string
+
+	for _, expr := range strings.Split(s, txt.Or) {
+		if w := rangeIntExpr(col, strings.TrimSpace(expr)); w != "" {
+			wheres = append(wheres, w)
+		}
+	}
+
+	return rangeOr(wheres)
+}
+
+// rangeIntExpr returns the where condition for a single comparison or range expression.
+func rangeIntExpr(col, expr string) string {
+	if expr == "" {
+		return ""
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if !strings.HasPrefix(expr, op) {
+			continue
+		}
+
+		if i, err := strconv.Atoi(strings.TrimSpace(expr[len(op):])); err == nil {
+			return fmt.Sprintf("%s %s %d", col, op, i)
+		}
+
+		return ""
+	}
+
+	if !strings.Contains(expr, RangeSep) {
+		if i, err := strconv.Atoi(expr); err == nil {
+			return fmt.Sprintf("%s = %d", col, i)
+		}
+
+		return ""
+	}
+
+	bounds := strings.SplitN(expr, RangeSep, 2)
+	from, fromErr := strconv.Atoi(strings.TrimSpace(bounds[0]))
+	to, toErr := strconv.Atoi(strings.TrimSpace(bounds[1]))
+
+	switch {
+	case fromErr == nil && toErr == nil:
+		if from > to {
+			from, to = to, from
+		}
+
+		return fmt.Sprintf("%s BETWEEN %d AND %d", col, from, to)
+	case fromErr == nil:
+		return fmt.Sprintf("%s >= %d", col, from)
+	case toErr == nil:
+		return fmt.Sprintf("%s <= %d", col, to)
+	default:
+		return ""
+	}
+}
+
+// RangeTolerance is the relative tolerance for matching decimal numbers, e.g. f-numbers stored with single precision.
+const RangeTolerance = 1e-4
+
+// RangeFloat returns a where condition that matches decimal numbers based on comparison and range
+// expressions like ">=2.8", "1.4..2", or "1.8|4". Values may also be fractions like "1/250".
+func RangeFloat(col, s string) (where string) {
+	s = strings.TrimSpace(s)
+
+	if col == "" || s == "" {
+		return ""
+	}
+
+	var wheres []string
+
+	for _, expr := range strings.Split(s, txt.Or) {
+		if w := rangeFloatExpr(col, strings.TrimSpace(expr)); w != "" {
+			wheres = append(wheres, w)
+		}
+	}
+
+	return rangeOr(wheres)
+}
+
+// rangeFloatExpr returns the where condition for a single decimal comparison or range expression.
+func rangeFloatExpr(col, expr string) string {
+	if expr == "" {
+		return ""
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if !strings.HasPrefix(expr, op) {
+			continue
+		}
+
+		f, ok := rangeFloat(expr[len(op):])
+
+		if !ok {
+			return ""
+		}
+
+		switch op {
+		case ">=":
+			return fmt.Sprintf("%s >= %s", col, floatBound(f, -1))
+		case "<=":
+			return fmt.Sprintf("%s <= %s", col, floatBound(f, 1))
+		case ">":
+			return fmt.Sprintf("%s > %s", col, floatBound(f, 1))
+		case "<":
+			return fmt.Sprintf("%s < %s", col, floatBound(f, -1))
+		default:
+			return fmt.Sprintf("%s BETWEEN %s AND %s", col, floatBound(f, -1), floatBound(f, 1))
+		}
+	}
+
+	if !strings.Contains(expr, RangeSep) {
+		if f, ok := rangeFloat(expr); ok {
+			return fmt.Sprintf("%s BETWEEN %s AND %s", col, floatBound(f, -1), floatBound(f, 1))
+		}
+
+		return ""
+	}
+
+	bounds := strings.SplitN(expr, RangeSep, 2)
+	from, fromOk := rangeFloat(bounds[0])
+	to, toOk := rangeFloat(bounds[1])
+
+	switch {
+	case fromOk && toOk:
+		if from > to {
+			from, to = to, from
+		}
+
+		return fmt.Sprintf("%s BETWEEN %s AND %s", col, floatBound(from, -1), floatBound(to, 1))
+	case fromOk:
+		return fmt.Sprintf("%s >= %s", col, floatBound(from, -1))
+	case toOk:
+		return fmt.Sprintf("%s <= %s", col, floatBound(to, 1))
+	default:
+		return ""
+	}
+}
+
+// rangeFloat parses a decimal number or a fraction like "1/250".
+func rangeFloat(s string) (f float64, ok bool) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return 0, false
+	}
+
+	if i := strings.Index(s, "/"); i > 0 {
+		num, numErr := strconv.ParseFloat(strings.TrimSpace(s[:i]), 64)
+		den, denErr := strconv.ParseFloat(strings.TrimSpace(s[i+1:]), 64)
+
+		if numErr != nil || denErr != nil || den == 0 {
+			return 0, false
+		}
+
+		f = num / den
+	} else if v, err := strconv.ParseFloat(s, 64); err != nil {
+		return 0, false
+	} else {
+		f = v
+	}
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+
+	return f, true
+}
+
+// floatBound returns a decimal number as SQL literal, moved by the relative tolerance in the given direction.
+func floatBound(f float64, dir int) string {
+	f += float64(dir) * math.Abs(f) * RangeTolerance
+
+	// Round to 10 significant digits to avoid literals like "2.7997199999999998".
+	f, _ = strconv.ParseFloat(strconv.FormatFloat(f, 'g', 10, 64), 64)
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// RangeDate returns a where condition that matches timestamps based on comparison and range expressions
+// like "2019..2021", ">=2020-06", "<2018-03-15", or "2019|2021". Years and months match the complete period.
+func RangeDate(col, s string) (where string) {
+	s = strings.TrimSpace(s)
+
+	if col == "" || s == "" {
+		return ""
+	}
+
+	var wheres []string
+
+	for _, expr := range strings.Split(s, txt.Or) {
+		if w := rangeDateExpr(col, strings.TrimSpace(expr)); w != "" {
+			wheres = append(wheres, w)
+		}
+	}
+
+	return rangeOr(wheres)
+}
+
+// rangeOr combines the conditions of alternative range expressions, each wrapped in parentheses
+// so that the result doesn't depend on how it is embedded in a query.
+func rangeOr(wheres []string) string {
+	if len(wheres) < 2 {
+		return strings.Join(wheres, "")
+	}
+
+	return "(" + strings.Join(wheres, ") OR (") + ")"
+}
+
+// rangeDateExpr returns the where condition for a single date comparison or range expression.
+func rangeDateExpr(col, expr string) string {
+	if expr == "" {
+		return ""
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if !strings.HasPrefix(expr, op) {
+			continue
+		}
+
+		start, end, ok := datePeriod(expr[len(op):])
+
+		if !ok {
+			return ""
+		}
+
+		switch op {
+		case ">=":
+			return dateCompare(col, ">=", start)
+		case "<=":
+			return dateCompare(col, "<", end)
+		case ">":
+			return dateCompare(col, ">=", end)
+		case "<":
+			return dateCompare(col, "<", start)
+		default:
+			return dateCompare(col, ">=", start) + " AND " + dateCompare(col, "<", end)
+		}
+	}
+
+	if !strings.Contains(expr, RangeSep) {
+		if start, end, ok := datePeriod(expr); ok {
+			return dateCompare(col, ">=", start) + " AND " + dateCompare(col, "<", end)
+		}
+
+		return ""
+	}
+
+	bounds := strings.SplitN(expr, RangeSep, 2)
+	fromStart, fromEnd, fromOk := datePeriod(bounds[0])
+	toStart, toEnd, toOk := datePeriod(bounds[1])
+
+	switch {
+	case fromOk && toOk:
+		if fromStart.After(toStart) {
+			fromStart, toEnd = toStart, fromEnd
+		}
+
+		return dateCompare(col, ">=", fromStart) + " AND " + dateCompare(col, "<", toEnd)
+	case fromOk:
+		return dateCompare(col, ">=", fromStart)
+	case toOk:
+		return dateCompare(col, "<", toEnd)
+	default:
+		return ""
+	}
+}
+
+// datePeriod parses a year, month, or day like "2019", "2019-06", or "2019-06-15" and returns
+// the start of the period and the start of the following period.
+func datePeriod(s string) (start, end time.Time, ok bool) {
+	s = strings.TrimSpace(s)
+
+	layouts := []struct {
+		layout string
+		years  int
+		months int
+		days   int
+	}{
+		{"2006", 1, 0, 0},
+		{"2006-01", 0, 1, 0},
+		{"2006-01-02", 0, 0, 1},
+	}
+
+	for _, l := range layouts {
+		if len(s) != len(l.layout) {
+			continue
+		}
+
+		if t, err := time.ParseInLocation(l.layout, s, time.UTC); err == nil {
+			return t, t.AddDate(l.years, l.months, l.days), true
+		}
+	}
+
+	return start, end, false
+}
+
+// dateCompare returns a where condition that compares a column with a timestamp.
+func dateCompare(col, op string, t time.Time) string {
+	return fmt.Sprintf("%s %s '%s'", col, op, t.Format("2006-01-02 15:04:05"))
+}
@@ -0,0 +1,111 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeInt(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", RangeInt("photos.photo_rating", ""))
+		assert.Equal(t, "", RangeInt("", "4"))
+	})
+	t.Run("Equal", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_rating = 4", RangeInt("photos.photo_rating", "4"))
+		assert.Equal(t, "photos.photo_rating = 0", RangeInt("photos.photo_rating", "=0"))
+		assert.Equal(t, "photos.photo_rating = -1", RangeInt("photos.photo_rating", "-1"))
+	})
+	t.Run("Compare", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_rating >= 4", RangeInt("photos.photo_rating", ">=4"))
+		assert.Equal(t, "photos.photo_rating <= 2", RangeInt("photos.photo_rating", "<= 2"))
+		assert.Equal(t, "photos.photo_rating > 3", RangeInt("photos.photo_rating", ">3"))
+		assert.Equal(t, "photos.photo_rating < 1", RangeInt("photos.photo_rating", "<1"))
+	})
+	t.Run("Range", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_rating BETWEEN 1 AND 3", RangeInt("photos.photo_rating", "1..3"))
+		assert.Equal(t, "photos.photo_rating BETWEEN 1 AND 3", RangeInt("photos.photo_rating", "3..1"))
+		assert.Equal(t, "photos.photo_rating >= 2", RangeInt("photos.photo_rating", "2.."))
+		assert.Equal(t, "photos.photo_rating <= 2", RangeInt("photos.photo_rating", "..2"))
+	})
+	t.Run("Or", func(t *testing.T) {
+		assert.Equal(t, "(photos.photo_rating = 1) OR (photos.photo_rating >= 4)", RangeInt("photos.photo_rating", "1|>=4"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, "", RangeInt("photos.photo_rating", "foo"))
+		assert.Equal(t, "", RangeInt("photos.photo_rating", ">=bar"))
+		assert.Equal(t, "", RangeInt("photos.photo_rating", "a..b"))
+		assert.Equal(t, "photos.photo_rating = 5", RangeInt("photos.photo_rating", "x|5"))
+	})
+}
+
+func TestRangeDate(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", RangeDate("photos.taken_at", ""))
+		assert.Equal(t, "", RangeDate("", "2019"))
+	})
+	t.Run("Year", func(t *testing.T) {
+		assert.Equal(t, "photos.taken_at >= '2019-01-01 00:00:00' AND photos.taken_at < '2020-01-01 00:00:00'", RangeDate("photos.taken_at", "2019"))
+	})
+	t.Run("Month", func(t *testing.T) {
+		assert.Equal(t, "photos.taken_at >= '2019-12-01 00:00:00' AND photos.taken_at < '2020-01-01 00:00:00'", RangeDate("photos.taken_at", "=2019-12"))
+	})
+	t.Run("Day", func(t *testing.T) {
+		assert.Equal(t, "photos.taken_at >= '2020-02-29 00:00:00' AND photos.taken_at < '2020-03-01 00:00:00'", RangeDate("photos.taken_at", "2020-02-29"))
+	})
+	t.Run("Compare", func(t *testing.T) {
+		assert.Equal(t, "photos.taken_at >= '2020-06-01 00:00:00'", RangeDate("photos.taken_at", ">=2020-06"))
+		assert.Equal(t, "photos.taken_at >= '2021-01-01 00:00:00'", RangeDate("photos.taken_at", ">2020"))
+		assert.Equal(t, "photos.taken_at < '2020-01-01 00:00:00'", RangeDate("photos.taken_at", "<2020"))
+		assert.Equal(t, "photos.taken_at < '2020-03-16 00:00:00'", RangeDate("photos.taken_at", "<=2020-03-15"))
+	})
+	t.Run("Range", func(t *testing.T) {
+		assert.Equal(t, "photos.taken_at >= '2019-01-01 00:00:00' AND photos.taken_at < '2022-01-01 00:00:00'", RangeDate("photos.taken_at", "2019..2021"))
+		assert.Equal(t, "photos.taken_at >= '2019-01-01 00:00:00' AND photos.taken_at < '2022-01-01 00:00:00'", RangeDate("photos.taken_at", "2021..2019"))
+		assert.Equal(t, "photos.taken_at >= '2019-06-01 00:00:00'", RangeDate("photos.taken_at", "2019-06.."))
+		assert.Equal(t, "photos.taken_at < '2019-07-01 00:00:00'", RangeDate("photos.taken_at", "..2019-06"))
+	})
+	t.Run("Or", func(t *testing.T) {
+		assert.Equal(t, "(photos.taken_at >= '2019-01-01 00:00:00' AND photos.taken_at < '2020-01-01 00:00:00') OR (photos.taken_at >= '2021-01-01 00:00:00')", RangeDate("photos.taken_at", "2019|>=2021"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, "", RangeDate("photos.taken_at", "foo"))
+		assert.Equal(t, "", RangeDate("photos.taken_at", "2019-13"))
+		assert.Equal(t, "", RangeDate("photos.taken_at", "'; DROP TABLE photos"))
+		assert.Equal(t, "", RangeDate("photos.taken_at", "a..b"))
+	})
+}
+
+func TestRangeFloat(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", RangeFloat("photos.photo_f_number", ""))
+		assert.Equal(t, "", RangeFloat("", "2.8"))
+	})
+	t.Run("Equal", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_f_number BETWEEN 2.79972 AND 2.80028", RangeFloat("photos.photo_f_number", "2.8"))
+		assert.Equal(t, "photos.photo_f_number BETWEEN 2.79972 AND 2.80028", RangeFloat("photos.photo_f_number", "=2.8"))
+		assert.Equal(t, "photos.photo_f_number BETWEEN 0 AND 0", RangeFloat("photos.photo_f_number", "0"))
+	})
+	t.Run("Compare", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_f_number >= 1.9998", RangeFloat("photos.photo_f_number", ">=2"))
+		assert.Equal(t, "photos.photo_f_number <= 4.0004", RangeFloat("photos.photo_f_number", "<= 4"))
+		assert.Equal(t, "photos.photo_f_number > 4.0004", RangeFloat("photos.photo_f_number", ">4"))
+		assert.Equal(t, "photos.photo_f_number < 3.9996", RangeFloat("photos.photo_f_number", "<4"))
+	})
+	t.Run("Fraction", func(t *testing.T) {
+		assert.Equal(t, "exposure <= 0.0040004", RangeFloat("exposure", "<=1/250"))
+		assert.Equal(t, "exposure BETWEEN 0.0039996 AND 0.01666833333", RangeFloat("exposure", "1/60..1/250"))
+		assert.Equal(t, "exposure >= 0.9999", RangeFloat("exposure", "1.."))
+	})
+	t.Run("Or", func(t *testing.T) {
+		assert.Equal(t, "(photos.photo_f_number < 1.9998) OR (photos.photo_f_number > 8.0008)", RangeFloat("photos.photo_f_number", "<2|>8"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, "", RangeFloat("photos.photo_f_number", "foo"))
+		assert.Equal(t, "", RangeFloat("photos.photo_f_number", ">=bar"))
+		assert.Equal(t, "", RangeFloat("photos.photo_f_number", "a..b"))
+		assert.Equal(t, "", RangeFloat("photos.photo_f_number", "1/0"))
+		assert.Equal(t, "", RangeFloat("photos.photo_f_number", "NaN"))
+		assert.Equal(t, "", RangeFloat("photos.photo_f_number", "'; DROP TABLE photos"))
+	})
+}
@@ -0,0 +1,305 @@
+package search
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// SimilarFile represents a file with a perceptual hash.
+type SimilarFile struct {
+	PhotoUID  string `json:"PhotoUID"`
+	FileUID   string `json:"UID"`
+	FileName  string `json:"Name"`
+	FileRoot  string `json:"Root"`
+	FileHash  string `json:"Hash"`
+	FileSize  int64  `json:"Size"`
+	FilePHash string `gorm:"column:file_phash;" json:"PHash"`
+	Distance  int    `gorm:"-" json:"Distance"`
+}
+
+// SimilarFiles represents a list of files with a perceptual hash.
+type SimilarFiles []SimilarFile
+
+// PhotoUIDs returns the distinct photo UIDs in the list.
+func (m SimilarFiles) PhotoUIDs() (result []string) {
+	found := make(map[string]bool, len(m))
+
+	for _, f := range m {
+		if !found[f.PhotoUID] {
+			found[f.PhotoUID] = true
+			result = append(result, f.PhotoUID)
+		}
+	}
+
+	return result
+}
+
+// similarFields are the columns of files with a perceptual hash.
+const similarFields = "photo_uid, file_uid, file_name, file_root, file_hash, file_size, file_phash"
+
+// PerceptualHashes returns all existing files with a perceptual hash sorted by file name.
+func PerceptualHashes() (result SimilarFiles, err error) {
+	err = UnscopedDb().
+		Table(entity.File{}.TableName()).
+		Select(similarFields).
+		Where("file_missing = 0 AND deleted_at IS NULL").
+		Where("file_phash IS NOT NULL AND file_phash <> ''").
+		Order("file_name, file_uid").
+		Scan(&result).Error
+
+	return result, err
+}
+
+// similarFilesByUID returns the existing files with a perceptual hash and one of the given UIDs.
+func similarFilesByUID(fileUIDs []string) (result SimilarFiles, err error) {
+	if len(fileUIDs) == 0 {
+		return result, nil
+	}
+
+	err = UnscopedDb().
+		Table(entity.File{}.TableName()).
+		Select(similarFields).
+		Where("file_uid IN (?) AND file_missing = 0 AND deleted_at IS NULL", fileUIDs).
+		Where("file_phash IS NOT NULL AND file_phash <> ''").
+		Scan(&result).Error
+
+	return result, err
+}
+
+// similarIndex returns a BK-tree of all valid perceptual hashes, the files sorted by name, and the files by UID.
+func similarIndex() (tree *phash.Tree, list SimilarFiles, files map[string]SimilarFile, err error) {
+	tree = phash.NewTree()
+
+	found, err := PerceptualHashes()
+
+	if err != nil {
+		return tree, list, files, err
+	}
+
+	files = make(map[string]SimilarFile, len(found))
+
+	for _, f := range found {
+		if h, err := phash.ParseHex(f.FilePHash); err != nil {
+			log.Debugf("files: %s in %s", err, f.FileUID)
+		} else {
+			tree.Add(f.FileUID, h)
+			list = append(list, f)
+			files[f.FileUID] = f
+		}
+	}
+
+	return tree, list, files, nil
+}
+
+// similarCache caches the BK-tree of perceptual hashes by file UID, so that it isn't rebuilt for each search.
+type similarCache struct {
+	mutex  sync.RWMutex
+	tree   *phash.Tree
+	hashes map[string]phash.Hash
+}
+
+var similarHashes = similarCache{}
+
+// load builds the tree from the index, unless this has already been done.
+func (c *similarCache) load() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.tree != nil {
+		return nil
+	}
+
+	tree, _, files, err := similarIndex()
+
+	if err != nil {
+		return err
+	}
+
+	c.tree = tree
+	c.hashes = make(map[string]phash.Hash, len(files))
+
+	for fileUID, f := range files {
+		c.hashes[fileUID], _ = phash.ParseHex(f.FilePHash)
+	}
+
+	return nil
+}
+
+// update replaces the perceptual hash of a file, or removes it if the hash is empty or invalid.
+func (c *similarCache) update(fileUID, phashHex string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Hashes are added when the tree is built.
+	if c.tree == nil {
+		return
+	}
+
+	if h, ok := c.hashes[fileUID]; ok {
+		c.tree.Remove(fileUID, h)
+		delete(c.hashes, fileUID)
+	}
+
+	if phashHex == "" {
+		return
+	} else if h, err := phash.ParseHex(phashHex); err == nil {
+		c.tree.Add(fileUID, h)
+		c.hashes[fileUID] = h
+	}
+}
+
+// find returns the distances of all files within maxDist of the given hashes.
+func (c *similarCache) find(hashes []phash.Hash, maxDist int) map[string]int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	distances := make(map[string]int)
+
+	for _, h := range hashes {
+		for _, match := range c.tree.Find(h, maxDist) {
+			if d, ok := distances[match.ID]; !ok || match.Distance < d {
+				distances[match.ID] = match.Distance
+			}
+		}
+	}
+
+	return distances
+}
+
+// UpdateSimilarFile updates the cached perceptual hash of a file, e.g. after it has been indexed.
+func UpdateSimilarFile(fileUID, phashHex string) {
+	similarHashes.update(fileUID, phashHex)
+}
+
+// RemoveSimilarFile removes a file from the cached perceptual hashes, e.g. after it has been deleted.
+func RemoveSimilarFile(fileUID string) {
+	similarHashes.update(fileUID, "")
+}
+
+// SimilarPhotos returns files of other photos that look similar to the given photo, sorted by distance.
+func SimilarPhotos(photoUID string, maxDist int) (result SimilarFiles, err error) {
+	if err = similarHashes.load(); err != nil {
+		return result, err
+	}
+
+	var own SimilarFiles
+
+	if err = UnscopedDb().
+		Table(entity.File{}.TableName()).
+		Select(similarFields).
+		Where("photo_uid = ? AND file_missing = 0 AND deleted_at IS NULL", photoUID).
+		Where("file_phash IS NOT NULL AND file_phash <> ''").
+		Scan(&own).Error; err != nil {
+		return result, err
+	}
+
+	hashes := make([]phash.Hash, 0, len(own))
+
+	for _, f := range own {
+		if h, err := phash.ParseHex(f.FilePHash); err == nil {
+			hashes = append(hashes, h)
+		}
+	}
+
+	distances := similarHashes.find(hashes, maxDist)
+	fileUIDs := make([]string, 0, len(distances))
+
+	for fileUID := range distances {
+		fileUIDs = append(fileUIDs, fileUID)
+	}
+
+	// Fetch the current file infos, as files may have been moved to other photos or deleted.
+	found, err := similarFilesByUID(fileUIDs)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, f := range found {
+		delete(distances, f.FileUID)
+
+		h, err := phash.ParseHex(f.FilePHash)
+
+		if err != nil || f.PhotoUID == photoUID {
+			continue
+		}
+
+		// The cached hash may be outdated if the file was indexed by another process.
+		f.Distance = phash.Bits + 1
+
+		for _, own := range hashes {
+			if d := own.Distance(h); d < f.Distance {
+				f.Distance = d
+			}
+		}
+
+		if f.Distance <= maxDist {
+			result = append(result, f)
+		} else {
+			UpdateSimilarFile(f.FileUID, f.FilePHash)
+		}
+	}
+
+	// Remove files that no longer exist from the cache.
+	for fileUID := range distances {
+		RemoveSimilarFile(fileUID)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Distance != result[j].Distance {
+			return result[i].Distance < result[j].Distance
+		}
+
+		return result[i].FileName < result[j].FileName
+	})
+
+	return result, nil
+}
+
+// SimilarGroups returns groups of files that belong to different photos and look similar,
+// for example re-encoded or resized copies.
+func SimilarGroups(maxDist int) (result []SimilarFiles, err error) {
+	tree, list, files, err := similarIndex()
+
+	if err != nil {
+		return result, err
+	}
+
+	grouped := make(map[string]bool, len(files))
+
+	for _, f := range list {
+		if grouped[f.FileUID] {
+			continue
+		}
+
+		h, _ := phash.ParseHex(f.FilePHash)
+
+		var group SimilarFiles
+
+		for _, match := range tree.Find(h, maxDist) {
+			if grouped[match.ID] {
+				continue
+			}
+
+			m := files[match.ID]
+			m.Distance = match.Distance
+			group = append(group, m)
+		}
+
+		// Only report files that belong to more than one photo.
+		if len(group.PhotoUIDs()) < 2 {
+			continue
+		}
+
+		for _, m := range group {
+			grouped[m.FileUID] = true
+		}
+
+		result = append(result, group)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,105 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+func TestPerceptualHashes(t *testing.T) {
+	result, err := PerceptualHashes()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(result), 4)
+
+	for _, f := range result {
+		assert.Len(t, f.FilePHash, 16)
+	}
+}
+
+func TestSimilarPhotos(t *testing.T) {
+	t.Run("Photo02", func(t *testing.T) {
+		result, err := SimilarPhotos("pt9jtdre2lvl0yh9", phash.DefaultDistance)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh0", "pt9jtdre2lvl0y12"}, result.PhotoUIDs())
+		assert.Equal(t, 1, result[0].Distance)
+		assert.Equal(t, 4, result[1].Distance)
+	})
+	t.Run("Distance", func(t *testing.T) {
+		result, err := SimilarPhotos("pt9jtdre2lvl0yh9", 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh0"}, result.PhotoUIDs())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		result, err := SimilarPhotos("pt9jtdre2lvl0000", phash.DefaultDistance)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
+
+func TestUpdateSimilarFile(t *testing.T) {
+	result, err := SimilarPhotos("pt9jtdre2lvl0yh9", phash.DefaultDistance)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result, 2)
+
+	f := result[0]
+
+	RemoveSimilarFile(f.FileUID)
+
+	if result, err = SimilarPhotos("pt9jtdre2lvl0yh9", phash.DefaultDistance); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"pt9jtdre2lvl0y12"}, result.PhotoUIDs())
+
+	UpdateSimilarFile(f.FileUID, f.FilePHash)
+
+	if result, err = SimilarPhotos("pt9jtdre2lvl0yh9", phash.DefaultDistance); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"pt9jtdre2lvl0yh0", "pt9jtdre2lvl0y12"}, result.PhotoUIDs())
+}
+
+func TestSimilarGroups(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		result, err := SimilarGroups(phash.DefaultDistance)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.ElementsMatch(t, []string{"pt9jtdre2lvl0yh9", "pt9jtdre2lvl0yh0", "pt9jtdre2lvl0y12"}, result[0].PhotoUIDs())
+	})
+	t.Run("Exact", func(t *testing.T) {
+		result, err := SimilarGroups(0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
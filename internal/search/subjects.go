@@ -15,8 +15,16 @@ import (
 
 // Subjects searches subjects and returns them.
 func Subjects(f form.SearchSubjects) (results SubjectResults, err error) {
+	results, _, err = SubjectsPaged(f)
+
+	return results, err
+}
+
+// SubjectsPaged returns a page of subjects like Subjects along with the cursor of the following page,
+// which is empty after the last subject.
+func SubjectsPaged(f form.SearchSubjects) (results SubjectResults, page Page, err error) {
 	if err := f.ParseQueryString(); err != nil {
-		return results, err
+		return results, page, err
 	}
 
 	subjTable := entity.Subject{}.TableName()
@@ -26,34 +34,72 @@ func Subjects(f form.SearchSubjects) (results SubjectResults, err error) {
 		Select(fmt.Sprintf("%s.*", subjTable))
 
 	// Limit result count.
-	if f.Count > 0 && f.Count <= MaxResults {
-		s = s.Limit(f.Count).Offset(f.Offset)
-	} else {
-		s = s.Limit(MaxResults).Offset(f.Offset)
-	}
+	limit := resultLimit(f.Count)
+	s = s.Limit(limit)
 
 	// Set sort order.
+	keys := subjectsKeys(f.Order)
+
 	switch f.Order {
-	case "name":
-		s = s.Order("subj_name")
-	case "count":
-		s = s.Order("file_count DESC")
 	case "added":
 		s = s.Order(fmt.Sprintf("%s.created_at DESC", subjTable))
-	case "relevance":
-		s = s.Order("subj_favorite DESC, photo_count DESC")
 	default:
-		s = s.Order("subj_favorite DESC, subj_name")
+		s = s.Order(keys.Order())
 	}
 
-	if f.UID != "" {
-		s = s.Where(fmt.Sprintf("%s.subj_uid IN (?)", subjTable), strings.Split(strings.ToLower(f.UID), txt.Or))
+	// Filter by subject type, name and the other form fields.
+	s = subjectsFilter(s, f)
+
+	page, err = scanPage(s, pageRequest{
+		Form:     &f,
+		Cursor:   f.Cursor,
+		Order:    f.Order,
+		Offset:   f.Offset,
+		Limit:    limit,
+		Total:    f.Total,
+		TotalCol: fmt.Sprintf("%s.subj_uid", subjTable),
+		Keys:     keys,
+	}, &results)
+
+	return results, page, err
+}
 
-		if result := s.Scan(&results); result.Error != nil {
-			return results, result.Error
+// subjectsKeys returns the unique sort keys for keyset pagination, or nil if the order isn't supported.
+func subjectsKeys(order string) sortKeys {
+	switch order {
+	case "name":
+		return sortKeys{
+			{Col: "subj_name", Type: keyString},
+			{Col: "subj_uid", Type: keyString},
+		}
+	case "count":
+		return sortKeys{
+			{Col: "file_count", Desc: true, Type: keyInt},
+			{Col: "subj_uid", Type: keyString},
 		}
+	case "added":
+		return nil
+	case "relevance":
+		return sortKeys{
+			{Col: "subj_favorite", Desc: true, Type: keyInt},
+			{Col: "photo_count", Desc: true, Type: keyInt},
+			{Col: "subj_uid", Type: keyString},
+		}
+	default:
+		return sortKeys{
+			{Col: "subj_favorite", Desc: true, Type: keyInt},
+			{Col: "subj_name", Type: keyString},
+			{Col: "subj_uid", Type: keyString},
+		}
+	}
+}
 
-		return results, nil
+// subjectsFilter applies the search form filters to a subject query.
+func subjectsFilter(s *gorm.DB, f form.SearchSubjects) *gorm.DB {
+	subjTable := entity.Subject{}.TableName()
+
+	if f.UID != "" {
+		return s.Where(fmt.Sprintf("%s.subj_uid IN (?)", subjTable), strings.Split(strings.ToLower(f.UID), txt.Or))
 	}
 
 	if f.Query != "" {
@@ -99,13 +145,7 @@ func Subjects(f form.SearchSubjects) (results SubjectResults, err error) {
 	}
 
 	// Omit deleted rows.
-	s = s.Where(fmt.Sprintf("%s.deleted_at IS NULL", subjTable))
-
-	if result := s.Scan(&results); result.Error != nil {
-		return results, result.Error
-	}
-
-	return results, nil
+	return s.Where(fmt.Sprintf("%s.deleted_at IS NULL", subjTable))
 }
 
 // SubjectUIDs finds subject UIDs matching the search string, and removes names from the remaining query.
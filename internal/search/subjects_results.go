@@ -21,3 +21,29 @@ type Subject struct {
 
 // SubjectResults represents subject search results.
 type SubjectResults []Subject
+
+// sortValues returns the subject fields matching the sort keys, so a cursor can point to this subject.
+func (m Subject) sortValues(keys sortKeys) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(keys))
+
+	for i, k := range keys {
+		switch k.Col {
+		case "subj_uid":
+			values[i] = m.SubjUID
+		case "subj_name":
+			values[i] = m.SubjName
+		case "subj_favorite":
+			values[i] = keyBoolValue(m.SubjFavorite)
+		case "file_count":
+			values[i] = keyIntValue(int64(m.FileCount))
+		case "photo_count":
+			values[i] = keyIntValue(int64(m.PhotoCount))
+		}
+	}
+
+	return values
+}
@@ -105,3 +105,46 @@ func TestSubjectUIDs(t *testing.T) {
 		assert.Equal(t, 0, len(results))
 	})
 }
+
+func TestSubjectsPaged(t *testing.T) {
+	for _, order := range []string{"", "name", "count", "relevance", "added"} {
+		t.Run("Order"+order, func(t *testing.T) {
+			f := form.SearchSubjects{Order: order, Count: 1000, Total: true}
+
+			all, allPage, err := SubjectsPaged(f)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, len(all), allPage.Total)
+
+			var results SubjectResults
+
+			f.Count = 2
+			f.Total = false
+
+			for i := 0; i <= len(all); i++ {
+				res, page, err := SubjectsPaged(f)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				results = append(results, res...)
+
+				if page.Cursor == "" {
+					break
+				}
+
+				f.Cursor = page.Cursor
+			}
+
+			if assert.Equal(t, len(all), len(results)) {
+				for i := range all {
+					assert.Equal(t, all[i].SubjUID, results[i].SubjUID)
+				}
+			}
+		})
+	}
+}
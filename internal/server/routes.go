@@ -67,14 +67,21 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.GetThumb(v1)
 		api.GetDownload(v1)
 		api.GetVideo(v1)
+		api.GetVideoHls(v1)
+		api.GetVideoHlsSegment(v1)
+		api.GetVideoPreview(v1)
 		api.CreateZip(v1)
 		api.DownloadZip(v1)
 
 		// Photos.
 		api.SearchPhotos(v1)
 		api.SearchGeo(v1)
+		api.SearchFacets(v1)
+		api.SearchMemories(v1)
+		api.ExportPhotos(v1)
 		api.GetPhoto(v1)
 		api.GetPhotoYaml(v1)
+		api.GetPhotoSimilar(v1)
 		api.UpdatePhoto(v1)
 		api.GetPhotoDownload(v1)
 		api.GetPhotoLinks(v1)
@@ -147,6 +154,7 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.CancelImport(v1)
 		api.StartIndexing(v1)
 		api.CancelIndexing(v1)
+		api.Geotag(v1)
 
 		// Batch operations.
 		api.BatchPhotosApprove(v1)
@@ -154,6 +162,8 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.BatchPhotosRestore(v1)
 		api.BatchPhotosPrivate(v1)
 		api.BatchPhotosDelete(v1)
+		api.BatchPhotosTimeShift(v1)
+		api.BatchPhotosTimeShiftUndo(v1)
 		api.BatchAlbumsDelete(v1)
 		api.BatchLabelsDelete(v1)
 
@@ -27,6 +27,8 @@ var services struct {
 	Moments     *photoprism.Moments
 	Faces       *photoprism.Faces
 	Places      *photoprism.Places
+	Geotag      *photoprism.Geotag
+	TimeShift   *photoprism.TimeShift
 	Purge       *photoprism.Purge
 	CleanUp     *photoprism.CleanUp
 	Nsfw        *nsfw.Detector
@@ -4,21 +4,28 @@ import (
 	"errors"
 	"fmt"
 	"image"
-	"image/png"
 	"os"
 	"path"
 	"path/filepath"
 
-	"github.com/disintegration/imaging"
-
+	"github.com/photoprism/photoprism/pkg/focus"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
 // Suffix returns the thumb cache file suffix.
 func Suffix(width, height int, opts ...ResampleOption) (result string) {
+	return SuffixFocus(width, height, nil, opts...)
+}
+
+// SuffixFocus returns the thumb cache file suffix, smart crops include a hash of the focus areas.
+func SuffixFocus(width, height int, areas focus.Areas, opts ...ResampleOption) (result string) {
 	method, _, format := ResampleOptions(opts...)
 
+	if focusHash := areas.Hash(); method == ResampleFillSmart && focusHash != "" {
+		return fmt.Sprintf("%dx%d_%s_%s.%s", width, height, ResampleMethods[method], focusHash, format)
+	}
+
 	result = fmt.Sprintf("%dx%d_%s.%s", width, height, ResampleMethods[method], format)
 
 	return result
@@ -26,6 +33,11 @@ func Suffix(width, height int, opts ...ResampleOption) (result string) {
 
 // FileName returns the thumb cache file name based on path, size, and options.
 func FileName(hash string, thumbPath string, width, height int, opts ...ResampleOption) (fileName string, err error) {
+	return FileNameFocus(hash, thumbPath, width, height, nil, opts...)
+}
+
+// FileNameFocus returns the thumb cache file name based on path, size, focus areas, and options.
+func FileNameFocus(hash string, thumbPath string, width, height int, areas focus.Areas, opts ...ResampleOption) (fileName string, err error) {
 	if InvalidSize(width) {
 		return "", fmt.Errorf("resample: width exceeds limit (%d)", width)
 	}
@@ -42,7 +54,7 @@ func FileName(hash string, thumbPath string, width, height int, opts ...Resample
 		return "", errors.New("resample: folder is empty")
 	}
 
-	suffix := Suffix(width, height, opts...)
+	suffix := SuffixFocus(width, height, areas, opts...)
 	p := path.Join(thumbPath, hash[0:1], hash[1:2], hash[2:3])
 
 	if err := os.MkdirAll(p, os.ModePerm); err != nil {
@@ -56,6 +68,11 @@ func FileName(hash string, thumbPath string, width, height int, opts ...Resample
 
 // FromCache returns the thumb cache file name for an image.
 func FromCache(imageFilename, hash, thumbPath string, width, height int, opts ...ResampleOption) (fileName string, err error) {
+	return FromCacheFocus(imageFilename, hash, thumbPath, width, height, nil, opts...)
+}
+
+// FromCacheFocus returns the thumb cache file name for an image cropped with the focus areas.
+func FromCacheFocus(imageFilename, hash, thumbPath string, width, height int, areas focus.Areas, opts ...ResampleOption) (fileName string, err error) {
 	if len(hash) < 4 {
 		return "", fmt.Errorf("resample: invalid file hash %s", sanitize.Log(hash))
 	}
@@ -64,7 +81,7 @@ func FromCache(imageFilename, hash, thumbPath string, width, height int, opts ..
 		return "", fmt.Errorf("resample: invalid file name %s", sanitize.Log(imageFilename))
 	}
 
-	fileName, err = FileName(hash, thumbPath, width, height, opts...)
+	fileName, err = FileNameFocus(hash, thumbPath, width, height, areas, opts...)
 
 	if err != nil {
 		log.Error(err)
@@ -80,14 +97,19 @@ func FromCache(imageFilename, hash, thumbPath string, width, height int, opts ..
 
 // FromFile returns the thumb cache file name for an image, and creates it if needed.
 func FromFile(imageFilename, hash, thumbPath string, width, height, orientation int, opts ...ResampleOption) (fileName string, err error) {
-	if fileName, err := FromCache(imageFilename, hash, thumbPath, width, height, opts...); err == nil {
+	return FromFileFocus(imageFilename, hash, thumbPath, width, height, orientation, nil, opts...)
+}
+
+// FromFileFocus returns the thumb cache file name for an image, and creates it with the focus areas if needed.
+func FromFileFocus(imageFilename, hash, thumbPath string, width, height, orientation int, areas focus.Areas, opts ...ResampleOption) (fileName string, err error) {
+	if fileName, err := FromCacheFocus(imageFilename, hash, thumbPath, width, height, areas, opts...); err == nil {
 		return fileName, err
 	} else if err != ErrThumbNotCached {
 		return "", err
 	}
 
 	// Generate thumb cache filename.
-	fileName, err = FileName(hash, thumbPath, width, height, opts...)
+	fileName, err = FileNameFocus(hash, thumbPath, width, height, areas, opts...)
 
 	if err != nil {
 		log.Error(err)
@@ -103,7 +125,7 @@ func FromFile(imageFilename, hash, thumbPath string, width, height, orientation
 	}
 
 	// Create thumb from image.
-	if _, err := Create(img, fileName, width, height, opts...); err != nil {
+	if _, err := CreateFocus(img, fileName, width, height, areas, opts...); err != nil {
 		return "", err
 	}
 
@@ -112,6 +134,11 @@ func FromFile(imageFilename, hash, thumbPath string, width, height, orientation
 
 // Create creates an image thumbnail.
 func Create(img image.Image, fileName string, width, height int, opts ...ResampleOption) (result image.Image, err error) {
+	return CreateFocus(img, fileName, width, height, nil, opts...)
+}
+
+// CreateFocus creates an image thumbnail, smart crops keep the focus areas visible if possible.
+func CreateFocus(img image.Image, fileName string, width, height int, areas focus.Areas, opts ...ResampleOption) (result image.Image, err error) {
 	if InvalidSize(width) {
 		return img, fmt.Errorf("resample: width has an invalid value (%d)", width)
 	}
@@ -120,19 +147,16 @@ func Create(img image.Image, fileName string, width, height int, opts ...Resampl
 		return img, fmt.Errorf("resample: height has an invalid value (%d)", height)
 	}
 
-	result = Resample(img, width, height, opts...)
+	result = ResampleFocus(img, width, height, areas, opts...)
 
-	var saveOption imaging.EncodeOption
+	_, _, format := ResampleOptions(opts...)
 
-	if filepath.Ext(fileName) == "."+string(fs.FormatPng) {
-		saveOption = imaging.PNGCompressionLevel(png.DefaultCompression)
-	} else if width <= 150 && height <= 150 {
-		saveOption = imaging.JPEGQuality(JpegQualitySmall)
-	} else {
-		saveOption = imaging.JPEGQuality(JpegQuality)
+	// Use file extension as fallback for backwards compatibility.
+	if format == fs.FormatJpeg && filepath.Ext(fileName) == "."+string(fs.FormatPng) {
+		format = fs.FormatPng
 	}
 
-	err = imaging.Save(result, fileName, saveOption)
+	err = Save(result, fileName, format, Quality(width, height, opts...))
 
 	if err != nil {
 		log.Errorf("resample: failed to save %s", sanitize.Log(filepath.Base(fileName)))
@@ -7,6 +7,7 @@ import (
 	"github.com/disintegration/imaging"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/photoprism/photoprism/pkg/focus"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
 
@@ -32,6 +33,60 @@ func TestResampleOptions(t *testing.T) {
 		assert.Equal(t, imaging.NearestNeighbor.Support, filter.Support)
 		assert.Equal(t, fs.FormatJpeg, format)
 	})
+	t.Run("ResampleWebP, Fit", func(t *testing.T) {
+		method, _, format := ResampleOptions(ResampleFit, ResampleDefault, ResampleWebP)
+
+		assert.Equal(t, ResampleFit, method)
+		assert.Equal(t, fs.FormatWebP, format)
+	})
+	t.Run("ResampleAvif, FillCenter", func(t *testing.T) {
+		method, _, format := ResampleOptions(ResampleFillCenter, ResampleDefault, ResampleAvif)
+
+		assert.Equal(t, ResampleFillCenter, method)
+		assert.Equal(t, fs.FormatAvif, format)
+	})
+	t.Run("FillSmart", func(t *testing.T) {
+		method, _, _ := ResampleOptions(ResampleFillSmart, ResampleDefault)
+
+		assert.Equal(t, ResampleFillSmart, method)
+	})
+	t.Run("FillDefault", func(t *testing.T) {
+		method, _, _ := ResampleOptions(ResampleFillDefault, ResampleDefault)
+		assert.Equal(t, ResampleFillCenter, method)
+
+		Crop = ResampleFillSmart
+		method, _, _ = ResampleOptions(ResampleFillDefault, ResampleDefault)
+		assert.Equal(t, ResampleFillSmart, method)
+		assert.Equal(t, "500x500_smart.jpg", Suffix(500, 500, Sizes[Tile500].Options...))
+		Crop = ResampleFillCenter
+	})
+}
+
+func TestFormatOption(t *testing.T) {
+	t.Run("Jpeg", func(t *testing.T) {
+		option, ok := FormatOption(fs.FormatJpeg)
+		assert.True(t, ok)
+		assert.Equal(t, ResampleJpeg, option)
+	})
+	t.Run("Png", func(t *testing.T) {
+		option, ok := FormatOption(fs.FormatPng)
+		assert.True(t, ok)
+		assert.Equal(t, ResamplePng, option)
+	})
+	t.Run("WebP", func(t *testing.T) {
+		option, ok := FormatOption(fs.FormatWebP)
+		assert.True(t, ok)
+		assert.Equal(t, ResampleWebP, option)
+	})
+	t.Run("Avif", func(t *testing.T) {
+		option, ok := FormatOption(fs.FormatAvif)
+		assert.True(t, ok)
+		assert.Equal(t, ResampleAvif, option)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, ok := FormatOption(fs.FormatGif)
+		assert.False(t, ok)
+	})
 }
 
 func TestResample(t *testing.T) {
@@ -145,6 +200,18 @@ func TestSuffix(t *testing.T) {
 	assert.Equal(t, "50x50_center.jpg", result)
 }
 
+func TestSuffixFocus(t *testing.T) {
+	areas := focus.Areas{{X: 0.1, Y: 0.1, W: 0.2, H: 0.2}}
+
+	t.Run("Smart", func(t *testing.T) {
+		assert.Equal(t, "50x50_smart_"+areas.Hash()+".jpg", SuffixFocus(50, 50, areas, ResampleFillSmart))
+		assert.Equal(t, "50x50_smart.jpg", SuffixFocus(50, 50, nil, ResampleFillSmart))
+	})
+	t.Run("Center", func(t *testing.T) {
+		assert.Equal(t, "50x50_center.jpg", SuffixFocus(50, 50, areas, ResampleFillCenter))
+	})
+}
+
 func TestFileName(t *testing.T) {
 	t.Run("colors", func(t *testing.T) {
 		colorThumb := Sizes[Colors]
@@ -462,3 +529,75 @@ func TestCreate(t *testing.T) {
 		t.Log(resized)
 	})
 }
+
+func TestCreate_Formats(t *testing.T) {
+	t.Run("WebP", func(t *testing.T) {
+		tile500 := Sizes[Tile500]
+		src := "testdata/example.jpg"
+		dst := "testdata/example.tile_500.webp"
+
+		WebPBin = fakeEncoder(t, `cp "$4" "$6"`)
+
+		defer func() {
+			WebPBin = ""
+		}()
+
+		assert.NoFileExists(t, dst)
+
+		img, err := imaging.Open(src)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = Create(img, dst, tile500.Width, tile500.Height, tile500.FormatOptions(fs.FormatWebP)...); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.FileExists(t, dst)
+		assert.NoFileExists(t, dst+".tmp.png")
+
+		if err := os.Remove(dst); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("EncoderFailed", func(t *testing.T) {
+		tile500 := Sizes[Tile500]
+		src := "testdata/example.jpg"
+		dst := "testdata/example.tile_500.webp"
+
+		WebPBin = fakeEncoder(t, `echo "encoder failed" >&2; exit 1`)
+
+		defer func() {
+			WebPBin = ""
+		}()
+
+		img, err := imaging.Open(src)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = Create(img, dst, tile500.Width, tile500.Height, tile500.FormatOptions(fs.FormatWebP)...)
+
+		assert.Error(t, err)
+		assert.NoFileExists(t, dst)
+		assert.NoFileExists(t, dst+".tmp.png")
+	})
+	t.Run("EncoderNotFound", func(t *testing.T) {
+		tile500 := Sizes[Tile500]
+		src := "testdata/example.jpg"
+		dst := "testdata/example.tile_500.avif"
+
+		img, err := imaging.Open(src)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = Create(img, dst, tile500.Width, tile500.Height, tile500.FormatOptions(fs.FormatAvif)...)
+
+		assert.Error(t, err)
+		assert.NoFileExists(t, dst)
+	})
+}
@@ -0,0 +1,171 @@
+package thumb
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CustomSize represents a user-defined thumbnail size, e.g. from the options.yml config file.
+type CustomSize struct {
+	Name    string   `yaml:"Name" json:"Name"`
+	Use     string   `yaml:"Use,omitempty" json:"Use,omitempty"`
+	Width   int      `yaml:"Width" json:"Width"`
+	Height  int      `yaml:"Height" json:"Height"`
+	Options []string `yaml:"Options,omitempty" json:"Options,omitempty"`
+	Quality int      `yaml:"Quality,omitempty" json:"Quality,omitempty"`
+	Public  bool     `yaml:"Public,omitempty" json:"Public,omitempty"`
+}
+
+// CustomSizes represents a list of user-defined thumbnail sizes.
+type CustomSizes []CustomSize
+
+// Names returns the names of all custom sizes.
+func (c CustomSizes) Names() (result []string) {
+	for _, s := range c {
+		result = append(result, s.Name)
+	}
+
+	return result
+}
+
+// CustomNames contains the names of all custom sizes in the order they were added.
+var CustomNames []Name
+
+// customNameRegexp matches valid custom size names, e.g. "signage_1080".
+var customNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// builtIn contains the names of all compiled-in thumbnail sizes.
+var builtIn = make(map[Name]bool, len(Sizes))
+
+// customQuality maps the size and resample method of custom thumbnails to their JPEG quality.
+var customQuality = make(map[customKey]int)
+
+// customKey identifies thumbnails with the same cache file name, except the format.
+type customKey struct {
+	width  int
+	height int
+	method ResampleOption
+}
+
+func init() {
+	for name := range Sizes {
+		builtIn[name] = true
+	}
+}
+
+// CustomOptions maps the config values of custom sizes to resample options.
+var CustomOptions = map[string]ResampleOption{
+	"fit":     ResampleFit,
+	"fill":    ResampleFillDefault,
+	"center":  ResampleFillCenter,
+	"left":    ResampleFillTopLeft,
+	"right":   ResampleFillBottomRight,
+	"smart":   ResampleFillSmart,
+	"resize":  ResampleResize,
+	"nearest": ResampleNearestNeighbor,
+	"jpeg":    ResampleJpeg,
+	"jpg":     ResampleJpeg,
+	"png":     ResamplePng,
+	"webp":    ResampleWebP,
+	"avif":    ResampleAvif,
+}
+
+// Size validates the custom size and returns it as thumbnail size.
+func (c CustomSize) Size() (result Size, err error) {
+	name := Name(c.Name)
+
+	if !customNameRegexp.MatchString(c.Name) {
+		return result, fmt.Errorf("thumb: invalid custom size name %q", c.Name)
+	} else if builtIn[name] {
+		return result, fmt.Errorf("thumb: custom size name %s is reserved", name)
+	} else if c.Width < 1 || c.Width > MaxSize() || c.Height < 1 || c.Height > MaxSize() {
+		return result, fmt.Errorf("thumb: custom size %s must be between 1 and %d pixels", name, MaxSize())
+	} else if c.Quality != 0 && (c.Quality < 25 || c.Quality > 100) {
+		return result, fmt.Errorf("thumb: custom size %s has invalid quality %d (25-100)", name, c.Quality)
+	}
+
+	opts := []ResampleOption{ResampleFit, ResampleDefault}
+
+	for _, s := range c.Options {
+		if option, ok := CustomOptions[strings.ToLower(strings.TrimSpace(s))]; !ok {
+			return result, fmt.Errorf("thumb: custom size %s has unknown option %q", name, s)
+		} else {
+			opts = append(opts, option)
+		}
+	}
+
+	use := c.Use
+
+	if use == "" {
+		use = "Custom"
+	}
+
+	result = Size{
+		Name:    name,
+		Use:     use,
+		Width:   c.Width,
+		Height:  c.Height,
+		Public:  c.Public,
+		Options: opts,
+		Formats: FormatsLarge,
+	}
+
+	return result, nil
+}
+
+// AddCustomSize validates a custom size and adds it to the available thumbnail sizes.
+func AddCustomSize(c CustomSize) error {
+	size, err := c.Size()
+
+	if err != nil {
+		return err
+	} else if _, exists := Sizes[size.Name]; exists {
+		return fmt.Errorf("thumb: custom size %s already exists", size.Name)
+	}
+
+	// Thumbnails with the same size and resample method share the same cache file.
+	key := customKey{width: size.Width, height: size.Height, method: size.Method()}
+
+	for name, s := range Sizes {
+		if s.Width == key.width && s.Height == key.height && s.Method() == key.method {
+			return fmt.Errorf("thumb: custom size %s is the same as %s", size.Name, name)
+		}
+	}
+
+	Sizes[size.Name] = size
+	CustomNames = append(CustomNames, size.Name)
+
+	if c.Quality > 0 {
+		customQuality[key] = c.Quality
+	}
+
+	return nil
+}
+
+// ResetCustomSizes removes all custom sizes.
+func ResetCustomSizes() {
+	for _, name := range CustomNames {
+		delete(Sizes, name)
+	}
+
+	CustomNames = nil
+	customQuality = make(map[customKey]int)
+}
+
+// Quality returns the JPEG quality for thumbnails with the given size and options.
+func Quality(width, height int, opts ...ResampleOption) int {
+	if len(customQuality) > 0 {
+		method, _, _ := ResampleOptions(opts...)
+
+		if q, ok := customQuality[customKey{width: width, height: height, method: method}]; ok {
+			return q
+		}
+	}
+
+	if width <= 150 && height <= 150 {
+		return JpegQualitySmall
+	}
+
+	return JpegQuality
+}
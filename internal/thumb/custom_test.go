@@ -0,0 +1,88 @@
+package thumb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestCustomSize_Size(t *testing.T) {
+	t.Run("Signage", func(t *testing.T) {
+		s, err := CustomSize{Name: "signage_1080", Width: 1080, Height: 1920, Options: []string{"Fill", "webp"}, Public: true}.Size()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Name("signage_1080"), s.Name)
+		assert.Equal(t, "Custom", s.Use)
+		assert.Equal(t, ResampleFillCenter, s.Method())
+		assert.Equal(t, fs.FormatWebP, s.Format())
+		assert.True(t, s.Public)
+	})
+	t.Run("DefaultOptions", func(t *testing.T) {
+		s, err := CustomSize{Name: "frame", Use: "Photo Frame", Width: 800, Height: 480}.Size()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Photo Frame", s.Use)
+		assert.Equal(t, ResampleFit, s.Method())
+		assert.Equal(t, fs.FormatJpeg, s.Format())
+	})
+	t.Run("BuiltIn", func(t *testing.T) {
+		_, err := CustomSize{Name: "tile_500", Width: 400, Height: 400}.Size()
+		assert.EqualError(t, err, "thumb: custom size name tile_500 is reserved")
+	})
+	t.Run("InvalidName", func(t *testing.T) {
+		_, err := CustomSize{Name: "Signage 1080", Width: 1080, Height: 1920}.Size()
+		assert.Error(t, err)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		_, err := CustomSize{Name: "huge", Width: 10000, Height: 1920}.Size()
+		assert.Error(t, err)
+	})
+	t.Run("InvalidQuality", func(t *testing.T) {
+		_, err := CustomSize{Name: "bad", Width: 100, Height: 100, Quality: 101}.Size()
+		assert.Error(t, err)
+	})
+	t.Run("UnknownOption", func(t *testing.T) {
+		_, err := CustomSize{Name: "bad", Width: 100, Height: 100, Options: []string{"sharpen"}}.Size()
+		assert.EqualError(t, err, "thumb: custom size bad has unknown option \"sharpen\"")
+	})
+}
+
+func TestAddCustomSize(t *testing.T) {
+	defer ResetCustomSizes()
+
+	assert.NoError(t, AddCustomSize(CustomSize{Name: "signage_1080", Width: 1080, Height: 1920, Options: []string{"fill"}, Quality: 90}))
+	assert.Error(t, AddCustomSize(CustomSize{Name: "signage_1080", Width: 1080, Height: 1920}))
+	assert.EqualError(t, AddCustomSize(CustomSize{Name: "mobile", Width: 720, Height: 720}), "thumb: custom size mobile is the same as fit_720")
+
+	assert.Equal(t, []Name{"signage_1080"}, CustomNames)
+	assert.Contains(t, Sizes, Name("signage_1080"))
+	assert.Equal(t, Name("signage_1080"), CacheSizes()[len(DefaultSizes)])
+
+	assert.Equal(t, 90, Quality(1080, 1920, Sizes["signage_1080"].Options...))
+	assert.Equal(t, JpegQuality, Quality(1080, 1920, ResampleFit))
+
+	ResetCustomSizes()
+
+	assert.Empty(t, CustomNames)
+	assert.NotContains(t, Sizes, Name("signage_1080"))
+	assert.Equal(t, JpegQuality, Quality(1080, 1920, ResampleFillCenter))
+	assert.Len(t, CacheSizes(), len(DefaultSizes))
+}
+
+func TestQuality(t *testing.T) {
+	assert.Equal(t, JpegQualitySmall, Quality(100, 100, ResampleFillCenter))
+	assert.Equal(t, JpegQuality, Quality(500, 500, ResampleFillCenter))
+}
+
+func TestCustomSizes_Names(t *testing.T) {
+	sizes := CustomSizes{{Name: "signage_1080"}, {Name: "frame"}}
+	assert.Equal(t, []string{"signage_1080", "frame"}, sizes.Names())
+}
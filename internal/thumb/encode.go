@@ -0,0 +1,110 @@
+package thumb
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+var (
+	WebPBin     = ""
+	WebPQuality = 80
+	AvifBin     = ""
+	AvifQuality = 60
+)
+
+// FormatSupported tests if thumbnails can be created in the file format.
+func FormatSupported(format fs.FileFormat) bool {
+	switch format {
+	case fs.FormatJpeg, fs.FormatPng:
+		return true
+	case fs.FormatWebP:
+		return WebPBin != ""
+	case fs.FormatAvif:
+		return AvifBin != ""
+	default:
+		return false
+	}
+}
+
+// FormatMimeType returns the mime type of a thumbnail file format.
+func FormatMimeType(format fs.FileFormat) string {
+	switch format {
+	case fs.FormatJpeg:
+		return fs.MimeTypeJpeg
+	case fs.FormatPng:
+		return fs.MimeTypePng
+	case fs.FormatWebP:
+		return fs.MimeTypeWebP
+	case fs.FormatAvif:
+		return fs.MimeTypeAvif
+	default:
+		return ""
+	}
+}
+
+// Save encodes the image in the file format and saves it with the given file name.
+func Save(img image.Image, fileName string, format fs.FileFormat, quality int) error {
+	switch format {
+	case fs.FormatPng:
+		return imaging.Save(img, fileName, imaging.PNGCompressionLevel(png.DefaultCompression))
+	case fs.FormatJpeg:
+		return imaging.Save(img, fileName, imaging.JPEGQuality(quality))
+	case fs.FormatWebP:
+		if WebPBin == "" {
+			return fmt.Errorf("resample: webp encoder not found")
+		}
+
+		return saveExternal(img, fileName, WebPBin, func(srcName string) []string {
+			return []string{"-quiet", "-q", strconv.Itoa(WebPQuality), srcName, "-o", fileName}
+		})
+	case fs.FormatAvif:
+		if AvifBin == "" {
+			return fmt.Errorf("resample: avif encoder not found")
+		}
+
+		return saveExternal(img, fileName, AvifBin, func(srcName string) []string {
+			return []string{"-q", strconv.Itoa(AvifQuality), srcName, fileName}
+		})
+	default:
+		return fmt.Errorf("resample: unsupported format %s", sanitize.Log(string(format)))
+	}
+}
+
+// saveExternal saves the image as temporary PNG file and then runs an external encoder command.
+func saveExternal(img image.Image, fileName, bin string, args func(srcName string) []string) (err error) {
+	tmpName := fileName + ".tmp.png"
+
+	if err = imaging.Save(img, tmpName, imaging.PNGCompressionLevel(png.NoCompression)); err != nil {
+		return err
+	}
+
+	defer os.Remove(tmpName)
+
+	cmd := exec.Command(bin, args(tmpName)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err = cmd.Run(); err != nil {
+		_ = os.Remove(fileName)
+
+		if stderr.String() != "" {
+			return fmt.Errorf("resample: %s (%s)", stderr.String(), filepath.Base(bin))
+		}
+
+		return fmt.Errorf("resample: %s (%s)", err, filepath.Base(bin))
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package thumb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// fakeEncoder creates a shell script that can be used as external encoder in tests.
+func fakeEncoder(t *testing.T, script string) string {
+	bin := filepath.Join(t.TempDir(), "encoder")
+
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	return bin
+}
+
+func TestFormatSupported(t *testing.T) {
+	assert.True(t, FormatSupported(fs.FormatJpeg))
+	assert.True(t, FormatSupported(fs.FormatPng))
+	assert.False(t, FormatSupported(fs.FormatWebP))
+	assert.False(t, FormatSupported(fs.FormatAvif))
+	assert.False(t, FormatSupported(fs.FormatGif))
+
+	AvifBin = "/usr/bin/avifenc"
+	assert.True(t, FormatSupported(fs.FormatAvif))
+	AvifBin = ""
+}
+
+func TestFormatMimeType(t *testing.T) {
+	assert.Equal(t, "image/jpeg", FormatMimeType(fs.FormatJpeg))
+	assert.Equal(t, "image/png", FormatMimeType(fs.FormatPng))
+	assert.Equal(t, "image/webp", FormatMimeType(fs.FormatWebP))
+	assert.Equal(t, "image/avif", FormatMimeType(fs.FormatAvif))
+	assert.Equal(t, "", FormatMimeType(fs.FormatGif))
+}
+
+func TestSave(t *testing.T) {
+	img, err := imaging.Open("testdata/example.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Png", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "example.png")
+
+		assert.NoError(t, Save(img, fileName, fs.FormatPng, JpegQuality))
+		assert.Equal(t, fs.MimeTypePng, fs.MimeType(fileName))
+	})
+	t.Run("Jpeg", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "example.jpg")
+
+		assert.NoError(t, Save(img, fileName, fs.FormatJpeg, JpegQuality))
+		assert.Equal(t, fs.MimeTypeJpeg, fs.MimeType(fileName))
+	})
+	t.Run("Avif", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "example.avif")
+
+		AvifBin = fakeEncoder(t, `echo "$@" > "$4"`)
+
+		defer func() {
+			AvifBin = ""
+		}()
+
+		if err := Save(img, fileName, fs.FormatAvif, JpegQuality); err != nil {
+			t.Fatal(err)
+		}
+
+		if args, err := os.ReadFile(fileName); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, "-q 60 "+fileName+".tmp.png "+fileName+"\n", string(args))
+		}
+	})
+	t.Run("WebPNotFound", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "example.webp")
+
+		assert.Error(t, Save(img, fileName, fs.FormatWebP, JpegQuality))
+		assert.NoFileExists(t, fileName)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "example.gif")
+
+		assert.Error(t, Save(img, fileName, fs.FormatGif, JpegQuality))
+	})
+}
@@ -6,4 +6,5 @@ import (
 
 var (
 	ErrThumbNotCached = errors.New("thumbnail not cached")
+	ErrQueueFull      = errors.New("too many thumbnails queued")
 )
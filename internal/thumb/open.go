@@ -1,6 +1,7 @@
 package thumb
 
 import (
+	"bytes"
 	"fmt"
 	"image"
 	_ "image/gif"
@@ -11,9 +12,11 @@ import (
 
 	"github.com/disintegration/imaging"
 	"github.com/mandykoh/prism/meta/autometa"
+	"github.com/mandykoh/prism/meta/icc"
 
 	"github.com/photoprism/photoprism/pkg/colors"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/heif"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
@@ -28,6 +31,11 @@ func Open(fileName string, orientation int) (result image.Image, err error) {
 		return OpenJpeg(fileName, orientation)
 	}
 
+	// Open HEIF?
+	if fs.GetFileFormat(fileName) == fs.FormatHEIF {
+		return OpenHeif(fileName, orientation)
+	}
+
 	// Open file with imaging function.
 	img, err := imaging.Open(fileName)
 
@@ -97,3 +105,45 @@ func OpenJpeg(fileName string, orientation int) (result image.Image, err error)
 
 	return img, nil
 }
+
+// OpenHeif decodes the primary image of a HEIF file and converts the color profile if necessary.
+// It is rotated based on the container properties, or the Exif orientation if there are none.
+func OpenHeif(fileName string, orientation int) (result image.Image, err error) {
+	if fileName == "" {
+		return result, fmt.Errorf("filename missing")
+	}
+
+	f, err := heif.Open(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	primary, err := f.Primary()
+
+	if err != nil {
+		return result, err
+	}
+
+	img, err := f.Decode(primary)
+
+	if err != nil {
+		return result, err
+	}
+
+	// Rotate based on Exif, as older files may only have an Exif orientation.
+	if orientation > 1 && !primary.Transformed() {
+		img = Rotate(img, orientation)
+	}
+
+	// Read ICC profile and convert colors if possible.
+	if data := f.ICC(primary); len(data) == 0 {
+		// Do nothing.
+	} else if iccProfile, err := icc.NewProfileReader(bytes.NewReader(data)).ReadProfile(); err != nil {
+		log.Tracef("resample: %s in %s (read color profile)", err, sanitize.Log(filepath.Base(fileName)))
+	} else if profile, err := iccProfile.Description(); err == nil && colors.ProfileDisplayP3.Equal(profile) {
+		img = colors.ToSRGB(img, colors.ProfileDisplayP3)
+	}
+
+	return img, nil
+}
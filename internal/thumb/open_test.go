@@ -1,7 +1,12 @@
 package thumb
 
 import (
+	"image"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/heif"
 )
 
 func TestOpen(t *testing.T) {
@@ -56,3 +61,44 @@ func TestOpen(t *testing.T) {
 		}
 	})
 }
+
+func TestOpenHeif(t *testing.T) {
+	const fileName = "../../assets/examples/iphone_7.heic"
+
+	t.Run("Unsupported", func(t *testing.T) {
+		if heif.Registered(heif.TypeHevc) {
+			t.Skip("hevc codec is registered")
+		}
+
+		_, err := Open(fileName, 6)
+		assert.Error(t, err)
+	})
+	t.Run("Codec", func(t *testing.T) {
+		heif.RegisterCodec(heif.TypeHevc, func(it *heif.Item, data []byte) (image.Image, error) {
+			return image.NewRGBA(image.Rect(0, 0, it.Width, it.Height)), nil
+		})
+
+		defer heif.RegisterCodec(heif.TypeHevc, nil)
+
+		img, err := Open(fileName, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, image.Rect(0, 0, 4032, 3024), img.Bounds())
+
+		// The Exif orientation is applied, as the file has no rotation property.
+		img, err = Open(fileName, 6)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, image.Rect(0, 0, 3024, 4032), img.Bounds())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := OpenHeif("testdata/missing.heic", 1)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,220 @@
+package thumb
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/focus"
+)
+
+// Priority represents the priority of thumbnail render jobs.
+type Priority int
+
+// Interactive requests like thumbnails requested by a browser have a higher priority than pre-caching.
+const (
+	PriorityLow Priority = iota
+	PriorityHigh
+)
+
+// QueueLimit is the default maximum number of interactive jobs waiting for a worker.
+var QueueLimit = 512
+
+// RenderQueue limits the number of thumbnails rendered concurrently, see SetWorkers.
+var RenderQueue = NewQueue(1)
+
+// QueueStatus represents the current state of a render queue.
+type QueueStatus struct {
+	Workers int `json:"workers"`
+	Running int `json:"running"`
+	Queued  int `json:"queued"`
+}
+
+// queueJob represents a render job that may be shared by multiple callers.
+type queueJob struct {
+	key      string
+	priority Priority
+	elem     *list.Element
+	waiters  int
+	render   func() (string, error)
+	done     chan struct{}
+	result   string
+	err      error
+}
+
+// Queue renders thumbnails with bounded concurrency, prioritizes interactive requests,
+// and coalesces concurrent requests for the same thumbnail.
+type Queue struct {
+	mu      sync.Mutex
+	workers int
+	limit   int
+	running int
+	jobs    map[string]*queueJob
+	waiting [2]*list.List
+}
+
+// NewQueue creates a render queue with the given number of workers.
+func NewQueue(workers int) *Queue {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &Queue{
+		workers: workers,
+		limit:   QueueLimit,
+		jobs:    make(map[string]*queueJob),
+		waiting: [2]*list.List{list.New(), list.New()},
+	}
+}
+
+// SetWorkers changes the maximum number of jobs running concurrently.
+func (q *Queue) SetWorkers(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.workers = workers
+	q.next()
+}
+
+// SetLimit changes the maximum number of interactive jobs waiting for a worker, 0 means no limit.
+func (q *Queue) SetLimit(limit int) {
+	if limit < 0 {
+		limit = 0
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.limit = limit
+}
+
+// Status returns the number of workers, running, and queued jobs.
+func (q *Queue) Status() QueueStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return QueueStatus{
+		Workers: q.workers,
+		Running: q.running,
+		Queued:  q.waiting[PriorityLow].Len() + q.waiting[PriorityHigh].Len(),
+	}
+}
+
+// Depth returns the number of running and queued jobs.
+func (q *Queue) Depth() int {
+	s := q.Status()
+
+	return s.Running + s.Queued
+}
+
+// Do runs the render function once a worker is available and returns its result. Callers requesting the same
+// key while a job is queued or running share its result, and interactive requests raise the job priority.
+//
+// Interactive requests fail with ErrQueueFull if too many jobs are waiting already. When the context is
+// canceled, Do returns right away and the job is dropped if it hasn't started yet and no one else waits for it.
+func (q *Queue) Do(ctx context.Context, key string, priority Priority, render func() (string, error)) (string, error) {
+	if priority != PriorityHigh {
+		priority = PriorityLow
+	}
+
+	q.mu.Lock()
+
+	j, ok := q.jobs[key]
+
+	if ok {
+		// Move queued job to the interactive queue?
+		if j.elem != nil && priority > j.priority {
+			q.waiting[j.priority].Remove(j.elem)
+			j.priority = priority
+			j.elem = q.waiting[priority].PushBack(j)
+		}
+	} else if priority == PriorityHigh && q.limit > 0 && q.waiting[PriorityHigh].Len() >= q.limit {
+		q.mu.Unlock()
+		return "", ErrQueueFull
+	} else {
+		j = &queueJob{key: key, priority: priority, render: render, done: make(chan struct{})}
+		j.elem = q.waiting[priority].PushBack(j)
+		q.jobs[key] = j
+		q.next()
+	}
+
+	j.waiters++
+	q.mu.Unlock()
+
+	select {
+	case <-j.done:
+		return j.result, j.err
+	case <-ctx.Done():
+		q.leave(j)
+		return "", ctx.Err()
+	}
+}
+
+// leave removes a caller from a job and drops the job if it is still queued and no one else waits for it.
+// Jobs that are already running complete, so that their result is cached for the next request.
+func (q *Queue) leave(j *queueJob) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	j.waiters--
+
+	if j.waiters > 0 || j.elem == nil {
+		return
+	}
+
+	q.waiting[j.priority].Remove(j.elem)
+	j.elem = nil
+	delete(q.jobs, j.key)
+}
+
+// run renders a job, removes it from the queue, and starts the next one.
+func (q *Queue) run(j *queueJob) {
+	j.result, j.err = j.render()
+
+	q.mu.Lock()
+	delete(q.jobs, j.key)
+	q.running--
+	q.next()
+	q.mu.Unlock()
+
+	close(j.done)
+}
+
+// next starts queued jobs while workers are available, the caller must hold the lock.
+func (q *Queue) next() {
+	for q.running < q.workers {
+		var j *queueJob
+
+		if e := q.waiting[PriorityHigh].Front(); e != nil {
+			j = q.waiting[PriorityHigh].Remove(e).(*queueJob)
+		} else if e = q.waiting[PriorityLow].Front(); e != nil {
+			j = q.waiting[PriorityLow].Remove(e).(*queueJob)
+		} else {
+			return
+		}
+
+		j.elem = nil
+		q.running++
+
+		go q.run(j)
+	}
+}
+
+// FromQueue returns the thumb cache file name for an image, and renders it in the queue if needed.
+func FromQueue(ctx context.Context, priority Priority, imageFilename, hash, thumbPath string, width, height, orientation int, areas focus.Areas, opts ...ResampleOption) (fileName string, err error) {
+	if fileName, err = FromCacheFocus(imageFilename, hash, thumbPath, width, height, areas, opts...); err != ErrThumbNotCached {
+		return fileName, err
+	}
+
+	if fileName, err = FileNameFocus(hash, thumbPath, width, height, areas, opts...); err != nil {
+		return "", err
+	}
+
+	return RenderQueue.Do(ctx, fileName, priority, func() (string, error) {
+		return FromFileFocus(imageFilename, hash, thumbPath, width, height, orientation, areas, opts...)
+	})
+}
@@ -0,0 +1,292 @@
+package thumb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitStatus waits until the queue has the given number of running and queued jobs.
+func waitStatus(t *testing.T, q *Queue, running, queued int) {
+	for i := 0; i < 500; i++ {
+		if s := q.Status(); s.Running == running && s.Queued == queued {
+			return
+		}
+
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	t.Fatalf("expected %d running and %d queued jobs, found %#v", running, queued, q.Status())
+}
+
+func TestNewQueue(t *testing.T) {
+	q := NewQueue(0)
+
+	assert.Equal(t, QueueStatus{Workers: 1}, q.Status())
+
+	q.SetWorkers(4)
+
+	assert.Equal(t, 4, q.Status().Workers)
+	assert.Equal(t, 0, q.Depth())
+}
+
+func TestQueue_Do(t *testing.T) {
+	t.Run("Result", func(t *testing.T) {
+		q := NewQueue(2)
+
+		result, err := q.Do(context.Background(), "a", PriorityHigh, func() (string, error) {
+			return "a.jpg", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "a.jpg", result)
+
+		_, err = q.Do(context.Background(), "b", PriorityLow, func() (string, error) {
+			return "", errors.New("failed")
+		})
+
+		assert.EqualError(t, err, "failed")
+		assert.Equal(t, 0, q.Depth())
+	})
+	t.Run("Concurrency", func(t *testing.T) {
+		q := NewQueue(2)
+
+		var running, max int32
+		var wg sync.WaitGroup
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				_, _ = q.Do(context.Background(), string(rune('a'+i)), PriorityHigh, func() (string, error) {
+					n := atomic.AddInt32(&running, 1)
+
+					for {
+						if m := atomic.LoadInt32(&max); n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+							break
+						}
+					}
+
+					time.Sleep(5 * time.Millisecond)
+					atomic.AddInt32(&running, -1)
+
+					return "", nil
+				})
+			}(i)
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, int32(2), max)
+	})
+	t.Run("Coalesce", func(t *testing.T) {
+		q := NewQueue(1)
+
+		var calls int32
+		var wg sync.WaitGroup
+
+		block := make(chan struct{})
+
+		go func() {
+			_, _ = q.Do(context.Background(), "blocker", PriorityHigh, func() (string, error) {
+				<-block
+				return "", nil
+			})
+		}()
+
+		waitStatus(t, q, 1, 0)
+
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				result, _ := q.Do(context.Background(), "same", PriorityHigh, func() (string, error) {
+					atomic.AddInt32(&calls, 1)
+					return "same.jpg", nil
+				})
+
+				assert.Equal(t, "same.jpg", result)
+			}()
+		}
+
+		waitStatus(t, q, 1, 1)
+
+		// Wait for the remaining callers to join the queued job.
+		time.Sleep(20 * time.Millisecond)
+
+		close(block)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls)
+	})
+	t.Run("Priority", func(t *testing.T) {
+		q := NewQueue(1)
+
+		var mu sync.Mutex
+		var order []string
+		var wg sync.WaitGroup
+
+		block := make(chan struct{})
+
+		go func() {
+			_, _ = q.Do(context.Background(), "blocker", PriorityLow, func() (string, error) {
+				<-block
+				return "", nil
+			})
+		}()
+
+		do := func(key string, priority Priority) {
+			defer wg.Done()
+
+			_, _ = q.Do(context.Background(), key, priority, func() (string, error) {
+				mu.Lock()
+				order = append(order, key)
+				mu.Unlock()
+				return "", nil
+			})
+		}
+
+		waitStatus(t, q, 1, 0)
+
+		wg.Add(3)
+		go do("precache", PriorityLow)
+		waitStatus(t, q, 1, 1)
+		go do("browser", PriorityHigh)
+		waitStatus(t, q, 1, 2)
+
+		// Requesting a queued thumbnail interactively raises its priority.
+		go do("precache_2", PriorityLow)
+		waitStatus(t, q, 1, 3)
+		wg.Add(1)
+		go do("precache_2", PriorityHigh)
+		time.Sleep(10 * time.Millisecond)
+
+		assert.Equal(t, 4, q.Depth())
+
+		close(block)
+		wg.Wait()
+
+		assert.Equal(t, []string{"browser", "precache_2", "precache"}, order)
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		q := NewQueue(1)
+
+		var calls int32
+
+		block := make(chan struct{})
+
+		go func() {
+			_, _ = q.Do(context.Background(), "blocker", PriorityHigh, func() (string, error) {
+				<-block
+				return "", nil
+			})
+		}()
+
+		waitStatus(t, q, 1, 0)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		result := make(chan error)
+
+		go func() {
+			_, err := q.Do(ctx, "abandoned", PriorityHigh, func() (string, error) {
+				atomic.AddInt32(&calls, 1)
+				return "", nil
+			})
+
+			result <- err
+		}()
+
+		waitStatus(t, q, 1, 1)
+		cancel()
+
+		assert.ErrorIs(t, <-result, context.Canceled)
+
+		// Abandoned jobs are dropped before they start.
+		waitStatus(t, q, 1, 0)
+		close(block)
+		waitStatus(t, q, 0, 0)
+
+		assert.Equal(t, int32(0), calls)
+	})
+	t.Run("Full", func(t *testing.T) {
+		q := NewQueue(1)
+		q.SetLimit(1)
+
+		block := make(chan struct{})
+
+		go func() {
+			_, _ = q.Do(context.Background(), "blocker", PriorityHigh, func() (string, error) {
+				<-block
+				return "", nil
+			})
+		}()
+
+		waitStatus(t, q, 1, 0)
+
+		go func() {
+			_, _ = q.Do(context.Background(), "queued", PriorityHigh, func() (string, error) {
+				return "", nil
+			})
+		}()
+
+		waitStatus(t, q, 1, 1)
+
+		_, err := q.Do(context.Background(), "rejected", PriorityHigh, func() (string, error) {
+			return "", nil
+		})
+
+		assert.Equal(t, ErrQueueFull, err)
+
+		// Background jobs always wait for a worker.
+		done := make(chan error)
+
+		go func() {
+			_, err := q.Do(context.Background(), "precache", PriorityLow, func() (string, error) {
+				return "", nil
+			})
+
+			done <- err
+		}()
+
+		waitStatus(t, q, 1, 2)
+		close(block)
+
+		assert.NoError(t, <-done)
+	})
+}
+
+func TestFromQueue(t *testing.T) {
+	t.Run("Tile50", func(t *testing.T) {
+		thumbPath := t.TempDir()
+		tile50 := Sizes[Tile50]
+
+		fileName, err := FromQueue(context.Background(), PriorityHigh, "testdata/example.jpg", "123456789098765432", thumbPath, tile50.Width, tile50.Height, OrientationNormal, nil, tile50.Options...)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.FileExists(t, fileName)
+
+		cached, err := FromQueue(context.Background(), PriorityLow, "testdata/example.jpg", "123456789098765432", thumbPath, tile50.Width, tile50.Height, OrientationNormal, nil, tile50.Options...)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fileName, cached)
+	})
+	t.Run("MissingFile", func(t *testing.T) {
+		tile50 := Sizes[Tile50]
+
+		_, err := FromQueue(context.Background(), PriorityHigh, "testdata/example.xxx", "193456789098765432", t.TempDir(), tile50.Width, tile50.Height, OrientationNormal, nil, tile50.Options...)
+
+		assert.Error(t, err)
+	})
+}
@@ -4,10 +4,17 @@ import (
 	"image"
 
 	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/pkg/focus"
 )
 
 // Resample downscales an image and returns it.
 func Resample(img image.Image, width, height int, opts ...ResampleOption) image.Image {
+	return ResampleFocus(img, width, height, nil, opts...)
+}
+
+// ResampleFocus downscales an image and returns it, smart crops keep the focus areas visible if possible.
+func ResampleFocus(img image.Image, width, height int, areas focus.Areas, opts ...ResampleOption) image.Image {
 	var resImg image.Image
 
 	method, filter, _ := ResampleOptions(opts...)
@@ -16,6 +23,8 @@ func Resample(img image.Image, width, height int, opts ...ResampleOption) image.
 		resImg = imaging.Fit(img, width, height, filter)
 	} else if method == ResampleFillCenter {
 		resImg = imaging.Fill(img, width, height, imaging.Center, filter)
+	} else if method == ResampleFillSmart {
+		resImg = FillSmart(img, width, height, areas, filter)
 	} else if method == ResampleFillTopLeft {
 		resImg = imaging.Fill(img, width, height, imaging.TopLeft, filter)
 	} else if method == ResampleFillBottomRight {
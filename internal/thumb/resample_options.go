@@ -16,12 +16,18 @@ const (
 	ResampleNearestNeighbor
 	ResampleDefault
 	ResamplePng
+	ResampleWebP
+	ResampleAvif
+	ResampleJpeg
+	ResampleFillSmart
+	ResampleFillDefault
 )
 
 var ResampleMethods = map[ResampleOption]string{
 	ResampleFillCenter:      "center",
 	ResampleFillTopLeft:     "left",
 	ResampleFillBottomRight: "right",
+	ResampleFillSmart:       "smart",
 	ResampleFit:             "fit",
 	ResampleResize:          "resize",
 }
@@ -36,6 +42,12 @@ func ResampleOptions(opts ...ResampleOption) (method ResampleOption, filter imag
 		switch option {
 		case ResamplePng:
 			format = fs.FormatPng
+		case ResampleJpeg:
+			format = fs.FormatJpeg
+		case ResampleWebP:
+			format = fs.FormatWebP
+		case ResampleAvif:
+			format = fs.FormatAvif
 		case ResampleNearestNeighbor:
 			filter = imaging.NearestNeighbor
 		case ResampleDefault:
@@ -46,6 +58,10 @@ func ResampleOptions(opts ...ResampleOption) (method ResampleOption, filter imag
 			method = ResampleFillCenter
 		case ResampleFillBottomRight:
 			method = ResampleFillBottomRight
+		case ResampleFillSmart:
+			method = ResampleFillSmart
+		case ResampleFillDefault:
+			method = Crop
 		case ResampleFit:
 			method = ResampleFit
 		case ResampleResize:
@@ -55,3 +71,19 @@ func ResampleOptions(opts ...ResampleOption) (method ResampleOption, filter imag
 
 	return method, filter, format
 }
+
+// FormatOption returns the resample option for a thumbnail file format, or false if it is not supported.
+func FormatOption(format fs.FileFormat) (ResampleOption, bool) {
+	switch format {
+	case fs.FormatJpeg:
+		return ResampleJpeg, true
+	case fs.FormatPng:
+		return ResamplePng, true
+	case fs.FormatWebP:
+		return ResampleWebP, true
+	case fs.FormatAvif:
+		return ResampleAvif, true
+	default:
+		return ResampleJpeg, false
+	}
+}
@@ -0,0 +1,157 @@
+package thumb
+
+import (
+	"image"
+	"math"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/pkg/colors"
+	"github.com/photoprism/photoprism/pkg/focus"
+)
+
+// SmartMapSize is the maximum width and height of the light map used to find the most detailed image area.
+var SmartMapSize = 64
+
+// SmartSteps is the number of equally spaced crop positions compared when no focus areas are known.
+var SmartSteps = 16
+
+// SmartCrop returns the image area to be cropped for a thumbnail with the given size, so that all focus areas
+// are visible if possible, or at least the largest one. Without focus areas, the image part with the highest
+// luminance entropy is used. The result is deterministic, so cached thumbnails remain valid.
+func SmartCrop(img image.Image, width, height int, areas focus.Areas) image.Rectangle {
+	b := img.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+
+	if width <= 0 || height <= 0 || srcW <= 0 || srcH <= 0 {
+		return b
+	}
+
+	// Find the largest crop area with the same aspect ratio as the thumbnail.
+	cropW, cropH := srcW, srcH
+
+	if srcW*height > srcH*width {
+		cropW = clampInt(int(math.Round(float64(srcH*width)/float64(height))), 1, srcW)
+	} else {
+		cropH = clampInt(int(math.Round(float64(srcW*height)/float64(width))), 1, srcH)
+	}
+
+	maxX, maxY := srcW-cropW, srcH-cropH
+
+	// Nothing to choose from?
+	if maxX == 0 && maxY == 0 {
+		return b
+	}
+
+	var x, y int
+
+	if valid := areas.Valid(); len(valid) > 0 {
+		cx, cy := focusCenter(valid, float64(srcW), float64(srcH), float64(cropW), float64(cropH))
+		x = clampInt(int(math.Round(cx-float64(cropW)/2)), 0, maxX)
+		y = clampInt(int(math.Round(cy-float64(cropH)/2)), 0, maxY)
+	} else {
+		x, y = entropyOffset(img, cropW, cropH)
+	}
+
+	return image.Rect(b.Min.X+x, b.Min.Y+y, b.Min.X+x+cropW, b.Min.Y+y+cropH)
+}
+
+// FillSmart crops and resizes an image to the given size, see SmartCrop.
+func FillSmart(img image.Image, width, height int, areas focus.Areas, filter imaging.ResampleFilter) image.Image {
+	return imaging.Resize(imaging.Crop(img, SmartCrop(img, width, height, areas)), width, height, filter)
+}
+
+// focusCenter returns the absolute center of all focus areas if they fit in the crop area,
+// or the center of the largest area otherwise.
+func focusCenter(areas focus.Areas, srcW, srcH, cropW, cropH float64) (x, y float64) {
+	minX, minY, maxX, maxY := areas[0].X, areas[0].Y, areas[0].X+areas[0].W, areas[0].Y+areas[0].H
+
+	for _, a := range areas[1:] {
+		minX = math.Min(minX, a.X)
+		minY = math.Min(minY, a.Y)
+		maxX = math.Max(maxX, a.X+a.W)
+		maxY = math.Max(maxY, a.Y+a.H)
+	}
+
+	if (maxX-minX)*srcW <= cropW && (maxY-minY)*srcH <= cropH {
+		return (minX + maxX) / 2 * srcW, (minY + maxY) / 2 * srcH
+	}
+
+	return (areas[0].X + areas[0].W/2) * srcW, (areas[0].Y + areas[0].H/2) * srcH
+}
+
+// entropyOffset returns the crop position with the highest luminance entropy.
+func entropyOffset(img image.Image, cropW, cropH int) (x, y int) {
+	b := img.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+
+	// Compare luminance values of a downscaled image to keep it fast.
+	small := imaging.Fit(img, SmartMapSize, SmartMapSize, imaging.Box)
+	w, h := small.Bounds().Dx(), small.Bounds().Dy()
+
+	if w == 0 || h == 0 {
+		return (srcW - cropW) / 2, (srcH - cropH) / 2
+	}
+
+	lum := make(colors.LightMap, w*h)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			lum[j*w+i] = colors.NewLuminance(small.At(i, j))
+		}
+	}
+
+	horizontal := srcW > cropW
+	size, length := w, clampInt(int(math.Round(float64(cropW*w)/float64(srcW))), 1, w)
+
+	if !horizontal {
+		size, length = h, clampInt(int(math.Round(float64(cropH*h)/float64(srcH))), 1, h)
+	}
+
+	free := size - length
+	center := float64(free) / 2
+	best, bestEntropy, bestDist, minDist := free/2, -1.0, math.MaxFloat64, math.MaxFloat64
+
+	for step := 0; step <= SmartSteps; step++ {
+		offset := int(math.Round(float64(free*step) / float64(SmartSteps)))
+
+		var window colors.LightMap
+
+		if horizontal {
+			for j := 0; j < h; j++ {
+				window = append(window, lum[j*w+offset:j*w+offset+length]...)
+			}
+		} else {
+			window = lum[offset*w : (offset+length)*w]
+		}
+
+		// Prefer positions closer to the center if the entropy is the same.
+		entropy := window.Entropy()
+		dist := math.Abs(float64(offset) - center)
+		minDist = math.Min(minDist, dist)
+
+		if entropy > bestEntropy || entropy == bestEntropy && dist < bestDist {
+			best, bestEntropy, bestDist = offset, entropy, dist
+		}
+	}
+
+	// Use the exact center if it's as good as any other position.
+	if bestDist == minDist {
+		return (srcW - cropW) / 2, (srcH - cropH) / 2
+	} else if horizontal {
+		return clampInt(int(math.Round(float64(best*srcW)/float64(w))), 0, srcW-cropW), 0
+	}
+
+	return 0, clampInt(int(math.Round(float64(best*srcH)/float64(h))), 0, srcH-cropH)
+}
+
+// clampInt limits an integer to the given range.
+func clampInt(i, min, max int) int {
+	if i < min {
+		return min
+	} else if i > max {
+		return max
+	}
+
+	return i
+}
@@ -0,0 +1,85 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/focus"
+)
+
+// testNoise returns a gray image with a noisy square at the given position.
+func testNoise(width, height int, area image.Rectangle) image.Image {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if (image.Point{X: x, Y: y}).In(area) {
+				img.SetGray(x, y, color.Gray{Y: uint8((x*37 + y*91) % 256)})
+			} else {
+				img.SetGray(x, y, color.Gray{Y: 128})
+			}
+		}
+	}
+
+	return img
+}
+
+func TestSmartCrop(t *testing.T) {
+	t.Run("Portrait", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 300, 600))
+		areas := focus.Areas{{X: 0.4, Y: 0.05, W: 0.2, H: 0.1}}
+
+		assert.Equal(t, image.Rect(0, 0, 300, 300), SmartCrop(img, 500, 500, areas))
+	})
+	t.Run("Landscape", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 900, 300))
+		areas := focus.Areas{{X: 0.6, Y: 0.3, W: 0.1, H: 0.3}}
+
+		assert.Equal(t, image.Rect(435, 0, 735, 300), SmartCrop(img, 224, 224, areas))
+	})
+	t.Run("Group", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 900, 300))
+		areas := focus.Areas{{X: 0.5, Y: 0.3, W: 0.1, H: 0.2}, {X: 0.7, Y: 0.3, W: 0.1, H: 0.2}}
+
+		assert.Equal(t, image.Rect(435, 0, 735, 300), SmartCrop(img, 100, 100, areas))
+	})
+	t.Run("LargestFace", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 900, 300))
+		areas := focus.Areas{{X: 0.05, Y: 0.3, W: 0.1, H: 0.2}, {X: 0.8, Y: 0.3, W: 0.15, H: 0.3}}
+		reversed := focus.Areas{areas[1], areas[0]}
+
+		assert.Equal(t, image.Rect(600, 0, 900, 300), SmartCrop(img, 100, 100, areas))
+		assert.Equal(t, SmartCrop(img, 100, 100, areas), SmartCrop(img, 100, 100, reversed))
+	})
+	t.Run("Entropy", func(t *testing.T) {
+		img := testNoise(900, 300, image.Rect(650, 50, 850, 250))
+		r := SmartCrop(img, 100, 100, nil)
+
+		assert.Equal(t, 300, r.Dx())
+		assert.Equal(t, 300, r.Dy())
+		assert.True(t, r.Min.X > 500)
+		assert.Equal(t, r, SmartCrop(img, 100, 100, nil))
+	})
+	t.Run("Uniform", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 900, 300))
+
+		assert.Equal(t, image.Rect(300, 0, 600, 300), SmartCrop(img, 100, 100, nil))
+	})
+	t.Run("SameAspectRatio", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 300, 300))
+
+		assert.Equal(t, img.Bounds(), SmartCrop(img, 100, 100, focus.Areas{{X: 0.1, Y: 0.1, W: 0.1, H: 0.1}}))
+	})
+}
+
+func TestFillSmart(t *testing.T) {
+	img := testNoise(600, 300, image.Rect(0, 0, 200, 300))
+	result := FillSmart(img, 50, 50, nil, imaging.Lanczos)
+
+	assert.Equal(t, 50, result.Bounds().Dx())
+	assert.Equal(t, 50, result.Bounds().Dy())
+}
@@ -1,11 +1,19 @@
 package thumb
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
 var (
 	SizePrecached    = 2048
 	SizeUncached     = 7680
 	JpegQuality      = 95
 	JpegQualitySmall = 80
 	Filter           = ResampleLanczos
+	Crop             = ResampleFillCenter
 )
 
 func MaxSize() int {
@@ -28,27 +36,34 @@ type Size struct {
 	Height  int              `json:"h"`
 	Public  bool             `json:"-"`
 	Options []ResampleOption `json:"-"`
+	Formats []fs.FileFormat  `json:"-"`
 }
 
 type SizeMap map[Name]Size
 
+// Preferred thumbnail file formats, in order of priority.
+var (
+	FormatsSmall = []fs.FileFormat{fs.FormatAvif, fs.FormatWebP, fs.FormatJpeg}
+	FormatsLarge = []fs.FileFormat{fs.FormatWebP, fs.FormatJpeg}
+)
+
 // Sizes contains the properties of all thumbnail sizes.
 var Sizes = SizeMap{
-	Tile50:   {Tile50, Tile500, "Lists", 50, 50, false, []ResampleOption{ResampleFillCenter, ResampleDefault}},
-	Tile100:  {Tile100, Tile500, "Maps", 100, 100, false, []ResampleOption{ResampleFillCenter, ResampleDefault}},
-	Tile224:  {Tile224, Tile500, "TensorFlow, Mosaic", 224, 224, false, []ResampleOption{ResampleFillCenter, ResampleDefault}},
-	Tile500:  {Tile500, "", "Tiles", 500, 500, false, []ResampleOption{ResampleFillCenter, ResampleDefault}},
-	Colors:   {Colors, Fit720, "Color Detection", 3, 3, false, []ResampleOption{ResampleResize, ResampleNearestNeighbor, ResamplePng}},
-	Left224:  {Left224, Fit720, "TensorFlow", 224, 224, false, []ResampleOption{ResampleFillTopLeft, ResampleDefault}},
-	Right224: {Right224, Fit720, "TensorFlow", 224, 224, false, []ResampleOption{ResampleFillBottomRight, ResampleDefault}},
-	Fit720:   {Fit720, "", "Mobile, TV", 720, 720, true, []ResampleOption{ResampleFit, ResampleDefault}},
-	Fit1280:  {Fit1280, Fit2048, "Mobile, HD Ready TV", 1280, 1024, true, []ResampleOption{ResampleFit, ResampleDefault}},
-	Fit1920:  {Fit1920, Fit2048, "Mobile, Full HD TV", 1920, 1200, true, []ResampleOption{ResampleFit, ResampleDefault}},
-	Fit2048:  {Fit2048, "", "Tablets, Cinema 2K", 2048, 2048, true, []ResampleOption{ResampleFit, ResampleDefault}},
-	Fit2560:  {Fit2560, "", "Quad HD, Retina Display", 2560, 1600, true, []ResampleOption{ResampleFit, ResampleDefault}},
-	Fit3840:  {Fit3840, "", "Ultra HD", 3840, 2400, false, []ResampleOption{ResampleFit, ResampleDefault}}, // Deprecated in favor of fit_4096
-	Fit4096:  {Fit4096, "", "Ultra HD, Retina 4K", 4096, 4096, true, []ResampleOption{ResampleFit, ResampleDefault}},
-	Fit7680:  {Fit7680, "", "8K Ultra HD 2, Retina 6K", 7680, 4320, true, []ResampleOption{ResampleFit, ResampleDefault}},
+	Tile50:   {Tile50, Tile500, "Lists", 50, 50, false, []ResampleOption{ResampleFillDefault, ResampleDefault}, FormatsSmall},
+	Tile100:  {Tile100, Tile500, "Maps", 100, 100, false, []ResampleOption{ResampleFillDefault, ResampleDefault}, FormatsSmall},
+	Tile224:  {Tile224, Tile500, "TensorFlow, Mosaic", 224, 224, false, []ResampleOption{ResampleFillDefault, ResampleDefault}, FormatsSmall},
+	Tile500:  {Tile500, "", "Tiles", 500, 500, false, []ResampleOption{ResampleFillDefault, ResampleDefault}, FormatsSmall},
+	Colors:   {Colors, Fit720, "Color Detection", 3, 3, false, []ResampleOption{ResampleResize, ResampleNearestNeighbor, ResamplePng}, nil},
+	Left224:  {Left224, Fit720, "TensorFlow", 224, 224, false, []ResampleOption{ResampleFillTopLeft, ResampleDefault}, nil},
+	Right224: {Right224, Fit720, "TensorFlow", 224, 224, false, []ResampleOption{ResampleFillBottomRight, ResampleDefault}, nil},
+	Fit720:   {Fit720, "", "Mobile, TV", 720, 720, true, []ResampleOption{ResampleFit, ResampleDefault}, FormatsSmall},
+	Fit1280:  {Fit1280, Fit2048, "Mobile, HD Ready TV", 1280, 1024, true, []ResampleOption{ResampleFit, ResampleDefault}, FormatsSmall},
+	Fit1920:  {Fit1920, Fit2048, "Mobile, Full HD TV", 1920, 1200, true, []ResampleOption{ResampleFit, ResampleDefault}, FormatsSmall},
+	Fit2048:  {Fit2048, "", "Tablets, Cinema 2K", 2048, 2048, true, []ResampleOption{ResampleFit, ResampleDefault}, FormatsLarge},
+	Fit2560:  {Fit2560, "", "Quad HD, Retina Display", 2560, 1600, true, []ResampleOption{ResampleFit, ResampleDefault}, FormatsLarge},
+	Fit3840:  {Fit3840, "", "Ultra HD", 3840, 2400, false, []ResampleOption{ResampleFit, ResampleDefault}, FormatsLarge}, // Deprecated in favor of fit_4096
+	Fit4096:  {Fit4096, "", "Ultra HD, Retina 4K", 4096, 4096, true, []ResampleOption{ResampleFit, ResampleDefault}, FormatsLarge},
+	Fit7680:  {Fit7680, "", "8K Ultra HD 2, Retina 6K", 7680, 4320, true, []ResampleOption{ResampleFit, ResampleDefault}, FormatsLarge},
 }
 
 // DefaultSizes contains all default size names.
@@ -69,6 +84,13 @@ var DefaultSizes = []Name{
 	Tile50,
 }
 
+// CacheSizes returns the names of all default and custom sizes, e.g. for pre-caching thumbnails.
+func CacheSizes() []Name {
+	result := make([]Name, 0, len(DefaultSizes)+len(CustomNames))
+
+	return append(append(result, DefaultSizes...), CustomNames...)
+}
+
 // Find returns the largest default thumbnail type for the given size limit.
 func Find(limit int) (name Name, size Size) {
 	for _, name = range DefaultSizes {
@@ -91,3 +113,97 @@ func (s Size) Uncached() bool {
 func (s Size) ExceedsLimit() bool {
 	return s.Width > MaxSize() || s.Height > MaxSize()
 }
+
+// Method returns the resample method of thumbnails created with the default options.
+func (s Size) Method() ResampleOption {
+	method, _, _ := ResampleOptions(s.Options...)
+
+	return method
+}
+
+// Smart tests if thumbnails are smart cropped, so that their file names depend on the focus areas.
+func (s Size) Smart() bool {
+	return s.Method() == ResampleFillSmart
+}
+
+// Format returns the file format of thumbnails created with the default options.
+func (s Size) Format() fs.FileFormat {
+	_, _, format := ResampleOptions(s.Options...)
+
+	return format
+}
+
+// HasFormat tests if thumbnails may be created in the file format.
+func (s Size) HasFormat(format fs.FileFormat) bool {
+	if format == s.Format() {
+		return true
+	}
+
+	for _, f := range s.Formats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatOptions returns the resample options for creating thumbnails in the file format.
+func (s Size) FormatOptions(format fs.FileFormat) []ResampleOption {
+	if format == s.Format() {
+		return s.Options
+	}
+
+	option, ok := FormatOption(format)
+
+	if !ok {
+		return s.Options
+	}
+
+	opts := make([]ResampleOption, 0, len(s.Options)+1)
+
+	return append(append(opts, s.Options...), option)
+}
+
+// Negotiate returns the preferred file format that is accepted by the client and supported by the available encoders.
+func (s Size) Negotiate(accept string) fs.FileFormat {
+	defaultFormat := s.Format()
+
+	for _, format := range s.Formats {
+		if format == defaultFormat {
+			break
+		} else if FormatSupported(format) && Accepts(accept, FormatMimeType(format)) {
+			return format
+		}
+	}
+
+	return defaultFormat
+}
+
+// Accepts tests if an HTTP Accept header explicitly contains the mime type, wildcards are ignored.
+func Accepts(accept, mimeType string) bool {
+	if accept == "" || mimeType == "" {
+		return false
+	}
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		params := strings.Split(mediaRange, ";")
+
+		if !strings.EqualFold(strings.TrimSpace(params[0]), mimeType) {
+			continue
+		}
+
+		// Check quality factor, a value of zero means "not acceptable".
+		for _, param := range params[1:] {
+			if kv := strings.SplitN(strings.TrimSpace(param), "=", 2); len(kv) != 2 || strings.TrimSpace(kv[0]) != "q" {
+				continue
+			} else if q, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64); err == nil && q <= 0 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 func TestSize_ExceedsLimit(t *testing.T) {
@@ -70,3 +72,101 @@ func TestFind(t *testing.T) {
 		assert.Equal(t, 1200, size.Height)
 	})
 }
+
+func TestSize_Smart(t *testing.T) {
+	assert.False(t, Sizes[Tile500].Smart())
+
+	Crop = ResampleFillSmart
+
+	defer func() {
+		Crop = ResampleFillCenter
+	}()
+
+	assert.True(t, Sizes[Tile500].Smart())
+	assert.False(t, Sizes[Fit1920].Smart())
+}
+
+func TestSize_Format(t *testing.T) {
+	assert.Equal(t, fs.FormatJpeg, Sizes[Tile500].Format())
+	assert.Equal(t, fs.FormatJpeg, Sizes[Fit1920].Format())
+	assert.Equal(t, fs.FormatPng, Sizes[Colors].Format())
+}
+
+func TestSize_HasFormat(t *testing.T) {
+	assert.True(t, Sizes[Tile500].HasFormat(fs.FormatJpeg))
+	assert.True(t, Sizes[Tile500].HasFormat(fs.FormatWebP))
+	assert.True(t, Sizes[Tile500].HasFormat(fs.FormatAvif))
+	assert.True(t, Sizes[Fit4096].HasFormat(fs.FormatWebP))
+	assert.False(t, Sizes[Fit4096].HasFormat(fs.FormatAvif))
+	assert.True(t, Sizes[Colors].HasFormat(fs.FormatPng))
+	assert.False(t, Sizes[Colors].HasFormat(fs.FormatWebP))
+	assert.False(t, Sizes[Left224].HasFormat(fs.FormatWebP))
+}
+
+func TestSize_FormatOptions(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		size := Sizes[Fit720]
+		assert.Equal(t, size.Options, size.FormatOptions(fs.FormatJpeg))
+		assert.Equal(t, "720x720_fit.jpg", Suffix(size.Width, size.Height, size.FormatOptions(fs.FormatJpeg)...))
+	})
+	t.Run("WebP", func(t *testing.T) {
+		size := Sizes[Fit720]
+		assert.Equal(t, []ResampleOption{ResampleFit, ResampleDefault, ResampleWebP}, size.FormatOptions(fs.FormatWebP))
+		assert.Equal(t, []ResampleOption{ResampleFit, ResampleDefault}, size.Options)
+		assert.Equal(t, "720x720_fit.webp", Suffix(size.Width, size.Height, size.FormatOptions(fs.FormatWebP)...))
+	})
+	t.Run("Avif", func(t *testing.T) {
+		size := Sizes[Tile500]
+		assert.Equal(t, "500x500_center.avif", Suffix(size.Width, size.Height, size.FormatOptions(fs.FormatAvif)...))
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		size := Sizes[Tile500]
+		assert.Equal(t, size.Options, size.FormatOptions(fs.FormatGif))
+	})
+}
+
+func TestSize_Negotiate(t *testing.T) {
+	const accept = "image/avif,image/webp,image/apng,image/svg+xml,image/*,*/*;q=0.8"
+
+	t.Run("NoEncoders", func(t *testing.T) {
+		assert.Equal(t, fs.FormatJpeg, Sizes[Tile500].Negotiate(accept))
+	})
+	t.Run("WebP", func(t *testing.T) {
+		WebPBin = "/usr/bin/cwebp"
+
+		defer func() {
+			WebPBin = ""
+		}()
+
+		assert.Equal(t, fs.FormatWebP, Sizes[Tile500].Negotiate(accept))
+		assert.Equal(t, fs.FormatWebP, Sizes[Fit4096].Negotiate(accept))
+		assert.Equal(t, fs.FormatJpeg, Sizes[Fit4096].Negotiate("image/*,*/*;q=0.8"))
+		assert.Equal(t, fs.FormatJpeg, Sizes[Left224].Negotiate(accept))
+		assert.Equal(t, fs.FormatPng, Sizes[Colors].Negotiate(accept))
+	})
+	t.Run("Avif", func(t *testing.T) {
+		WebPBin = "/usr/bin/cwebp"
+		AvifBin = "/usr/bin/avifenc"
+
+		defer func() {
+			WebPBin = ""
+			AvifBin = ""
+		}()
+
+		assert.Equal(t, fs.FormatAvif, Sizes[Tile500].Negotiate(accept))
+		assert.Equal(t, fs.FormatWebP, Sizes[Fit4096].Negotiate(accept))
+		assert.Equal(t, fs.FormatWebP, Sizes[Tile500].Negotiate("image/avif;q=0,image/webp"))
+		assert.Equal(t, fs.FormatJpeg, Sizes[Tile500].Negotiate(""))
+	})
+}
+
+func TestAccepts(t *testing.T) {
+	assert.True(t, Accepts("image/avif,image/webp,*/*", "image/webp"))
+	assert.True(t, Accepts("image/avif, image/webp;q=0.9", "image/webp"))
+	assert.True(t, Accepts("IMAGE/WEBP", "image/webp"))
+	assert.False(t, Accepts("image/webp;q=0", "image/webp"))
+	assert.False(t, Accepts("image/webp; q=0.0", "image/webp"))
+	assert.False(t, Accepts("image/*,*/*", "image/webp"))
+	assert.False(t, Accepts("", "image/webp"))
+	assert.False(t, Accepts("image/webp", ""))
+}
@@ -0,0 +1,164 @@
+package video
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// HlsSegmentDuration is the target duration of a single HLS segment.
+var HlsSegmentDuration = 6 * time.Second
+
+// Rendition represents an HLS video stream variant.
+type Rendition struct {
+	Name         string
+	Height       int
+	Bitrate      int
+	AudioBitrate int
+	Profile      string
+	Level        string
+	Codecs       string
+}
+
+// Renditions lists the supported HLS stream variants, ordered by size.
+var Renditions = []Rendition{
+	{Name: "360p", Height: 360, Bitrate: 800000, AudioBitrate: 96000, Profile: "main", Level: "3.0", Codecs: "avc1.4d401e,mp4a.40.2"},
+	{Name: "720p", Height: 720, Bitrate: 2800000, AudioBitrate: 128000, Profile: "high", Level: "4.0", Codecs: "avc1.640028,mp4a.40.2"},
+	{Name: "1080p", Height: 1080, Bitrate: 5000000, AudioBitrate: 128000, Profile: "high", Level: "4.0", Codecs: "avc1.640028,mp4a.40.2"},
+	{Name: "2160p", Height: 2160, Bitrate: 14000000, AudioBitrate: 192000, Profile: "high", Level: "5.1", Codecs: "avc1.640033,mp4a.40.2"},
+}
+
+// FindRendition returns the HLS rendition with the given name.
+func FindRendition(name string) (Rendition, bool) {
+	for _, r := range Renditions {
+		if r.Name == name {
+			return r, true
+		}
+	}
+
+	return Rendition{}, false
+}
+
+// RenditionsFor returns the HLS renditions suitable for a video with the given dimensions,
+// so that videos are never upscaled. The smallest rendition is always included.
+func RenditionsFor(width, height int) (result []Rendition) {
+	short := shortSide(width, height)
+
+	for i, r := range Renditions {
+		if i == 0 || r.Height <= short {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
+// Size returns the rendition width and height for a video with the given dimensions.
+func (r Rendition) Size(width, height int) (w, h int) {
+	if width <= 0 || height <= 0 {
+		return r.Height * 16 / 9, r.Height
+	}
+
+	short := r.Height
+
+	if s := shortSide(width, height); s < short {
+		short = s
+	}
+
+	if width >= height {
+		h = short
+		w = evenInt(float64(width) * float64(short) / float64(height))
+	} else {
+		w = short
+		h = evenInt(float64(height) * float64(short) / float64(width))
+	}
+
+	return w, h
+}
+
+// Bandwidth returns the peak bandwidth of the rendition in bits per second.
+func (r Rendition) Bandwidth() int {
+	return r.Bitrate + r.AudioBitrate
+}
+
+// PlaylistName returns the media playlist file name of the rendition.
+func (r Rendition) PlaylistName() string {
+	return r.Name + ".m3u8"
+}
+
+// HlsSegmentCount returns the number of HLS segments for a video of the given duration.
+func HlsSegmentCount(duration time.Duration) int {
+	if duration <= 0 {
+		return 0
+	}
+
+	return int((duration + HlsSegmentDuration - 1) / HlsSegmentDuration)
+}
+
+// HlsSegment returns the start time and duration of the HLS segment with the given index.
+func HlsSegment(duration time.Duration, index int) (start, length time.Duration, err error) {
+	if index < 0 || index >= HlsSegmentCount(duration) {
+		return 0, 0, fmt.Errorf("hls: invalid segment index %d", index)
+	}
+
+	start = time.Duration(index) * HlsSegmentDuration
+	length = HlsSegmentDuration
+
+	if start+length > duration {
+		length = duration - start
+	}
+
+	return start, length, nil
+}
+
+// HlsMasterPlaylist returns an HLS master playlist with the renditions suitable for a video with the given dimensions.
+func HlsMasterPlaylist(width, height int) string {
+	var b strings.Builder
+
+	b.WriteString("#EXTM3U\n")
+	b.WriteString("#EXT-X-VERSION:3\n")
+
+	for _, r := range RenditionsFor(width, height) {
+		w, h := r.Size(width, height)
+		b.WriteString(fmt.Sprintf("#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d,CODECS=\"%s\"\n", r.Bandwidth(), w, h, r.Codecs))
+		b.WriteString(r.PlaylistName() + "\n")
+	}
+
+	return b.String()
+}
+
+// HlsMediaPlaylist returns an HLS media playlist for a rendition of a video with the given duration.
+func HlsMediaPlaylist(r Rendition, duration time.Duration) string {
+	var b strings.Builder
+
+	b.WriteString("#EXTM3U\n")
+	b.WriteString("#EXT-X-VERSION:3\n")
+	b.WriteString(fmt.Sprintf("#EXT-X-TARGETDURATION:%d\n", int(math.Ceil(HlsSegmentDuration.Seconds()))))
+	b.WriteString("#EXT-X-MEDIA-SEQUENCE:0\n")
+	b.WriteString("#EXT-X-PLAYLIST-TYPE:VOD\n")
+
+	for i := 0; i < HlsSegmentCount(duration); i++ {
+		_, length, _ := HlsSegment(duration, i)
+		b.WriteString(fmt.Sprintf("#EXTINF:%.3f,\n", length.Seconds()))
+		b.WriteString(fmt.Sprintf("%s/%d.ts\n", r.Name, i))
+	}
+
+	b.WriteString("#EXT-X-ENDLIST\n")
+
+	return b.String()
+}
+
+// shortSide returns the shorter side of a video.
+func shortSide(width, height int) int {
+	if width < height {
+		return width
+	}
+
+	return height
+}
+
+// evenInt rounds to the nearest even integer, as required by most video encoders.
+func evenInt(f float64) int {
+	return int(math.Round(f/2)) * 2
+}
@@ -0,0 +1,111 @@
+package video
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindRendition(t *testing.T) {
+	t.Run("720p", func(t *testing.T) {
+		r, ok := FindRendition("720p")
+		assert.True(t, ok)
+		assert.Equal(t, 720, r.Height)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, ok := FindRendition("123p")
+		assert.False(t, ok)
+	})
+}
+
+func TestRenditionsFor(t *testing.T) {
+	t.Run("4K", func(t *testing.T) {
+		assert.Len(t, RenditionsFor(3840, 2160), 4)
+	})
+	t.Run("FullHD", func(t *testing.T) {
+		result := RenditionsFor(1920, 1080)
+		assert.Len(t, result, 3)
+		assert.Equal(t, "1080p", result[2].Name)
+	})
+	t.Run("Portrait", func(t *testing.T) {
+		result := RenditionsFor(720, 1280)
+		assert.Len(t, result, 2)
+		assert.Equal(t, "720p", result[1].Name)
+	})
+	t.Run("Small", func(t *testing.T) {
+		result := RenditionsFor(270, 480)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "360p", result[0].Name)
+	})
+}
+
+func TestRendition_Size(t *testing.T) {
+	r, _ := FindRendition("720p")
+
+	t.Run("Landscape", func(t *testing.T) {
+		w, h := r.Size(3840, 2160)
+		assert.Equal(t, 1280, w)
+		assert.Equal(t, 720, h)
+	})
+	t.Run("Portrait", func(t *testing.T) {
+		w, h := r.Size(1080, 1920)
+		assert.Equal(t, 720, w)
+		assert.Equal(t, 1280, h)
+	})
+	t.Run("NoUpscale", func(t *testing.T) {
+		w, h := r.Size(270, 480)
+		assert.Equal(t, 270, w)
+		assert.Equal(t, 480, h)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		w, h := r.Size(0, 0)
+		assert.Equal(t, 1280, w)
+		assert.Equal(t, 720, h)
+	})
+}
+
+func TestHlsSegment(t *testing.T) {
+	duration := 14500 * time.Millisecond
+
+	assert.Equal(t, 0, HlsSegmentCount(0))
+	assert.Equal(t, 1, HlsSegmentCount(time.Second))
+	assert.Equal(t, 3, HlsSegmentCount(duration))
+
+	start, length, err := HlsSegment(duration, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 6*time.Second, start)
+	assert.Equal(t, 6*time.Second, length)
+
+	start, length, err = HlsSegment(duration, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 12*time.Second, start)
+	assert.Equal(t, 2500*time.Millisecond, length)
+
+	_, _, err = HlsSegment(duration, 3)
+	assert.Error(t, err)
+
+	_, _, err = HlsSegment(duration, -1)
+	assert.Error(t, err)
+}
+
+func TestHlsMasterPlaylist(t *testing.T) {
+	result := HlsMasterPlaylist(1920, 1080)
+
+	assert.True(t, strings.HasPrefix(result, "#EXTM3U\n"))
+	assert.Contains(t, result, "#EXT-X-STREAM-INF:BANDWIDTH=896000,RESOLUTION=640x360,CODECS=\"avc1.4d401e,mp4a.40.2\"\n360p.m3u8\n")
+	assert.Contains(t, result, "RESOLUTION=1920x1080")
+	assert.Contains(t, result, "1080p.m3u8")
+	assert.NotContains(t, result, "2160p")
+}
+
+func TestHlsMediaPlaylist(t *testing.T) {
+	r, _ := FindRendition("720p")
+	result := HlsMediaPlaylist(r, 14500*time.Millisecond)
+
+	expected := "#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-TARGETDURATION:6\n#EXT-X-MEDIA-SEQUENCE:0\n#EXT-X-PLAYLIST-TYPE:VOD\n" +
+		"#EXTINF:6.000,\n720p/0.ts\n#EXTINF:6.000,\n720p/1.ts\n#EXTINF:2.500,\n720p/2.ts\n#EXT-X-ENDLIST\n"
+
+	assert.Equal(t, expected, result)
+}
@@ -0,0 +1,181 @@
+package video
+
+import (
+	"image"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/disintegration/imaging"
+)
+
+// Video poster frame positions.
+const (
+	PosterAuto  = "auto"
+	PosterFirst = "first"
+)
+
+var (
+	PosterCandidates    = 5
+	PosterMinBrightness = 20.0
+	PosterMinContrast   = 10.0
+	PosterMinSharpness  = 25.0
+	PosterAnalyzeSize   = 256
+)
+
+// FrameStats represents luma statistics of a video frame used for poster selection.
+type FrameStats struct {
+	Brightness float64 // Mean luma from 0 to 255.
+	Contrast   float64 // Standard deviation of luma.
+	Sharpness  float64 // Variance of the Laplacian.
+}
+
+// Black tests if the frame is too dark, e.g. at the beginning of a video.
+func (s FrameStats) Black() bool {
+	return s.Brightness < PosterMinBrightness
+}
+
+// Flat tests if the frame has almost no contrast, e.g. during a fade.
+func (s FrameStats) Flat() bool {
+	return s.Contrast < PosterMinContrast
+}
+
+// Blurry tests if the frame lacks sharp edges, e.g. due to motion blur.
+func (s FrameStats) Blurry() bool {
+	return s.Sharpness < PosterMinSharpness
+}
+
+// Usable tests if the frame is suitable as video poster.
+func (s FrameStats) Usable() bool {
+	return !s.Black() && !s.Flat() && !s.Blurry()
+}
+
+// Score returns a quality score for comparing frames if none is usable.
+func (s FrameStats) Score() float64 {
+	return math.Min(s.Brightness, 255-s.Brightness) + s.Contrast + math.Sqrt(s.Sharpness)
+}
+
+// AnalyzeFrame returns the luma statistics of a video frame.
+func AnalyzeFrame(img image.Image) (s FrameStats) {
+	if img == nil {
+		return s
+	}
+
+	b := img.Bounds()
+
+	if b.Dx() > PosterAnalyzeSize || b.Dy() > PosterAnalyzeSize {
+		img = imaging.Fit(img, PosterAnalyzeSize, PosterAnalyzeSize, imaging.Box)
+		b = img.Bounds()
+	}
+
+	w, h := b.Dx(), b.Dy()
+
+	if w == 0 || h == 0 {
+		return s
+	}
+
+	luma := make([]float64, w*h)
+	var sum float64
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, bl, _ := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			l := (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)) / 257
+			luma[y*w+x] = l
+			sum += l
+		}
+	}
+
+	s.Brightness = sum / float64(w*h)
+
+	var variance float64
+
+	for _, l := range luma {
+		variance += (l - s.Brightness) * (l - s.Brightness)
+	}
+
+	s.Contrast = math.Sqrt(variance / float64(w*h))
+
+	if w < 3 || h < 3 {
+		return s
+	}
+
+	// Variance of the Laplacian as measure of focus.
+	var lapSum, lapSq float64
+	n := float64((w - 2) * (h - 2))
+
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			i := y*w + x
+			v := luma[i-w] + luma[i+w] + luma[i-1] + luma[i+1] - 4*luma[i]
+			lapSum += v
+			lapSq += v * v
+		}
+	}
+
+	mean := lapSum / n
+	s.Sharpness = lapSq/n - mean*mean
+
+	return s
+}
+
+// BestFrame returns the index of the first usable frame, or of the frame with the highest score
+// if none is usable. It returns -1 if the list is empty.
+func BestFrame(frames []FrameStats) int {
+	best := -1
+
+	for i, s := range frames {
+		if s.Usable() {
+			return i
+		} else if best < 0 || s.Score() > frames[best].Score() {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// PosterOffsets returns the candidate poster frame positions for a video of the given duration.
+func PosterOffsets(duration time.Duration, count int) []time.Duration {
+	first := time.Millisecond
+
+	if duration <= 0 || count <= 1 {
+		return []time.Duration{first}
+	}
+
+	result := make([]time.Duration, count)
+	result[0] = first
+
+	for i := 1; i < count; i++ {
+		result[i] = duration * time.Duration(i) / time.Duration(count)
+	}
+
+	return result
+}
+
+// PosterOffset returns the fixed poster frame position for the configured value,
+// or false if the poster frame should be selected automatically.
+func PosterOffset(poster string, duration time.Duration) (time.Duration, bool) {
+	poster = strings.ToLower(strings.TrimSpace(poster))
+
+	if poster == PosterFirst {
+		return time.Millisecond, true
+	}
+
+	d, err := time.ParseDuration(poster)
+
+	if err != nil || d < 0 {
+		return 0, false
+	}
+
+	if d == 0 {
+		d = time.Millisecond
+	}
+
+	// Don't seek beyond the end of the video.
+	if duration > 0 && d >= duration {
+		d = duration / 2
+	}
+
+	return d, true
+}
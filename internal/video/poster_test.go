@@ -0,0 +1,97 @@
+package video
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func testFrame(c color.Color, pattern bool) image.Image {
+	img := imaging.New(320, 180, c)
+
+	if pattern {
+		for y := 0; y < 180; y++ {
+			for x := 0; x < 320; x++ {
+				if (x/8+y/8)%2 == 0 {
+					img.Set(x, y, color.White)
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+func TestAnalyzeFrame(t *testing.T) {
+	t.Run("Black", func(t *testing.T) {
+		s := AnalyzeFrame(testFrame(color.Black, false))
+		assert.True(t, s.Black())
+		assert.True(t, s.Flat())
+		assert.True(t, s.Blurry())
+		assert.False(t, s.Usable())
+	})
+	t.Run("Gray", func(t *testing.T) {
+		s := AnalyzeFrame(testFrame(color.Gray{Y: 128}, false))
+		assert.False(t, s.Black())
+		assert.True(t, s.Flat())
+		assert.False(t, s.Usable())
+	})
+	t.Run("Blurry", func(t *testing.T) {
+		s := AnalyzeFrame(imaging.Blur(testFrame(color.Gray{Y: 64}, true), 8))
+		assert.False(t, s.Black())
+		assert.True(t, s.Blurry())
+		assert.False(t, s.Usable())
+	})
+	t.Run("Sharp", func(t *testing.T) {
+		s := AnalyzeFrame(testFrame(color.Gray{Y: 64}, true))
+		assert.False(t, s.Black())
+		assert.False(t, s.Flat())
+		assert.False(t, s.Blurry())
+		assert.True(t, s.Usable())
+	})
+	t.Run("Nil", func(t *testing.T) {
+		assert.Equal(t, FrameStats{}, AnalyzeFrame(nil))
+	})
+}
+
+func TestBestFrame(t *testing.T) {
+	black := FrameStats{Brightness: 5, Contrast: 2, Sharpness: 1}
+	blurry := FrameStats{Brightness: 120, Contrast: 40, Sharpness: 10}
+	sharp := FrameStats{Brightness: 100, Contrast: 50, Sharpness: 400}
+
+	assert.Equal(t, -1, BestFrame(nil))
+	assert.Equal(t, 2, BestFrame([]FrameStats{black, blurry, sharp, sharp}))
+	assert.Equal(t, 1, BestFrame([]FrameStats{black, blurry, black}))
+	assert.Equal(t, 0, BestFrame([]FrameStats{black}))
+}
+
+func TestPosterOffsets(t *testing.T) {
+	assert.Equal(t, []time.Duration{time.Millisecond}, PosterOffsets(0, 5))
+	assert.Equal(t, []time.Duration{time.Millisecond, 2 * time.Second, 4 * time.Second, 6 * time.Second, 8 * time.Second}, PosterOffsets(10*time.Second, 5))
+}
+
+func TestPosterOffset(t *testing.T) {
+	t.Run("Auto", func(t *testing.T) {
+		_, ok := PosterOffset(PosterAuto, 10*time.Second)
+		assert.False(t, ok)
+	})
+	t.Run("First", func(t *testing.T) {
+		d, ok := PosterOffset(PosterFirst, 10*time.Second)
+		assert.True(t, ok)
+		assert.Equal(t, time.Millisecond, d)
+	})
+	t.Run("Offset", func(t *testing.T) {
+		d, ok := PosterOffset("5s", 10*time.Second)
+		assert.True(t, ok)
+		assert.Equal(t, 5*time.Second, d)
+	})
+	t.Run("TooLong", func(t *testing.T) {
+		d, ok := PosterOffset("1m", 10*time.Second)
+		assert.True(t, ok)
+		assert.Equal(t, 5*time.Second, d)
+	})
+}
@@ -0,0 +1,124 @@
+package video
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Video preview file names in the thumbnail cache.
+const (
+	PreviewClip   = "clip.mp4"
+	PreviewSprite = "sprite.jpg"
+	PreviewVtt    = "sprite.vtt"
+)
+
+var (
+	ClipDuration      = 3 * time.Second
+	ClipHeight        = 360
+	SpriteMaxTiles    = 100
+	SpriteColumns     = 10
+	SpriteTileWidth   = 160
+	SpriteMinInterval = 2 * time.Second
+)
+
+// PreviewNames lists the video preview file names.
+var PreviewNames = []string{PreviewClip, PreviewSprite, PreviewVtt}
+
+// ScaleFilter returns an ffmpeg video filter that scales the shorter side to the given size
+// without upscaling, keeping the aspect ratio independent of the orientation.
+func ScaleFilter(size int) string {
+	return fmt.Sprintf("scale='if(gte(iw,ih),-2,min(%[1]d,iw))':'if(gte(iw,ih),min(%[1]d,ih),-2)',format=yuv420p", size)
+}
+
+// ClipOffset returns the start position of the animated preview clip.
+func ClipOffset(duration time.Duration) time.Duration {
+	if duration <= ClipDuration*2 {
+		return 0
+	}
+
+	return duration / 10
+}
+
+// Sprite represents the tile layout of a video scrubbing sprite sheet.
+type Sprite struct {
+	Duration   time.Duration
+	Interval   time.Duration
+	Count      int
+	Columns    int
+	Rows       int
+	TileWidth  int
+	TileHeight int
+}
+
+// NewSprite returns the sprite sheet layout for a video with the given duration and dimensions.
+func NewSprite(duration time.Duration, width, height int) Sprite {
+	s := Sprite{Duration: duration, TileWidth: SpriteTileWidth}
+
+	if width > 0 && height > 0 {
+		s.TileHeight = evenInt(float64(SpriteTileWidth) * float64(height) / float64(width))
+	} else {
+		s.TileHeight = evenInt(float64(SpriteTileWidth) * 9 / 16)
+	}
+
+	if duration <= 0 {
+		return s
+	}
+
+	// Use whole seconds, so that tiles never exceed the maximum count.
+	s.Interval = (duration/time.Duration(SpriteMaxTiles) + time.Second - 1).Truncate(time.Second)
+
+	if s.Interval < SpriteMinInterval {
+		s.Interval = SpriteMinInterval
+	}
+
+	s.Count = int((duration + s.Interval - 1) / s.Interval)
+	s.Columns = SpriteColumns
+
+	if s.Count < s.Columns {
+		s.Columns = s.Count
+	}
+
+	s.Rows = (s.Count + s.Columns - 1) / s.Columns
+
+	return s
+}
+
+// Empty tests if the sprite has no tiles.
+func (s Sprite) Empty() bool {
+	return s.Count <= 0
+}
+
+// Filter returns the ffmpeg video filter for rendering the sprite sheet.
+func (s Sprite) Filter() string {
+	return fmt.Sprintf("fps=1/%d,scale=%d:%d,tile=%dx%d", int(s.Interval.Seconds()), s.TileWidth, s.TileHeight, s.Columns, s.Rows)
+}
+
+// Vtt returns a WebVTT thumbnail track that references the tiles in the sprite image.
+func (s Sprite) Vtt(imageUri string) string {
+	var b strings.Builder
+
+	b.WriteString("WEBVTT\n")
+
+	for i := 0; i < s.Count; i++ {
+		start := time.Duration(i) * s.Interval
+		end := start + s.Interval
+
+		if end > s.Duration {
+			end = s.Duration
+		}
+
+		x := (i % s.Columns) * s.TileWidth
+		y := (i / s.Columns) * s.TileHeight
+
+		b.WriteString(fmt.Sprintf("\n%s --> %s\n%s#xywh=%d,%d,%d,%d\n", vttTime(start), vttTime(end), imageUri, x, y, s.TileWidth, s.TileHeight))
+	}
+
+	return b.String()
+}
+
+// vttTime formats a duration as WebVTT timestamp.
+func vttTime(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
@@ -0,0 +1,53 @@
+package video
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaleFilter(t *testing.T) {
+	assert.Equal(t, "scale='if(gte(iw,ih),-2,min(360,iw))':'if(gte(iw,ih),min(360,ih),-2)',format=yuv420p", ScaleFilter(360))
+}
+
+func TestClipOffset(t *testing.T) {
+	assert.Equal(t, time.Duration(0), ClipOffset(5*time.Second))
+	assert.Equal(t, 6*time.Second, ClipOffset(time.Minute))
+}
+
+func TestNewSprite(t *testing.T) {
+	t.Run("Short", func(t *testing.T) {
+		s := NewSprite(9*time.Second, 1920, 1080)
+		assert.Equal(t, 2*time.Second, s.Interval)
+		assert.Equal(t, 5, s.Count)
+		assert.Equal(t, 5, s.Columns)
+		assert.Equal(t, 1, s.Rows)
+		assert.Equal(t, 160, s.TileWidth)
+		assert.Equal(t, 90, s.TileHeight)
+		assert.Equal(t, "fps=1/2,scale=160:90,tile=5x1", s.Filter())
+	})
+	t.Run("Long", func(t *testing.T) {
+		s := NewSprite(10*time.Minute+time.Second, 1080, 1920)
+		assert.Equal(t, 7*time.Second, s.Interval)
+		assert.Equal(t, 86, s.Count)
+		assert.Equal(t, 10, s.Columns)
+		assert.Equal(t, 9, s.Rows)
+		assert.Equal(t, 284, s.TileHeight)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		s := NewSprite(0, 0, 0)
+		assert.True(t, s.Empty())
+		assert.Equal(t, 90, s.TileHeight)
+	})
+}
+
+func TestSprite_Vtt(t *testing.T) {
+	s := NewSprite(25*time.Second, 1920, 1080)
+	result := s.Vtt("sprite.jpg")
+
+	assert.True(t, strings.HasPrefix(result, "WEBVTT\n\n00:00:00.000 --> 00:00:02.000\nsprite.jpg#xywh=0,0,160,90\n"))
+	assert.Contains(t, result, "\n00:00:20.000 --> 00:00:22.000\nsprite.jpg#xywh=0,90,160,90\n")
+	assert.True(t, strings.HasSuffix(result, "\n00:00:24.000 --> 00:00:25.000\nsprite.jpg#xywh=320,90,160,90\n"))
+}
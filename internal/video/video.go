@@ -32,37 +32,161 @@ https://docs.photoprism.app/developer-guide/
 package video
 
 import (
+	"strings"
+
 	"github.com/photoprism/photoprism/pkg/fs"
 )
 
+// Type represents a video conversion target.
 type Type struct {
-	Format fs.FileFormat
-	Codec  fs.FileCodec
-	Width  int
-	Height int
-	Public bool
+	Format    fs.FileFormat
+	Codec     fs.FileCodec
+	Encoder   string
+	Container string
+	Mime      string
+	Width     int
+	Height    int
+	Public    bool
 }
 
 type TypeMap map[string]Type
 
 var TypeMp4 = Type{
-	Format: fs.FormatMp4,
-	Codec:  fs.CodecAvc,
-	Width:  0,
-	Height: 0,
-	Public: true,
+	Format:    fs.FormatMp4,
+	Codec:     fs.CodecAvc,
+	Encoder:   "libx264",
+	Container: "mp4",
+	Mime:      `video/mp4; codecs="avc1"`,
+	Width:     0,
+	Height:    0,
+	Public:    true,
 }
 
 var TypeAvc = Type{
-	Format: fs.FormatAvc,
-	Codec:  fs.CodecAvc,
-	Width:  0,
-	Height: 0,
-	Public: true,
+	Format:    fs.FormatAvc,
+	Codec:     fs.CodecAvc,
+	Encoder:   "libx264",
+	Container: "mp4",
+	Mime:      `video/mp4; codecs="avc1"`,
+	Width:     0,
+	Height:    0,
+	Public:    true,
+}
+
+var TypeHevc = Type{
+	Format:    fs.FormatHEVC,
+	Codec:     fs.CodecHvc,
+	Encoder:   "libx265",
+	Container: "mp4",
+	Mime:      `video/mp4; codecs="hvc1"`,
+	Width:     0,
+	Height:    0,
+	Public:    true,
+}
+
+var TypeVp9 = Type{
+	Format:    fs.FormatVp9,
+	Codec:     fs.CodecVp9,
+	Encoder:   "libvpx-vp9",
+	Container: "webm",
+	Mime:      `video/webm; codecs="vp9"`,
+	Width:     0,
+	Height:    0,
+	Public:    true,
+}
+
+var TypeAv1 = Type{
+	Format:    fs.FormatAv1,
+	Codec:     fs.CodecAv1,
+	Encoder:   "libaom-av1",
+	Container: "mp4",
+	Mime:      `video/mp4; codecs="av01"`,
+	Width:     0,
+	Height:    0,
+	Public:    true,
 }
 
 var Types = TypeMap{
-	"":    TypeAvc,
-	"mp4": TypeMp4,
-	"avc": TypeAvc,
+	"":     TypeAvc,
+	"mp4":  TypeMp4,
+	"avc":  TypeAvc,
+	"hevc": TypeHevc,
+	"vp9":  TypeVp9,
+	"webm": TypeVp9,
+	"av1":  TypeAv1,
+}
+
+// SidecarFormats maps codecs to the file formats of transcoded sidecar files, so that
+// they can't be confused with originals, e.g. "video.avc" instead of "video.mp4".
+var SidecarFormats = map[fs.FileCodec]fs.FileFormat{
+	fs.CodecAvc: fs.FormatAvc,
+	fs.CodecHvc: fs.FormatHEVC,
+	fs.CodecVp9: fs.FormatVp9,
+	fs.CodecAv1: fs.FormatAv1,
+}
+
+// SidecarFormat returns the file format of transcoded sidecar files with this type.
+func (t Type) SidecarFormat() fs.FileFormat {
+	if f, ok := SidecarFormats[t.Codec]; ok {
+		return f
+	}
+
+	return t.Format
+}
+
+// Codecs maps codec names as reported by clients and metadata to conversion targets.
+var Codecs = TypeMap{
+	"avc":  TypeAvc,
+	"avc1": TypeAvc,
+	"h264": TypeAvc,
+	"hevc": TypeHevc,
+	"hvc1": TypeHevc,
+	"hev1": TypeHevc,
+	"h265": TypeHevc,
+	"vp9":  TypeVp9,
+	"vp09": TypeVp9,
+	"av1":  TypeAv1,
+	"av01": TypeAv1,
+}
+
+// FindCodec returns the conversion target for a codec name.
+func FindCodec(name string) (Type, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	// Codec strings like "avc1.640028" contain profile and level.
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i]
+	}
+
+	t, ok := Codecs[name]
+
+	return t, ok
+}
+
+// Supports tests if one of the codec names matches the type.
+func (t Type) Supports(codecs []string) bool {
+	for _, name := range codecs {
+		if c, ok := FindCodec(name); ok && c.Codec == t.Codec {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Select returns the type that should be streamed to a client supporting the given codecs.
+// The original is streamed if the client can play its codec, otherwise the first preferred
+// target supported by the client is returned, with AVC as fallback.
+func Select(fileCodec string, clientCodecs, preferred []string) (t Type, original bool) {
+	if c, ok := FindCodec(fileCodec); ok && c.Supports(clientCodecs) {
+		return c, true
+	}
+
+	for _, name := range preferred {
+		if c, ok := FindCodec(name); ok && c.Supports(clientCodecs) {
+			return c, false
+		}
+	}
+
+	return TypeAvc, fs.FileCodec(fileCodec) == TypeAvc.Codec
 }
@@ -1,6 +1,12 @@
 package video
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
 
 func TestTypes(t *testing.T) {
 	if val := Types[""]; val != TypeAvc {
@@ -15,3 +21,63 @@ func TestTypes(t *testing.T) {
 		t.Fatal("mp4 type should be avc")
 	}
 }
+
+func TestType_SidecarFormat(t *testing.T) {
+	assert.Equal(t, fs.FormatAvc, TypeMp4.SidecarFormat())
+	assert.Equal(t, fs.FormatAvc, TypeAvc.SidecarFormat())
+	assert.Equal(t, fs.FormatHEVC, TypeHevc.SidecarFormat())
+	assert.Equal(t, fs.FormatVp9, TypeVp9.SidecarFormat())
+	assert.Equal(t, fs.FormatAv1, TypeAv1.SidecarFormat())
+}
+
+func TestFindCodec(t *testing.T) {
+	t.Run("Avc", func(t *testing.T) {
+		result, ok := FindCodec("avc1.640028")
+		assert.True(t, ok)
+		assert.Equal(t, TypeAvc, result)
+	})
+	t.Run("Hevc", func(t *testing.T) {
+		result, ok := FindCodec("HEV1")
+		assert.True(t, ok)
+		assert.Equal(t, TypeHevc, result)
+	})
+	t.Run("Vp9", func(t *testing.T) {
+		result, ok := FindCodec("vp09.00.10.08")
+		assert.True(t, ok)
+		assert.Equal(t, "webm", result.Container)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, ok := FindCodec("vp8")
+		assert.False(t, ok)
+	})
+}
+
+func TestType_Supports(t *testing.T) {
+	assert.True(t, TypeHevc.Supports([]string{"avc1", "hvc1"}))
+	assert.True(t, TypeAv1.Supports([]string{"av01.0.05M.08"}))
+	assert.False(t, TypeVp9.Supports([]string{"avc1", "hvc1"}))
+	assert.False(t, TypeVp9.Supports(nil))
+}
+
+func TestSelect(t *testing.T) {
+	t.Run("Original", func(t *testing.T) {
+		result, original := Select("hvc1", []string{"avc1", "hvc1"}, []string{"av1", "avc"})
+		assert.True(t, original)
+		assert.Equal(t, TypeHevc, result)
+	})
+	t.Run("Preferred", func(t *testing.T) {
+		result, original := Select("hvc1", []string{"avc1", "vp09", "av01"}, []string{"av1", "vp9", "avc"})
+		assert.False(t, original)
+		assert.Equal(t, TypeAv1, result)
+	})
+	t.Run("Fallback", func(t *testing.T) {
+		result, original := Select("hvc1", []string{"vp8"}, []string{"vp9"})
+		assert.False(t, original)
+		assert.Equal(t, TypeAvc, result)
+	})
+	t.Run("AvcOriginal", func(t *testing.T) {
+		result, original := Select("avc1", nil, []string{"hevc"})
+		assert.True(t, original)
+		assert.Equal(t, TypeAvc, result)
+	})
+}
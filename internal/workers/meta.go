@@ -127,6 +127,13 @@ func (m *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 		log.Warn(err)
 	}
 
+	// Update "On This Day" album?
+	if m.conf.MemoriesAlbum() {
+		if err := photoprism.NewMemories(m.conf).Start(time.Now()); err != nil {
+			log.Warn(err)
+		}
+	}
+
 	// Update precalculated photo and file counts.
 	if err := entity.UpdateCounts(); err != nil {
 		log.Warnf("index: %s (update counts)", err.Error())
@@ -38,12 +38,13 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
 )
 
 var log = event.Log
 var stop = make(chan bool, 1)
 
-// Start runs the metadata, share & sync background workers at regular intervals.
+// Start runs the metadata, share, sync & video preview background workers at regular intervals.
 func Start(conf *config.Config) {
 	interval := conf.WakeupInterval()
 
@@ -64,11 +65,13 @@ func Start(conf *config.Config) {
 				mutex.MetaWorker.Cancel()
 				mutex.ShareWorker.Cancel()
 				mutex.SyncWorker.Cancel()
+				mutex.VideoWorker.Cancel()
 				return
 			case <-ticker.C:
 				StartMeta(conf)
 				StartShare(conf)
 				StartSync(conf)
+				StartVideoPreviews(conf)
 			}
 		}
 	}()
@@ -118,3 +121,20 @@ func StartSync(conf *config.Config) {
 		}()
 	}
 }
+
+// StartVideoPreviews runs the video preview worker once.
+func StartVideoPreviews(conf *config.Config) {
+	if conf.DisableVideoPreviews() {
+		return
+	}
+
+	// Video previews may take a long time, so they are not part of the shared busy check.
+	if !mutex.WorkersBusy() && !mutex.VideoWorker.Busy() {
+		go func() {
+			worker := photoprism.NewVideoPreviews(conf)
+			if _, err := worker.Start(false); err != nil {
+				log.Warnf("video: %s", err)
+			}
+		}()
+	}
+}
@@ -1,5 +1,7 @@
 package colors
 
+import "math"
+
 type LightMap []Luminance
 
 // Hex returns all luminance value as a hex encoded string.
@@ -56,6 +58,34 @@ var diffValues = []diffValue{
 	https://jenssegers.com/perceptual-image-hashes
 */
 
+// Entropy returns the Shannon entropy of the luminance values in bits, which is higher for detailed image areas.
+func (m LightMap) Entropy() (result float64) {
+	if len(m) == 0 {
+		return 0
+	}
+
+	var hist [MaxLuminance + 1]int
+
+	for _, l := range m {
+		if l > MaxLuminance {
+			l = MaxLuminance
+		}
+
+		hist[l]++
+	}
+
+	n := float64(len(m))
+
+	for _, count := range hist {
+		if count > 0 {
+			p := float64(count) / n
+			result -= p * math.Log2(p)
+		}
+	}
+
+	return result
+}
+
 // Diff returns an integer that can be used to find similar images.
 func (m LightMap) Diff() (result uint32) {
 	if len(m) != 9 {
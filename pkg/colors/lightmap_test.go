@@ -82,3 +82,18 @@ func TestLightMap_Diff(t *testing.T) {
 		t.Logf("values: %d, %d, %d, %d", d1, d2, d3, d4)
 	})
 }
+
+func TestLightMap_Entropy(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, 0.0, LightMap{}.Entropy())
+	})
+	t.Run("Flat", func(t *testing.T) {
+		assert.Equal(t, 0.0, LightMap{5, 5, 5, 5}.Entropy())
+	})
+	t.Run("TwoValues", func(t *testing.T) {
+		assert.Equal(t, 1.0, LightMap{0, 15, 0, 15}.Entropy())
+	})
+	t.Run("FourValues", func(t *testing.T) {
+		assert.Equal(t, 2.0, LightMap{0, 5, 10, 15}.Entropy())
+	})
+}
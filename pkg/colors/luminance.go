@@ -1,9 +1,24 @@
 package colors
 
-import "fmt"
+import (
+	"fmt"
+	"image/color"
+	"math"
+)
+
+// MaxLuminance is the maximum luminance value.
+const MaxLuminance = 15
 
 type Luminance uint8
 
+// NewLuminance returns the relative luminance of a color from 0 to MaxLuminance.
+func NewLuminance(c color.Color) Luminance {
+	r, g, b, _ := c.RGBA()
+	y := (0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)) / 0xffff
+
+	return Luminance(math.Round(y * MaxLuminance))
+}
+
 func (l Luminance) Hex() string {
 	return fmt.Sprintf("%X", l)
 }
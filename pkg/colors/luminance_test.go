@@ -1,6 +1,7 @@
 package colors
 
 import (
+	"image/color"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,3 +11,10 @@ func TestLuminance_Hex(t *testing.T) {
 	lum := Luminance(1)
 	assert.Equal(t, "1", lum.Hex())
 }
+
+func TestNewLuminance(t *testing.T) {
+	assert.Equal(t, Luminance(0), NewLuminance(color.Black))
+	assert.Equal(t, Luminance(MaxLuminance), NewLuminance(color.White))
+	assert.Equal(t, Luminance(8), NewLuminance(color.Gray{Y: 128}))
+	assert.Equal(t, Luminance(3), NewLuminance(color.RGBA{R: 255, A: 255}))
+}
@@ -0,0 +1,101 @@
+/*
+
+Package focus provides image areas that should remain visible in cropped thumbnails, e.g. faces.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.app/developer-guide/
+
+*/
+package focus
+
+import (
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strings"
+)
+
+// Area represents an image area that should be visible in cropped thumbnails, e.g. a face.
+// Position and size are relative to the image width and height, from 0 to 1.
+type Area struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	W float64 `json:"w"`
+	H float64 `json:"h"`
+}
+
+// Valid tests if the area has a size and is inside the image.
+func (a Area) Valid() bool {
+	return a.W > 0 && a.H > 0 && a.X >= 0 && a.Y >= 0 && a.X+a.W <= 1.01 && a.Y+a.H <= 1.01
+}
+
+// String returns the rounded area position and size as string.
+func (a Area) String() string {
+	return fmt.Sprintf("%.3f,%.3f,%.3f,%.3f", a.X, a.Y, a.W, a.H)
+}
+
+// Areas represents a list of focus areas.
+type Areas []Area
+
+// Valid returns the valid focus areas sorted by size and position, so that the result doesn't depend on the original order.
+func (areas Areas) Valid() (result Areas) {
+	for _, a := range areas {
+		if a.Valid() {
+			result = append(result, a)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+
+		if sa, sb := a.W*a.H, b.W*b.H; sa != sb {
+			return sa > sb
+		} else if a.X != b.X {
+			return a.X < b.X
+		}
+
+		return a.Y < b.Y
+	})
+
+	return result
+}
+
+// Hash returns a short checksum of the valid focus areas, or an empty string if there are none.
+func (areas Areas) Hash() string {
+	valid := areas.Valid()
+
+	if len(valid) == 0 {
+		return ""
+	}
+
+	s := make([]string, len(valid))
+
+	for i := range valid {
+		s[i] = valid[i].String()
+	}
+
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(strings.Join(s, ";"))))
+}
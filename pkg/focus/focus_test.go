@@ -0,0 +1,56 @@
+package focus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArea_Valid(t *testing.T) {
+	assert.True(t, Area{X: 0.1, Y: 0.1, W: 0.2, H: 0.2}.Valid())
+	assert.False(t, Area{X: 0.5, Y: 0.5, W: 0, H: 0.1}.Valid())
+	assert.False(t, Area{X: 0.9, Y: 0.9, W: 0.5, H: 0.5}.Valid())
+	assert.False(t, Area{X: -0.1, Y: 0.1, W: 0.2, H: 0.2}.Valid())
+}
+
+func TestArea_String(t *testing.T) {
+	assert.Equal(t, "0.100,0.200,0.333,0.250", Area{X: 0.1, Y: 0.2, W: 0.33333, H: 0.25}.String())
+}
+
+func TestAreas_Valid(t *testing.T) {
+	areas := Areas{
+		{X: 0.6, Y: 0.1, W: 0.1, H: 0.1},
+		{X: 0.1, Y: 0.1, W: 0.2, H: 0.2},
+		{X: 0.2, Y: 0.1, W: 0.1, H: 0.1},
+		{X: 0.9, Y: 0.9, W: 0.5, H: 0.5},
+		{X: 0.5, Y: 0.5, W: 0, H: 0.1},
+	}
+
+	result := areas.Valid()
+
+	assert.Equal(t, Areas{
+		{X: 0.1, Y: 0.1, W: 0.2, H: 0.2},
+		{X: 0.2, Y: 0.1, W: 0.1, H: 0.1},
+		{X: 0.6, Y: 0.1, W: 0.1, H: 0.1},
+	}, result)
+}
+
+func TestAreas_Hash(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", Areas{}.Hash())
+		assert.Equal(t, "", Areas{{X: 0.5, Y: 0.5, W: 0, H: 0.1}}.Hash())
+	})
+	t.Run("Order", func(t *testing.T) {
+		a := Areas{{X: 0.1, Y: 0.1, W: 0.2, H: 0.2}, {X: 0.6, Y: 0.1, W: 0.1, H: 0.1}}
+		b := Areas{a[1], a[0], {X: 0.9, Y: 0.9, W: 0.5, H: 0.5}}
+
+		assert.Len(t, a.Hash(), 8)
+		assert.Equal(t, a.Hash(), b.Hash())
+	})
+	t.Run("Changed", func(t *testing.T) {
+		a := Areas{{X: 0.1, Y: 0.1, W: 0.2, H: 0.2}}
+		b := Areas{{X: 0.15, Y: 0.1, W: 0.2, H: 0.2}}
+
+		assert.NotEqual(t, a.Hash(), b.Hash())
+	})
+}
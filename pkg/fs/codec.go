@@ -11,6 +11,9 @@ type FileCodec string
 const (
 	CodecAvc   FileCodec = "avc1"
 	CodecHvc   FileCodec = "hvc1"
+	CodecHev   FileCodec = "hev1"
+	CodecVp9   FileCodec = "vp09"
+	CodecAv1   FileCodec = "av01"
 	CodecJpeg  FileCodec = "jpeg"
 	CodecOther FileCodec = ""
 )
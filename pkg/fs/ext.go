@@ -8,6 +8,9 @@ const (
 	YamlExt     = ".yml"
 	JpegExt     = ".jpg"
 	AvcExt      = ".avc"
+	HevcExt     = ".hevc"
+	Vp9Ext      = ".vp9"
+	Av1Ext      = ".av1"
 	FujiRawExt  = ".raf"
 	CanonCr3Ext = ".cr3"
 )
@@ -17,6 +17,8 @@ const (
 	FormatGif      FileFormat = "gif"  // GIF image file.
 	FormatTiff     FileFormat = "tiff" // TIFF image file.
 	FormatBitmap   FileFormat = "bmp"  // BMP image file.
+	FormatWebP     FileFormat = "webp" // WebP image file.
+	FormatAvif     FileFormat = "avif" // AV1 Image File Format.
 	FormatRaw      FileFormat = "raw"  // RAW image file.
 	FormatHEIF     FileFormat = "heif" // High Efficiency Image File Format
 	FormatHEVC     FileFormat = "hevc"
@@ -24,6 +26,8 @@ const (
 	FormatMp4      FileFormat = "mp4"
 	FormatMpo      FileFormat = "mpo"
 	FormatAvc      FileFormat = "avc"
+	FormatVp9      FileFormat = "vp9"
+	FormatAv1      FileFormat = "av1"
 	FormatAvi      FileFormat = "avi"
 	Format3gp      FileFormat = "3gp"
 	Format3g2      FileFormat = "3g2"
@@ -66,6 +70,8 @@ var FileExt = FileExtensions{
 	".m4v":  FormatMp4,
 	".avc":  FormatAvc,
 	".hevc": FormatHEVC,
+	".vp9":  FormatVp9,
+	".av1":  FormatAv1,
 	".3gp":  Format3gp,
 	".3g2":  Format3g2,
 	".flv":  FormatFlv,
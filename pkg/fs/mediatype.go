@@ -22,6 +22,8 @@ var MediaTypes = map[FileFormat]MediaType{
 	FormatAvi:      MediaVideo,
 	FormatHEVC:     MediaVideo,
 	FormatAvc:      MediaVideo,
+	FormatVp9:      MediaVideo,
+	FormatAv1:      MediaVideo,
 	FormatMp4:      MediaVideo,
 	FormatMov:      MediaVideo,
 	Format3gp:      MediaVideo,
@@ -13,6 +13,8 @@ const (
 	MimeTypeBitmap = "image/bmp"
 	MimeTypeTiff   = "image/tiff"
 	MimeTypeHEIF   = "image/heif"
+	MimeTypeWebP   = "image/webp"
+	MimeTypeAvif   = "image/avif"
 )
 
 // MimeType returns the mime type of a file, empty string if unknown.
@@ -0,0 +1,37 @@
+package fts
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Terms returns the stemmed search terms in a text, without stopwords.
+func Terms(s, lang string) (results []string) {
+	if s == "" {
+		return results
+	}
+
+	for _, w := range txt.Words(s) {
+		w = strings.ToLower(w)
+
+		// Index compound words as a whole and by part, e.g. "ile-de-france".
+		parts := []string{w}
+
+		if strings.Contains(w, "-") {
+			parts = append(parts, strings.Split(w, "-")...)
+		}
+
+		for _, p := range parts {
+			p = strings.Trim(p, "-'")
+
+			if len(p) < 2 || txt.StopWords[p] {
+				continue
+			}
+
+			results = append(results, Stem(p, lang))
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,25 @@
+package fts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerms(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, Terms("", "en"))
+	})
+	t.Run("English", func(t *testing.T) {
+		assert.Equal(t, []string{"cat", "walk", "bridg"}, Terms("Cats walking on the Bridge", "en"))
+	})
+	t.Run("German", func(t *testing.T) {
+		assert.Equal(t, []string{"haus", "haus"}, Terms("Häuser und Haus", "de"))
+	})
+	t.Run("Compound", func(t *testing.T) {
+		assert.Equal(t, []string{"ile-de-franc", "ile", "franc"}, Terms("Ile-de-France", "en"))
+	})
+	t.Run("StopWords", func(t *testing.T) {
+		assert.Empty(t, Terms("IMG upload", "en"))
+	})
+}
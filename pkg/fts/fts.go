@@ -0,0 +1,112 @@
+/*
+
+Package fts provides full-text search indexes with relevance ranking and language-specific stemming.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.app/developer-guide/
+
+*/
+package fts
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Index backends.
+const (
+	None     = "none"
+	Embedded = "embedded"
+)
+
+// ErrEmptyQuery is returned if a search query does not contain any indexable terms.
+var ErrEmptyQuery = errors.New("fts: empty query")
+
+// Index represents a full-text search index.
+type Index interface {
+	Update(doc Document) error
+	Delete(id uint) error
+	Reset() error
+	Search(q string, limit int) (Hits, error)
+	Len() int
+}
+
+// New returns a new index for the given backend and language, or nil if indexing is disabled.
+func New(backend, lang string) (Index, error) {
+	switch strings.ToLower(strings.TrimSpace(backend)) {
+	case "", None:
+		return nil, nil
+	case Embedded:
+		return NewMemory(lang), nil
+	default:
+		return nil, fmt.Errorf("fts: unsupported backend %s", backend)
+	}
+}
+
+// Field represents a weighted document text field.
+type Field struct {
+	Text   string
+	Weight uint
+}
+
+// Document represents an item to be indexed.
+type Document struct {
+	ID     uint
+	Fields []Field
+}
+
+// Hit represents a document matching a search query.
+type Hit struct {
+	ID    uint
+	Score float64
+}
+
+// Hits represents search results sorted by relevance.
+type Hits []Hit
+
+// IDs returns the document IDs of all hits.
+func (h Hits) IDs() []uint {
+	result := make([]uint, len(h))
+
+	for i, hit := range h {
+		result[i] = hit.ID
+	}
+
+	return result
+}
+
+// Sort sorts hits by score in descending order, and by ID if the score is the same.
+func (h Hits) Sort() {
+	sort.Slice(h, func(i, j int) bool {
+		if h[i].Score == h[j].Score {
+			return h[i].ID < h[j].ID
+		}
+
+		return h[i].Score > h[j].Score
+	})
+}
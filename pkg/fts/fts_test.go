@@ -0,0 +1,41 @@
+package fts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		idx, err := New(None, "en")
+
+		assert.NoError(t, err)
+		assert.Nil(t, idx)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		idx, err := New("", "en")
+
+		assert.NoError(t, err)
+		assert.Nil(t, idx)
+	})
+	t.Run("Embedded", func(t *testing.T) {
+		idx, err := New(" Embedded ", "en")
+
+		assert.NoError(t, err)
+		assert.IsType(t, &Memory{}, idx)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		idx, err := New("elastic", "en")
+
+		assert.EqualError(t, err, "fts: unsupported backend elastic")
+		assert.Nil(t, idx)
+	})
+}
+
+func TestHits_Sort(t *testing.T) {
+	hits := Hits{{ID: 3, Score: 1}, {ID: 2, Score: 2.5}, {ID: 1, Score: 1}}
+	hits.Sort()
+
+	assert.Equal(t, []uint{2, 1, 3}, hits.IDs())
+}
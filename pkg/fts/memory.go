@@ -0,0 +1,205 @@
+package fts
+
+import (
+	"math"
+	"strings"
+	"sync"
+)
+
+// BM25 ranking parameters.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// PrefixWeight is the score factor for terms that only match by prefix, e.g. "bri" and "bridge".
+const PrefixWeight = 0.5
+
+// Memory is an embedded in-memory index ranking matches with BM25.
+type Memory struct {
+	mu       sync.RWMutex
+	lang     string
+	postings map[string]map[uint]float64 // Weighted term frequencies by document.
+	docs     map[uint]memoryDoc
+	totalLen float64
+}
+
+// memoryDoc stores the indexed terms and length of a document, so that it can be removed.
+type memoryDoc struct {
+	terms  []string
+	length float64
+}
+
+// NewMemory returns a new embedded index for the given language.
+func NewMemory(lang string) *Memory {
+	return &Memory{
+		lang:     lang,
+		postings: make(map[string]map[uint]float64),
+		docs:     make(map[uint]memoryDoc),
+	}
+}
+
+// Len returns the number of indexed documents.
+func (idx *Memory) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.docs)
+}
+
+// Update adds a document to the index, or replaces it if it already exists.
+func (idx *Memory) Update(doc Document) error {
+	freq := make(map[string]float64)
+	length := 0.0
+
+	for _, field := range doc.Fields {
+		weight := float64(field.Weight)
+
+		if weight <= 0 {
+			weight = 1
+		}
+
+		for _, term := range Terms(field.Text, idx.lang) {
+			freq[term] += weight
+			length += weight
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(doc.ID)
+
+	if len(freq) == 0 {
+		return nil
+	}
+
+	terms := make([]string, 0, len(freq))
+
+	for term, f := range freq {
+		if idx.postings[term] == nil {
+			idx.postings[term] = make(map[uint]float64)
+		}
+
+		idx.postings[term][doc.ID] = f
+		terms = append(terms, term)
+	}
+
+	idx.docs[doc.ID] = memoryDoc{terms: terms, length: length}
+	idx.totalLen += length
+
+	return nil
+}
+
+// Delete removes a document from the index.
+func (idx *Memory) Delete(id uint) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(id)
+
+	return nil
+}
+
+// Reset removes all documents from the index.
+func (idx *Memory) Reset() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.postings = make(map[string]map[uint]float64)
+	idx.docs = make(map[uint]memoryDoc)
+	idx.totalLen = 0
+
+	return nil
+}
+
+// remove removes a document, the caller must hold the write lock.
+func (idx *Memory) remove(id uint) {
+	doc, ok := idx.docs[id]
+
+	if !ok {
+		return
+	}
+
+	for _, term := range doc.terms {
+		delete(idx.postings[term], id)
+
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+
+	idx.totalLen -= doc.length
+	delete(idx.docs, id)
+}
+
+// Search returns up to limit documents matching any of the query terms, sorted by relevance.
+// Terms with at least 3 characters also match longer terms with the same prefix.
+func (idx *Memory) Search(q string, limit int) (Hits, error) {
+	terms := Terms(q, idx.lang)
+
+	if len(terms) == 0 {
+		return nil, ErrEmptyQuery
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	n := float64(len(idx.docs))
+
+	if n == 0 {
+		return nil, nil
+	}
+
+	avgLen := idx.totalLen / n
+	scores := make(map[uint]float64)
+
+	for _, term := range terms {
+		for match, weight := range idx.matches(term) {
+			postings := idx.postings[match]
+			df := float64(len(postings))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+			for id, tf := range postings {
+				norm := tf + bm25K1*(1-bm25B+bm25B*idx.docs[id].length/avgLen)
+				scores[id] += weight * idf * tf * (bm25K1 + 1) / norm
+			}
+		}
+	}
+
+	hits := make(Hits, 0, len(scores))
+
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+
+	hits.Sort()
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits, nil
+}
+
+// matches returns the indexed terms matching a query term with their score factor,
+// the caller must hold the read lock.
+func (idx *Memory) matches(term string) map[string]float64 {
+	result := make(map[string]float64)
+
+	if _, ok := idx.postings[term]; ok {
+		result[term] = 1
+	}
+
+	if len(term) < 3 {
+		return result
+	}
+
+	for t := range idx.postings {
+		if t != term && strings.HasPrefix(t, term) {
+			result[t] = PrefixWeight
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,110 @@
+package fts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testMemory() *Memory {
+	idx := NewMemory("en")
+
+	_ = idx.Update(Document{ID: 1, Fields: []Field{{Text: "Golden Gate Bridge", Weight: 3}, {Text: "bridge, sunset, san francisco"}}})
+	_ = idx.Update(Document{ID: 2, Fields: []Field{{Text: "Cat on a Bridge", Weight: 3}, {Text: "cat, animal"}}})
+	_ = idx.Update(Document{ID: 3, Fields: []Field{{Text: "Sunset at the Beach", Weight: 3}, {Text: "beach, sunset, ocean"}}})
+	_ = idx.Update(Document{ID: 4, Fields: []Field{{Text: "Cats sleeping", Weight: 3}}})
+
+	return idx
+}
+
+func TestMemory_Search(t *testing.T) {
+	t.Run("Stemmed", func(t *testing.T) {
+		hits, err := testMemory().Search("bridges", 0)
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []uint{1, 2}, hits.IDs())
+	})
+	t.Run("Ranking", func(t *testing.T) {
+		hits, err := testMemory().Search("cat", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{2, 4}, hits.IDs())
+		assert.Greater(t, hits[0].Score, hits[1].Score)
+	})
+	t.Run("AnyTerm", func(t *testing.T) {
+		hits, err := testMemory().Search("sunset bridge", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), hits[0].ID)
+		assert.ElementsMatch(t, []uint{1, 2, 3}, hits.IDs())
+	})
+	t.Run("Prefix", func(t *testing.T) {
+		hits, err := testMemory().Search("sun", 0)
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []uint{1, 3}, hits.IDs())
+	})
+	t.Run("Limit", func(t *testing.T) {
+		hits, err := testMemory().Search("sunset bridge", 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, hits, 2)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		hits, err := testMemory().Search("mountain", 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+	t.Run("EmptyQuery", func(t *testing.T) {
+		hits, err := testMemory().Search("the", 0)
+
+		assert.Equal(t, ErrEmptyQuery, err)
+		assert.Empty(t, hits)
+	})
+}
+
+func TestMemory_Update(t *testing.T) {
+	idx := testMemory()
+
+	assert.Equal(t, 4, idx.Len())
+	assert.NoError(t, idx.Update(Document{ID: 2, Fields: []Field{{Text: "Dog on a Bridge"}}}))
+	assert.Equal(t, 4, idx.Len())
+
+	hits, err := idx.Search("cat", 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{4}, hits.IDs())
+
+	hits, err = idx.Search("dog", 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{2}, hits.IDs())
+}
+
+func TestMemory_Delete(t *testing.T) {
+	idx := testMemory()
+
+	assert.NoError(t, idx.Delete(1))
+	assert.NoError(t, idx.Delete(99))
+	assert.Equal(t, 3, idx.Len())
+
+	hits, err := idx.Search("bridge", 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{2}, hits.IDs())
+	assert.NotContains(t, idx.postings, "golden")
+}
+
+func TestMemory_Reset(t *testing.T) {
+	idx := testMemory()
+
+	assert.NoError(t, idx.Reset())
+	assert.Equal(t, 0, idx.Len())
+	assert.Empty(t, idx.postings)
+
+	hits, err := idx.Search("bridge", 0)
+
+	assert.NoError(t, err)
+	assert.Empty(t, hits)
+}
@@ -0,0 +1,105 @@
+package fts
+
+import "strings"
+
+// Stemmer reduces a lowercase word to its stem.
+type Stemmer func(w string) string
+
+// Stemmers maps language codes to stemmers.
+var Stemmers = map[string]Stemmer{
+	"en": StemEnglish,
+	"de": StemGerman,
+}
+
+// Stem returns the stem of a lowercase word in the given language, e.g. "en" or "de_DE".
+// Words are returned unchanged if no stemmer is available for the language.
+func Stem(w, lang string) string {
+	if len(lang) > 2 {
+		lang = lang[:2]
+	}
+
+	if stem, ok := Stemmers[strings.ToLower(lang)]; ok {
+		return stem(w)
+	}
+
+	return w
+}
+
+// vowel tests if the character is a vowel.
+func vowel(c byte) bool {
+	switch c {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	}
+
+	return false
+}
+
+// hasVowel tests if the string contains a vowel.
+func hasVowel(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if vowel(s[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StemEnglish removes common English plural, verb, and adverb suffixes, e.g. "bridges" becomes "bridg".
+func StemEnglish(w string) string {
+	if len(w) < 4 {
+		return w
+	}
+
+	switch {
+	case strings.HasSuffix(w, "sses"):
+		w = w[:len(w)-2]
+	case strings.HasSuffix(w, "ies"):
+		w = w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "ss"), strings.HasSuffix(w, "us"), strings.HasSuffix(w, "is"):
+		// Keep, e.g. "glass", "bus", or "paris".
+	case strings.HasSuffix(w, "s"):
+		w = w[:len(w)-1]
+	}
+
+	for _, suffix := range []string{"ing", "ed", "ly"} {
+		if stem := strings.TrimSuffix(w, suffix); stem != w && len(stem) >= 3 && hasVowel(stem) {
+			w = stem
+
+			// Remove double consonants, e.g. "swimm" becomes "swim".
+			if n := len(w); n > 3 && w[n-1] == w[n-2] && !vowel(w[n-1]) && !strings.ContainsRune("lsz", rune(w[n-1])) {
+				w = w[:n-1]
+			}
+
+			break
+		}
+	}
+
+	if n := len(w); n > 4 && w[n-1] == 'e' {
+		w = w[:n-1]
+	}
+
+	return w
+}
+
+// germanUmlauts replaces umlauts so that "Häuser" and "Haus" share the same stem.
+var germanUmlauts = strings.NewReplacer("ä", "a", "ö", "o", "ü", "u", "ß", "ss")
+
+// StemGerman removes common German inflection suffixes, e.g. "häusern" becomes "haus".
+func StemGerman(w string) string {
+	w = germanUmlauts.Replace(w)
+
+	for _, suffix := range []string{"ern", "em", "en", "er", "es", "e"} {
+		if stem := strings.TrimSuffix(w, suffix); stem != w && len(stem) >= 3 {
+			return stem
+		}
+	}
+
+	// Remove "s" only after a valid s-ending, so that "tags" becomes "tag" but "haus" is kept.
+	if n := len(w); n > 3 && w[n-1] == 's' && strings.IndexByte("bdfghklmnrt", w[n-2]) >= 0 {
+		return w[:n-1]
+	}
+
+	return w
+}
@@ -0,0 +1,72 @@
+package fts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	t.Run("English", func(t *testing.T) {
+		assert.Equal(t, "bridg", Stem("bridges", "en"))
+		assert.Equal(t, "bridg", Stem("bridge", "en_US"))
+	})
+	t.Run("German", func(t *testing.T) {
+		assert.Equal(t, "haus", Stem("häusern", "de-DE"))
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		assert.Equal(t, "maisons", Stem("maisons", "fr"))
+	})
+}
+
+func TestStemEnglish(t *testing.T) {
+	words := map[string]string{
+		"cat":       "cat",
+		"cats":      "cat",
+		"glasses":   "glass",
+		"glass":     "glass",
+		"cities":    "city",
+		"city":      "city",
+		"bus":       "bus",
+		"paris":     "paris",
+		"swimming":  "swim",
+		"walked":    "walk",
+		"quickly":   "quick",
+		"falling":   "fall",
+		"houses":    "hous",
+		"house":     "hous",
+		"red":       "red",
+		"sunset":    "sunset",
+		"mountains": "mountain",
+	}
+
+	for w, expected := range words {
+		t.Run(w, func(t *testing.T) {
+			assert.Equal(t, expected, StemEnglish(w))
+		})
+	}
+}
+
+func TestStemGerman(t *testing.T) {
+	words := map[string]string{
+		"haus":    "haus",
+		"häuser":  "haus",
+		"hauses":  "haus",
+		"tags":    "tag",
+		"berge":   "berg",
+		"bergen":  "berg",
+		"see":     "see",
+		"straße":  "strass",
+		"straßen": "strass",
+		"kindern": "kind",
+		"hund":    "hund",
+		"katzen":  "katz",
+		"schönem": "schon",
+	}
+
+	for w, expected := range words {
+		t.Run(w, func(t *testing.T) {
+			assert.Equal(t, expected, StemGerman(w))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package geo
+
+import (
+	"gopkg.in/photoprism/go-tz.v2/tz"
+)
+
+// TimeZone returns the name of the time zone at the given coordinates based on the embedded
+// time zone boundary data, or an empty string if unknown.
+func TimeZone(lat, lng float64) string {
+	if lat == 0 && lng == 0 {
+		return ""
+	} else if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return ""
+	}
+
+	zones, err := tz.GetZone(tz.Point{
+		Lat: lat,
+		Lon: lng,
+	})
+
+	if err != nil || len(zones) == 0 {
+		return ""
+	}
+
+	return zones[0]
+}
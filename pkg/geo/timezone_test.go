@@ -0,0 +1,42 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeZone(t *testing.T) {
+	t.Run("StrasbourgKehl", func(t *testing.T) {
+		assert.Equal(t, "Europe/Paris", TimeZone(48.5734, 7.7521))
+		assert.Equal(t, "Europe/Berlin", TimeZone(48.5716, 7.8156))
+	})
+	t.Run("BaselSaintLouis", func(t *testing.T) {
+		assert.Equal(t, "Europe/Zurich", TimeZone(47.5596, 7.5886))
+		assert.Equal(t, "Europe/Paris", TimeZone(47.5835, 7.5727))
+	})
+	t.Run("HendayeIrun", func(t *testing.T) {
+		assert.Equal(t, "Europe/Paris", TimeZone(43.3584, -1.7745))
+		assert.Equal(t, "Europe/Madrid", TimeZone(43.3391, -1.7894))
+	})
+	t.Run("GoriziaNovaGorica", func(t *testing.T) {
+		assert.Equal(t, "Europe/Rome", TimeZone(45.9401, 13.6209))
+		assert.Equal(t, "Europe/Ljubljana", TimeZone(45.9558, 13.6483))
+	})
+	t.Run("TijuanaSanYsidro", func(t *testing.T) {
+		assert.Equal(t, "America/Tijuana", TimeZone(32.5149, -117.0382))
+		assert.Equal(t, "America/Los_Angeles", TimeZone(32.5650, -117.0460))
+	})
+	t.Run("DetroitWindsor", func(t *testing.T) {
+		assert.Equal(t, "America/Detroit", TimeZone(42.3314, -83.0458))
+		assert.Equal(t, "America/Toronto", TimeZone(42.3149, -83.0364))
+	})
+	t.Run("Ocean", func(t *testing.T) {
+		assert.Equal(t, "Etc/GMT+2", TimeZone(0, -30))
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		assert.Equal(t, "", TimeZone(0, 0))
+		assert.Equal(t, "", TimeZone(91, 0))
+		assert.Equal(t, "", TimeZone(10, 181))
+	})
+}
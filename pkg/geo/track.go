@@ -0,0 +1,179 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Track file formats.
+const (
+	TrackGPX     = "gpx"
+	TrackKML     = "kml"
+	TrackGeoJSON = "geojson"
+)
+
+// ErrEmptyTrack is returned if a track contains no timestamped positions.
+var ErrEmptyTrack = errors.New("track contains no timestamped positions")
+
+// Track represents a list of timestamped positions, sorted by time.
+type Track []Position
+
+// NewTrack returns a new track containing the timestamped positions, sorted by time.
+func NewTrack(positions ...Position) Track {
+	t := make(Track, 0, len(positions))
+
+	for _, p := range positions {
+		if p.Time.IsZero() || p.Lat == 0 && p.Lng == 0 {
+			continue
+		}
+
+		p.Time = p.Time.UTC()
+		t = append(t, p)
+	}
+
+	sort.SliceStable(t, func(i, j int) bool {
+		return t[i].Time.Before(t[j].Time)
+	})
+
+	return t
+}
+
+// TrackFormat returns the track format based on the file extension, or an empty string if unsupported.
+func TrackFormat(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".gpx":
+		return TrackGPX
+	case ".kml":
+		return TrackKML
+	case ".geojson", ".json":
+		return TrackGeoJSON
+	default:
+		return ""
+	}
+}
+
+// ReadTrack reads a GPX, KML, or GeoJSON track file.
+func ReadTrack(fileName string) (Track, error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return Track{}, err
+	}
+
+	defer f.Close()
+
+	return ParseTrack(f, TrackFormat(fileName))
+}
+
+// ParseTrack parses track data in the specified format.
+func ParseTrack(r io.Reader, format string) (t Track, err error) {
+	switch format {
+	case TrackGPX:
+		t, err = ParseGPX(r)
+	case TrackKML:
+		t, err = ParseKML(r)
+	case TrackGeoJSON:
+		t, err = ParseGeoJSON(r)
+	default:
+		return Track{}, fmt.Errorf("unsupported track format %q", format)
+	}
+
+	if err != nil {
+		return t, err
+	} else if len(t) == 0 {
+		return t, ErrEmptyTrack
+	}
+
+	return t, nil
+}
+
+// Merge returns a new track containing the positions of both tracks.
+func (t Track) Merge(other Track) Track {
+	return NewTrack(append(append(Track{}, t...), other...)...)
+}
+
+// Start returns the time of the first position.
+func (t Track) Start() time.Time {
+	if len(t) == 0 {
+		return time.Time{}
+	}
+
+	return t[0].Time
+}
+
+// End returns the time of the last position.
+func (t Track) End() time.Time {
+	if len(t) == 0 {
+		return time.Time{}
+	}
+
+	return t[len(t)-1].Time
+}
+
+// Position returns the position at the given time based on the closest track points. It returns false
+// if no track point is within the max time gap.
+func (t Track) Position(at time.Time, maxGap time.Duration) (pos Position, gap time.Duration, ok bool) {
+	if len(t) == 0 || at.IsZero() {
+		return pos, gap, false
+	}
+
+	at = at.UTC()
+
+	// Index of the first position at or after the given time.
+	i := sort.Search(len(t), func(i int) bool {
+		return !t[i].Time.Before(at)
+	})
+
+	var prev, next *Position
+	var prevGap, nextGap time.Duration
+
+	if i > 0 {
+		prev = &t[i-1]
+		prevGap = at.Sub(prev.Time)
+	}
+
+	if i < len(t) {
+		next = &t[i]
+		nextGap = next.Time.Sub(at)
+	}
+
+	switch {
+	case next != nil && nextGap == 0:
+		return *next, 0, true
+	case prev != nil && next != nil && prevGap <= maxGap && nextGap <= maxGap:
+		m := NewMovement(*prev, *next)
+		pos = m.EstimatePosition(at)
+		pos.Estimate = false
+
+		if prevGap < nextGap {
+			return pos, prevGap, true
+		}
+
+		return pos, nextGap, true
+	case prev != nil && prevGap <= maxGap && (next == nil || prevGap <= nextGap):
+		return *prev, prevGap, true
+	case next != nil && nextGap <= maxGap:
+		return *next, nextGap, true
+	default:
+		return pos, gap, false
+	}
+}
+
+// parseTrackTime parses a track point timestamp.
+func parseTrackTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.UTC(), nil
+	} else if t, err := time.Parse("2006-01-02T15:04:05", s); err == nil {
+		return t.UTC(), nil
+	} else {
+		return time.Time{}, err
+	}
+}
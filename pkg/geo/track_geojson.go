@@ -0,0 +1,117 @@
+package geo
+
+import (
+	"encoding/json"
+	"io"
+
+	geojson "github.com/paulmach/go.geojson"
+)
+
+// ParseGeoJSON parses timestamped positions from GeoJSON data.
+//
+// Supported are LineString and MultiLineString features with a "coordTimes" property, as
+// created by common GPX and KML converters, and Point features with a "time" property.
+func ParseGeoJSON(r io.Reader) (Track, error) {
+	data, err := io.ReadAll(r)
+
+	if err != nil {
+		return Track{}, err
+	}
+
+	var features []*geojson.Feature
+
+	if fc, err := geojson.UnmarshalFeatureCollection(data); err == nil && len(fc.Features) > 0 {
+		features = fc.Features
+	} else if f, err := geojson.UnmarshalFeature(data); err == nil && f.Geometry != nil {
+		features = []*geojson.Feature{f}
+	} else if err != nil {
+		return Track{}, err
+	}
+
+	var positions []Position
+
+	for _, f := range features {
+		if f == nil || f.Geometry == nil {
+			continue
+		}
+
+		switch f.Geometry.Type {
+		case geojson.GeometryPoint:
+			if t, err := parseTrackTime(geojsonString(f.Properties["time"])); err == nil {
+				p := geojsonPosition(f.Geometry.Point)
+				p.Time = t
+				positions = append(positions, p)
+			}
+		case geojson.GeometryLineString:
+			times := geojsonStrings(f.Properties["coordTimes"])
+			positions = append(positions, geojsonLine(f.Geometry.LineString, times)...)
+		case geojson.GeometryMultiLineString:
+			var times [][]string
+
+			if raw, err := json.Marshal(f.Properties["coordTimes"]); err == nil {
+				_ = json.Unmarshal(raw, &times)
+			}
+
+			for i, line := range f.Geometry.MultiLineString {
+				if i < len(times) {
+					positions = append(positions, geojsonLine(line, times[i])...)
+				}
+			}
+		}
+	}
+
+	return NewTrack(positions...), nil
+}
+
+// geojsonLine returns the positions of a line with matching timestamps.
+func geojsonLine(coords [][]float64, times []string) (positions []Position) {
+	for i := 0; i < len(coords) && i < len(times); i++ {
+		if t, err := parseTrackTime(times[i]); err == nil {
+			p := geojsonPosition(coords[i])
+			p.Time = t
+			positions = append(positions, p)
+		}
+	}
+
+	return positions
+}
+
+// geojsonPosition returns the position of a GeoJSON coordinate: longitude, latitude, and optional altitude.
+func geojsonPosition(coord []float64) (p Position) {
+	if len(coord) < 2 {
+		return p
+	}
+
+	p.Lng = coord[0]
+	p.Lat = coord[1]
+
+	if len(coord) > 2 {
+		p.Altitude = coord[2]
+	}
+
+	return p
+}
+
+// geojsonString returns a property value as string.
+func geojsonString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	return ""
+}
+
+// geojsonStrings returns a property value as list of strings.
+func geojsonStrings(v interface{}) (result []string) {
+	values, ok := v.([]interface{})
+
+	if !ok {
+		return result
+	}
+
+	for _, val := range values {
+		result = append(result, geojsonString(val))
+	}
+
+	return result
+}
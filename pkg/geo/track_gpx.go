@@ -0,0 +1,62 @@
+package geo
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// gpxPoint represents a GPX waypoint, route point, or track point.
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lng  float64 `xml:"lon,attr"`
+	Ele  float64 `xml:"ele"`
+	Time string  `xml:"time"`
+	Name string  `xml:"name"`
+}
+
+// gpxDocument represents a GPX 1.0 or 1.1 document, see https://www.topografix.com/gpx.asp
+type gpxDocument struct {
+	XMLName   xml.Name   `xml:"gpx"`
+	Waypoints []gpxPoint `xml:"wpt"`
+	Routes    []struct {
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+	Tracks []struct {
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// ParseGPX parses timestamped positions from GPX data.
+func ParseGPX(r io.Reader) (Track, error) {
+	doc := gpxDocument{}
+
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return Track{}, err
+	}
+
+	var positions []Position
+
+	add := func(points []gpxPoint) {
+		for _, p := range points {
+			if t, err := parseTrackTime(p.Time); err == nil {
+				positions = append(positions, Position{Name: p.Name, Time: t, Lat: p.Lat, Lng: p.Lng, Altitude: p.Ele})
+			}
+		}
+	}
+
+	for _, trk := range doc.Tracks {
+		for _, seg := range trk.Segments {
+			add(seg.Points)
+		}
+	}
+
+	for _, rte := range doc.Routes {
+		add(rte.Points)
+	}
+
+	add(doc.Waypoints)
+
+	return NewTrack(positions...), nil
+}
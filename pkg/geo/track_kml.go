@@ -0,0 +1,136 @@
+package geo
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseKML parses timestamped positions from KML data, see https://developers.google.com/kml/documentation/kmlreference
+//
+// Supported are gx:Track elements with when and gx:coord values, and
+// placemarks with a TimeStamp and a Point.
+func ParseKML(r io.Reader) (Track, error) {
+	d := xml.NewDecoder(r)
+
+	var positions []Position
+	var path []string
+	var whens []time.Time
+	var coords []Position
+	var stamp time.Time
+	var point *Position
+
+	for {
+		tok, err := d.Token()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return Track{}, err
+		}
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			name := el.Name.Local
+			parent := ""
+
+			if len(path) > 0 {
+				parent = path[len(path)-1]
+			}
+
+			path = append(path, name)
+
+			switch {
+			case name == "Track":
+				whens = whens[:0]
+				coords = coords[:0]
+			case name == "Placemark":
+				stamp = time.Time{}
+				point = nil
+			case name == "when" && parent == "Track":
+				var s string
+
+				if err := d.DecodeElement(&s, &el); err != nil {
+					return Track{}, err
+				}
+
+				path = path[:len(path)-1]
+
+				t, _ := parseTrackTime(s)
+				whens = append(whens, t)
+			case name == "coord" && parent == "Track":
+				var s string
+
+				if err := d.DecodeElement(&s, &el); err != nil {
+					return Track{}, err
+				}
+
+				path = path[:len(path)-1]
+
+				// Values are separated by spaces: longitude, latitude, and altitude.
+				coords = append(coords, parseKmlCoord(strings.Fields(s)))
+			case name == "when" && parent == "TimeStamp":
+				var s string
+
+				if err := d.DecodeElement(&s, &el); err != nil {
+					return Track{}, err
+				}
+
+				path = path[:len(path)-1]
+
+				stamp, _ = parseTrackTime(s)
+			case name == "coordinates" && parent == "Point":
+				var s string
+
+				if err := d.DecodeElement(&s, &el); err != nil {
+					return Track{}, err
+				}
+
+				path = path[:len(path)-1]
+
+				// Values are separated by commas: longitude, latitude, and altitude.
+				p := parseKmlCoord(strings.Split(strings.TrimSpace(s), ","))
+				point = &p
+			}
+		case xml.EndElement:
+			if len(path) > 0 {
+				path = path[:len(path)-1]
+			}
+
+			switch el.Name.Local {
+			case "Track":
+				for i := 0; i < len(whens) && i < len(coords); i++ {
+					p := coords[i]
+					p.Time = whens[i]
+					positions = append(positions, p)
+				}
+			case "Placemark":
+				if point != nil && !stamp.IsZero() {
+					p := *point
+					p.Time = stamp
+					positions = append(positions, p)
+				}
+			}
+		}
+	}
+
+	return NewTrack(positions...), nil
+}
+
+// parseKmlCoord returns a position based on longitude, latitude, and optional altitude values.
+func parseKmlCoord(values []string) (p Position) {
+	if len(values) < 2 {
+		return p
+	}
+
+	p.Lng, _ = strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+	p.Lat, _ = strconv.ParseFloat(strings.TrimSpace(values[1]), 64)
+
+	if len(values) > 2 {
+		p.Altitude, _ = strconv.ParseFloat(strings.TrimSpace(values[2]), 64)
+	}
+
+	return p
+}
@@ -0,0 +1,137 @@
+package geo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackFormat(t *testing.T) {
+	assert.Equal(t, TrackGPX, TrackFormat("walk.GPX"))
+	assert.Equal(t, TrackKML, TrackFormat("/tracks/walk.kml"))
+	assert.Equal(t, TrackGeoJSON, TrackFormat("walk.geojson"))
+	assert.Equal(t, TrackGeoJSON, TrackFormat("walk.json"))
+	assert.Equal(t, "", TrackFormat("walk.jpg"))
+}
+
+func TestReadTrack(t *testing.T) {
+	for _, fileName := range []string{"testdata/track.gpx", "testdata/track.kml", "testdata/track.geojson"} {
+		t.Run(fileName, func(t *testing.T) {
+			track, err := ReadTrack(fileName)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Len(t, track, 4)
+			assert.Equal(t, time.Date(2021, 6, 12, 9, 0, 0, 0, time.UTC), track.Start())
+			assert.Equal(t, time.Date(2021, 6, 12, 10, 20, 0, 0, time.UTC), track.End())
+			assert.Equal(t, 52.5200, track[0].Lat)
+			assert.Equal(t, 13.4050, track[0].Lng)
+			assert.Equal(t, float64(34), track[0].Altitude)
+			assert.Equal(t, 52.5163, track[1].Lat)
+			assert.Equal(t, 13.3777, track[1].Lng)
+			assert.Equal(t, 35.5, track[1].Altitude)
+		})
+	}
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := ReadTrack("testdata/missing.gpx")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseTrack(t *testing.T) {
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		_, err := ParseTrack(strings.NewReader(""), "csv")
+		assert.Error(t, err)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := ParseTrack(strings.NewReader(`<gpx version="1.1"></gpx>`), TrackGPX)
+		assert.Equal(t, ErrEmptyTrack, err)
+	})
+	t.Run("InvalidXml", func(t *testing.T) {
+		_, err := ParseTrack(strings.NewReader(`<gpx`), TrackGPX)
+		assert.Error(t, err)
+	})
+	t.Run("GeoJSONFeature", func(t *testing.T) {
+		track, err := ParseTrack(strings.NewReader(`{"type": "Feature", "properties": {"time": "2021-06-12T09:00:00+02:00"},
+			"geometry": {"type": "Point", "coordinates": [13.405, 52.52]}}`), TrackGeoJSON)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, track, 1)
+		assert.Equal(t, time.Date(2021, 6, 12, 7, 0, 0, 0, time.UTC), track[0].Time)
+	})
+}
+
+func TestNewTrack(t *testing.T) {
+	t.Run("SortAndFilter", func(t *testing.T) {
+		track := NewTrack(
+			Position{Time: time.Date(2021, 6, 12, 10, 0, 0, 0, time.UTC), Lat: 2, Lng: 2},
+			Position{Time: time.Date(2021, 6, 12, 9, 0, 0, 0, time.UTC), Lat: 1, Lng: 1},
+			Position{Lat: 3, Lng: 3},
+			Position{Time: time.Date(2021, 6, 12, 11, 0, 0, 0, time.UTC)},
+		)
+
+		assert.Len(t, track, 2)
+		assert.Equal(t, float64(1), track[0].Lat)
+		assert.Equal(t, float64(2), track[1].Lat)
+	})
+	t.Run("Merge", func(t *testing.T) {
+		a := NewTrack(Position{Time: time.Date(2021, 6, 12, 10, 0, 0, 0, time.UTC), Lat: 2, Lng: 2})
+		b := NewTrack(Position{Time: time.Date(2021, 6, 12, 9, 0, 0, 0, time.UTC), Lat: 1, Lng: 1})
+
+		track := a.Merge(b)
+
+		assert.Len(t, track, 2)
+		assert.Len(t, a, 1)
+		assert.Equal(t, float64(1), track[0].Lat)
+	})
+}
+
+func TestTrack_Position(t *testing.T) {
+	track, err := ReadTrack("testdata/track.gpx")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	maxGap := 10 * time.Minute
+
+	t.Run("Exact", func(t *testing.T) {
+		pos, gap, ok := track.Position(time.Date(2021, 6, 12, 10, 10, 0, 0, time.UTC), maxGap)
+
+		assert.True(t, ok)
+		assert.Equal(t, time.Duration(0), gap)
+		assert.Equal(t, 52.5186, pos.Lat)
+		assert.Equal(t, 13.3762, pos.Lng)
+	})
+	t.Run("Interpolated", func(t *testing.T) {
+		pos, gap, ok := track.Position(time.Date(2021, 6, 12, 12, 5, 0, 0, time.FixedZone("CEST", 7200)), maxGap)
+
+		assert.True(t, ok)
+		assert.Equal(t, 5*time.Minute, gap)
+		assert.InEpsilon(t, 52.51745, pos.Lat, 0.000001)
+		assert.InEpsilon(t, 13.37695, pos.Lng, 0.000001)
+		assert.False(t, pos.Estimate)
+	})
+	t.Run("Nearest", func(t *testing.T) {
+		pos, gap, ok := track.Position(time.Date(2021, 6, 12, 10, 25, 0, 0, time.UTC), maxGap)
+
+		assert.True(t, ok)
+		assert.Equal(t, 5*time.Minute, gap)
+		assert.Equal(t, 52.5209, pos.Lat)
+	})
+	t.Run("GapTooLarge", func(t *testing.T) {
+		_, _, ok := track.Position(time.Date(2021, 6, 12, 9, 30, 0, 0, time.UTC), maxGap)
+		assert.False(t, ok)
+	})
+	t.Run("ZeroTime", func(t *testing.T) {
+		_, _, ok := track.Position(time.Time{}, maxGap)
+		assert.False(t, ok)
+	})
+}
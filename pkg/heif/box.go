@@ -0,0 +1,137 @@
+package heif
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var errShortBox = errors.New("heif: unexpected end of box")
+
+// box represents an ISO base media file format box.
+type box struct {
+	typ  string
+	data []byte // Box payload without header.
+}
+
+// reader reads big-endian values from a box payload.
+type reader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+// newReader returns a new payload reader.
+func newReader(data []byte) *reader {
+	return &reader{data: data}
+}
+
+// bytes returns the next n bytes.
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	} else if n < 0 || r.pos+n > len(r.data) {
+		r.err = errShortBox
+		return nil
+	}
+
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+
+	return b
+}
+
+// uint reads an unsigned integer with the given size in bytes.
+func (r *reader) uint(size int) uint64 {
+	b := r.bytes(size)
+
+	if b == nil {
+		return 0
+	}
+
+	switch size {
+	case 0:
+		return 0
+	case 1:
+		return uint64(b[0])
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b))
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b))
+	case 8:
+		return binary.BigEndian.Uint64(b)
+	default:
+		r.err = errors.New("heif: invalid integer size")
+		return 0
+	}
+}
+
+func (r *reader) uint8() uint8   { return uint8(r.uint(1)) }
+func (r *reader) uint16() uint16 { return uint16(r.uint(2)) }
+func (r *reader) uint32() uint32 { return uint32(r.uint(4)) }
+
+// fullBox reads the version and flags of a full box.
+func (r *reader) fullBox() (version uint8, flags uint32) {
+	v := r.uint32()
+	return uint8(v >> 24), v & 0xffffff
+}
+
+// string reads a null-terminated string.
+func (r *reader) string() string {
+	if r.err != nil {
+		return ""
+	}
+
+	for i := r.pos; i < len(r.data); i++ {
+		if r.data[i] == 0 {
+			s := string(r.data[r.pos:i])
+			r.pos = i + 1
+			return s
+		}
+	}
+
+	// Tolerate missing null terminator at the end of the box.
+	s := string(r.data[r.pos:])
+	r.pos = len(r.data)
+
+	return s
+}
+
+// remaining returns the unread payload.
+func (r *reader) remaining() []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	return r.data[r.pos:]
+}
+
+// boxes returns the child boxes in the unread payload.
+func (r *reader) boxes() (result []box) {
+	data := r.remaining()
+
+	for len(data) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(data[0:4]))
+		typ := string(data[4:8])
+		header := uint64(8)
+
+		if size == 1 {
+			if len(data) < 16 {
+				break
+			}
+
+			size = binary.BigEndian.Uint64(data[8:16])
+			header = 16
+		} else if size == 0 {
+			size = uint64(len(data))
+		}
+
+		if size < header || size > uint64(len(data)) {
+			break
+		}
+
+		result = append(result, box{typ: typ, data: data[header:size]})
+		data = data[size:]
+	}
+
+	return result
+}
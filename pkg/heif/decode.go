@@ -0,0 +1,349 @@
+package heif
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"sync"
+
+	"github.com/disintegration/imaging"
+)
+
+// Codec decodes the coded data of an image item.
+type Codec func(it *Item, data []byte) (image.Image, error)
+
+// BatchCodec decodes the coded data of several image items at once, e.g. all tiles of a grid,
+// and returns the images in the same order. This is faster if the decoder runs in a separate process.
+type BatchCodec func(items []*Item, data [][]byte) ([]image.Image, error)
+
+var codecs = map[string]Codec{
+	TypeJpeg: decodeJpeg,
+}
+
+var batchCodecs = map[string]BatchCodec{}
+
+var codecMutex = sync.RWMutex{}
+
+// RegisterCodec registers a decoder for an item type, e.g. TypeHevc.
+func RegisterCodec(itemType string, c Codec) {
+	codecMutex.Lock()
+	defer codecMutex.Unlock()
+
+	if c == nil {
+		delete(codecs, itemType)
+	} else {
+		codecs[itemType] = c
+	}
+}
+
+// RegisterBatchCodec registers a decoder for several items of the same type, e.g. TypeHevc.
+// It is also used for single items if no other decoder is registered for the type.
+func RegisterBatchCodec(itemType string, c BatchCodec) {
+	codecMutex.Lock()
+	defer codecMutex.Unlock()
+
+	if c == nil {
+		delete(batchCodecs, itemType)
+	} else {
+		batchCodecs[itemType] = c
+	}
+}
+
+// Registered tests if a decoder for the item type is registered.
+func Registered(itemType string) bool {
+	_, ok := findCodec(itemType)
+	return ok
+}
+
+// findCodec returns the decoder for an item type.
+func findCodec(itemType string) (Codec, bool) {
+	codecMutex.RLock()
+	defer codecMutex.RUnlock()
+
+	if c, ok := codecs[itemType]; ok {
+		return c, true
+	} else if b, ok := batchCodecs[itemType]; ok {
+		return func(it *Item, data []byte) (image.Image, error) {
+			images, err := b([]*Item{it}, [][]byte{data})
+
+			if err != nil {
+				return nil, err
+			} else if len(images) != 1 {
+				return nil, fmt.Errorf("heif: expected 1 image for item %d, got %d", it.ID, len(images))
+			}
+
+			return images[0], nil
+		}, true
+	}
+
+	return nil, false
+}
+
+// findBatchCodec returns the batch decoder for an item type.
+func findBatchCodec(itemType string) (BatchCodec, bool) {
+	codecMutex.RLock()
+	defer codecMutex.RUnlock()
+
+	c, ok := batchCodecs[itemType]
+
+	return c, ok
+}
+
+// Supported tests if an image item can be decoded.
+func (f *File) Supported(it *Item) bool {
+	if it == nil {
+		return false
+	} else if it.Type != TypeGrid {
+		_, ok := findCodec(it.Type)
+		return ok
+	}
+
+	tiles := it.Refs[RefDerived]
+
+	if len(tiles) == 0 {
+		return false
+	}
+
+	for _, id := range tiles {
+		if tile := f.Item(id); tile == nil || tile.Type == TypeGrid || !f.Supported(tile) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Decode returns the item as image with rotation and mirroring applied.
+func (f *File) Decode(it *Item) (img image.Image, err error) {
+	if it == nil {
+		return nil, ErrNoItem
+	}
+
+	if it.Type == TypeGrid {
+		img, err = f.decodeGrid(it)
+	} else {
+		img, err = f.decodeItem(it)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range it.transforms {
+		if len(t.data) == 0 {
+			continue
+		}
+
+		switch t.typ {
+		case "irot":
+			// Rotation is counter-clockwise in steps of 90 degrees.
+			switch t.data[0] & 3 {
+			case 1:
+				img = imaging.Rotate90(img)
+			case 2:
+				img = imaging.Rotate180(img)
+			case 3:
+				img = imaging.Rotate270(img)
+			}
+		case "imir":
+			if t.data[0]&1 == 0 {
+				img = imaging.FlipH(img)
+			} else {
+				img = imaging.FlipV(img)
+			}
+		}
+	}
+
+	return img, nil
+}
+
+// decodeItem decodes a coded image item.
+func (f *File) decodeItem(it *Item) (image.Image, error) {
+	codec, ok := findCodec(it.Type)
+
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnsupported, it.Type)
+	}
+
+	data, err := f.Data(it)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return codec(it, data)
+}
+
+// decodeGrid decodes the tiles of a grid item and returns them as a single image.
+func (f *File) decodeGrid(it *Item) (image.Image, error) {
+	data, err := f.Data(it)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r := newReader(data)
+	r.uint8()
+	flags := r.uint8()
+	rows := int(r.uint8()) + 1
+	cols := int(r.uint8()) + 1
+
+	size := 2
+
+	if flags&1 == 1 {
+		size = 4
+	}
+
+	width := int(r.uint(size))
+	height := int(r.uint(size))
+
+	if r.err != nil || width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("heif: invalid grid %d", it.ID)
+	}
+
+	tiles := it.Refs[RefDerived]
+
+	if len(tiles) != rows*cols {
+		return nil, fmt.Errorf("heif: grid %d has %d tiles, expected %d", it.ID, len(tiles), rows*cols)
+	}
+
+	items := make([]*Item, len(tiles))
+
+	for i, id := range tiles {
+		tile := f.Item(id)
+
+		if tile == nil || tile.Type == TypeGrid {
+			return nil, fmt.Errorf("heif: invalid tile %d in grid %d", id, it.ID)
+		}
+
+		items[i] = tile
+	}
+
+	images, err := f.decodeTiles(items)
+
+	if err != nil {
+		return nil, err
+	} else if len(images) != len(items) {
+		return nil, fmt.Errorf("heif: decoded %d tiles in grid %d, expected %d", len(images), it.ID, len(items))
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	var tileWidth, tileHeight int
+
+	for i, img := range images {
+		if i == 0 {
+			tileWidth, tileHeight = img.Bounds().Dx(), img.Bounds().Dy()
+		}
+
+		at := image.Pt(i%cols*tileWidth, i/cols*tileHeight)
+		draw.Draw(canvas, img.Bounds().Sub(img.Bounds().Min).Add(at), img, img.Bounds().Min, draw.Src)
+	}
+
+	return canvas, nil
+}
+
+// decodeTiles decodes the tiles of a grid, all at once if they have the same type and a batch decoder is registered.
+func (f *File) decodeTiles(tiles []*Item) ([]image.Image, error) {
+	batch, ok := findBatchCodec(tiles[0].Type)
+
+	for _, tile := range tiles[1:] {
+		if tile.Type != tiles[0].Type {
+			ok = false
+			break
+		}
+	}
+
+	// Decode tiles one by one?
+	if !ok {
+		images := make([]image.Image, len(tiles))
+
+		for i, tile := range tiles {
+			img, err := f.decodeItem(tile)
+
+			if err != nil {
+				return nil, err
+			}
+
+			images[i] = img
+		}
+
+		return images, nil
+	}
+
+	data := make([][]byte, len(tiles))
+
+	for i, tile := range tiles {
+		b, err := f.Data(tile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		data[i] = b
+	}
+
+	return batch(tiles, data)
+}
+
+// decodeJpeg decodes JPEG coded image items.
+func decodeJpeg(_ *Item, data []byte) (image.Image, error) {
+	return jpeg.Decode(bytes.NewReader(data))
+}
+
+// Decode reads a HEIF image from r and returns its primary image.
+func Decode(r io.Reader) (image.Image, error) {
+	f, err := parseReader(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	primary, err := f.Primary()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Decode(primary)
+}
+
+// DecodeConfig returns the color model and dimensions of the primary image without decoding it.
+func DecodeConfig(r io.Reader) (image.Config, error) {
+	f, err := parseReader(r)
+
+	if err != nil {
+		return image.Config{}, err
+	}
+
+	primary, err := f.Primary()
+
+	if err != nil {
+		return image.Config{}, err
+	}
+
+	width, height := primary.DisplaySize()
+
+	return image.Config{ColorModel: color.YCbCrModel, Width: width, Height: height}, nil
+}
+
+// parseReader reads and parses a HEIF file.
+func parseReader(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(data), int64(len(data)))
+}
+
+func init() {
+	for _, brand := range []string{"heic", "heix", "heim", "heis", "mif1", "msf1"} {
+		image.RegisterFormat("heif", "????ftyp"+brand, Decode, DecodeConfig)
+	}
+}
@@ -0,0 +1,209 @@
+package heif
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile_Decode(t *testing.T) {
+	data := testFile(t)
+	f, err := Parse(bytes.NewReader(data), int64(len(data)))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Grid", func(t *testing.T) {
+		primary, _ := f.Primary()
+
+		assert.True(t, f.Supported(primary))
+
+		img, err := f.Decode(primary)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 16, img.Bounds().Dx())
+		assert.Equal(t, 64, img.Bounds().Dy())
+
+		// The red tile is at the bottom after rotating counter-clockwise.
+		r, _, b, _ := img.At(8, 48).RGBA()
+		assert.Greater(t, r, b)
+		r, _, b, _ = img.At(8, 16).RGBA()
+		assert.Greater(t, b, r)
+	})
+	t.Run("Auxiliary", func(t *testing.T) {
+		primary, _ := f.Primary()
+		img, err := f.Decode(f.Auxiliary(primary)[0])
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, image.Rect(0, 0, 32, 8), img.Bounds())
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		if Registered(TypeHevc) {
+			t.Skip("hevc decoder registered")
+		}
+
+		hevc, err := Open(exampleFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		primary, _ := hevc.Primary()
+
+		assert.False(t, hevc.Supported(primary))
+
+		_, err = hevc.Decode(primary)
+
+		assert.True(t, errors.Is(err, ErrUnsupported))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		_, err := f.Decode(nil)
+		assert.Equal(t, ErrNoItem, err)
+	})
+}
+
+// withoutHevcCodecs unregisters HEVC decoders, e.g. libde265, until the test has finished.
+func withoutHevcCodecs(t *testing.T) {
+	codecMutex.Lock()
+	c, hasCodec := codecs[TypeHevc]
+	b, hasBatch := batchCodecs[TypeHevc]
+	delete(codecs, TypeHevc)
+	delete(batchCodecs, TypeHevc)
+	codecMutex.Unlock()
+
+	t.Cleanup(func() {
+		if hasCodec {
+			RegisterCodec(TypeHevc, c)
+		}
+
+		if hasBatch {
+			RegisterBatchCodec(TypeHevc, b)
+		}
+	})
+}
+
+func TestRegisterCodec(t *testing.T) {
+	withoutHevcCodecs(t)
+
+	f, err := Open(exampleFile)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	primary, _ := f.Primary()
+
+	RegisterCodec(TypeHevc, func(it *Item, data []byte) (image.Image, error) {
+		return image.NewGray(image.Rect(0, 0, it.Width, it.Height)), nil
+	})
+
+	assert.True(t, Registered(TypeHevc))
+	assert.True(t, f.Supported(primary))
+
+	img, err := f.Decode(primary)
+
+	RegisterCodec(TypeHevc, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, image.Rect(0, 0, 4032, 3024), img.Bounds())
+	assert.False(t, Registered(TypeHevc))
+	assert.False(t, f.Supported(primary))
+}
+
+func TestRegisterBatchCodec(t *testing.T) {
+	withoutHevcCodecs(t)
+
+	f, err := Open(exampleFile)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	primary, _ := f.Primary()
+
+	calls := 0
+
+	RegisterBatchCodec(TypeHevc, func(items []*Item, data [][]byte) ([]image.Image, error) {
+		calls++
+
+		images := make([]image.Image, len(items))
+
+		for i, it := range items {
+			images[i] = image.NewGray(image.Rect(0, 0, it.Width, it.Height))
+		}
+
+		return images, nil
+	})
+
+	assert.True(t, Registered(TypeHevc))
+	assert.True(t, f.Supported(primary))
+
+	img, err := f.Decode(primary)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// All tiles of the grid are decoded at once.
+	assert.Equal(t, image.Rect(0, 0, 4032, 3024), img.Bounds())
+	assert.Equal(t, 1, calls)
+
+	img, err = f.Decode(f.Item(1))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, image.Rect(0, 0, 512, 512), img.Bounds())
+	assert.Equal(t, 2, calls)
+
+	RegisterBatchCodec(TypeHevc, nil)
+
+	assert.False(t, Registered(TypeHevc))
+	assert.False(t, f.Supported(primary))
+}
+
+func TestDecodeConfig(t *testing.T) {
+	file, err := os.Open(exampleFile)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	cfg, format, err := image.DecodeConfig(file)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "heif", format)
+	assert.Equal(t, 4032, cfg.Width)
+	assert.Equal(t, 3024, cfg.Height)
+}
+
+func TestDecode(t *testing.T) {
+	img, format, err := image.Decode(bytes.NewReader(testFile(t)))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "heif", format)
+	assert.Equal(t, image.Rect(0, 0, 16, 64), img.Bounds())
+}
@@ -0,0 +1,400 @@
+/*
+
+Package heif reads High Efficiency Image File Format (HEIF) containers, as used for HEIC images.
+
+JPEG coded images are decoded natively. HEVC coded images, e.g. from iPhones, are decoded in-process
+with libde265 if the package is built with the "libde265" tag. Otherwise, a decoder must be registered
+with RegisterCodec or RegisterBatchCodec, which PhotoPrism does with FFmpeg as fallback.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.app/developer-guide/
+
+*/
+package heif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var (
+	ErrNotHeif     = errors.New("heif: invalid file format")
+	ErrNoPrimary   = errors.New("heif: found no primary image")
+	ErrNoItem      = errors.New("heif: item not found")
+	ErrUnsupported = errors.New("heif: unsupported codec")
+)
+
+// Item types.
+const (
+	TypeHevc = "hvc1"
+	TypeAv1  = "av01"
+	TypeJpeg = "jpeg"
+	TypeGrid = "grid"
+	TypeExif = "Exif"
+)
+
+// Item reference types.
+const (
+	RefDerived   = "dimg" // Derived image, e.g. grid tiles.
+	RefThumbnail = "thmb"
+	RefAuxiliary = "auxl" // Auxiliary image, e.g. depth map or alpha plane.
+	RefContent   = "cdsc" // Content description, e.g. Exif metadata.
+)
+
+// Item represents a HEIF item like an image, a grid of image tiles, or metadata.
+type Item struct {
+	ID      uint32
+	Type    string
+	Name    string
+	Hidden  bool
+	Width   int
+	Height  int
+	AuxType string // Auxiliary image type, e.g. "urn:mpeg:hevc:2015:auxid:2" for depth maps.
+	Config  []byte // Decoder configuration, e.g. the hvcC payload.
+	ICC     []byte // Embedded ICC color profile.
+	Refs    map[string][]uint32
+
+	transforms []box
+	location   location
+}
+
+// location represents the position of item data in the file or meta box.
+type location struct {
+	method  int
+	base    uint64
+	extents []extent
+}
+
+// extent represents a continuous range of item data.
+type extent struct {
+	offset uint64
+	length uint64
+}
+
+// IsImage tests if the item is a coded or derived image.
+func (it *Item) IsImage() bool {
+	switch it.Type {
+	case TypeHevc, TypeAv1, TypeJpeg, TypeGrid:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsDepth tests if the item is a depth or disparity map.
+func (it *Item) IsDepth() bool {
+	return strings.HasSuffix(it.AuxType, "auxid:2") || strings.Contains(it.AuxType, "depth")
+}
+
+// IsAlpha tests if the item is an alpha plane.
+func (it *Item) IsAlpha() bool {
+	return strings.HasSuffix(it.AuxType, "auxid:1") || strings.Contains(it.AuxType, "alpha")
+}
+
+// Transformed tests if the item has rotation or mirroring properties.
+func (it *Item) Transformed() bool {
+	return len(it.transforms) > 0
+}
+
+// DisplaySize returns the image size after applying rotations.
+func (it *Item) DisplaySize() (width, height int) {
+	width, height = it.Width, it.Height
+
+	for _, t := range it.transforms {
+		if t.typ == "irot" && len(t.data) > 0 && t.data[0]&1 == 1 {
+			width, height = height, width
+		}
+	}
+
+	return width, height
+}
+
+// File represents a parsed HEIF container.
+type File struct {
+	Brand   string
+	Brands  []string
+	Items   []*Item
+	primary uint32
+	idat    []byte
+	r       io.ReaderAt
+	size    int64
+}
+
+// Open reads and parses a HEIF file.
+func Open(fileName string) (*File, error) {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(data), int64(len(data)))
+}
+
+// Parse parses the HEIF container structure.
+func Parse(r io.ReaderAt, size int64) (*File, error) {
+	f := &File{r: r, size: size}
+
+	h := make([]byte, 8)
+	offset := int64(0)
+
+	// Find the top-level ftyp and meta boxes.
+	for offset+8 <= size {
+		if _, err := r.ReadAt(h, offset); err != nil {
+			return nil, ErrNotHeif
+		}
+
+		boxSize := int64(binary.BigEndian.Uint32(h[0:4]))
+		typ := string(h[4:8])
+		header := int64(8)
+
+		if boxSize == 1 {
+			b := make([]byte, 8)
+
+			if _, err := r.ReadAt(b, offset+8); err != nil {
+				return nil, ErrNotHeif
+			}
+
+			boxSize = int64(binary.BigEndian.Uint64(b))
+			header = 16
+		} else if boxSize == 0 {
+			boxSize = size - offset
+		}
+
+		if boxSize < header || offset+boxSize > size {
+			return nil, ErrNotHeif
+		}
+
+		switch typ {
+		case "ftyp", "meta":
+			data := make([]byte, boxSize-header)
+
+			if _, err := r.ReadAt(data, offset+header); err != nil {
+				return nil, err
+			}
+
+			if typ == "ftyp" {
+				f.parseFileType(data)
+			} else if err := f.parseMeta(data); err != nil {
+				return nil, err
+			}
+		}
+
+		offset += boxSize
+	}
+
+	if f.Brand == "" || len(f.Items) == 0 {
+		return nil, ErrNotHeif
+	}
+
+	return f, nil
+}
+
+// parseFileType parses the ftyp box with the major and compatible brands.
+func (f *File) parseFileType(data []byte) {
+	r := newReader(data)
+	f.Brand = string(r.bytes(4))
+	r.uint32()
+
+	for r.err == nil && len(r.remaining()) >= 4 {
+		f.Brands = append(f.Brands, string(r.bytes(4)))
+	}
+}
+
+// Item returns the item with the given ID.
+func (f *File) Item(id uint32) *Item {
+	for _, it := range f.Items {
+		if it.ID == id {
+			return it
+		}
+	}
+
+	return nil
+}
+
+// Primary returns the primary image item.
+func (f *File) Primary() (*Item, error) {
+	if it := f.Item(f.primary); it != nil && it.IsImage() {
+		return it, nil
+	}
+
+	return nil, ErrNoPrimary
+}
+
+// Images returns the main images like burst frames or alternatives, primary image first.
+func (f *File) Images() (result []*Item) {
+	if it, err := f.Primary(); err == nil {
+		result = append(result, it)
+	}
+
+	for _, it := range f.Items {
+		if it.ID == f.primary || it.Hidden || !it.IsImage() {
+			continue
+		}
+
+		if len(it.Refs[RefThumbnail]) > 0 || len(it.Refs[RefAuxiliary]) > 0 {
+			continue
+		}
+
+		result = append(result, it)
+	}
+
+	return result
+}
+
+// Thumbnails returns the thumbnail images of an item.
+func (f *File) Thumbnails(it *Item) []*Item {
+	return f.referencing(it, RefThumbnail)
+}
+
+// Auxiliary returns the auxiliary images of an item, like depth maps, alpha planes, or HDR gain maps.
+func (f *File) Auxiliary(it *Item) []*Item {
+	return f.referencing(it, RefAuxiliary)
+}
+
+// Exif returns the Exif metadata of the primary image without the TIFF header offset.
+func (f *File) Exif() ([]byte, error) {
+	primary, err := f.Primary()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, it := range f.referencing(primary, RefContent) {
+		if it.Type != TypeExif {
+			continue
+		}
+
+		data, err := f.Data(it)
+
+		if err != nil {
+			return nil, err
+		} else if len(data) < 4 {
+			return nil, ErrNoItem
+		}
+
+		skip := int(newReader(data).uint32()) + 4
+
+		if skip > len(data) {
+			return nil, ErrNoItem
+		}
+
+		return data[skip:], nil
+	}
+
+	return nil, ErrNoItem
+}
+
+// referencing returns the items with a reference of the given type to an item.
+func (f *File) referencing(it *Item, refType string) (result []*Item) {
+	if it == nil {
+		return nil
+	}
+
+	for _, other := range f.Items {
+		for _, id := range other.Refs[refType] {
+			if id == it.ID {
+				result = append(result, other)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// Data returns the item data.
+func (f *File) Data(it *Item) ([]byte, error) {
+	if it == nil {
+		return nil, ErrNoItem
+	}
+
+	var buf bytes.Buffer
+
+	for _, e := range it.location.extents {
+		offset := it.location.base + e.offset
+
+		switch it.location.method {
+		case 0:
+			length := e.length
+
+			// A length of zero refers to the rest of the file.
+			if length == 0 && int64(offset) < f.size {
+				length = uint64(f.size) - offset
+			}
+
+			if int64(offset+length) > f.size {
+				return nil, fmt.Errorf("heif: item %d exceeds file size", it.ID)
+			}
+
+			b := make([]byte, length)
+
+			if _, err := f.r.ReadAt(b, int64(offset)); err != nil {
+				return nil, err
+			}
+
+			buf.Write(b)
+		case 1:
+			end := offset + e.length
+
+			if e.length == 0 {
+				end = uint64(len(f.idat))
+			}
+
+			if end > uint64(len(f.idat)) || offset > end {
+				return nil, fmt.Errorf("heif: item %d exceeds idat size", it.ID)
+			}
+
+			buf.Write(f.idat[offset:end])
+		default:
+			return nil, fmt.Errorf("heif: construction method %d not supported", it.location.method)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ICC returns the embedded ICC color profile of an image, which may be stored with the tiles of a grid.
+func (f *File) ICC(it *Item) []byte {
+	if it == nil {
+		return nil
+	} else if len(it.ICC) > 0 || it.Type != TypeGrid {
+		return it.ICC
+	}
+
+	for _, id := range it.Refs[RefDerived] {
+		if tile := f.Item(id); tile != nil && len(tile.ICC) > 0 {
+			return tile.ICC
+		}
+	}
+
+	return nil
+}
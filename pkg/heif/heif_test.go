@@ -0,0 +1,257 @@
+package heif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const exampleFile = "../../assets/examples/iphone_7.heic"
+
+// testBox returns an ISO base media file format box.
+func testBox(typ string, payload ...[]byte) []byte {
+	b := bytes.Join(payload, nil)
+	h := make([]byte, 8)
+	binary.BigEndian.PutUint32(h, uint32(len(b)+8))
+	copy(h[4:], typ)
+
+	return append(h, b...)
+}
+
+// testInt returns big-endian integers with the given size in bytes.
+func testInt(size int, values ...int) []byte {
+	var buf bytes.Buffer
+
+	for _, v := range values {
+		switch size {
+		case 1:
+			buf.WriteByte(byte(v))
+		case 2:
+			_ = binary.Write(&buf, binary.BigEndian, uint16(v))
+		default:
+			_ = binary.Write(&buf, binary.BigEndian, uint32(v))
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// testJpeg returns a JPEG encoded test image filled with the given color.
+func testJpeg(t *testing.T, width, height int, c color.Color) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// testItem describes an item of a test file.
+type testItem struct {
+	id     int
+	typ    string
+	hidden bool
+	data   []byte
+	props  []int
+	refs   map[string][]int
+}
+
+// testFile returns a HEIF file with JPEG coded images: a primary 64x16 grid (3) of a red and a blue tile (1, 2)
+// rotated by 90 degrees, with a thumbnail (4) and a depth map (5), and a second image (6), e.g. a burst frame.
+func testFile(t *testing.T) []byte {
+	grid := append(testInt(1, 0, 0, 0, 1), testInt(2, 64, 16)...)
+
+	items := []testItem{
+		{id: 1, typ: TypeJpeg, hidden: true, data: testJpeg(t, 32, 16, color.RGBA{R: 255, A: 255}), props: []int{1}},
+		{id: 2, typ: TypeJpeg, hidden: true, data: testJpeg(t, 32, 16, color.RGBA{B: 255, A: 255}), props: []int{1}},
+		{id: 3, typ: TypeGrid, data: grid, props: []int{2, 3}, refs: map[string][]int{RefDerived: {1, 2}}},
+		{id: 4, typ: TypeJpeg, hidden: true, data: testJpeg(t, 16, 8, color.White), props: []int{4}, refs: map[string][]int{RefThumbnail: {3}}},
+		{id: 5, typ: TypeJpeg, hidden: true, data: testJpeg(t, 32, 8, color.Gray{Y: 128}), props: []int{5, 6}, refs: map[string][]int{RefAuxiliary: {3}}},
+		{id: 6, typ: TypeJpeg, data: testJpeg(t, 24, 24, color.Black), props: []int{7}},
+	}
+
+	ispe := func(w, h int) []byte {
+		return testBox("ispe", testInt(4, 0, w, h))
+	}
+
+	ipco := testBox("ipco",
+		ispe(32, 16),
+		ispe(64, 16),
+		testBox("irot", testInt(1, 1)),
+		ispe(16, 8),
+		ispe(32, 8),
+		testBox("auxC", testInt(4, 0), []byte("urn:mpeg:hevc:2015:auxid:2\x00")),
+		ispe(24, 24),
+	)
+
+	var infe, ipma, iref [][]byte
+
+	for _, it := range items {
+		flags := 0
+
+		if it.hidden {
+			flags = 1
+		}
+
+		infe = append(infe, testBox("infe", testInt(4, 2<<24|flags), testInt(2, it.id, 0), []byte(it.typ), []byte{0}))
+
+		ipma = append(ipma, testInt(2, it.id), testInt(1, len(it.props)))
+
+		for _, p := range it.props {
+			ipma = append(ipma, testInt(1, 0x80|p))
+		}
+
+		for typ, to := range it.refs {
+			iref = append(iref, testBox(typ, testInt(2, it.id, len(to)), testInt(2, to...)))
+		}
+	}
+
+	ipmaBox := testBox("ipma", testInt(4, 0, len(items)), bytes.Join(ipma, nil))
+
+	// The item locations depend on the size of the meta box, which doesn't depend on the offsets.
+	build := func(offset int) []byte {
+		var iloc [][]byte
+
+		iloc = append(iloc, testInt(4, 0), testInt(1, 0x44, 0), testInt(2, len(items)))
+
+		for _, it := range items {
+			iloc = append(iloc, testInt(2, it.id, 0, 1), testInt(4, offset, len(it.data)))
+			offset += len(it.data)
+		}
+
+		return testBox("meta", testInt(4, 0),
+			testBox("hdlr", testInt(4, 0, 0), []byte("pict"), testInt(4, 0, 0, 0), []byte{0}),
+			testBox("pitm", testInt(4, 0), testInt(2, 3)),
+			testBox("iinf", testInt(4, 0), testInt(2, len(items)), bytes.Join(infe, nil)),
+			testBox("iloc", bytes.Join(iloc, nil)),
+			testBox("iref", testInt(4, 0), bytes.Join(iref, nil)),
+			testBox("iprp", ipco, ipmaBox),
+		)
+	}
+
+	ftyp := testBox("ftyp", []byte("heic"), testInt(4, 0), []byte("mif1heic"))
+	meta := build(0)
+
+	var mdat [][]byte
+
+	for _, it := range items {
+		mdat = append(mdat, it.data)
+	}
+
+	return bytes.Join([][]byte{ftyp, build(len(ftyp) + len(meta) + 8), testBox("mdat", bytes.Join(mdat, nil))}, nil)
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("iphone_7.heic", func(t *testing.T) {
+		f, err := Open(exampleFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "heic", f.Brand)
+		assert.Equal(t, []string{"mif1", "heic"}, f.Brands)
+		assert.Len(t, f.Items, 51)
+
+		primary, err := f.Primary()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, TypeGrid, primary.Type)
+		assert.Len(t, primary.Refs[RefDerived], 48)
+		assert.Equal(t, 4032, primary.Width)
+		assert.Equal(t, 3024, primary.Height)
+		assert.Len(t, f.Images(), 1)
+		assert.Empty(t, f.Thumbnails(primary))
+		assert.Empty(t, f.Auxiliary(primary))
+		assert.False(t, primary.Transformed())
+
+		tile := f.Item(primary.Refs[RefDerived][0])
+
+		assert.Equal(t, TypeHevc, tile.Type)
+		assert.True(t, tile.Hidden)
+		assert.Equal(t, 512, tile.Width)
+		assert.NotEmpty(t, tile.Config)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Open("testdata/missing.heic")
+		assert.Error(t, err)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Items", func(t *testing.T) {
+		data := testFile(t)
+		f, err := Parse(bytes.NewReader(data), int64(len(data)))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		primary, err := f.Primary()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, uint32(3), primary.ID)
+		assert.Equal(t, []uint32{1, 2}, primary.Refs[RefDerived])
+
+		width, height := primary.DisplaySize()
+		assert.True(t, primary.Transformed())
+		assert.Equal(t, 16, width)
+		assert.Equal(t, 64, height)
+
+		if thumbs := f.Thumbnails(primary); assert.Len(t, thumbs, 1) {
+			assert.Equal(t, uint32(4), thumbs[0].ID)
+		}
+
+		if aux := f.Auxiliary(primary); assert.Len(t, aux, 1) {
+			assert.Equal(t, uint32(5), aux[0].ID)
+			assert.True(t, aux[0].IsDepth())
+			assert.False(t, aux[0].IsAlpha())
+		}
+
+		if images := f.Images(); assert.Len(t, images, 2) {
+			assert.Equal(t, uint32(3), images[0].ID)
+			assert.Equal(t, uint32(6), images[1].ID)
+		}
+	})
+	t.Run("NotHeif", func(t *testing.T) {
+		data := testJpeg(t, 8, 8, color.White)
+		_, err := Parse(bytes.NewReader(data), int64(len(data)))
+		assert.Equal(t, ErrNotHeif, err)
+	})
+}
+
+func TestFile_Exif(t *testing.T) {
+	f, err := Open(exampleFile)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := f.Exif()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "MM\x00*", string(data[:4]))
+}
@@ -0,0 +1,58 @@
+package heif
+
+import (
+	"bytes"
+	"errors"
+)
+
+var startCode = []byte{0, 0, 0, 1}
+
+// AnnexB converts the hvcC decoder configuration and the length-prefixed NAL units of an HEVC
+// image item to an Annex B byte stream, as expected by common decoders like FFmpeg.
+func AnnexB(config, data []byte) ([]byte, error) {
+	if len(config) < 23 {
+		return nil, errors.New("heif: invalid hevc decoder configuration")
+	}
+
+	var buf bytes.Buffer
+
+	// Parameter sets like VPS, SPS, and PPS.
+	r := newReader(config[22:])
+	arrays := int(r.uint8())
+
+	for i := 0; i < arrays && r.err == nil; i++ {
+		r.uint8()
+		n := int(r.uint16())
+
+		for j := 0; j < n && r.err == nil; j++ {
+			nal := r.bytes(int(r.uint16()))
+			buf.Write(startCode)
+			buf.Write(nal)
+		}
+	}
+
+	if r.err != nil {
+		return nil, errors.New("heif: invalid hevc parameter sets")
+	}
+
+	// Coded slices.
+	lengthSize := int(config[21]&3) + 1
+
+	if lengthSize == 3 {
+		return nil, errors.New("heif: invalid hevc nal length size")
+	}
+
+	r = newReader(data)
+
+	for r.err == nil && len(r.remaining()) > 0 {
+		nal := r.bytes(int(r.uint(lengthSize)))
+		buf.Write(startCode)
+		buf.Write(nal)
+	}
+
+	if r.err != nil {
+		return nil, errors.New("heif: invalid hevc nal unit")
+	}
+
+	return buf.Bytes(), nil
+}
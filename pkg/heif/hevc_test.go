@@ -0,0 +1,51 @@
+package heif
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnexB(t *testing.T) {
+	t.Run("iphone_7.heic", func(t *testing.T) {
+		f, err := Open(exampleFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tile := f.Item(1)
+		data, err := f.Data(tile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := AnnexB(tile.Config, data)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var types []byte
+
+		for _, nal := range bytes.Split(result, startCode)[1:] {
+			types = append(types, nal[0]>>1&0x3f)
+		}
+
+		// VPS, SPS, PPS, and an IDR slice.
+		assert.Equal(t, []byte{32, 33, 34, 20}, types)
+	})
+	t.Run("InvalidConfig", func(t *testing.T) {
+		_, err := AnnexB([]byte{1, 2, 3}, nil)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidData", func(t *testing.T) {
+		config := make([]byte, 23)
+		config[21] = 3
+
+		_, err := AnnexB(config, []byte{0, 0, 0, 9, 1})
+		assert.Error(t, err)
+	})
+}
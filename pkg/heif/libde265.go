@@ -0,0 +1,214 @@
+//go:build libde265
+// +build libde265
+
+package heif
+
+/*
+#cgo LDFLAGS: -lde265
+#include <stdlib.h>
+#include <libde265/de265.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"unsafe"
+)
+
+// Matrix coefficients of decoded pictures, see ITU-T H.273.
+const (
+	matrixBT709  = 1
+	matrixBT2020 = 9
+)
+
+func init() {
+	RegisterCodec(TypeHevc, decodeHevc)
+}
+
+// decodeHevc decodes HEVC coded image items in-process with libde265.
+func decodeHevc(it *Item, data []byte) (image.Image, error) {
+	stream, err := AnnexB(it.Config, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := C.de265_new_decoder()
+
+	if ctx == nil {
+		return nil, errors.New("heif: failed creating hevc decoder")
+	}
+
+	defer C.de265_free_decoder(ctx)
+
+	buf := C.CBytes(stream)
+	defer C.free(buf)
+
+	if err := C.de265_push_data(ctx, buf, C.int(len(stream)), 0, nil); err != C.DE265_OK {
+		return nil, de265Error(err)
+	}
+
+	C.de265_flush_data(ctx)
+
+	var more C.int = 1
+
+	for more != 0 {
+		more = 0
+
+		if err := C.de265_decode(ctx, &more); err == C.DE265_ERROR_WAITING_FOR_INPUT_DATA {
+			break
+		} else if err != C.DE265_OK {
+			return nil, de265Error(err)
+		}
+
+		if pic := C.de265_get_next_picture(ctx); pic != nil {
+			defer C.de265_release_next_picture(ctx)
+			return hevcImage(pic)
+		}
+	}
+
+	return nil, fmt.Errorf("heif: no picture decoded from hevc item %d", it.ID)
+}
+
+// de265Error returns the libde265 error message as error.
+func de265Error(err C.de265_error) error {
+	return fmt.Errorf("heif: %s", C.GoString(C.de265_get_error_text(err)))
+}
+
+// hevcImage copies a decoded picture to an image, as it's only valid until it's released.
+func hevcImage(pic *C.struct_de265_image) (image.Image, error) {
+	fullRange := C.de265_get_image_full_range_flag(pic) != 0
+	y, width, height, err := hevcPlane(pic, 0, fullRange)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rect := image.Rect(0, 0, width, height)
+
+	var ratio image.YCbCrSubsampleRatio
+
+	switch C.de265_get_chroma_format(pic) {
+	case C.de265_chroma_mono:
+		return &image.Gray{Pix: y, Stride: width, Rect: rect}, nil
+	case C.de265_chroma_420:
+		ratio = image.YCbCrSubsampleRatio420
+	case C.de265_chroma_422:
+		ratio = image.YCbCrSubsampleRatio422
+	default:
+		ratio = image.YCbCrSubsampleRatio444
+	}
+
+	img := image.NewYCbCr(rect, ratio)
+	copy(img.Y, y)
+
+	for i, dst := range [][]uint8{img.Cb, img.Cr} {
+		c, cw, ch, err := hevcPlane(pic, i+1, fullRange)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for row := 0; row < ch && row*img.CStride < len(dst); row++ {
+			copy(dst[row*img.CStride:(row+1)*img.CStride], c[row*cw:(row+1)*cw])
+		}
+	}
+
+	// Go assumes BT.601 coefficients, so convert other color matrices to RGB.
+	switch C.de265_get_image_matrix_coefficients(pic) {
+	case matrixBT709:
+		return ycbcrToRGBA(img, 0.2126, 0.0722), nil
+	case matrixBT2020:
+		return ycbcrToRGBA(img, 0.2627, 0.0593), nil
+	}
+
+	return img, nil
+}
+
+// hevcPlane returns the samples of a color channel as 8-bit values and expands limited range
+// samples to the full range expected by Go.
+func hevcPlane(pic *C.struct_de265_image, channel int, fullRange bool) (pix []uint8, width, height int, err error) {
+	width = int(C.de265_get_image_width(pic, C.int(channel)))
+	height = int(C.de265_get_image_height(pic, C.int(channel)))
+	depth := int(C.de265_get_bits_per_pixel(pic, C.int(channel)))
+
+	if width <= 0 || height <= 0 {
+		return nil, 0, 0, fmt.Errorf("heif: invalid hevc picture size %dx%d", width, height)
+	} else if depth < 8 || depth > 16 {
+		return nil, 0, 0, fmt.Errorf("heif: unsupported hevc bit depth %d", depth)
+	}
+
+	var stride C.int
+
+	ptr := C.de265_get_image_plane(pic, C.int(channel), &stride)
+
+	if ptr == nil {
+		return nil, 0, 0, fmt.Errorf("heif: missing hevc picture plane %d", channel)
+	}
+
+	src := unsafe.Slice((*uint8)(unsafe.Pointer(ptr)), int(stride)*height)
+	pix = make([]uint8, width*height)
+
+	for row := 0; row < height; row++ {
+		line := src[row*int(stride):]
+
+		for x := 0; x < width; x++ {
+			var v int
+
+			if depth == 8 {
+				v = int(line[x])
+			} else {
+				// Samples with more than 8 bits are stored as uint16 in native byte order.
+				v = int(*(*uint16)(unsafe.Pointer(&line[2*x]))) >> (depth - 8)
+			}
+
+			if fullRange {
+				pix[row*width+x] = uint8(v)
+			} else if channel == 0 {
+				pix[row*width+x] = clampUint8((v - 16) * 255 / 219)
+			} else {
+				pix[row*width+x] = clampUint8((v-128)*255/224 + 128)
+			}
+		}
+	}
+
+	return pix, width, height, nil
+}
+
+// ycbcrToRGBA converts an image to RGB with the luma coefficients kr and kb.
+func ycbcrToRGBA(img *image.YCbCr, kr, kb float64) *image.RGBA {
+	b := img.Bounds()
+	result := image.NewRGBA(b)
+	kg := 1 - kr - kb
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			yy := float64(img.Y[img.YOffset(x, y)])
+			cb := float64(img.Cb[img.COffset(x, y)]) - 128
+			cr := float64(img.Cr[img.COffset(x, y)]) - 128
+
+			result.SetRGBA(x, y, color.RGBA{
+				R: clampUint8(int(yy + 2*(1-kr)*cr + 0.5)),
+				G: clampUint8(int(yy - (2*kb*(1-kb)*cb+2*kr*(1-kr)*cr)/kg + 0.5)),
+				B: clampUint8(int(yy + 2*(1-kb)*cb + 0.5)),
+				A: 255,
+			})
+		}
+	}
+
+	return result
+}
+
+// clampUint8 limits a value to the range of uint8.
+func clampUint8(v int) uint8 {
+	if v < 0 {
+		return 0
+	} else if v > 255 {
+		return 255
+	}
+
+	return uint8(v)
+}
@@ -0,0 +1,54 @@
+//go:build libde265
+// +build libde265
+
+package heif
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeHevc(t *testing.T) {
+	f, err := Open(exampleFile)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Grid", func(t *testing.T) {
+		primary, _ := f.Primary()
+
+		assert.True(t, f.Supported(primary))
+
+		img, err := f.Decode(primary)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, image.Rect(0, 0, 4032, 3024), img.Bounds())
+
+		// The sky is in the top left corner.
+		r, _, b, _ := img.At(100, 100).RGBA()
+		assert.Greater(t, b, r)
+	})
+	t.Run("Tile", func(t *testing.T) {
+		img, err := f.Decode(f.Item(1))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, image.Rect(0, 0, 512, 512), img.Bounds())
+	})
+	t.Run("InvalidData", func(t *testing.T) {
+		primary, _ := f.Primary()
+		tile := f.Item(primary.Refs[RefDerived][0])
+
+		_, err := decodeHevc(tile, []byte{0, 0, 0, 2, 1, 2})
+
+		assert.Error(t, err)
+	})
+}
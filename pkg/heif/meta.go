@@ -0,0 +1,296 @@
+package heif
+
+import (
+	"fmt"
+)
+
+// parseMeta parses the meta box with the item information, locations, references, and properties.
+func (f *File) parseMeta(data []byte) error {
+	r := newReader(data)
+	r.fullBox()
+
+	if r.err != nil {
+		return ErrNotHeif
+	}
+
+	children := r.boxes()
+
+	// Items must be known before locations, references, and properties can be assigned.
+	for _, b := range children {
+		switch b.typ {
+		case "pitm":
+			f.parsePrimary(b.data)
+		case "iinf":
+			if err := f.parseItemInfo(b.data); err != nil {
+				return err
+			}
+		case "idat":
+			f.idat = b.data
+		}
+	}
+
+	for _, b := range children {
+		var err error
+
+		switch b.typ {
+		case "iloc":
+			err = f.parseLocations(b.data)
+		case "iref":
+			err = f.parseReferences(b.data)
+		case "iprp":
+			err = f.parseProperties(b.data)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parsePrimary parses the primary item box.
+func (f *File) parsePrimary(data []byte) {
+	r := newReader(data)
+
+	if v, _ := r.fullBox(); v == 0 {
+		f.primary = uint32(r.uint16())
+	} else {
+		f.primary = r.uint32()
+	}
+}
+
+// parseItemInfo parses the item information box and its entries.
+func (f *File) parseItemInfo(data []byte) error {
+	r := newReader(data)
+
+	if v, _ := r.fullBox(); v == 0 {
+		r.uint16()
+	} else {
+		r.uint32()
+	}
+
+	if r.err != nil {
+		return fmt.Errorf("heif: invalid iinf box")
+	}
+
+	for _, b := range r.boxes() {
+		if b.typ != "infe" {
+			continue
+		}
+
+		e := newReader(b.data)
+		v, flags := e.fullBox()
+
+		// Versions 0 and 1 don't contain an item type.
+		if v < 2 {
+			continue
+		}
+
+		it := &Item{Hidden: flags&1 == 1, Refs: make(map[string][]uint32)}
+
+		if v == 2 {
+			it.ID = uint32(e.uint16())
+		} else {
+			it.ID = e.uint32()
+		}
+
+		e.uint16()
+		it.Type = string(e.bytes(4))
+		it.Name = e.string()
+
+		if e.err != nil {
+			return fmt.Errorf("heif: invalid infe box")
+		}
+
+		f.Items = append(f.Items, it)
+	}
+
+	return nil
+}
+
+// parseLocations parses the item location box.
+func (f *File) parseLocations(data []byte) error {
+	r := newReader(data)
+	v, _ := r.fullBox()
+
+	sizes := r.uint16()
+	offsetSize := int(sizes >> 12)
+	lengthSize := int(sizes >> 8 & 0xf)
+	baseSize := int(sizes >> 4 & 0xf)
+	indexSize := 0
+
+	if v == 1 || v == 2 {
+		indexSize = int(sizes & 0xf)
+	}
+
+	var count uint32
+
+	if v < 2 {
+		count = uint32(r.uint16())
+	} else {
+		count = r.uint32()
+	}
+
+	for i := uint32(0); i < count && r.err == nil; i++ {
+		var id uint32
+
+		if v < 2 {
+			id = uint32(r.uint16())
+		} else {
+			id = r.uint32()
+		}
+
+		loc := location{}
+
+		if v == 1 || v == 2 {
+			loc.method = int(r.uint16() & 0xf)
+		}
+
+		r.uint16()
+		loc.base = r.uint(baseSize)
+
+		extents := int(r.uint16())
+
+		for j := 0; j < extents && r.err == nil; j++ {
+			r.uint(indexSize)
+			e := extent{offset: r.uint(offsetSize)}
+			e.length = r.uint(lengthSize)
+			loc.extents = append(loc.extents, e)
+		}
+
+		if it := f.Item(id); it != nil {
+			it.location = loc
+		}
+	}
+
+	if r.err != nil {
+		return fmt.Errorf("heif: invalid iloc box")
+	}
+
+	return nil
+}
+
+// parseReferences parses the item reference box.
+func (f *File) parseReferences(data []byte) error {
+	r := newReader(data)
+	v, _ := r.fullBox()
+
+	for _, b := range r.boxes() {
+		ref := newReader(b.data)
+
+		for ref.err == nil && len(ref.remaining()) > 0 {
+			var from uint32
+
+			if v == 0 {
+				from = uint32(ref.uint16())
+			} else {
+				from = ref.uint32()
+			}
+
+			n := int(ref.uint16())
+			to := make([]uint32, 0, n)
+
+			for i := 0; i < n; i++ {
+				if v == 0 {
+					to = append(to, uint32(ref.uint16()))
+				} else {
+					to = append(to, ref.uint32())
+				}
+			}
+
+			if ref.err != nil {
+				return fmt.Errorf("heif: invalid %s reference", b.typ)
+			}
+
+			if it := f.Item(from); it != nil {
+				it.Refs[b.typ] = append(it.Refs[b.typ], to...)
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseProperties parses the item properties box and assigns the properties to items.
+func (f *File) parseProperties(data []byte) error {
+	children := newReader(data).boxes()
+
+	var props []box
+
+	for _, b := range children {
+		if b.typ == "ipco" {
+			props = newReader(b.data).boxes()
+		}
+	}
+
+	for _, b := range children {
+		if b.typ != "ipma" {
+			continue
+		}
+
+		r := newReader(b.data)
+		v, flags := r.fullBox()
+		count := r.uint32()
+
+		for i := uint32(0); i < count && r.err == nil; i++ {
+			var id uint32
+
+			if v < 1 {
+				id = uint32(r.uint16())
+			} else {
+				id = r.uint32()
+			}
+
+			it := f.Item(id)
+			n := int(r.uint8())
+
+			for j := 0; j < n && r.err == nil; j++ {
+				var index int
+
+				if flags&1 == 1 {
+					index = int(r.uint16() & 0x7fff)
+				} else {
+					index = int(r.uint8() & 0x7f)
+				}
+
+				// Property indexes start at 1, 0 means no property.
+				if it == nil || index == 0 || index > len(props) {
+					continue
+				}
+
+				it.applyProperty(props[index-1])
+			}
+		}
+
+		if r.err != nil {
+			return fmt.Errorf("heif: invalid ipma box")
+		}
+	}
+
+	return nil
+}
+
+// applyProperty assigns an item property.
+func (it *Item) applyProperty(p box) {
+	switch p.typ {
+	case "ispe":
+		r := newReader(p.data)
+		r.fullBox()
+		it.Width = int(r.uint32())
+		it.Height = int(r.uint32())
+	case "auxC":
+		r := newReader(p.data)
+		r.fullBox()
+		it.AuxType = r.string()
+	case "hvcC", "av1C":
+		it.Config = p.data
+	case "colr":
+		if len(p.data) > 4 && (string(p.data[:4]) == "prof" || string(p.data[:4]) == "rICC") {
+			it.ICC = p.data[4:]
+		}
+	case "irot", "imir":
+		// Transformations must be applied in the order of association.
+		it.transforms = append(it.transforms, p)
+	}
+}
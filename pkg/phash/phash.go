@@ -0,0 +1,109 @@
+/*
+
+Package phash provides perceptual image hashes to find visually similar images.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.app/developer-guide/
+
+*/
+package phash
+
+import (
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"math/bits"
+
+	"github.com/disintegration/imaging"
+)
+
+// Hash represents a 64-bit perceptual image hash.
+type Hash uint64
+
+// Bits is the number of bits in a hash, and the maximum distance between two hashes.
+const Bits = 64
+
+// DefaultDistance is the default maximum Hamming distance of similar images.
+const DefaultDistance = 10
+
+// Difference returns the difference hash (dHash) of an image. Each bit indicates if a pixel is brighter
+// than its right neighbour in a 9x8 grayscale version of the image, so the hash doesn't change when
+// images are re-encoded, resized, or slightly color corrected.
+func Difference(img image.Image) (h Hash) {
+	small := imaging.Resize(img, 9, 8, imaging.Box)
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if brightness(small.At(x, y)) > brightness(small.At(x+1, y)) {
+				h |= 1 << uint(y*8+x)
+			}
+		}
+	}
+
+	return h
+}
+
+// Distance returns the Hamming distance between two hashes, i.e. the number of different bits.
+func (h Hash) Distance(other Hash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
+
+// Hex returns the hash as hex encoded string.
+func (h Hash) Hex() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// String implements the Stringer interface.
+func (h Hash) String() string {
+	return h.Hex()
+}
+
+// ParseHex returns the hash encoded in a hex string.
+func ParseHex(s string) (h Hash, err error) {
+	if len(s) != 16 {
+		return 0, fmt.Errorf("phash: invalid hash %q", s)
+	}
+
+	b, err := hex.DecodeString(s)
+
+	if err != nil {
+		return 0, fmt.Errorf("phash: invalid hash %q", s)
+	}
+
+	for _, v := range b {
+		h = h<<8 | Hash(v)
+	}
+
+	return h, nil
+}
+
+// brightness returns the relative luminance of a color.
+func brightness(c color.Color) float64 {
+	r, g, b, _ := c.RGBA()
+
+	return 0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)
+}
@@ -0,0 +1,72 @@
+package phash
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+// gradient returns a test image with a horizontal and vertical gradient.
+func gradient(w, h int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(255 * x / w), G: uint8(255 * y / h), B: uint8(255 * (x + y) / (w + h)), A: 255})
+		}
+	}
+
+	return img
+}
+
+func TestDifference(t *testing.T) {
+	t.Run("Uniform", func(t *testing.T) {
+		img := imaging.New(100, 100, color.NRGBA{R: 128, G: 128, B: 128, A: 255})
+		assert.Equal(t, Hash(0), Difference(img))
+	})
+	t.Run("Gradient", func(t *testing.T) {
+		img := imaging.FlipH(gradient(180, 160))
+		assert.Equal(t, Hash(0xffffffffffffffff), Difference(img))
+	})
+	t.Run("Resized", func(t *testing.T) {
+		img := gradient(640, 480)
+		img.(*image.NRGBA).Set(300, 200, color.White)
+		small := imaging.Resize(img, 160, 120, imaging.Lanczos)
+		assert.LessOrEqual(t, Difference(img).Distance(Difference(small)), 2)
+	})
+	t.Run("Different", func(t *testing.T) {
+		a := gradient(640, 480)
+		b := imaging.FlipH(a)
+		assert.Greater(t, Difference(a).Distance(Difference(b)), DefaultDistance)
+	})
+}
+
+func TestHash_Distance(t *testing.T) {
+	assert.Equal(t, 0, Hash(0xff).Distance(0xff))
+	assert.Equal(t, 8, Hash(0xff).Distance(0))
+	assert.Equal(t, Bits, Hash(0).Distance(0xffffffffffffffff))
+}
+
+func TestHash_Hex(t *testing.T) {
+	assert.Equal(t, "00000000000000ff", Hash(0xff).Hex())
+	assert.Equal(t, "8000000000000001", Hash(0x8000000000000001).String())
+}
+
+func TestParseHex(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		h, err := ParseHex("8000000000000001")
+		assert.NoError(t, err)
+		assert.Equal(t, Hash(0x8000000000000001), h)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := ParseHex("")
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseHex("800000000000000x")
+		assert.Error(t, err)
+	})
+}
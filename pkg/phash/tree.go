@@ -0,0 +1,146 @@
+package phash
+
+import "sort"
+
+// Match represents an item found in a Tree.
+type Match struct {
+	ID       string
+	Hash     Hash
+	Distance int
+}
+
+// Matches represents a list of matches sorted by distance.
+type Matches []Match
+
+// IDs returns the IDs of all matches.
+func (m Matches) IDs() (result []string) {
+	for _, match := range m {
+		result = append(result, match.ID)
+	}
+
+	return result
+}
+
+// node represents a hash and the IDs of all items with this hash.
+type node struct {
+	hash     Hash
+	ids      []string
+	children map[int]*node
+}
+
+// Tree is a BK-tree that finds hashes within a given Hamming distance without comparing all of them.
+// It is not safe for concurrent use while items are added.
+type Tree struct {
+	root *node
+	size int
+}
+
+// NewTree returns a new, empty tree.
+func NewTree() *Tree {
+	return &Tree{}
+}
+
+// Len returns the number of items in the tree.
+func (t *Tree) Len() int {
+	return t.size
+}
+
+// Add adds an item with the given ID and hash.
+func (t *Tree) Add(id string, h Hash) {
+	t.size++
+
+	if t.root == nil {
+		t.root = &node{hash: h, ids: []string{id}}
+		return
+	}
+
+	n := t.root
+
+	for {
+		d := n.hash.Distance(h)
+
+		if d == 0 {
+			n.ids = append(n.ids, id)
+			return
+		}
+
+		child, ok := n.children[d]
+
+		if !ok {
+			if n.children == nil {
+				n.children = make(map[int]*node)
+			}
+
+			n.children[d] = &node{hash: h, ids: []string{id}}
+			return
+		}
+
+		n = child
+	}
+}
+
+// Remove removes the item with the given ID and hash and returns true if it was found.
+// Nodes remain in the tree, so that their subtrees can still be searched.
+func (t *Tree) Remove(id string, h Hash) bool {
+	n := t.root
+
+	for n != nil {
+		d := n.hash.Distance(h)
+
+		if d > 0 {
+			n = n.children[d]
+			continue
+		}
+
+		for i := range n.ids {
+			if n.ids[i] == id {
+				n.ids = append(n.ids[:i], n.ids[i+1:]...)
+				t.size--
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return false
+}
+
+// Find returns all items with a distance of at most maxDist, sorted by distance and ID.
+func (t *Tree) Find(h Hash, maxDist int) (result Matches) {
+	if t.root == nil || maxDist < 0 {
+		return result
+	}
+
+	stack := []*node{t.root}
+
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		d := n.hash.Distance(h)
+
+		if d <= maxDist {
+			for _, id := range n.ids {
+				result = append(result, Match{ID: id, Hash: n.hash, Distance: d})
+			}
+		}
+
+		// Only subtrees within the distance range can contain matches (triangle inequality).
+		for cd, child := range n.children {
+			if cd >= d-maxDist && cd <= d+maxDist {
+				stack = append(stack, child)
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Distance != result[j].Distance {
+			return result[i].Distance < result[j].Distance
+		}
+
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
@@ -0,0 +1,88 @@
+package phash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree_Find(t *testing.T) {
+	tree := NewTree()
+
+	tree.Add("a", 0x0)
+	tree.Add("b", 0x1)
+	tree.Add("c", 0x3)
+	tree.Add("d", 0xff)
+	tree.Add("e", 0x0)
+	tree.Add("f", 0xffffffff00000000)
+
+	t.Run("Len", func(t *testing.T) {
+		assert.Equal(t, 6, tree.Len())
+	})
+	t.Run("Exact", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "e"}, tree.Find(0x0, 0).IDs())
+	})
+	t.Run("Distance", func(t *testing.T) {
+		result := tree.Find(0x0, 2)
+		assert.Equal(t, []string{"a", "e", "b", "c"}, result.IDs())
+		assert.Equal(t, 2, result[3].Distance)
+		assert.Equal(t, Hash(0x3), result[3].Hash)
+	})
+	t.Run("All", func(t *testing.T) {
+		assert.Len(t, tree.Find(0x0, Bits), 6)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Empty(t, tree.Find(0xf0f0f0f0f0f0f0f0, 4))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, NewTree().Find(0x0, 10))
+	})
+}
+
+func TestTree_Remove(t *testing.T) {
+	tree := NewTree()
+
+	tree.Add("a", 0x0)
+	tree.Add("b", 0x1)
+	tree.Add("c", 0x3)
+	tree.Add("d", 0x0)
+
+	assert.True(t, tree.Remove("a", 0x0))
+	assert.False(t, tree.Remove("a", 0x0))
+	assert.False(t, tree.Remove("b", 0x3))
+	assert.False(t, tree.Remove("x", 0xff))
+	assert.Equal(t, 3, tree.Len())
+	assert.Equal(t, []string{"d", "b", "c"}, tree.Find(0x0, 2).IDs())
+
+	// Children of empty nodes can still be found.
+	assert.True(t, tree.Remove("d", 0x0))
+	assert.Equal(t, []string{"b", "c"}, tree.Find(0x0, 2).IDs())
+	assert.False(t, NewTree().Remove("a", 0x0))
+}
+
+func TestTree_Brute(t *testing.T) {
+	var hashes []Hash
+	tree := NewTree()
+
+	h := Hash(0x9e3779b97f4a7c15)
+
+	for i := 0; i < 500; i++ {
+		h ^= h << 13
+		h ^= h >> 7
+		h ^= h << 17
+		hashes = append(hashes, h)
+		tree.Add(string(rune('a'+i%26))+h.Hex(), h)
+	}
+
+	for _, q := range hashes[:20] {
+		expected := 0
+
+		for _, h := range hashes {
+			if q.Distance(h) <= 24 {
+				expected++
+			}
+		}
+
+		assert.Len(t, tree.Find(q, 24), expected)
+	}
+}
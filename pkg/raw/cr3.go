@@ -0,0 +1,132 @@
+package raw
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// box represents an ISO base media file format box.
+type box struct {
+	typ    string
+	offset int64 // Start of the box payload.
+	size   int64 // Size of the box payload.
+}
+
+// readBoxes returns the boxes in the given range.
+func readBoxes(r io.ReaderAt, offset, end int64) (result []box) {
+	h := make([]byte, 16)
+
+	for offset+8 <= end {
+		if _, err := r.ReadAt(h[:8], offset); err != nil {
+			break
+		}
+
+		size := int64(binary.BigEndian.Uint32(h[0:4]))
+		typ := string(h[4:8])
+		header := int64(8)
+
+		if size == 1 {
+			if _, err := r.ReadAt(h[8:16], offset+8); err != nil {
+				break
+			}
+
+			size = int64(binary.BigEndian.Uint64(h[8:16]))
+			header = 16
+		} else if size == 0 {
+			size = end - offset
+		}
+
+		if size < header || offset+size > end {
+			break
+		}
+
+		result = append(result, box{typ: typ, offset: offset + header, size: size - header})
+		offset += size
+	}
+
+	return result
+}
+
+// findBox returns the first box of the given type.
+func findBox(boxes []box, typ string) (box, bool) {
+	for _, b := range boxes {
+		if b.typ == typ {
+			return b, true
+		}
+	}
+
+	return box{}, false
+}
+
+// cr3Previews returns the JPEG candidates referenced in the tracks of a Canon CR3 file,
+// which contains the full-size JPEG preview in the first track.
+func cr3Previews(r io.ReaderAt, size int64) (result []Preview, err error) {
+	moov, ok := findBox(readBoxes(r, 0, size), "moov")
+
+	if !ok {
+		return nil, nil
+	}
+
+	for _, trak := range readBoxes(r, moov.offset, moov.offset+moov.size) {
+		if trak.typ != "trak" {
+			continue
+		}
+
+		stbl, ok := findPath(r, trak, "mdia", "minf", "stbl")
+
+		if !ok {
+			continue
+		}
+
+		boxes := readBoxes(r, stbl.offset, stbl.offset+stbl.size)
+
+		p := Preview{}
+
+		if co64, ok := findBox(boxes, "co64"); ok && co64.size >= 16 {
+			b := make([]byte, 8)
+
+			if _, err := r.ReadAt(b, co64.offset+8); err == nil {
+				p.Offset = int64(binary.BigEndian.Uint64(b))
+			}
+		} else if stco, ok := findBox(boxes, "stco"); ok && stco.size >= 12 {
+			b := make([]byte, 4)
+
+			if _, err := r.ReadAt(b, stco.offset+8); err == nil {
+				p.Offset = int64(binary.BigEndian.Uint32(b))
+			}
+		}
+
+		if stsz, ok := findBox(boxes, "stsz"); ok && stsz.size >= 12 {
+			b := make([]byte, 16)
+
+			if n, _ := r.ReadAt(b, stsz.offset); n >= 12 {
+				p.Length = int64(binary.BigEndian.Uint32(b[4:8]))
+
+				if p.Length == 0 && n >= 16 {
+					p.Length = int64(binary.BigEndian.Uint32(b[12:16]))
+				}
+			}
+		}
+
+		if p.Offset > 0 {
+			result = append(result, p)
+		}
+	}
+
+	return result, nil
+}
+
+// findPath returns the nested box with the given path.
+func findPath(r io.ReaderAt, parent box, path ...string) (box, bool) {
+	for _, typ := range path {
+		b, ok := findBox(readBoxes(r, parent.offset, parent.offset+parent.size), typ)
+
+		if !ok {
+			return box{}, false
+		}
+
+		parent = b
+	}
+
+	return parent, true
+}
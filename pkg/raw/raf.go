@@ -0,0 +1,27 @@
+package raw
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// rafPreviews returns the JPEG preview referenced in the header of a Fujifilm RAF file.
+func rafPreviews(r io.ReaderAt, size int64) (result []Preview, err error) {
+	h := make([]byte, 8)
+
+	// The JPEG offset and length are stored as big-endian values at offset 84.
+	if _, err = r.ReadAt(h, 84); err != nil {
+		return nil, err
+	}
+
+	p := Preview{
+		Offset: int64(binary.BigEndian.Uint32(h[0:4])),
+		Length: int64(binary.BigEndian.Uint32(h[4:8])),
+	}
+
+	if p.Offset > 0 && p.Offset < size {
+		result = append(result, p)
+	}
+
+	return result, nil
+}
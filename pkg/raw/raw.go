@@ -0,0 +1,197 @@
+/*
+
+Package raw extracts embedded JPEG previews from camera RAW files.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.app/developer-guide/
+
+*/
+package raw
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"os"
+	"sort"
+)
+
+// ErrNoPreview is returned if a RAW file contains no decodable JPEG preview.
+var ErrNoPreview = errors.New("raw: found no embedded preview")
+
+// ScanMinSize is the minimum long side of a preview found by parsing the file structure,
+// below which the file is scanned for JPEG markers, e.g. for previews in maker notes.
+var ScanMinSize = 1280
+
+// Preview represents an embedded JPEG preview image.
+type Preview struct {
+	Offset int64
+	Length int64
+	Width  int
+	Height int
+}
+
+// Pixels returns the number of pixels.
+func (p Preview) Pixels() int {
+	return p.Width * p.Height
+}
+
+// LongSide returns the length of the longer side in pixels.
+func (p Preview) LongSide() int {
+	if p.Width > p.Height {
+		return p.Width
+	}
+
+	return p.Height
+}
+
+// Previews returns the decodable JPEG previews embedded in a RAW file, largest first.
+func Previews(fileName string) (result []Preview, err error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return previews(f, info.Size())
+}
+
+// Largest returns the largest JPEG preview embedded in a RAW file.
+func Largest(fileName string) (Preview, error) {
+	result, err := Previews(fileName)
+
+	if err != nil {
+		return Preview{}, err
+	} else if len(result) == 0 {
+		return Preview{}, ErrNoPreview
+	}
+
+	return result[0], nil
+}
+
+// Decode returns the largest JPEG preview embedded in a RAW file as image.
+func Decode(fileName string) (image.Image, Preview, error) {
+	p, err := Largest(fileName)
+
+	if err != nil {
+		return nil, p, err
+	}
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, p, err
+	}
+
+	defer f.Close()
+
+	img, err := jpeg.Decode(io.NewSectionReader(f, p.Offset, p.Length))
+
+	if err != nil {
+		return nil, p, fmt.Errorf("raw: %s", err)
+	}
+
+	return img, p, nil
+}
+
+// previews finds embedded JPEG previews depending on the file format.
+func previews(r io.ReaderAt, size int64) (result []Preview, err error) {
+	header := make([]byte, 16)
+
+	if _, err = r.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("raw: %s", err)
+	}
+
+	var candidates []Preview
+
+	switch {
+	case bytes.HasPrefix(header, []byte("FUJIFILMCCD-RAW")):
+		candidates, err = rafPreviews(r, size)
+	case bytes.Equal(header[4:8], []byte("ftyp")) && bytes.Equal(header[8:12], []byte("crx ")):
+		candidates, err = cr3Previews(r, size)
+	case tiffHeader(header):
+		candidates, err = tiffPreviews(r, size, 0)
+	default:
+		return nil, errors.New("raw: unsupported file format")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	result = validate(r, size, candidates)
+
+	// Scan for JPEG markers if the file structure contains no large preview.
+	if len(result) == 0 || result[0].LongSide() < ScanMinSize {
+		result = validate(r, size, append(candidates, scanPreviews(r, size)...))
+	}
+
+	return result, nil
+}
+
+// validate returns the candidates that are baseline or progressive JPEGs, largest first.
+func validate(r io.ReaderAt, size int64, candidates []Preview) (result []Preview) {
+	done := make(map[int64]bool)
+
+	for _, p := range candidates {
+		if done[p.Offset] || p.Offset <= 0 || p.Offset >= size {
+			continue
+		}
+
+		done[p.Offset] = true
+
+		if p.Length <= 0 || p.Offset+p.Length > size {
+			p.Length = size - p.Offset
+		}
+
+		// Lossless JPEG as used for RAW image data is not supported by the decoder.
+		if cfg, err := jpeg.DecodeConfig(io.NewSectionReader(r, p.Offset, p.Length)); err != nil {
+			continue
+		} else {
+			p.Width = cfg.Width
+			p.Height = cfg.Height
+		}
+
+		result = append(result, p)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Pixels() > result[j].Pixels()
+	})
+
+	return result
+}
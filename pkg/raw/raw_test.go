@@ -0,0 +1,197 @@
+package raw
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testJpeg returns a JPEG encoded test image with the given size.
+func testJpeg(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// writeFile writes test data to a temporary file and returns its name.
+func writeFile(t *testing.T, name string, data []byte) string {
+	fileName := filepath.Join(t.TempDir(), name)
+
+	if err := os.WriteFile(fileName, data, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
+
+// testTiff returns a little-endian TIFF file with a JPEG thumbnail in IFD0 and a JPEG strip in IFD1.
+func testTiff(t *testing.T, magic string, thumb, preview []byte) []byte {
+	var buf bytes.Buffer
+	le := binary.LittleEndian
+
+	buf.WriteString(magic)
+	_ = binary.Write(&buf, le, uint32(8))
+
+	// IFD0 with JPEGInterchangeFormat and JPEGInterchangeFormatLength.
+	ifd1 := uint32(8 + 2 + 2*12 + 4)
+	data := ifd1 + 2 + 3*12 + 4
+	_ = binary.Write(&buf, le, uint16(2))
+	_ = binary.Write(&buf, le, []uint16{tagJpegOffset, 4})
+	_ = binary.Write(&buf, le, []uint32{1, data})
+	_ = binary.Write(&buf, le, []uint16{tagJpegLength, 4})
+	_ = binary.Write(&buf, le, []uint32{1, uint32(len(thumb))})
+	_ = binary.Write(&buf, le, ifd1)
+
+	// IFD1 with a single JPEG strip.
+	_ = binary.Write(&buf, le, uint16(3))
+	_ = binary.Write(&buf, le, []uint16{tagCompression, 3})
+	_ = binary.Write(&buf, le, []uint32{1, 6})
+	_ = binary.Write(&buf, le, []uint16{tagStripOffsets, 4})
+	_ = binary.Write(&buf, le, []uint32{1, data + uint32(len(thumb))})
+	_ = binary.Write(&buf, le, []uint16{tagStripCounts, 4})
+	_ = binary.Write(&buf, le, []uint32{1, uint32(len(preview))})
+	_ = binary.Write(&buf, le, uint32(0))
+
+	buf.Write(thumb)
+	buf.Write(preview)
+
+	return buf.Bytes()
+}
+
+func TestPreviews(t *testing.T) {
+	t.Run("DNG", func(t *testing.T) {
+		result, err := Previews(filepath.Join("..", "..", "assets", "examples", "canon_eos_6d.dng"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, result)
+		assert.Equal(t, 1024, result[0].Width)
+		assert.Equal(t, 683, result[0].Height)
+		assert.Equal(t, int64(145584), result[0].Offset)
+		assert.Equal(t, int64(57901), result[0].Length)
+	})
+	t.Run("CR2", func(t *testing.T) {
+		fileName := writeFile(t, "test.cr2", testTiff(t, "II*\x00", testJpeg(t, 160, 120), testJpeg(t, 1600, 1200)))
+
+		result, err := Previews(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+		assert.Equal(t, 1600, result[0].Width)
+		assert.Equal(t, 160, result[1].Width)
+	})
+	t.Run("ORF", func(t *testing.T) {
+		// The large preview is not referenced in the IFDs, e.g. because it's stored in the maker notes.
+		data := testTiff(t, "IIRO", testJpeg(t, 160, 120), []byte("no preview"))
+		data = append(data, testJpeg(t, 1600, 1200)...)
+		fileName := writeFile(t, "test.orf", data)
+
+		p, err := Largest(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1600, p.Width)
+		assert.Equal(t, 1200, p.Height)
+	})
+	t.Run("RAF", func(t *testing.T) {
+		preview := testJpeg(t, 1920, 1280)
+		header := make([]byte, 160)
+		copy(header, "FUJIFILMCCD-RAW 0201FF383501")
+		binary.BigEndian.PutUint32(header[84:], uint32(len(header)))
+		binary.BigEndian.PutUint32(header[88:], uint32(len(preview)))
+
+		fileName := writeFile(t, "test.raf", append(header, preview...))
+
+		result, err := Previews(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, int64(160), result[0].Offset)
+		assert.Equal(t, 1920, result[0].Width)
+	})
+	t.Run("CR3", func(t *testing.T) {
+		preview := testJpeg(t, 1600, 1000)
+
+		mkBox := func(typ string, payload ...[]byte) []byte {
+			b := bytes.Join(payload, nil)
+			h := make([]byte, 8)
+			binary.BigEndian.PutUint32(h, uint32(len(b)+8))
+			copy(h[4:], typ)
+			return append(h, b...)
+		}
+
+		ftyp := mkBox("ftyp", []byte("crx \x00\x00\x00\x01crx isom"))
+
+		stsz := make([]byte, 12)
+		binary.BigEndian.PutUint32(stsz[4:], uint32(len(preview)))
+		binary.BigEndian.PutUint32(stsz[8:], 1)
+
+		co64 := make([]byte, 16)
+		binary.BigEndian.PutUint32(co64[4:], 1)
+
+		moovSize := len(mkBox("moov", mkBox("trak", mkBox("mdia", mkBox("minf", mkBox("stbl", mkBox("stsz", stsz), mkBox("co64", co64)))))))
+		binary.BigEndian.PutUint64(co64[8:], uint64(len(ftyp)+moovSize+8))
+
+		moov := mkBox("moov", mkBox("trak", mkBox("mdia", mkBox("minf", mkBox("stbl", mkBox("stsz", stsz), mkBox("co64", co64))))))
+		data := bytes.Join([][]byte{ftyp, moov, mkBox("mdat", preview)}, nil)
+
+		result, err := Previews(writeFile(t, "test.cr3", data))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, result)
+		assert.Equal(t, int64(len(ftyp)+moovSize+8), result[0].Offset)
+		assert.Equal(t, int64(len(preview)), result[0].Length)
+		assert.Equal(t, 1600, result[0].Width)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := Previews(writeFile(t, "test.txt", []byte("Hello World, this is not a RAW file.")))
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Previews("testdata/missing.cr2")
+		assert.Error(t, err)
+	})
+}
+
+func TestLargest(t *testing.T) {
+	t.Run("NoPreview", func(t *testing.T) {
+		data := testTiff(t, "II*\x00", []byte("no thumb"), []byte("no preview"))
+		_, err := Largest(writeFile(t, "test.nef", data))
+		assert.Equal(t, ErrNoPreview, err)
+	})
+}
+
+func TestDecode(t *testing.T) {
+	img, p, err := Decode(filepath.Join("..", "..", "assets", "examples", "canon_eos_6d.dng"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1024, p.LongSide())
+	assert.Equal(t, 1024, img.Bounds().Dx())
+	assert.Equal(t, 683, img.Bounds().Dy())
+}
@@ -0,0 +1,49 @@
+package raw
+
+import (
+	"bytes"
+	"io"
+)
+
+// ScanMaxSize is the maximum number of bytes scanned for JPEG markers.
+var ScanMaxSize int64 = 256 * 1024 * 1024
+
+// scanPreviews scans a file for JPEG start of image markers and returns their offsets as candidates.
+func scanPreviews(r io.ReaderAt, size int64) (result []Preview) {
+	const chunkSize = 1024 * 1024
+
+	marker := []byte{0xFF, 0xD8, 0xFF}
+	buf := make([]byte, chunkSize+len(marker)-1)
+
+	if size > ScanMaxSize {
+		size = ScanMaxSize
+	}
+
+	// Skip the file header, it never contains a preview.
+	for offset := int64(1); offset < size; offset += chunkSize {
+		n, err := r.ReadAt(buf, offset)
+
+		if n == 0 {
+			break
+		}
+
+		data := buf[:n]
+
+		for i := 0; ; {
+			j := bytes.Index(data[i:], marker)
+
+			if j < 0 {
+				break
+			}
+
+			result = append(result, Preview{Offset: offset + int64(i+j)})
+			i += j + 1
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,196 @@
+package raw
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// TIFF tags used to locate embedded previews.
+const (
+	tagCompression  = 0x0103
+	tagStripOffsets = 0x0111
+	tagStripCounts  = 0x0117
+	tagSubIFDs      = 0x014A
+	tagJpegOffset   = 0x0201
+	tagJpegLength   = 0x0202
+	tagExifIFD      = 0x8769
+)
+
+// Maximum number of IFDs to parse, as protection against loops in broken files.
+const maxIFDs = 64
+
+// tiffHeader tests if the header belongs to a TIFF-based RAW format like CR2, NEF, ARW, DNG, or ORF.
+func tiffHeader(h []byte) bool {
+	switch {
+	case bytes.HasPrefix(h, []byte("II*\x00")), bytes.HasPrefix(h, []byte("MM\x00*")):
+		return true
+	case bytes.HasPrefix(h, []byte("IIRO")), bytes.HasPrefix(h, []byte("IIRS")), bytes.HasPrefix(h, []byte("MMOR")):
+		// Olympus ORF.
+		return true
+	default:
+		return false
+	}
+}
+
+// tiffEntry represents an IFD entry.
+type tiffEntry struct {
+	tag    uint16
+	typ    uint16
+	count  uint32
+	values []uint32
+}
+
+// tiffPreviews returns the JPEG preview candidates referenced in the IFDs of a TIFF file starting at base.
+func tiffPreviews(r io.ReaderAt, size, base int64) (result []Preview, err error) {
+	h := make([]byte, 8)
+
+	if _, err = r.ReadAt(h, base); err != nil {
+		return nil, err
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+
+	if h[0] == 'M' {
+		order = binary.BigEndian
+	}
+
+	queue := []int64{int64(order.Uint32(h[4:8]))}
+	visited := make(map[int64]bool)
+
+	for len(queue) > 0 && len(visited) < maxIFDs {
+		offset := queue[0]
+		queue = queue[1:]
+
+		if offset <= 0 || visited[offset] || base+offset >= size {
+			continue
+		}
+
+		visited[offset] = true
+
+		entries, next := readIFD(r, order, base, offset)
+
+		if next > 0 {
+			queue = append(queue, next)
+		}
+
+		tags := make(map[uint16]tiffEntry, len(entries))
+
+		for _, e := range entries {
+			tags[e.tag] = e
+
+			switch e.tag {
+			case tagSubIFDs, tagExifIFD:
+				for _, v := range e.values {
+					queue = append(queue, int64(v))
+				}
+			}
+		}
+
+		if off, ok := tags[tagJpegOffset]; ok && len(off.values) > 0 {
+			p := Preview{Offset: base + int64(off.values[0])}
+
+			if l, ok := tags[tagJpegLength]; ok && len(l.values) > 0 {
+				p.Length = int64(l.values[0])
+			}
+
+			result = append(result, p)
+		}
+
+		// Images stored as single JPEG strip, e.g. in CR2 and DNG.
+		c, ok := tags[tagCompression]
+
+		if !ok || len(c.values) == 0 || (c.values[0] != 6 && c.values[0] != 7) {
+			continue
+		}
+
+		if off, ok := tags[tagStripOffsets]; ok && len(off.values) == 1 {
+			p := Preview{Offset: base + int64(off.values[0])}
+
+			if l, ok := tags[tagStripCounts]; ok && len(l.values) == 1 {
+				p.Length = int64(l.values[0])
+			}
+
+			result = append(result, p)
+		}
+	}
+
+	return result, nil
+}
+
+// readIFD reads the entries of an IFD and returns them along with the next IFD offset.
+// Offsets are relative to the TIFF header at base.
+func readIFD(r io.ReaderAt, order binary.ByteOrder, base, offset int64) (entries []tiffEntry, next int64) {
+	b := make([]byte, 2)
+
+	if _, err := r.ReadAt(b, base+offset); err != nil {
+		return nil, 0
+	}
+
+	n := int(order.Uint16(b))
+
+	if n == 0 || n > 1000 {
+		return nil, 0
+	}
+
+	data := make([]byte, n*12+4)
+
+	if _, err := r.ReadAt(data, base+offset+2); err != nil {
+		return nil, 0
+	}
+
+	for i := 0; i < n; i++ {
+		e := data[i*12 : i*12+12]
+		entry := tiffEntry{
+			tag:   order.Uint16(e[0:2]),
+			typ:   order.Uint16(e[2:4]),
+			count: order.Uint32(e[4:8]),
+		}
+
+		switch entry.tag {
+		case tagCompression, tagStripOffsets, tagStripCounts, tagSubIFDs, tagJpegOffset, tagJpegLength, tagExifIFD:
+			entry.values = readValues(r, order, base, entry, e[8:12])
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, int64(order.Uint32(data[n*12:]))
+}
+
+// readValues returns the SHORT, LONG, or IFD values of an entry.
+func readValues(r io.ReaderAt, order binary.ByteOrder, base int64, e tiffEntry, value []byte) (result []uint32) {
+	var size int
+
+	switch e.typ {
+	case 3:
+		size = 2
+	case 4, 13:
+		size = 4
+	default:
+		return nil
+	}
+
+	if e.count == 0 || e.count > 4096 {
+		return nil
+	}
+
+	data := value
+
+	if n := int(e.count) * size; n > 4 {
+		data = make([]byte, n)
+
+		if _, err := r.ReadAt(data, base+int64(order.Uint32(value))); err != nil {
+			return nil
+		}
+	}
+
+	for i := 0; i < int(e.count); i++ {
+		if size == 2 {
+			result = append(result, uint32(order.Uint16(data[i*2:])))
+		} else {
+			result = append(result, order.Uint32(data[i*4:]))
+		}
+	}
+
+	return result
+}
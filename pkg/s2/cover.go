@@ -0,0 +1,138 @@
+package s2
+
+import (
+	"github.com/golang/geo/r1"
+	gs2 "github.com/golang/geo/s2"
+	"github.com/golang/geo/s1"
+)
+
+// MaxCoverCells is the default max number of cells used to cover a region.
+var MaxCoverCells = 16
+
+// CoverRect returns the cell tokens covering a rectangle, the longitude range
+// crosses the antimeridian if lngMin is greater than lngMax.
+func CoverRect(latMin, lngMin, latMax, lngMax float64, maxCells int) []string {
+	if latMin > latMax || latMin < -90 || latMax > 90 || lngMin < -180 || lngMin > 180 || lngMax < -180 || lngMax > 180 {
+		return nil
+	}
+
+	rect := gs2.Rect{
+		Lat: r1.Interval{Lo: latMin * s1.Degree.Radians(), Hi: latMax * s1.Degree.Radians()},
+		Lng: s1.IntervalFromEndpoints(lngMin*s1.Degree.Radians(), lngMax*s1.Degree.Radians()),
+	}
+
+	return cover(rect, maxCells)
+}
+
+// CoverPolygon returns the cell tokens covering a polygon, given as a ring of [lng, lat] positions
+// like in GeoJSON. The ring may be closed and is assumed to be smaller than a hemisphere.
+func CoverPolygon(ring [][]float64, maxCells int) []string {
+	p := NewPolygon(ring)
+
+	if p == nil {
+		return nil
+	}
+
+	return cover(p.polygon, maxCells)
+}
+
+// Polygon represents an area on the sphere, e.g. to find locations within a search polygon.
+type Polygon struct {
+	polygon *gs2.Polygon
+}
+
+// NewPolygon returns a polygon with an outer ring of [lng, lat] positions like in GeoJSON, or nil
+// if the ring is invalid. The ring may be closed and is assumed to be smaller than a hemisphere.
+func NewPolygon(ring [][]float64) *Polygon {
+	points := make([]gs2.Point, 0, len(ring))
+
+	for i, p := range ring {
+		if len(p) < 2 || p[1] < -90 || p[1] > 90 || p[0] < -180 || p[0] > 180 {
+			return nil
+		}
+
+		// Skip closing position.
+		if i > 0 && i == len(ring)-1 && p[0] == ring[0][0] && p[1] == ring[0][1] {
+			break
+		}
+
+		points = append(points, gs2.PointFromLatLng(gs2.LatLngFromDegrees(p[1], p[0])))
+	}
+
+	if len(points) < 3 {
+		return nil
+	}
+
+	loop := gs2.LoopFromPoints(points)
+	loop.Normalize()
+
+	return &Polygon{polygon: gs2.PolygonFromLoops([]*gs2.Loop{loop})}
+}
+
+// Cover returns the tokens of the cells covering the polygon, split into cells that are
+// entirely inside the polygon and cells that intersect its boundary.
+func (p *Polygon) Cover(maxCells int) (interior, boundary []string) {
+	for _, token := range cover(p.polygon, maxCells) {
+		if p.polygon.ContainsCell(gs2.CellFromCellID(gs2.CellIDFromToken(token))) {
+			interior = append(interior, token)
+		} else {
+			boundary = append(boundary, token)
+		}
+	}
+
+	return interior, boundary
+}
+
+// Contains tests if a location is inside the polygon.
+func (p *Polygon) Contains(lat, lng float64) bool {
+	return p.polygon.ContainsPoint(gs2.PointFromLatLng(gs2.LatLngFromDegrees(lat, lng)))
+}
+
+// cover returns the cell tokens covering a region.
+func cover(region gs2.Region, maxCells int) []string {
+	if maxCells <= 0 {
+		maxCells = MaxCoverCells
+	}
+
+	rc := &gs2.RegionCoverer{MaxLevel: DefaultLevel, MaxCells: maxCells}
+	cells := rc.Covering(region)
+	result := make([]string, len(cells))
+
+	for i, c := range cells {
+		result[i] = c.ToToken()
+	}
+
+	return result
+}
+
+// TokenRange returns the first and last token at the default level within a cell,
+// so that locations in the cell can be found with an index-friendly range condition.
+func TokenRange(token string) (min, max string) {
+	c := gs2.CellIDFromToken(NormalizeToken(token))
+
+	if !c.IsValid() || c.Level() > DefaultLevel {
+		return min, max
+	}
+
+	return c.ChildBeginAtLevel(DefaultLevel).ToToken(), c.ChildEndAtLevel(DefaultLevel).Prev().ToToken()
+}
+
+// PrefixedTokenRange returns the first and last prefixed token at the default level within a cell.
+func PrefixedTokenRange(token string) (min, max string) {
+	min, max = TokenRange(token)
+
+	return Prefix(min), Prefix(max)
+}
+
+// TokenLength returns the number of token characters needed to identify a cell at the given level,
+// so that locations can be grouped by token prefix.
+func TokenLength(level int) int {
+	if level < 0 {
+		level = 0
+	} else if level > DefaultLevel {
+		level = DefaultLevel
+	}
+
+	// The face is encoded in 3 bits, followed by 2 bits per level.
+	return (2*level + 6) / 4
+}
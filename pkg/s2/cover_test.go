@@ -0,0 +1,141 @@
+package s2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoverRect(t *testing.T) {
+	t.Run("germany", func(t *testing.T) {
+		cells := CoverRect(48.5, 8.9, 48.6, 9.1, 8)
+		token := Token(48.56344833333333, 8.996878333333333)
+
+		assert.NotEmpty(t, cells)
+		assert.LessOrEqual(t, len(cells), 8)
+
+		found := false
+
+		for _, c := range cells {
+			min, max := TokenRange(c)
+
+			if token >= min && token <= max {
+				found = true
+			}
+		}
+
+		assert.True(t, found)
+	})
+	t.Run("antimeridian", func(t *testing.T) {
+		cells := CoverRect(-20, 170, -10, -170, 0)
+
+		assert.NotEmpty(t, cells)
+		assert.LessOrEqual(t, len(cells), MaxCoverCells)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Empty(t, CoverRect(50, 8, 40, 9, 8))
+		assert.Empty(t, CoverRect(40, 8, 50, 190, 8))
+	})
+}
+
+func TestCoverPolygon(t *testing.T) {
+	t.Run("germany", func(t *testing.T) {
+		ring := [][]float64{{8.9, 48.5}, {9.1, 48.5}, {9.1, 48.6}, {8.9, 48.6}, {8.9, 48.5}}
+		cells := CoverPolygon(ring, 8)
+		token := Token(48.56344833333333, 8.996878333333333)
+
+		assert.NotEmpty(t, cells)
+
+		found := false
+
+		for _, c := range cells {
+			min, max := TokenRange(c)
+
+			if token >= min && token <= max {
+				found = true
+			}
+		}
+
+		assert.True(t, found)
+	})
+	t.Run("clockwise", func(t *testing.T) {
+		ring := [][]float64{{8.9, 48.5}, {8.9, 48.6}, {9.1, 48.6}, {9.1, 48.5}}
+		cells := CoverPolygon(ring, 8)
+
+		assert.NotEmpty(t, cells)
+		assert.LessOrEqual(t, len(cells), 8)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Empty(t, CoverPolygon([][]float64{{8.9, 48.5}, {9.1, 48.5}, {8.9, 48.5}}, 8))
+		assert.Empty(t, CoverPolygon([][]float64{{8.9, 48.5}, {9.1, 148.5}, {9.1, 48.6}}, 8))
+	})
+}
+
+func TestPolygon(t *testing.T) {
+	// Concave polygon shaped like the letter "U".
+	ring := [][]float64{{8.9, 48.5}, {9.1, 48.5}, {9.1, 48.6}, {9.05, 48.6}, {9.05, 48.52}, {8.95, 48.52}, {8.95, 48.6}, {8.9, 48.6}, {8.9, 48.5}}
+	p := NewPolygon(ring)
+
+	if p == nil {
+		t.Fatal("polygon must not be nil")
+	}
+
+	t.Run("Contains", func(t *testing.T) {
+		assert.True(t, p.Contains(48.51, 9.0))
+		assert.True(t, p.Contains(48.58, 8.92))
+		assert.True(t, p.Contains(48.58, 9.08))
+		assert.False(t, p.Contains(48.58, 9.0))
+		assert.False(t, p.Contains(48.7, 9.0))
+	})
+	t.Run("Cover", func(t *testing.T) {
+		interior, boundary := p.Cover(32)
+
+		assert.NotEmpty(t, boundary)
+		assert.ElementsMatch(t, CoverPolygon(ring, 32), append(interior, boundary...))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Nil(t, NewPolygon([][]float64{{8.9, 48.5}, {9.1, 48.5}, {8.9, 48.5}}))
+		assert.Nil(t, NewPolygon([][]float64{{8.9, 48.5}, {9.1, 148.5}, {9.1, 48.6}}))
+	})
+}
+
+func TestTokenRange(t *testing.T) {
+	t.Run("level_21", func(t *testing.T) {
+		token := Token(48.56344833333333, 8.996878333333333)
+		min, max := TokenRange(token)
+
+		assert.Equal(t, token, min)
+		assert.Equal(t, token, max)
+	})
+	t.Run("level_10", func(t *testing.T) {
+		token := Token(48.56344833333333, 8.996878333333333)
+		min, max := TokenRange(TokenLevel(48.56344833333333, 8.996878333333333, 10))
+
+		assert.Len(t, min, 12)
+		assert.Len(t, max, 12)
+		assert.True(t, token > min)
+		assert.True(t, token < max)
+	})
+	t.Run("prefixed", func(t *testing.T) {
+		min, max := PrefixedTokenRange(PrefixedToken(48.56344833333333, 8.996878333333333))
+
+		assert.True(t, strings.HasPrefix(min, TokenPrefix))
+		assert.True(t, strings.HasPrefix(max, TokenPrefix))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		min, max := TokenRange("xxx")
+
+		assert.Empty(t, min)
+		assert.Empty(t, max)
+	})
+}
+
+func TestTokenLength(t *testing.T) {
+	assert.Equal(t, 1, TokenLength(0))
+	assert.Equal(t, 2, TokenLength(2))
+	assert.Equal(t, 6, TokenLength(10))
+	assert.Equal(t, 12, TokenLength(21))
+	assert.Equal(t, 12, TokenLength(30))
+	assert.Equal(t, 1, TokenLength(-1))
+}
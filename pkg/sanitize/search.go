@@ -30,6 +30,16 @@ func SearchString(s string) string {
 	return strings.Trim(s, "&|\\<>\n\r\t")
 }
 
+// SearchRange sanitizes a range filter value like SearchString, but keeps leading comparison
+// operators, e.g. ">=4".
+func SearchRange(s string) string {
+	if s == "" || reject(s, MaxLength) {
+		return Empty
+	}
+
+	return strings.TrimRight(strings.TrimLeft(s, "&|\\\n\r\t"), "&|\\<>\n\r\t")
+}
+
 // SearchQuery replaces search operator with default symbols.
 func SearchQuery(s string) string {
 	if s == "" || reject(s, MaxLength) {
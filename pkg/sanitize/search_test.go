@@ -19,6 +19,24 @@ func TestSearchString(t *testing.T) {
 		q := SearchString(" Flowers in the Park ")
 		assert.Equal(t, " Flowers in the Park ", q)
 	})
+	t.Run("Comparison", func(t *testing.T) {
+		assert.Equal(t, "=4", SearchString(">=4"))
+		assert.Equal(t, "foo", SearchString("<foo>"))
+	})
+}
+
+func TestSearchRange(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", SearchRange(""))
+	})
+	t.Run("Comparison", func(t *testing.T) {
+		assert.Equal(t, ">=4", SearchRange(">=4"))
+		assert.Equal(t, "<2", SearchRange("<2|"))
+		assert.Equal(t, "foo", SearchRange("&foo>"))
+	})
+	t.Run("Range", func(t *testing.T) {
+		assert.Equal(t, "1..3", SearchRange("1..3"))
+	})
 }
 
 func TestSearchQuery(t *testing.T) {
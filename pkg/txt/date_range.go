@@ -0,0 +1,391 @@
+package txt
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MonthNames maps month names and abbreviations in supported languages to months.
+var MonthNames = map[string]time.Month{
+	// English
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+	// German
+	"januar": time.January, "jänner": time.January, "februar": time.February, "märz": time.March,
+	"mai": time.May, "juni": time.June, "juli": time.July, "oktober": time.October, "dezember": time.December,
+	// French
+	"janvier": time.January, "février": time.February, "fevrier": time.February, "mars": time.March,
+	"avril": time.April, "juin": time.June, "juillet": time.July, "août": time.August, "aout": time.August,
+	"septembre": time.September, "octobre": time.October, "novembre": time.November,
+	"décembre": time.December, "decembre": time.December,
+	// Spanish
+	"enero": time.January, "febrero": time.February, "marzo": time.March, "abril": time.April,
+	"mayo": time.May, "junio": time.June, "julio": time.July, "agosto": time.August,
+	"septiembre": time.September, "setiembre": time.September, "octubre": time.October,
+	"noviembre": time.November, "diciembre": time.December,
+	// Dutch
+	"januari": time.January, "februari": time.February, "maart": time.March, "mei": time.May,
+	"augustus": time.August,
+}
+
+// Season represents a meteorological season in the northern hemisphere.
+type Season int
+
+const (
+	Spring Season = iota + 1
+	Summer
+	Autumn
+	Winter
+)
+
+// SeasonNames maps season names in supported languages to seasons.
+var SeasonNames = map[string]Season{
+	"spring": Spring, "summer": Summer, "autumn": Autumn, "fall": Autumn, "winter": Winter,
+	"frühling": Spring, "frühjahr": Spring, "sommer": Summer, "herbst": Autumn,
+	"printemps": Spring, "été": Summer, "ete": Summer, "automne": Autumn, "hiver": Winter,
+	"primavera": Spring, "verano": Summer, "otoño": Autumn, "otono": Autumn, "invierno": Winter,
+	"lente": Spring, "voorjaar": Spring, "zomer": Summer, "herfst": Autumn, "najaar": Autumn,
+}
+
+// Holiday returns the first and the day after the last day of a holiday in the given year.
+type Holiday func(year int) (start, end time.Time)
+
+// Holidays maps holiday names in supported languages to their dates, names with several words
+// are matched as phrase.
+var Holidays = map[string]Holiday{
+	"christmas":        christmas,
+	"xmas":             christmas,
+	"weihnachten":      christmas,
+	"noël":             christmas,
+	"noel":             christmas,
+	"navidad":          christmas,
+	"kerstmis":         christmas,
+	"kerst":            christmas,
+	"new years eve":    newYearsEve,
+	"nye":              newYearsEve,
+	"silvester":        newYearsEve,
+	"réveillon":        newYearsEve,
+	"nochevieja":       newYearsEve,
+	"oudejaarsavond":   newYearsEve,
+	"new year":         newYear,
+	"new years":        newYear,
+	"new years day":    newYear,
+	"neujahr":          newYear,
+	"nouvel an":        newYear,
+	"año nuevo":        newYear,
+	"nieuwjaar":        newYear,
+	"easter":           easter,
+	"ostern":           easter,
+	"pâques":           easter,
+	"paques":           easter,
+	"pascua":           easter,
+	"pasen":            easter,
+	"halloween":        halloween,
+	"valentine":        valentines,
+	"valentines":       valentines,
+	"valentines day":   valentines,
+	"valentinstag":     valentines,
+	"saint valentin":   valentines,
+	"san valentín":     valentines,
+	"san valentin":     valentines,
+	"valentijnsdag":    valentines,
+	"thanksgiving":     thanksgiving,
+	"thanksgiving day": thanksgiving,
+	"independence day": independenceDay,
+	"fourth of july":   independenceDay,
+	"4th of july":      independenceDay,
+}
+
+// Words that refer to the previous or current occurrence of a date, e.g. in "last summer".
+var (
+	lastWords = map[string]bool{"last": true, "past": true, "previous": true, "letzten": true,
+		"letztes": true, "letzte": true, "vergangenen": true, "vergangenes": true, "vorigen": true, "voriges": true}
+	thisWords = map[string]bool{"this": true, "dieses": true, "diesen": true, "diese": true}
+)
+
+// Units of relative dates.
+const (
+	unitYear  = "year"
+	unitMonth = "month"
+	unitWeek  = "week"
+)
+
+var unitWords = map[string]string{
+	"year": unitYear, "jahr": unitYear,
+	"month": unitMonth, "monat": unitMonth,
+	"week": unitWeek, "woche": unitWeek,
+}
+
+// DateRange finds a date phrase like "christmas 2019", "last summer" or "march 2020" in a search query
+// and returns the date range it refers to, as well as the remaining query. The end of the range is the
+// day after the last day. Zero dates and the unchanged query are returned if no date phrase was found.
+func DateRange(q string, now time.Time) (start, end time.Time, rest string) {
+	tokens := dateTokens(q)
+	words := make([]string, len(tokens))
+
+	for i, t := range tokens {
+		words[i] = dateWord(t)
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	for i := range words {
+		if n, s, e := matchDate(words[i:], today); n > 0 {
+			return s, e, dateRest(tokens[:i], tokens[i+n:])
+		}
+	}
+
+	return start, end, q
+}
+
+// matchDate tests if the words start with a date phrase and returns the number of matching words and the date range.
+func matchDate(words []string, today time.Time) (n int, start, end time.Time) {
+	if len(words) == 0 || words[0] == "" {
+		return 0, start, end
+	}
+
+	switch words[0] {
+	case "today", "heute", "aujourdhui", "hoy", "vandaag":
+		return 1, today, today.AddDate(0, 0, 1)
+	case "yesterday", "gestern", "ayer", "gisteren":
+		return 1, today.AddDate(0, 0, -1), today
+	}
+
+	// Relative dates like "last summer" or "this year"?
+	if last, this := lastWords[words[0]], thisWords[words[0]]; (last || this) && len(words) > 1 {
+		if unit, ok := unitWords[words[1]]; ok {
+			start, end = unitRange(unit, today, last)
+			return 2, start, end
+		}
+
+		if m, p, ok := matchPeriod(words[1:]); ok {
+			if this {
+				start, end = thisPeriod(p, today)
+			} else {
+				start, end = lastPeriod(p, today)
+			}
+
+			return 1 + m, start, end
+		}
+
+		return 0, start, end
+	}
+
+	// Holidays, seasons and months followed by a year like in "christmas 2019" or "march 2020"?
+	if m, p, ok := matchPeriod(words); ok && len(words) > m {
+		if year := dateYear(words[m]); year > 0 {
+			start, end = p(year)
+			return m + 1, start, end
+		}
+	}
+
+	return 0, start, end
+}
+
+// matchPeriod tests if the words start with a holiday, season or month name.
+func matchPeriod(words []string) (n int, p Holiday, ok bool) {
+	// Prefer longer holiday names, e.g. "new years eve" over "new years".
+	for l := 3; l > 0; l-- {
+		if len(words) < l {
+			continue
+		}
+
+		if h, found := Holidays[strings.Join(words[:l], " ")]; found {
+			return l, h, true
+		}
+	}
+
+	if s, found := SeasonNames[words[0]]; found {
+		return 1, seasonRange(s), true
+	}
+
+	if m, found := MonthNames[words[0]]; found {
+		return 1, monthRange(m), true
+	}
+
+	return 0, nil, false
+}
+
+// unitRange returns the current or previous calendar year, month or week.
+func unitRange(unit string, today time.Time, last bool) (start, end time.Time) {
+	switch unit {
+	case unitYear:
+		start = time.Date(today.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		if last {
+			start = start.AddDate(-1, 0, 0)
+		}
+		return start, start.AddDate(1, 0, 0)
+	case unitMonth:
+		start = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if last {
+			start = start.AddDate(0, -1, 0)
+		}
+		return start, start.AddDate(0, 1, 0)
+	default:
+		// Weeks start on Monday.
+		start = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		if last {
+			start = start.AddDate(0, 0, -7)
+		}
+		return start, start.AddDate(0, 0, 7)
+	}
+}
+
+// lastPeriod returns the most recent occurrence of a period that has ended by today.
+func lastPeriod(p Holiday, today time.Time) (start, end time.Time) {
+	for year := today.Year(); year > today.Year()-3; year-- {
+		if start, end = p(year); !end.After(today.AddDate(0, 0, 1)) {
+			return start, end
+		}
+	}
+
+	return start, end
+}
+
+// thisPeriod returns the occurrence of a period that includes today, or the one in the current year.
+func thisPeriod(p Holiday, today time.Time) (start, end time.Time) {
+	// Winter and new year start in the previous year.
+	if start, end = p(today.Year() - 1); !today.Before(start) && today.Before(end) {
+		return start, end
+	}
+
+	return p(today.Year())
+}
+
+// seasonRange returns the date range of a meteorological season, winter starts in December of the given year.
+func seasonRange(s Season) Holiday {
+	return func(year int) (start, end time.Time) {
+		start = time.Date(year, time.Month(3*int(s)), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 3, 0)
+	}
+}
+
+// monthRange returns the date range of a month.
+func monthRange(m time.Month) Holiday {
+	return func(year int) (start, end time.Time) {
+		start = time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+}
+
+// days returns a range of days starting at the given date.
+func days(year int, month time.Month, day, n int) (start, end time.Time) {
+	start = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 0, n)
+}
+
+func christmas(year int) (start, end time.Time)       { return days(year, time.December, 24, 3) }
+func newYearsEve(year int) (start, end time.Time)     { return days(year, time.December, 31, 1) }
+func newYear(year int) (start, end time.Time)         { return days(year-1, time.December, 31, 2) }
+func halloween(year int) (start, end time.Time)       { return days(year, time.October, 31, 1) }
+func valentines(year int) (start, end time.Time)      { return days(year, time.February, 14, 1) }
+func independenceDay(year int) (start, end time.Time) { return days(year, time.July, 4, 1) }
+
+// easter returns the days from Good Friday to Easter Monday.
+func easter(year int) (start, end time.Time) {
+	// Anonymous Gregorian algorithm, see https://en.wikipedia.org/wiki/Date_of_Easter.
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := (19*a + b - b/4 - (b-(b+8)/25+1)/3 + 15) % 30
+	e := (32 + 2*(b%4) + 2*(c/4) - d - c%4) % 7
+	f := d + e - 7*((a+11*d+22*e)/451) + 114
+
+	return days(year, time.Month(f/31), f%31+1-2, 4)
+}
+
+// thanksgiving returns the days from the fourth Thursday in November until Sunday.
+func thanksgiving(year int) (start, end time.Time) {
+	first := time.Date(year, time.November, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(time.Thursday) - int(first.Weekday()) + 7) % 7
+
+	return days(year, time.November, 1+offset+21, 4)
+}
+
+// dateYear returns the year if the word is a four digit number, or 0 otherwise.
+func dateYear(w string) int {
+	if len(w) != 4 {
+		return 0
+	}
+
+	year, err := strconv.Atoi(w)
+
+	if err != nil || year < 1000 {
+		return 0
+	}
+
+	return year
+}
+
+// dateTokens splits a search query into words and operators.
+func dateTokens(q string) (tokens []string) {
+	for _, f := range strings.Fields(q) {
+		for {
+			i := strings.IndexAny(f, And+Or)
+
+			if i < 0 {
+				break
+			}
+
+			if i > 0 {
+				tokens = append(tokens, f[:i])
+			}
+
+			tokens = append(tokens, f[i:i+1])
+			f = f[i+1:]
+		}
+
+		if f != "" {
+			tokens = append(tokens, f)
+		}
+	}
+
+	return tokens
+}
+
+// dateWord returns the lowercase word without punctuation and apostrophes, e.g. "valentines" for "Valentine's".
+func dateWord(token string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r):
+			return unicode.ToLower(r)
+		case unicode.IsDigit(r):
+			return r
+		default:
+			return -1
+		}
+	}, token)
+}
+
+// dateRest joins the remaining tokens and removes dangling operators.
+func dateRest(before, after []string) string {
+	var result []string
+
+	for _, t := range append(append([]string{}, before...), after...) {
+		isOp := t == And || t == Or
+
+		if isOp && (len(result) == 0 || result[len(result)-1] == And || result[len(result)-1] == Or) {
+			continue
+		}
+
+		result = append(result, t)
+	}
+
+	if n := len(result); n > 0 && (result[n-1] == And || result[n-1] == Or) {
+		result = result[:n-1]
+	}
+
+	return strings.Join(result, " ")
+}
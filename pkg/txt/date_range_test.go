@@ -0,0 +1,125 @@
+package txt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateRange(t *testing.T) {
+	now := time.Date(2022, 7, 14, 15, 30, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("Christmas", func(t *testing.T) {
+		start, end, rest := DateRange("christmas 2019", now)
+		assert.Equal(t, date(2019, 12, 24), start)
+		assert.Equal(t, date(2019, 12, 27), end)
+		assert.Equal(t, "", rest)
+	})
+	t.Run("Weihnachten", func(t *testing.T) {
+		start, end, rest := DateRange("Baum Weihnachten 2020", now)
+		assert.Equal(t, date(2020, 12, 24), start)
+		assert.Equal(t, date(2020, 12, 27), end)
+		assert.Equal(t, "Baum", rest)
+	})
+	t.Run("MonthYear", func(t *testing.T) {
+		start, end, rest := DateRange("beach march 2020 sunset", now)
+		assert.Equal(t, date(2020, 3, 1), start)
+		assert.Equal(t, date(2020, 4, 1), end)
+		assert.Equal(t, "beach sunset", rest)
+	})
+	t.Run("French", func(t *testing.T) {
+		start, end, _ := DateRange("août 2018", now)
+		assert.Equal(t, date(2018, 8, 1), start)
+		assert.Equal(t, date(2018, 9, 1), end)
+	})
+	t.Run("SeasonYear", func(t *testing.T) {
+		start, end, _ := DateRange("winter 2019", now)
+		assert.Equal(t, date(2019, 12, 1), start)
+		assert.Equal(t, date(2020, 3, 1), end)
+	})
+	t.Run("LastSummer", func(t *testing.T) {
+		start, end, rest := DateRange("dog last summer", now)
+		assert.Equal(t, date(2021, 6, 1), start)
+		assert.Equal(t, date(2021, 9, 1), end)
+		assert.Equal(t, "dog", rest)
+	})
+	t.Run("LastSpring", func(t *testing.T) {
+		start, end, _ := DateRange("letzten Frühling", now)
+		assert.Equal(t, date(2022, 3, 1), start)
+		assert.Equal(t, date(2022, 6, 1), end)
+	})
+	t.Run("ThisSummer", func(t *testing.T) {
+		start, end, _ := DateRange("this summer", now)
+		assert.Equal(t, date(2022, 6, 1), start)
+		assert.Equal(t, date(2022, 9, 1), end)
+	})
+	t.Run("ThisWinter", func(t *testing.T) {
+		start, end, _ := DateRange("this winter", date(2023, 1, 10))
+		assert.Equal(t, date(2022, 12, 1), start)
+		assert.Equal(t, date(2023, 3, 1), end)
+	})
+	t.Run("LastChristmas", func(t *testing.T) {
+		start, end, _ := DateRange("last christmas", now)
+		assert.Equal(t, date(2021, 12, 24), start)
+		assert.Equal(t, date(2021, 12, 27), end)
+	})
+	t.Run("LastYear", func(t *testing.T) {
+		start, end, _ := DateRange("last year", now)
+		assert.Equal(t, date(2021, 1, 1), start)
+		assert.Equal(t, date(2022, 1, 1), end)
+	})
+	t.Run("ThisMonth", func(t *testing.T) {
+		start, end, _ := DateRange("this month", now)
+		assert.Equal(t, date(2022, 7, 1), start)
+		assert.Equal(t, date(2022, 8, 1), end)
+	})
+	t.Run("LastWeek", func(t *testing.T) {
+		start, end, _ := DateRange("last week", now)
+		assert.Equal(t, date(2022, 7, 4), start)
+		assert.Equal(t, date(2022, 7, 11), end)
+	})
+	t.Run("Yesterday", func(t *testing.T) {
+		start, end, _ := DateRange("gestern", now)
+		assert.Equal(t, date(2022, 7, 13), start)
+		assert.Equal(t, date(2022, 7, 14), end)
+	})
+	t.Run("Easter", func(t *testing.T) {
+		start, end, _ := DateRange("easter 2024", now)
+		assert.Equal(t, date(2024, 3, 29), start)
+		assert.Equal(t, date(2024, 4, 2), end)
+
+		start, _, _ = DateRange("Ostern 2019", now)
+		assert.Equal(t, date(2019, 4, 19), start)
+	})
+	t.Run("NewYearsEve", func(t *testing.T) {
+		start, end, _ := DateRange("New Year's Eve 2021", now)
+		assert.Equal(t, date(2021, 12, 31), start)
+		assert.Equal(t, date(2022, 1, 1), end)
+	})
+	t.Run("NewYear", func(t *testing.T) {
+		start, end, _ := DateRange("new year 2020", now)
+		assert.Equal(t, date(2019, 12, 31), start)
+		assert.Equal(t, date(2020, 1, 2), end)
+	})
+	t.Run("Thanksgiving", func(t *testing.T) {
+		start, end, _ := DateRange("thanksgiving 2021", now)
+		assert.Equal(t, date(2021, 11, 25), start)
+		assert.Equal(t, date(2021, 11, 29), end)
+	})
+	t.Run("Operators", func(t *testing.T) {
+		_, _, rest := DateRange("cat&christmas 2019|dog", now)
+		assert.Equal(t, "cat & dog", rest)
+	})
+	t.Run("NoDate", func(t *testing.T) {
+		for _, q := range []string{"", "christmas", "march", "last", "this cat", "2019", "christmas tree"} {
+			start, end, rest := DateRange(q, now)
+			assert.True(t, start.IsZero(), q)
+			assert.True(t, end.IsZero(), q)
+			assert.Equal(t, q, rest)
+		}
+	})
+}